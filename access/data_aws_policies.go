@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"regexp"
 
+	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -179,7 +180,8 @@ func DataAwsAssumeRolePolicy() *schema.Resource {
 			if v, ok := d.GetOk("for_log_delivery"); ok {
 				if v.(bool) {
 					// this is production UsageDelivery IAM role, that is considered a constant
-					logDeliveryARN := "arn:aws:iam::414351767826:role/SaasUsageDeliveryRole-prod-IAMRole-3PLHICCRR1TK"
+					logDeliveryARN := fmt.Sprintf("arn:aws:iam::%s:role/SaasUsageDeliveryRole-prod-IAMRole-3PLHICCRR1TK",
+						common.DatabricksAwsAccountID)
 					policy.Statements[0].Principal["AWS"] = logDeliveryARN
 				}
 			}
@@ -195,7 +197,7 @@ func DataAwsAssumeRolePolicy() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"databricks_account_id": {
 				Type:     schema.TypeString,
-				Default:  "414351767826",
+				Default:  common.DatabricksAwsAccountID,
 				Optional: true,
 			},
 			"for_log_delivery": {
@@ -260,7 +262,7 @@ func DataAwsBucketPolicy() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"databricks_account_id": {
 				Type:     schema.TypeString,
-				Default:  "414351767826",
+				Default:  common.DatabricksAwsAccountID,
 				Optional: true,
 			},
 			"full_access_role": {
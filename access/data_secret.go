@@ -0,0 +1,56 @@
+package access
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceSecret returns metadata of the secret, so that its existence is checked during plan.
+// Secret value is never exposed.
+func DataSourceSecret() *schema.Resource {
+	type entity struct {
+		Scope                string `json:"scope"`
+		Key                  string `json:"key"`
+		LastUpdatedTimestamp int64  `json:"last_updated_timestamp,omitempty" tf:"computed"`
+		ConfigReference      string `json:"config_reference,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		// nolint
+		s["scope"].ValidateFunc = validScope
+		// nolint
+		s["key"].ValidateFunc = validScope
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			secret, err := NewSecretsAPI(ctx, m).Read(this.Scope, this.Key)
+			if e, ok := err.(common.APIError); ok && e.IsMissing() {
+				return diag.Errorf("Cannot find secret %s in scope %s", this.Key, this.Scope)
+			}
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.LastUpdatedTimestamp = secret.LastUpdatedTimestamp
+			// reference could be used in spark_conf and env vars of clusters
+			this.ConfigReference = fmt.Sprintf("{{secrets/%s/%s}}", this.Scope, this.Key)
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(fmt.Sprintf("%s|||%s", this.Scope, this.Key))
+			return nil
+		},
+	}
+}
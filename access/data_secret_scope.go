@@ -0,0 +1,50 @@
+package access
+
+import (
+	"context"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceSecretScope returns metadata of secret scope looked up by its name
+func DataSourceSecretScope() *schema.Resource {
+	type entity struct {
+		Name             string            `json:"name"`
+		BackendType      string            `json:"backend_type,omitempty" tf:"computed"`
+		KeyvaultMetadata *KeyvaultMetadata `json:"keyvault_metadata,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		// nolint
+		s["name"].ValidateFunc = validScope
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			scope, err := NewSecretScopesAPI(ctx, m).Read(this.Name)
+			if e, ok := err.(common.APIError); ok && e.IsMissing() {
+				return diag.Errorf("Cannot find secret scope %s", this.Name)
+			}
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.BackendType = scope.BackendType
+			this.KeyvaultMetadata = scope.KeyvaultMetadata
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(scope.Name)
+			return nil
+		},
+	}
+}
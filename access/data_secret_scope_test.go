@@ -0,0 +1,59 @@
+package access
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceSecretScope(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/secrets/scopes/list",
+				Response: SecretScopeList{
+					Scopes: []SecretScope{
+						{
+							Name:        "abc",
+							BackendType: "AZURE_KEYVAULT",
+							KeyvaultMetadata: &KeyvaultMetadata{
+								ResourceID: "bcd",
+								DNSName:    "https://my-kv.vault.azure.net/",
+							},
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceSecretScope(),
+		ID:          ".",
+		HCL:         `name = "abc"`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "AZURE_KEYVAULT", d.Get("backend_type"))
+	assert.Equal(t, "https://my-kv.vault.azure.net/", d.Get("keyvault_metadata.0.dns_name"))
+}
+
+func TestDataSourceSecretScope_NotFound(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/secrets/scopes/list",
+				Response: SecretScopeList{},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceSecretScope(),
+		ID:          ".",
+		HCL:         `name = "abc"`,
+	}.Apply(t)
+	assert.EqualError(t, err, "Cannot find secret scope abc")
+}
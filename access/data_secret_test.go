@@ -0,0 +1,84 @@
+package access
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceSecret(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/secrets/list?scope=foo",
+				Response: SecretsList{
+					Secrets: []SecretMetadata{
+						{
+							Key:                  "bar",
+							LastUpdatedTimestamp: 12345678,
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceSecret(),
+		ID:          ".",
+		HCL: `
+		scope = "foo"
+		key = "bar"`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "foo|||bar", d.Id())
+	assert.Equal(t, 12345678, d.Get("last_updated_timestamp"))
+	assert.Equal(t, "{{secrets/foo/bar}}", d.Get("config_reference"))
+}
+
+func TestDataSourceSecret_NotFound(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/secrets/list?scope=foo",
+				Response: SecretsList{},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceSecret(),
+		ID:          ".",
+		HCL: `
+		scope = "foo"
+		key = "bar"`,
+	}.Apply(t)
+	assert.EqualError(t, err, "Cannot find secret bar in scope foo")
+}
+
+func TestDataSourceSecret_NoScope(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/secrets/list?scope=foo",
+				Status:   404,
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Scope foo does not exist!",
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceSecret(),
+		ID:          ".",
+		HCL: `
+		scope = "foo"
+		key = "bar"`,
+	}.Apply(t)
+	assert.EqualError(t, err, "Cannot find secret bar in scope foo")
+}
@@ -2,10 +2,15 @@ package access
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"strings"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal"
 	"github.com/databrickslabs/databricks-terraform/internal/util"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -44,6 +49,14 @@ type ipAccessListUpdateRequest struct {
 	Enabled     bool     `json:"enabled,omitempty"`
 }
 
+// ipAccessListPatchRequest always sends enabled flag, so that the list could be disabled in place
+type ipAccessListPatchRequest struct {
+	Label       string   `json:"label,omitempty"`
+	ListType    string   `json:"list_type,omitempty"`
+	IPAddresses []string `json:"ip_addresses,omitempty"`
+	Enabled     bool     `json:"enabled"`
+}
+
 // Preview feature: https://docs.databricks.com/security/network/ip-access-list.html
 // REST API: https://docs.databricks.com/dev-tools/api/latest/ip-access-list.html#operation/create-list
 type ipAccessListsAPI struct {
@@ -59,19 +72,30 @@ func NewIPAccessListsAPI(ctx context.Context, m interface{}) ipAccessListsAPI {
 	}
 }
 
+// quotaError makes the error about exceeding the number of IP addresses in all lists readable
+func quotaError(err error) error {
+	if e, ok := err.(common.APIError); ok && e.ErrorCode == "QUOTA_EXCEEDED" {
+		return fmt.Errorf("IP access lists of the workspace can have at most 1000 IP addresses "+
+			"and CIDR ranges combined. Please merge addresses into CIDR ranges or remove unused lists: %s", e.Message)
+	}
+	return err
+}
+
 // Create creates the IP Access List to given the instance pool configuration
 func (a ipAccessListsAPI) Create(cr createIPAccessListRequest) (status ipAccessListStatus, err error) {
 	wrapper := ipAccessListStatusWrapper{}
 	err = a.client.Post(a.context, "/ip-access-lists", cr, &wrapper)
 	if err != nil {
+		err = quotaError(err)
 		return
 	}
 	status = wrapper.IPAccessList
 	return
 }
 
-func (a ipAccessListsAPI) Update(objectID string, ur ipAccessListUpdateRequest) error {
-	return a.client.Put(a.context, "/ip-access-lists/"+objectID, ur)
+// Update changes the IP access list in place
+func (a ipAccessListsAPI) Update(objectID string, ur ipAccessListPatchRequest) error {
+	return quotaError(a.client.Patch(a.context, "/ip-access-lists/"+objectID, ur))
 }
 
 func (a ipAccessListsAPI) Delete(objectID string) (err error) {
@@ -88,7 +112,187 @@ func (a ipAccessListsAPI) Read(objectID string) (status ipAccessListStatus, err
 
 func (a ipAccessListsAPI) List() (listResponse listIPAccessListsResponse, err error) {
 	listResponse = listIPAccessListsResponse{}
-	err = a.client.Get(a.context, "/ip-access-lists", &listResponse, nil)
+	err = a.client.Get(a.context, "/ip-access-lists", nil, &listResponse)
+	return
+}
+
+// enabled returns true, if IP access lists are enforced in the workspace
+func (a ipAccessListsAPI) enabled() (bool, error) {
+	conf := map[string]string{}
+	err := a.client.Get(a.context, "/workspace-conf", map[string]string{
+		"keys": "enableIpAccessLists",
+	}, &conf)
+	return conf["enableIpAccessLists"] == "true", err
+}
+
+// IPAccessListRule is the part of IP access list, that is relevant for evaluating access
+// of an address. It's shared between workspace-level and account-level IP access lists.
+type IPAccessListRule struct {
+	ListType    string
+	IPAddresses []string
+	Enabled     bool
+}
+
+// Contains returns true if given IP address is matched by any of addresses or CIDR ranges in the rule
+func (r IPAccessListRule) Contains(ip net.IP) bool {
+	for _, address := range r.IPAddresses {
+		if strings.Contains(address, "/") {
+			_, cidr, err := net.ParseCIDR(address)
+			if err == nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if ip.Equal(net.ParseIP(address)) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBlocked evaluates all enabled rules the same way as Databricks does:
+// block lists take precedence and allow lists, if present, have to match the address
+func IsBlocked(ip net.IP, rules []IPAccessListRule) bool {
+	hasAllowLists, allowed := false, false
+	for _, r := range rules {
+		if !r.Enabled {
+			continue
+		}
+		if r.ListType == "BLOCK" && r.Contains(ip) {
+			return true
+		}
+		if r.ListType == "ALLOW" {
+			hasAllowLists = true
+			allowed = allowed || r.Contains(ip)
+		}
+	}
+	return hasAllowLists && !allowed
+}
+
+func (l ipAccessListStatus) rule() IPAccessListRule {
+	return IPAccessListRule{
+		ListType:    l.ListType,
+		IPAddresses: l.IPAddresses,
+		Enabled:     l.Enabled,
+	}
+}
+
+// checkLockout verifies, that the configured address is still able to reach the workspace after the list
+// is created or updated. The check is done only when IP access lists are enabled for the workspace.
+func (a ipAccessListsAPI) checkLockout(acl ipAccessListStatus, ip net.IP) error {
+	enabled, err := a.enabled()
+	if err != nil || !enabled {
+		return err
+	}
+	existing, err := a.List()
+	if err != nil {
+		return err
+	}
+	rules := []IPAccessListRule{acl.rule()}
+	for _, l := range existing.ListIPAccessListsResponse {
+		if l.ListID != acl.ListID {
+			rules = append(rules, l.rule())
+		}
+	}
+	if IsBlocked(ip, rules) {
+		return fmt.Errorf("IP access list %s would block %s from accessing the workspace. "+
+			"Please allow it or change lockout_check_ip", acl.Label, ip)
+	}
+	return nil
+}
+
+// maxIPAccessListValues is the documented quota of IP addresses and CIDR ranges in all lists combined
+const maxIPAccessListValues = 1000
+
+// maxIPAccessLists is the documented quota of IP access lists in the workspace
+const maxIPAccessLists = 1000
+
+// checkQuota fails, if the list with given number of addresses doesn't fit into the quotas
+// together with all other lists of the workspace. New lists have empty identifier.
+func (a ipAccessListsAPI) checkQuota(listID string, addresses int) error {
+	existing, err := a.List()
+	if err != nil {
+		return err
+	}
+	used, lists := 0, 0
+	for _, l := range existing.ListIPAccessListsResponse {
+		if l.ListID == listID {
+			continue
+		}
+		used += len(l.IPAddresses)
+		lists++
+	}
+	if listID == "" && lists >= maxIPAccessLists {
+		return fmt.Errorf("Workspace can have at most %d IP access lists, but it already has %d of them. "+
+			"Please merge or remove unused lists", maxIPAccessLists, lists)
+	}
+	if used+addresses <= maxIPAccessListValues {
+		return nil
+	}
+	return fmt.Errorf("IP access lists of the workspace can have at most %d IP addresses and CIDR ranges "+
+		"combined, but %d other lists already have %d of them and this list adds %d. Please merge "+
+		"addresses into CIDR ranges or remove unused lists", maxIPAccessListValues, lists, used, addresses)
+}
+
+// parseIPNet returns network of IP address or CIDR range. Single address is the network of one host.
+func parseIPNet(address string) (*net.IPNet, error) {
+	if strings.Contains(address, "/") {
+		_, cidr, err := net.ParseCIDR(address)
+		return cidr, err
+	}
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil, fmt.Errorf("%s is not a valid IP address", address)
+	}
+	bits := 8 * net.IPv4len
+	if ip.To4() == nil {
+		bits = 8 * net.IPv6len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+// checkOverlaps warns, if any two entries of the list cover the same addresses. Invalid entries
+// are reported by validIPAddressOrCIDR.
+func checkOverlaps(addresses []string) (diags diag.Diagnostics) {
+	nets := make([]*net.IPNet, len(addresses))
+	for i, address := range addresses {
+		n, err := parseIPNet(address)
+		if err != nil {
+			continue
+		}
+		for j, other := range nets[:i] {
+			if other == nil || !(n.Contains(other.IP) || other.Contains(n.IP)) {
+				continue
+			}
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       fmt.Sprintf("%s overlaps with %s in the same list", address, addresses[j]),
+				Detail:        "Please remove one of them",
+				AttributePath: cty.GetAttrPath("ip_addresses").IndexInt(i),
+			})
+			break
+		}
+		nets[i] = n
+	}
+	return
+}
+
+// validIPAddressOrCIDR checks syntax of IPv4 address or CIDR range and warns about
+// ranges with host bits, e.g. 10.0.0.1/24, which cover the whole network
+func validIPAddressOrCIDR(v interface{}, k string) (ws []string, es []error) {
+	address := v.(string)
+	if !strings.Contains(address, "/") {
+		return validation.IsIPv4Address(v, k)
+	}
+	ip, cidr, err := net.ParseCIDR(address)
+	if err != nil {
+		es = append(es, fmt.Errorf("%s: %s is not a valid CIDR range", k, address))
+		return
+	}
+	if !ip.Equal(cidr.IP) {
+		ws = append(ws, fmt.Sprintf("%s: %s has host bits set and covers the whole %s range",
+			k, address, cidr))
+	}
 	return
 }
 
@@ -99,24 +303,49 @@ func ResourceIPAccessList() *schema.Resource {
 		s["list_type"].ValidateFunc = validation.StringInSlice([]string{"ALLOW", "BLOCK"}, false)
 		s["ip_addresses"].Elem = &schema.Schema{
 			Type:         schema.TypeString,
-			ValidateFunc: validation.Any(validation.IsIPv4Address, validation.IsCIDR),
+			ValidateFunc: validIPAddressOrCIDR,
 		}
 		s["enabled"].Default = true
+		s["lockout_check_ip"] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsIPAddress,
+		}
 		return s
 	})
-	return util.CommonResource{
+	r := util.CommonResource{
 		Schema: s,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			var iacl createIPAccessListRequest
+			var iacl ipAccessListUpdateRequest
 			if err := internal.DataToStructPointer(d, s, &iacl); err != nil {
 				return err
 			}
-			status, err := NewIPAccessListsAPI(ctx, c).Create(iacl)
+			ipAccessLists := NewIPAccessListsAPI(ctx, c)
+			if ip := d.Get("lockout_check_ip").(string); ip != "" {
+				err := ipAccessLists.checkLockout(ipAccessListStatus{
+					Label:       iacl.Label,
+					ListType:    iacl.ListType,
+					IPAddresses: iacl.IPAddresses,
+					Enabled:     iacl.Enabled,
+				}, net.ParseIP(ip))
+				if err != nil {
+					return err
+				}
+			}
+			status, err := ipAccessLists.Create(createIPAccessListRequest{
+				Label:       iacl.Label,
+				ListType:    iacl.ListType,
+				IPAddresses: iacl.IPAddresses,
+			})
 			if err != nil {
 				return err
 			}
 			d.SetId(status.ListID)
-			return nil
+			if iacl.Enabled {
+				return nil
+			}
+			// lists are always created enabled
+			return ipAccessLists.Update(status.ListID, ipAccessListPatchRequest{Enabled: false})
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			status, err := NewIPAccessListsAPI(ctx, c).Read(d.Id())
@@ -130,10 +359,59 @@ func ResourceIPAccessList() *schema.Resource {
 			if err := internal.DataToStructPointer(d, s, &iacl); err != nil {
 				return err
 			}
-			return NewIPAccessListsAPI(ctx, c).Update(d.Id(), iacl)
+			ipAccessLists := NewIPAccessListsAPI(ctx, c)
+			if ip := d.Get("lockout_check_ip").(string); ip != "" {
+				err := ipAccessLists.checkLockout(ipAccessListStatus{
+					ListID:      d.Id(),
+					Label:       iacl.Label,
+					ListType:    iacl.ListType,
+					IPAddresses: iacl.IPAddresses,
+					Enabled:     iacl.Enabled,
+				}, net.ParseIP(ip))
+				if err != nil {
+					return err
+				}
+			}
+			return ipAccessLists.Update(d.Id(), ipAccessListPatchRequest{
+				Label:       iacl.Label,
+				ListType:    iacl.ListType,
+				IPAddresses: iacl.IPAddresses,
+				Enabled:     iacl.Enabled,
+			})
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			return NewIPAccessListsAPI(ctx, c).Delete(d.Id())
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			if (d.Id() != "" && !d.HasChange("ip_addresses")) || !d.NewValueKnown("ip_addresses") {
+				return nil
+			}
+			addresses := []string{}
+			for _, v := range d.Get("ip_addresses").([]interface{}) {
+				addresses = append(addresses, v.(string))
+			}
+			if len(addresses) == 0 {
+				return nil
+			}
+			// quota is checked against lists, that already exist, so that plan fails early in most cases
+			return NewIPAccessListsAPI(ctx, c).checkQuota(d.Id(), len(addresses))
+		},
 	}.ToResource()
+	// CustomizeDiff cannot return warnings, so overlaps are reported once the list is applied
+	create, update := r.CreateContext, r.UpdateContext
+	r.CreateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		return append(create(ctx, d, m), overlapWarnings(d)...)
+	}
+	r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		return append(update(ctx, d, m), overlapWarnings(d)...)
+	}
+	return r
+}
+
+func overlapWarnings(d *schema.ResourceData) diag.Diagnostics {
+	addresses := []string{}
+	for _, v := range d.Get("ip_addresses").([]interface{}) {
+		addresses = append(addresses, v.(string))
+	}
+	return checkOverlaps(addresses)
 }
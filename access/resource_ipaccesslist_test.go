@@ -3,12 +3,17 @@ package access
 // REST API: https://docs.databricks.com/dev-tools/api/latest/ip-access-list.html#operation/create-list
 
 import (
+	"fmt"
+	"net"
 	"net/http"
 	"testing"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -21,9 +26,32 @@ var (
 	TestingIPAddressesState = []interface{}{"1.2.3.4", "1.2.4.0/24"}
 )
 
+func ipAccessListsEnabledFixture(enabled string) qa.HTTPFixture {
+	return qa.HTTPFixture{
+		Method:   http.MethodGet,
+		Resource: "/api/2.0/workspace-conf?keys=enableIpAccessLists",
+		Response: map[string]string{
+			"enableIpAccessLists": enabled,
+		},
+	}
+}
+
+// ipAccessListsFixture returns existing lists of the workspace, that are listed during plan
+func ipAccessListsFixture(lists ...ipAccessListStatus) qa.HTTPFixture {
+	return qa.HTTPFixture{
+		Method:       http.MethodGet,
+		Resource:     "/api/2.0/ip-access-lists",
+		ReuseRequest: true,
+		Response: listIPAccessListsResponse{
+			ListIPAccessListsResponse: lists,
+		},
+	}
+}
+
 func TestIPACLCreate(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			ipAccessListsFixture(),
 			{
 				Method:   http.MethodPost,
 				Resource: "/api/2.0/ip-access-lists",
@@ -85,6 +113,7 @@ func TestIPACLCreate(t *testing.T) {
 func TestAPIACLCreate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			ipAccessListsFixture(),
 			{
 				Method:   http.MethodPost,
 				Resource: "/api/2.0/ip-access-lists",
@@ -111,6 +140,7 @@ func TestAPIACLCreate_Error(t *testing.T) {
 func TestIPACLUpdate(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			ipAccessListsFixture(),
 			{
 				Method:   http.MethodGet,
 				Resource: "/api/2.0/ip-access-lists/" + TestingID,
@@ -130,7 +160,7 @@ func TestIPACLUpdate(t *testing.T) {
 				},
 			},
 			{
-				Method:   http.MethodPut,
+				Method:   http.MethodPatch,
 				Resource: "/api/2.0/ip-access-lists/" + TestingID,
 				Response: ipAccessListStatusWrapper{
 					IPAccessList: ipAccessListStatus{
@@ -168,8 +198,9 @@ func TestIPACLUpdate(t *testing.T) {
 func TestIPACLUpdate_Error(t *testing.T) {
 	_, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			ipAccessListsFixture(),
 			{
-				Method:   http.MethodPut,
+				Method:   http.MethodPatch,
 				Resource: "/api/2.0/ip-access-lists/" + TestingID,
 				Response: common.APIErrorBody{
 					ErrorCode: "SERVER_ERROR",
@@ -299,3 +330,407 @@ func TestIPACLDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "IP access list is not available in ")
 	assert.Equal(t, TestingID, d.Id())
 }
+
+func TestIPACLUpdate_Disable(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			ipAccessListsFixture(),
+			{
+				Method:   http.MethodPatch,
+				Resource: "/api/2.0/ip-access-lists/" + TestingID,
+				ExpectedRequest: ipAccessListPatchRequest{
+					Label:       TestingLabel,
+					ListType:    TestingListType,
+					IPAddresses: TestingIPAddresses,
+					Enabled:     false,
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/ip-access-lists/" + TestingID,
+				Response: ipAccessListStatusWrapper{
+					IPAccessList: ipAccessListStatus{
+						ListID:      TestingID,
+						Label:       TestingLabel,
+						ListType:    TestingListType,
+						IPAddresses: TestingIPAddresses,
+						Enabled:     false,
+					},
+				},
+			},
+		},
+		Resource: ResourceIPAccessList(),
+		InstanceState: map[string]string{
+			"label":          TestingLabel,
+			"list_type":      TestingListType,
+			"ip_addresses.#": "2",
+			"ip_addresses.0": "1.2.3.4",
+			"ip_addresses.1": "1.2.4.0/24",
+			"enabled":        "true",
+		},
+		HCL: `
+		label = "Naughty"
+		list_type = "BLOCK"
+		ip_addresses = ["1.2.3.4", "1.2.4.0/24"]
+		enabled = false
+		`,
+		Update: true,
+		ID:     TestingID,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, false, d.Get("enabled"))
+}
+
+func TestIPACLCreate_Disabled(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			ipAccessListsFixture(),
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/ip-access-lists",
+				Response: ipAccessListStatusWrapper{
+					IPAccessList: ipAccessListStatus{
+						ListID: TestingID,
+					},
+				},
+			},
+			{
+				Method:   http.MethodPatch,
+				Resource: "/api/2.0/ip-access-lists/" + TestingID,
+				ExpectedRequest: map[string]interface{}{
+					"enabled": false,
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/ip-access-lists/" + TestingID,
+				Response: ipAccessListStatusWrapper{
+					IPAccessList: ipAccessListStatus{
+						ListID:      TestingID,
+						Label:       TestingLabel,
+						ListType:    TestingListType,
+						IPAddresses: TestingIPAddresses,
+					},
+				},
+			},
+		},
+		Resource: ResourceIPAccessList(),
+		HCL: `
+		label = "Naughty"
+		list_type = "BLOCK"
+		ip_addresses = ["1.2.3.4", "1.2.4.0/24"]
+		enabled = false
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+}
+
+func TestIPACLCreate_Lockout(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			ipAccessListsEnabledFixture("true"),
+			{
+				Method:       http.MethodGet,
+				Resource:     "/api/2.0/ip-access-lists",
+				ReuseRequest: true,
+				Response: listIPAccessListsResponse{
+					ListIPAccessListsResponse: []ipAccessListStatus{
+						{
+							ListID:      "other",
+							ListType:    "ALLOW",
+							IPAddresses: []string{"4.3.2.1"},
+							Enabled:     false,
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceIPAccessList(),
+		HCL: `
+		label = "office"
+		list_type = "ALLOW"
+		ip_addresses = ["1.2.3.0/24"]
+		lockout_check_ip = "4.3.2.1"
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.EqualError(t, err, "IP access list office would block 4.3.2.1 from accessing the workspace. "+
+		"Please allow it or change lockout_check_ip")
+}
+
+func TestIPACLUpdate_LockoutCoveredByOtherList(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			ipAccessListsEnabledFixture("true"),
+			{
+				Method:       http.MethodGet,
+				Resource:     "/api/2.0/ip-access-lists",
+				ReuseRequest: true,
+				Response: listIPAccessListsResponse{
+					ListIPAccessListsResponse: []ipAccessListStatus{
+						{
+							ListID:      TestingID,
+							ListType:    "ALLOW",
+							IPAddresses: []string{"4.3.2.1"},
+							Enabled:     true,
+						},
+						{
+							ListID:      "vpn",
+							ListType:    "ALLOW",
+							IPAddresses: []string{"4.3.0.0/16"},
+							Enabled:     true,
+						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodPatch,
+				Resource: "/api/2.0/ip-access-lists/" + TestingID,
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/ip-access-lists/" + TestingID,
+				Response: ipAccessListStatusWrapper{
+					IPAccessList: ipAccessListStatus{
+						ListID:      TestingID,
+						Label:       "office",
+						ListType:    "ALLOW",
+						IPAddresses: []string{"1.2.3.0/24"},
+						Enabled:     true,
+					},
+				},
+			},
+		},
+		Resource: ResourceIPAccessList(),
+		InstanceState: map[string]string{
+			"label":          "office",
+			"list_type":      "ALLOW",
+			"ip_addresses.#": "1",
+			"ip_addresses.0": "4.3.2.1",
+			"enabled":        "true",
+		},
+		HCL: `
+		label = "office"
+		list_type = "ALLOW"
+		ip_addresses = ["1.2.3.0/24"]
+		lockout_check_ip = "4.3.2.1"
+		`,
+		Update: true,
+		ID:     TestingID,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+}
+
+func TestIPACLCreate_NoLockoutCheck(t *testing.T) {
+	// without lockout_check_ip neither workspace configuration nor lists are checked on apply
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			ipAccessListsFixture(),
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/ip-access-lists",
+				Response: ipAccessListStatusWrapper{
+					IPAccessList: ipAccessListStatus{
+						ListID: TestingID,
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/ip-access-lists/" + TestingID,
+				Response: ipAccessListStatusWrapper{
+					IPAccessList: ipAccessListStatus{
+						ListID:      TestingID,
+						Label:       "everyone",
+						ListType:    "ALLOW",
+						IPAddresses: []string{"0.0.0.0/0"},
+						Enabled:     true,
+					},
+				},
+			},
+		},
+		Resource: ResourceIPAccessList(),
+		HCL: `
+		label = "everyone"
+		list_type = "ALLOW"
+		ip_addresses = ["0.0.0.0/0"]
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+}
+
+func TestIPACLCreate_Quota(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			ipAccessListsFixture(),
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/ip-access-lists",
+				Status:   400,
+				Response: common.APIErrorBody{
+					ErrorCode: "QUOTA_EXCEEDED",
+					Message:   "Too many IP addresses",
+				},
+			},
+		},
+		Resource: ResourceIPAccessList(),
+		HCL: `
+		label = "office"
+		list_type = "BLOCK"
+		ip_addresses = ["1.2.3.0/24"]
+		`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "IP access lists of the workspace can have at most 1000 IP addresses")
+}
+
+func TestIPACLIsBlocked(t *testing.T) {
+	ip := net.ParseIP("10.0.0.1")
+	assert.False(t, IsBlocked(ip, []IPAccessListRule{}))
+	assert.True(t, IsBlocked(ip, []IPAccessListRule{
+		{ListType: "BLOCK", IPAddresses: []string{"0.0.0.0/0"}, Enabled: true},
+	}))
+	assert.False(t, IsBlocked(ip, []IPAccessListRule{
+		{ListType: "ALLOW", IPAddresses: []string{"10.0.0.1"}, Enabled: true},
+	}))
+	assert.False(t, IsBlocked(ip, []IPAccessListRule{
+		{ListType: "ALLOW", IPAddresses: []string{"10.0.0.0/24"}, Enabled: true},
+	}))
+	assert.True(t, IsBlocked(ip, []IPAccessListRule{
+		{ListType: "ALLOW", IPAddresses: []string{"1.2.3.4"}, Enabled: true},
+	}))
+	assert.False(t, IsBlocked(ip, []IPAccessListRule{
+		{ListType: "ALLOW", IPAddresses: []string{"1.2.3.4"}, Enabled: false},
+	}))
+	assert.True(t, IsBlocked(ip, []IPAccessListRule{
+		{ListType: "ALLOW", IPAddresses: []string{"10.0.0.1"}, Enabled: true},
+		{ListType: "BLOCK", IPAddresses: []string{"10.0.0.0/8"}, Enabled: true},
+	}))
+}
+
+func TestIPACLCreate_QuotaDuringPlan(t *testing.T) {
+	addresses := []string{}
+	for i := 0; i < 999; i++ {
+		addresses = append(addresses, fmt.Sprintf("10.0.%d.%d", i/250, i%250))
+	}
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			ipAccessListsFixture(ipAccessListStatus{
+				ListID:      "other",
+				ListType:    "ALLOW",
+				IPAddresses: addresses,
+			}),
+		},
+		Resource: ResourceIPAccessList(),
+		HCL: `
+		label = "office"
+		list_type = "ALLOW"
+		ip_addresses = ["1.2.3.4", "1.2.4.0/24"]
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.EqualError(t, err, "IP access lists of the workspace can have at most 1000 IP addresses and "+
+		"CIDR ranges combined, but 1 other lists already have 999 of them and this list adds 2. "+
+		"Please merge addresses into CIDR ranges or remove unused lists")
+}
+
+func TestIPACLCreate_Overlaps(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			ipAccessListsFixture(),
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/ip-access-lists",
+				ExpectedRequest: createIPAccessListRequest{
+					Label:       "office",
+					ListType:    "ALLOW",
+					IPAddresses: []string{"1.2.4.0/24", "1.2.3.4", "1.2.4.5"},
+				},
+				Response: ipAccessListStatusWrapper{
+					IPAccessList: ipAccessListStatus{
+						ListID: "123",
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/ip-access-lists/123",
+				Response: ipAccessListStatusWrapper{
+					IPAccessList: ipAccessListStatus{
+						ListID:      "123",
+						Label:       "office",
+						ListType:    "ALLOW",
+						IPAddresses: []string{"1.2.4.0/24", "1.2.3.4", "1.2.4.5"},
+						Enabled:     true,
+					},
+				},
+			},
+		},
+		Resource: ResourceIPAccessList(),
+		HCL: `
+		label = "office"
+		list_type = "ALLOW"
+		ip_addresses = ["1.2.4.0/24", "1.2.3.4", "1.2.4.5"]
+		`,
+		Create: true,
+	}.Apply(t)
+	// overlaps are only a warning
+	assert.NoError(t, err, err)
+}
+
+func TestIPACLCreate_ListsQuota(t *testing.T) {
+	lists := []ipAccessListStatus{}
+	for i := 0; i < maxIPAccessLists; i++ {
+		lists = append(lists, ipAccessListStatus{
+			ListID:      fmt.Sprintf("list-%d", i),
+			ListType:    "BLOCK",
+			IPAddresses: []string{},
+		})
+	}
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			ipAccessListsFixture(lists...),
+		},
+		Resource: ResourceIPAccessList(),
+		HCL: `
+		label = "office"
+		list_type = "ALLOW"
+		ip_addresses = ["1.2.3.4"]
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.EqualError(t, err, "Workspace can have at most 1000 IP access lists, but it already has "+
+		"1000 of them. Please merge or remove unused lists")
+}
+
+func TestIPACLCheckOverlaps(t *testing.T) {
+	assert.Len(t, checkOverlaps([]string{"1.2.3.4", "1.2.3.5", "1.2.4.0/24", "1.2.5.0/24"}), 0)
+
+	diags := checkOverlaps([]string{"1.2.3.4", "1.2.3.4"})
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "1.2.3.4 overlaps with 1.2.3.4 in the same list", diags[0].Summary)
+	assert.Equal(t, cty.GetAttrPath("ip_addresses").IndexInt(1), diags[0].AttributePath)
+
+	diags = checkOverlaps([]string{"1.2.3.0/24", "1.2.0.0/16", "1.2"})
+	require.Len(t, diags, 1)
+	assert.Equal(t, "1.2.0.0/16 overlaps with 1.2.3.0/24 in the same list", diags[0].Summary)
+}
+
+func TestIPACLValidIPAddressOrCIDR(t *testing.T) {
+	ws, es := validIPAddressOrCIDR("1.2.3.0/24", "ip_addresses.0")
+	assert.Len(t, ws, 0)
+	assert.Len(t, es, 0)
+
+	ws, es = validIPAddressOrCIDR("1.2.3.4/24", "ip_addresses.0")
+	assert.Equal(t, []string{"ip_addresses.0: 1.2.3.4/24 has host bits set and covers the whole 1.2.3.0/24 range"}, ws)
+	assert.Len(t, es, 0)
+
+	_, es = validIPAddressOrCIDR("1.2.3.0/33", "ip_addresses.0")
+	assert.EqualError(t, es[0], "ip_addresses.0: 1.2.3.0/33 is not a valid CIDR range")
+
+	_, es = validIPAddressOrCIDR("1.2.3", "ip_addresses.0")
+	assert.Len(t, es, 1)
+}
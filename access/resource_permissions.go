@@ -3,6 +3,7 @@ package access
 import (
 	"context"
 	"fmt"
+	"log"
 	"path"
 	"strconv"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	"github.com/databrickslabs/databricks-terraform/workspace"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/pkg/errors"
 )
 
@@ -78,6 +80,17 @@ type AccessControlChange struct {
 	PermissionLevel      string `json:"permission_level"`
 }
 
+// principal returns name of user, group or service principal
+func (acc AccessControlChange) principal() string {
+	switch {
+	case acc.UserName != "":
+		return "user " + acc.UserName
+	case acc.GroupName != "":
+		return "group " + acc.GroupName
+	}
+	return "service principal " + acc.ServicePrincipalName
+}
+
 func (acc AccessControlChange) String() string {
 	return fmt.Sprintf("%v%v%v %s", acc.UserName, acc.GroupName, acc.ServicePrincipalName,
 		acc.PermissionLevel)
@@ -99,33 +112,147 @@ type PermissionsAPI struct {
 
 // Update updates object permissions. Technically, it's using method named SetOrDelete, but here we do more
 func (a PermissionsAPI) Update(objectID string, objectACL AccessControlChangeList) error {
-	if "/authorization/tokens" == objectID {
+	warnings, err := a.update(objectID, objectACL)
+	for _, warning := range warnings {
+		log.Printf("[WARN] %s", warning)
+	}
+	return err
+}
+
+// update replaces object permissions and returns warnings about configured entries, that were ignored
+func (a PermissionsAPI) update(objectID string, objectACL AccessControlChangeList) ([]string, error) {
+	err := validatePermissionLevels(objectID, objectACL)
+	if err != nil {
+		return nil, err
+	}
+	objectACL, warnings, err := a.withMandatoryEntries(objectID, objectACL)
+	if err != nil {
+		return nil, err
+	}
+	return warnings, a.client.Put(a.context, "/preview/permissions"+objectID, objectACL)
+}
+
+// permissionLevels restricts permission levels, that could be assigned on objects with given ID prefix
+var permissionLevels = []struct {
+	prefix, kind string
+	levels       []string
+}{
+	{"/sql/warehouses", "SQL endpoints", []string{"CAN_USE", "CAN_MANAGE", "IS_OWNER"}},
+	{"/authorization/tokens", "tokens", []string{"CAN_USE"}},
+	{"/authorization/passwords", "passwords", []string{"CAN_USE"}},
+}
+
+// validatePermissionLevels checks configured permission levels before anything is sent to API
+func validatePermissionLevels(objectID string, objectACL AccessControlChangeList) error {
+	for _, pl := range permissionLevels {
+		if !strings.HasPrefix(objectID, pl.prefix) {
+			continue
+		}
+		for _, acl := range objectACL.AccessControlList {
+			if acl.GroupName == "admins" && objectID != "/authorization/passwords" {
+				// ignored anyway, see withMandatoryEntries
+				continue
+			}
+			allowed := false
+			for _, level := range pl.levels {
+				if acl.PermissionLevel == level {
+					allowed = true
+				}
+			}
+			if !allowed {
+				return fmt.Errorf("Permission level %s is not supported for %s. Only %s allowed",
+					acl.PermissionLevel, pl.kind, strings.Join(pl.levels, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// withMandatoryEntries merges entries of admins group and object owner into the access control list,
+// so that applying it never locks everyone out of the object. Configured entries for the same
+// principals are dropped and reported as warnings.
+func (a PermissionsAPI) withMandatoryEntries(objectID string,
+	objectACL AccessControlChangeList) (AccessControlChangeList, []string, error) {
+	var mandatory []AccessControlChange
+	var warnings []string
+	kind, owned := ownedObjectKind(objectID)
+	owners := 0
+	for _, acl := range objectACL.AccessControlList {
+		if acl.PermissionLevel == "IS_OWNER" {
+			owners++
+		}
+	}
+	if owners > 1 {
+		// object has exactly one owner and putting the new one replaces the previous
+		return objectACL, nil, fmt.Errorf("%s can have only one IS_OWNER, but %d are configured", kind, owners)
+	}
+	if objectID != "/authorization/passwords" {
+		current, err := a.Read(objectID)
+		if err != nil {
+			return objectACL, nil, err
+		}
+		for _, acl := range current.AccessControlList {
+			change, direct := acl.toAccessControlChange()
+			if !direct {
+				continue
+			}
+			if acl.GroupName == "admins" && objectID != "/authorization/tokens" {
+				// keep everything direct for admin group
+				mandatory = append(mandatory, change)
+			}
+			if change.PermissionLevel == "IS_OWNER" && owned && owners == 0 {
+				// keep current owner, if new one is not configured
+				mandatory = append(mandatory, change)
+				owners++
+			}
+		}
+	}
+	if objectID == "/authorization/tokens" {
 		// Cannot remove admins's CAN_MANAGE permission on tokens
-		objectACL.AccessControlList = append(objectACL.AccessControlList, AccessControlChange{
+		mandatory = append(mandatory, AccessControlChange{
 			GroupName:       "admins",
 			PermissionLevel: "CAN_MANAGE",
 		})
 	}
-	if strings.HasPrefix(objectID, "/jobs") {
-		owners := 0
-		for _, acl := range objectACL.AccessControlList {
-			if acl.PermissionLevel == "IS_OWNER" {
-				owners++
-			}
+	if owned && owners == 0 {
+		me, err := identity.NewUsersAPI(a.context, a.client).Me()
+		if err != nil {
+			return objectACL, nil, err
 		}
-		if owners == 0 {
-			me, err := identity.NewUsersAPI(a.context, a.client).Me()
-			if err != nil {
-				return err
+		// add owner if it's missing, otherwise automated planning might be difficult
+		mandatory = append(mandatory, AccessControlChange{
+			UserName:        me.UserName,
+			PermissionLevel: "IS_OWNER",
+		})
+	}
+	merged := AccessControlChangeList{}
+	for _, acl := range objectACL.AccessControlList {
+		if acl.GroupName == "admins" && objectID != "/authorization/passwords" {
+			warnings = append(warnings, fmt.Sprintf("Permissions of admins group on %s cannot be changed, "+
+				"so configured %s is ignored", objectID, acl.PermissionLevel))
+			continue
+		}
+		if m, ok := findPrincipal(mandatory, acl); ok {
+			if m.PermissionLevel != acl.PermissionLevel {
+				warnings = append(warnings, fmt.Sprintf("%s is the owner of %s, so configured %s is ignored",
+					acl.principal(), objectID, acl.PermissionLevel))
 			}
-			// add owner if it's missing, otherwise automated planning might be difficult
-			objectACL.AccessControlList = append(objectACL.AccessControlList, AccessControlChange{
-				UserName:        me.UserName,
-				PermissionLevel: "IS_OWNER",
-			})
+			continue
+		}
+		merged.AccessControlList = append(merged.AccessControlList, acl)
+	}
+	merged.AccessControlList = append(merged.AccessControlList, mandatory...)
+	return merged, warnings, nil
+}
+
+// findPrincipal returns access control change for the same user, group or service principal
+func findPrincipal(changes []AccessControlChange, acl AccessControlChange) (AccessControlChange, bool) {
+	for _, change := range changes {
+		if change.principal() == acl.principal() {
+			return change, true
 		}
 	}
-	return a.client.Put(a.context, "/preview/permissions"+objectID, objectACL)
+	return AccessControlChange{}, false
 }
 
 // Delete gracefully removes permissions. Technically, it's using method named SetOrDelete, but here we do more
@@ -153,9 +280,33 @@ func (a PermissionsAPI) Delete(objectID string) error {
 			PermissionLevel: "IS_OWNER",
 		})
 	}
+	if strings.HasPrefix(objectID, "/sql/warehouses") {
+		var warehouse struct {
+			CreatorName string `json:"creator_name"`
+		}
+		err = a.client.Get(a.context, objectID, nil, &warehouse)
+		if err != nil {
+			return err
+		}
+		accl.AccessControlList = append(accl.AccessControlList, AccessControlChange{
+			UserName:        warehouse.CreatorName,
+			PermissionLevel: "IS_OWNER",
+		})
+	}
 	return a.client.Put(a.context, "/preview/permissions"+objectID, accl)
 }
 
+// ownedObjectKind tells if the object always has a single IS_OWNER and returns its kind for error messages
+func ownedObjectKind(objectID string) (string, bool) {
+	switch {
+	case strings.HasPrefix(objectID, "/jobs"):
+		return "Job", true
+	case strings.HasPrefix(objectID, "/sql/warehouses"):
+		return "SQL endpoint", true
+	}
+	return "", false
+}
+
 // Read gets all relevant permissions for the object, including inherited ones
 func (a PermissionsAPI) Read(objectID string) (objectACL ObjectACL, err error) {
 	err = a.client.Get(a.context, "/preview/permissions"+objectID, nil, &objectACL)
@@ -190,17 +341,58 @@ func permissionsResourceIDFields(ctx context.Context) []permissionsIDFieldMappin
 		{"notebook_path", "notebook", "notebooks", PATH},
 		{"directory_id", "directory", "directories", SIMPLE},
 		{"directory_path", "directory", "directories", PATH},
+		{"sql_endpoint_id", "warehouses", "sql/warehouses", SIMPLE},
 		{"authorization", "tokens", "authorization", SIMPLE},
 		{"authorization", "passwords", "authorization", SIMPLE},
 	}
 }
 
+// validatePermissionsObjectID checks, that object ID is in /<resource type>/<id> format of one of supported resources
+func validatePermissionsObjectID(ctx context.Context, objectID string) error {
+	formats := []string{}
+	for _, mapping := range permissionsResourceIDFields(ctx) {
+		if strings.HasSuffix(mapping.field, "_path") {
+			// paths are resolved to IDs on create, so that they have the same object ID
+			continue
+		}
+		prefix := "/" + mapping.resourceType + "/"
+		if mapping.field == "authorization" {
+			if objectID == prefix+mapping.objectType {
+				return nil
+			}
+			formats = append(formats, prefix+mapping.objectType)
+			continue
+		}
+		id := strings.TrimPrefix(objectID, prefix)
+		if strings.HasPrefix(objectID, prefix) && id != "" && !strings.Contains(id, "/") {
+			return nil
+		}
+		formats = append(formats, fmt.Sprintf("%s<%s>", prefix, mapping.field))
+	}
+	return fmt.Errorf("Unsupported object %s. ID has to be one of %s", objectID, strings.Join(formats, ", "))
+}
+
 // PermissionsEntity is the one used for resource metadata
 type PermissionsEntity struct {
 	ObjectType        string                `json:"object_type,omitempty" tf:"computed"`
 	AccessControlList []AccessControlChange `json:"access_control" tf:"slice_set"`
 }
 
+// isOwnerConfigured tells if IS_OWNER is among configured access controls. It's always false
+// while importing, because the owner is kept on update anyway.
+func isOwnerConfigured(d *schema.ResourceData) bool {
+	accessControl, ok := d.Get("access_control").(*schema.Set)
+	if !ok {
+		return false
+	}
+	for _, v := range accessControl.List() {
+		if v.(map[string]interface{})["permission_level"] == "IS_OWNER" {
+			return true
+		}
+	}
+	return false
+}
+
 // ToPermissionsEntity ..
 func (oa *ObjectACL) ToPermissionsEntity(ctx context.Context, d *schema.ResourceData, me string) (PermissionsEntity, error) {
 	entity := PermissionsEntity{}
@@ -213,9 +405,15 @@ func (oa *ObjectACL) ToPermissionsEntity(ctx context.Context, d *schema.Resource
 			// not possible to lower one's permissions anywhere from CAN_MANAGE
 			continue
 		}
-		if change, direct := accessControl.toAccessControlChange(); direct {
-			entity.AccessControlList = append(entity.AccessControlList, change)
+		change, direct := accessControl.toAccessControlChange()
+		if !direct {
+			continue
+		}
+		if change.PermissionLevel == "IS_OWNER" && !isOwnerConfigured(d) {
+			// every job and SQL endpoint has an owner, that is added on update, if it's not configured
+			continue
 		}
+		entity.AccessControlList = append(entity.AccessControlList, change)
 	}
 	for _, mapping := range permissionsResourceIDFields(ctx) {
 		if mapping.objectType != oa.ObjectType {
@@ -237,6 +435,17 @@ func (oa *ObjectACL) ToPermissionsEntity(ctx context.Context, d *schema.Resource
 	return entity, fmt.Errorf("Unknown object type %s", oa.ObjectType)
 }
 
+// warningDiagnostics converts warnings to diagnostics, that are shown to the user without failing the apply
+func warningDiagnostics(warnings []string) (diags diag.Diagnostics) {
+	for _, warning := range warnings {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  warning,
+		})
+	}
+	return
+}
+
 // ResourcePermissions definition
 func ResourcePermissions() *schema.Resource {
 	s := internal.StructToSchema(PermissionsEntity{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
@@ -254,6 +463,7 @@ func ResourcePermissions() *schema.Resource {
 				s[mapping.field].ConflictsWith = append(s[mapping.field].ConflictsWith, m.field)
 			}
 		}
+		s["authorization"].ValidateFunc = validation.StringInSlice([]string{"tokens", "passwords"}, false)
 		s["access_control"].MinItems = 1
 		return s
 	})
@@ -280,6 +490,10 @@ func ResourcePermissions() *schema.Resource {
 			d.SetId("")
 			return nil
 		}
+		if _, ok := d.GetOk("access_control"); !ok {
+			// access controls are not in the state only while importing
+			d.MarkNewResource()
+		}
 		err = internal.StructToData(entity, s, d)
 		if err != nil {
 			return diag.FromErr(err)
@@ -302,14 +516,14 @@ func ResourcePermissions() *schema.Resource {
 						return diag.FromErr(err)
 					}
 					objectID := fmt.Sprintf("/%s/%s", mapping.resourceType, id)
-					err = NewPermissionsAPI(ctx, m).Update(objectID, AccessControlChangeList{
+					warnings, err := NewPermissionsAPI(ctx, m).update(objectID, AccessControlChangeList{
 						AccessControlList: entity.AccessControlList,
 					})
 					if err != nil {
 						return diag.FromErr(err)
 					}
 					d.SetId(objectID)
-					return readContext(ctx, d, m)
+					return append(warningDiagnostics(warnings), readContext(ctx, d, m)...)
 				}
 			}
 			return diag.Errorf("At least one type of resource identifiers must be set")
@@ -320,13 +534,13 @@ func ResourcePermissions() *schema.Resource {
 			if err != nil {
 				return diag.FromErr(err)
 			}
-			err = NewPermissionsAPI(ctx, m).Update(d.Id(), AccessControlChangeList{
+			warnings, err := NewPermissionsAPI(ctx, m).update(d.Id(), AccessControlChangeList{
 				AccessControlList: entity.AccessControlList,
 			})
 			if err != nil {
 				return diag.FromErr(err)
 			}
-			return readContext(ctx, d, m)
+			return append(warningDiagnostics(warnings), readContext(ctx, d, m)...)
 		},
 		DeleteContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 			err := NewPermissionsAPI(ctx, m).Delete(d.Id())
@@ -336,7 +550,13 @@ func ResourcePermissions() *schema.Resource {
 			return nil
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: func(ctx context.Context, d *schema.ResourceData,
+				m interface{}) ([]*schema.ResourceData, error) {
+				if err := validatePermissionsObjectID(ctx, d.Id()); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
 		},
 	}
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/databrickslabs/databricks-terraform/workspace"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -314,30 +315,766 @@ func TestResourcePermissionsCreate(t *testing.T) {
 					},
 				},
 			},
+			{
+				Method:       http.MethodGet,
+				ReuseRequest: true,
+				Resource:     "/api/2.0/preview/permissions/clusters/abc",
+				Response: ObjectACL{
+					ObjectID:   "/clusters/abc",
+					ObjectType: "cluster",
+					AccessControlList: []AccessControl{
+						{
+							UserName: TestingUser,
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_READ",
+									Inherited:       false,
+								},
+							},
+						},
+						{
+							UserName: TestingAdminUser,
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_MANAGE",
+									Inherited:       false,
+								},
+							},
+						},
+					},
+				},
+			},
 			{
 				Method:   http.MethodGet,
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Response: identity.ScimUser{
+					UserName: TestingAdminUser,
+				},
+			},
+		},
+		Resource: ResourcePermissions(),
+		State: map[string]interface{}{
+			"cluster_id": "abc",
+			"access_control": []interface{}{
+				map[string]interface{}{
+					"user_name":        TestingUser,
+					"permission_level": "CAN_READ",
+				},
+			},
+		},
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	ac := d.Get("access_control").(*schema.Set)
+	require.Equal(t, 1, len(ac.List()))
+	firstElem := ac.List()[0].(map[string]interface{})
+	assert.Equal(t, TestingUser, firstElem["user_name"])
+	assert.Equal(t, "CAN_READ", firstElem["permission_level"])
+}
+
+func TestResourcePermissionsCreate_ClusterCanRestart(t *testing.T) {
+	accessControl := []interface{}{
+		map[string]interface{}{
+			"group_name":       "oncall",
+			"permission_level": "CAN_RESTART",
+		},
+	}
+	r := ResourcePermissions()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPut,
 				Resource: "/api/2.0/preview/permissions/clusters/abc",
+				ExpectedRequest: AccessControlChangeList{
+					AccessControlList: []AccessControlChange{
+						{
+							GroupName:       "oncall",
+							PermissionLevel: "CAN_RESTART",
+						},
+					},
+				},
+			},
+			{
+				Method:       http.MethodGet,
+				ReuseRequest: true,
+				Resource:     "/api/2.0/preview/permissions/clusters/abc",
 				Response: ObjectACL{
 					ObjectID:   "/clusters/abc",
 					ObjectType: "cluster",
+					AccessControlList: []AccessControl{
+						{
+							GroupName: "oncall",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel:     "CAN_ATTACH_TO",
+									Inherited:           true,
+									InheritedFromObject: []string{"/clusters/"},
+								},
+								{
+									PermissionLevel: "CAN_RESTART",
+								},
+							},
+						},
+						{
+							GroupName: "admins",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel:     "CAN_MANAGE",
+									Inherited:           true,
+									InheritedFromObject: []string{"/clusters/"},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Response: identity.ScimUser{
+					UserName: TestingAdminUser,
+				},
+			},
+		},
+		Resource: r,
+		State: map[string]interface{}{
+			"cluster_id":     "abc",
+			"access_control": accessControl,
+		},
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	ac := d.Get("access_control").(*schema.Set)
+	require.Equal(t, 1, len(ac.List()))
+	firstElem := ac.List()[0].(map[string]interface{})
+	assert.Equal(t, "oncall", firstElem["group_name"])
+	assert.Equal(t, "CAN_RESTART", firstElem["permission_level"])
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cluster_id":     "abc",
+		"access_control": accessControl,
+	}), nil)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "plan is not stable: %v", diff)
+}
+
+func TestResourcePermissionsCreate_NotebookPath_NotExists(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2FDevelopment%2FInit",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource: ResourcePermissions(),
+		State: map[string]interface{}{
+			"notebook_path": "/Development/Init",
+			"access_control": []interface{}{
+				map[string]interface{}{
+					"user_name":        TestingUser,
+					"permission_level": "CAN_USE",
+				},
+			},
+		},
+		Create: true,
+	}.Apply(t)
+
+	assert.Error(t, err)
+}
+
+func TestResourcePermissionsCreate_NotebookPath(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2FDevelopment%2FInit",
+				Response: workspace.ObjectStatus{
+					ObjectID:   988765,
+					ObjectType: "NOTEBOOK",
+				},
+			},
+			{
+				Method:   http.MethodPut,
+				Resource: "/api/2.0/preview/permissions/notebooks/988765",
+				ExpectedRequest: AccessControlChangeList{
+					AccessControlList: []AccessControlChange{
+						{
+							UserName:        TestingUser,
+							PermissionLevel: "CAN_USE",
+						},
+					},
+				},
+			},
+			{
+				Method:       http.MethodGet,
+				ReuseRequest: true,
+				Resource:     "/api/2.0/preview/permissions/notebooks/988765",
+				Response: ObjectACL{
+					ObjectID:   "/notebooks/988765",
+					ObjectType: "notebook",
 					AccessControlList: []AccessControl{
 						{
 							UserName: TestingUser,
 							AllPermissions: []Permission{
 								{
-									PermissionLevel: "CAN_READ",
+									PermissionLevel: "CAN_USE",
+									Inherited:       false,
+								},
+							},
+						},
+						{
+							UserName: TestingAdminUser,
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_MANAGE",
 									Inherited:       false,
 								},
 							},
 						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Response: identity.ScimUser{
+					UserName: TestingAdminUser,
+				},
+			},
+		},
+		Resource: ResourcePermissions(),
+		State: map[string]interface{}{
+			"notebook_path": "/Development/Init",
+			"access_control": []interface{}{
+				map[string]interface{}{
+					"user_name":        TestingUser,
+					"permission_level": "CAN_USE",
+				},
+			},
+		},
+		Create: true,
+	}.Apply(t)
+
+	assert.NoError(t, err, err)
+	ac := d.Get("access_control").(*schema.Set)
+	require.Equal(t, 1, len(ac.List()))
+	firstElem := ac.List()[0].(map[string]interface{})
+	assert.Equal(t, TestingUser, firstElem["user_name"])
+	assert.Equal(t, "CAN_USE", firstElem["permission_level"])
+}
+
+func TestResourcePermissionsCreate_error(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/preview/permissions/clusters/abc",
+				Response: ObjectACL{},
+			},
+			{
+				Method:   http.MethodPut,
+				Resource: "/api/2.0/preview/permissions/clusters/abc",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource: ResourcePermissions(),
+		State: map[string]interface{}{
+			"cluster_id": "abc",
+			"access_control": []interface{}{
+				map[string]interface{}{
+					"user_name":        TestingUser,
+					"permission_level": "CAN_USE",
+				},
+			},
+		},
+		Create: true,
+	}.Apply(t)
+	if assert.Error(t, err) {
+		if e, ok := err.(common.APIError); ok {
+			assert.Equal(t, "INVALID_REQUEST", e.ErrorCode)
+		}
+	}
+}
+
+func TestResourcePermissionsUpdate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       http.MethodGet,
+				ReuseRequest: true,
+				Resource:     "/api/2.0/preview/permissions/jobs/9",
+				Response: ObjectACL{
+					ObjectID:   "/jobs/9",
+					ObjectType: "job",
+					AccessControlList: []AccessControl{
+						{
+							UserName: TestingUser,
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_RUN",
+									Inherited:       false,
+								},
+							},
+						},
+						{
+							UserName: TestingAdminUser,
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_MANAGE",
+									Inherited:       false,
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:       http.MethodGet,
+				ReuseRequest: true,
+				Resource:     "/api/2.0/preview/scim/v2/Me",
+				Response: identity.ScimUser{
+					UserName: TestingAdminUser,
+				},
+			},
+			{
+				Method:   http.MethodPut,
+				Resource: "/api/2.0/preview/permissions/jobs/9",
+				ExpectedRequest: AccessControlChangeList{
+					AccessControlList: []AccessControlChange{
+						{
+							UserName:        TestingUser,
+							PermissionLevel: "CAN_RUN",
+						},
+						{
+							UserName:        TestingAdminUser,
+							PermissionLevel: "IS_OWNER",
+						},
+					},
+				},
+			},
+		},
+		HCL: `
+		job_id = 9
+
+		access_control {
+			user_name = "ben"
+			permission_level = "CAN_RUN"
+		}
+		`,
+		Resource: ResourcePermissions(),
+		Update:   true,
+		ID:       "/jobs/9",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "/jobs/9", d.Id())
+	ac := d.Get("access_control").(*schema.Set)
+	require.Equal(t, 1, len(ac.List()))
+	firstElem := ac.List()[0].(map[string]interface{})
+	assert.Equal(t, TestingUser, firstElem["user_name"])
+	assert.Equal(t, "CAN_RUN", firstElem["permission_level"])
+}
+
+func TestResourcePermissionsUpdate_JobOwnerTransfer(t *testing.T) {
+	r := ResourcePermissions()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPut,
+				Resource: "/api/2.0/preview/permissions/jobs/9",
+				ExpectedRequest: AccessControlChangeList{
+					AccessControlList: []AccessControlChange{
+						{
+							ServicePrincipalName: "ci",
+							PermissionLevel:      "IS_OWNER",
+						},
+						{
+							GroupName:       "oncall",
+							PermissionLevel: "CAN_MANAGE_RUN",
+						},
+					},
+				},
+			},
+			{
+				Method:       http.MethodGet,
+				ReuseRequest: true,
+				Resource:     "/api/2.0/preview/permissions/jobs/9",
+				Response: ObjectACL{
+					ObjectID:   "/jobs/9",
+					ObjectType: "job",
+					AccessControlList: []AccessControl{
+						{
+							GroupName: "oncall",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_MANAGE_RUN",
+								},
+							},
+						},
+						{
+							ServicePrincipalName: "ci",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "IS_OWNER",
+								},
+							},
+						},
+						{
+							GroupName: "admins",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel:     "CAN_MANAGE",
+									Inherited:           true,
+									InheritedFromObject: []string{"/jobs/"},
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Response: identity.ScimUser{
+					UserName: TestingAdminUser,
+				},
+			},
+		},
+		HCL: `
+		job_id = 9
+
+		access_control {
+			group_name = "oncall"
+			permission_level = "CAN_MANAGE_RUN"
+		}
+
+		access_control {
+			service_principal_name = "ci"
+			permission_level = "IS_OWNER"
+		}
+		`,
+		Resource: r,
+		Update:   true,
+		ID:       "/jobs/9",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	ac := d.Get("access_control").(*schema.Set)
+	require.Equal(t, 2, len(ac.List()))
+	levels := map[string]string{}
+	for _, v := range ac.List() {
+		elem := v.(map[string]interface{})
+		levels[elem["group_name"].(string)+elem["service_principal_name"].(string)] =
+			elem["permission_level"].(string)
+	}
+	assert.Equal(t, map[string]string{
+		"oncall": "CAN_MANAGE_RUN",
+		"ci":     "IS_OWNER",
+	}, levels)
+}
+
+func TestResourcePermissionsUpdate_JobManyOwners(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		HCL: `
+		job_id = 9
+
+		access_control {
+			user_name = "ben"
+			permission_level = "IS_OWNER"
+		}
+
+		access_control {
+			service_principal_name = "ci"
+			permission_level = "IS_OWNER"
+		}
+		`,
+		Resource: ResourcePermissions(),
+		Update:   true,
+		ID:       "/jobs/9",
+	}.Apply(t)
+	assert.EqualError(t, err, "Job can have only one IS_OWNER, but 2 are configured")
+}
+
+func TestResourcePermissionsRead_JobOwnerNotConfigured(t *testing.T) {
+	accessControl := []interface{}{
+		map[string]interface{}{
+			"group_name":       "oncall",
+			"permission_level": "CAN_MANAGE_RUN",
+		},
+	}
+	r := ResourcePermissions()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/preview/permissions/jobs/9",
+				Response: ObjectACL{
+					ObjectID:   "/jobs/9",
+					ObjectType: "job",
+					AccessControlList: []AccessControl{
+						{
+							GroupName: "oncall",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_MANAGE_RUN",
+								},
+							},
+						},
+						{
+							UserName: "creator",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "IS_OWNER",
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Response: identity.ScimUser{
+					UserName: TestingAdminUser,
+				},
+			},
+		},
+		State: map[string]interface{}{
+			"job_id":         "9",
+			"access_control": accessControl,
+		},
+		Resource: r,
+		Read:     true,
+		ID:       "/jobs/9",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	ac := d.Get("access_control").(*schema.Set)
+	require.Equal(t, 1, len(ac.List()))
+	firstElem := ac.List()[0].(map[string]interface{})
+	assert.Equal(t, "oncall", firstElem["group_name"])
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"job_id":         "9",
+		"access_control": accessControl,
+	}), nil)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "plan is not stable: %v", diff)
+}
+
+func TestResourcePermissionsCreate_SQLEndpoint(t *testing.T) {
+	accessControl := []interface{}{
+		map[string]interface{}{
+			"group_name":       "analysts",
+			"permission_level": "CAN_USE",
+		},
+	}
+	r := ResourcePermissions()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       http.MethodGet,
+				ReuseRequest: true,
+				Resource:     "/api/2.0/preview/scim/v2/Me",
+				Response: identity.ScimUser{
+					UserName: TestingAdminUser,
+				},
+			},
+			{
+				Method:   http.MethodPut,
+				Resource: "/api/2.0/preview/permissions/sql/warehouses/abc",
+				ExpectedRequest: AccessControlChangeList{
+					AccessControlList: []AccessControlChange{
+						{
+							GroupName:       "analysts",
+							PermissionLevel: "CAN_USE",
+						},
+						{
+							UserName:        TestingAdminUser,
+							PermissionLevel: "IS_OWNER",
+						},
+					},
+				},
+			},
+			{
+				Method:       http.MethodGet,
+				ReuseRequest: true,
+				Resource:     "/api/2.0/preview/permissions/sql/warehouses/abc",
+				Response: ObjectACL{
+					ObjectID:   "warehouses/abc",
+					ObjectType: "warehouses",
+					AccessControlList: []AccessControl{
+						{
+							GroupName: "analysts",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_USE",
+								},
+							},
+						},
+						{
+							UserName: TestingAdminUser,
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "IS_OWNER",
+								},
+							},
+						},
+						{
+							GroupName: "admins",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_MANAGE",
+									Inherited:       true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Resource: r,
+		State: map[string]interface{}{
+			"sql_endpoint_id": "abc",
+			"access_control":  accessControl,
+		},
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "/sql/warehouses/abc", d.Id())
+	assert.Equal(t, "warehouses", d.Get("object_type"))
+	assert.Equal(t, "abc", d.Get("sql_endpoint_id"))
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"sql_endpoint_id": "abc",
+		"access_control":  accessControl,
+	}), nil)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "plan is not stable: %v", diff)
+}
+
+func TestResourcePermissionsCreate_SQLEndpointInvalidLevel(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		HCL: `
+		sql_endpoint_id = "abc"
+
+		access_control {
+			group_name = "analysts"
+			permission_level = "CAN_RUN"
+		}
+		`,
+		Resource: ResourcePermissions(),
+		Create:   true,
+	}.Apply(t)
+	assert.EqualError(t, err, "Permission level CAN_RUN is not supported for SQL endpoints. "+
+		"Only CAN_USE, CAN_MANAGE, IS_OWNER allowed")
+}
+
+func TestResourcePermissionsDelete_SQLEndpoint(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/preview/permissions/sql/warehouses/abc",
+				Response: ObjectACL{
+					ObjectID:   "warehouses/abc",
+					ObjectType: "warehouses",
+					AccessControlList: []AccessControl{
+						{
+							GroupName: "analysts",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_USE",
+								},
+							},
+						},
+						{
+							UserName: TestingUser,
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "IS_OWNER",
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/sql/warehouses/abc",
+				Response: map[string]string{
+					"id":           "abc",
+					"creator_name": "creator",
+				},
+			},
+			{
+				Method:   http.MethodPut,
+				Resource: "/api/2.0/preview/permissions/sql/warehouses/abc",
+				ExpectedRequest: AccessControlChangeList{
+					AccessControlList: []AccessControlChange{
+						{
+							UserName:        "creator",
+							PermissionLevel: "IS_OWNER",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourcePermissions(),
+		Delete:   true,
+		ID:       "/sql/warehouses/abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+}
+
+func TestResourcePermissionsCreate_Tokens(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       http.MethodGet,
+				ReuseRequest: true,
+				Resource:     "/api/2.0/preview/permissions/authorization/tokens",
+				Response: ObjectACL{
+					ObjectID:   "authorization/tokens",
+					ObjectType: "tokens",
+					AccessControlList: []AccessControl{
+						{
+							GroupName: "users",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_USE",
+								},
+							},
+						},
+						{
+							GroupName: "admins",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_MANAGE",
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   http.MethodPut,
+				Resource: "/api/2.0/preview/permissions/authorization/tokens",
+				ExpectedRequest: AccessControlChangeList{
+					AccessControlList: []AccessControlChange{
+						{
+							GroupName:       "users",
+							PermissionLevel: "CAN_USE",
+						},
 						{
-							UserName: TestingAdminUser,
-							AllPermissions: []Permission{
-								{
-									PermissionLevel: "CAN_MANAGE",
-									Inherited:       false,
-								},
-							},
+							GroupName:       "admins",
+							PermissionLevel: "CAN_MANAGE",
 						},
 					},
 				},
@@ -351,72 +1088,51 @@ func TestResourcePermissionsCreate(t *testing.T) {
 			},
 		},
 		Resource: ResourcePermissions(),
-		State: map[string]interface{}{
-			"cluster_id": "abc",
-			"access_control": []interface{}{
-				map[string]interface{}{
-					"user_name":        TestingUser,
-					"permission_level": "CAN_READ",
-				},
-			},
-		},
+		HCL: `
+		authorization = "tokens"
+
+		access_control {
+			group_name = "users"
+			permission_level = "CAN_USE"
+		}
+		`,
 		Create: true,
 	}.Apply(t)
-	assert.NoError(t, err, err)
+	require.NoError(t, err, err)
+	assert.Equal(t, "/authorization/tokens", d.Id())
+	assert.Equal(t, "tokens", d.Get("authorization"))
+	assert.Equal(t, "tokens", d.Get("object_type"))
 	ac := d.Get("access_control").(*schema.Set)
-	require.Equal(t, 1, len(ac.List()))
-	firstElem := ac.List()[0].(map[string]interface{})
-	assert.Equal(t, TestingUser, firstElem["user_name"])
-	assert.Equal(t, "CAN_READ", firstElem["permission_level"])
+	require.Equal(t, 1, len(ac.List()), "admins group must not be in the state")
+	assert.Equal(t, "users", ac.List()[0].(map[string]interface{})["group_name"])
 }
 
-func TestResourcePermissionsCreate_NotebookPath_NotExists(t *testing.T) {
+func TestResourcePermissionsCreate_TokensInvalidLevel(t *testing.T) {
 	_, err := qa.ResourceFixture{
-		Fixtures: []qa.HTTPFixture{
-			{
-				Method:   http.MethodGet,
-				Resource: "/api/2.0/workspace/get-status?path=%2FDevelopment%2FInit",
-				Response: common.APIErrorBody{
-					ErrorCode: "INVALID_REQUEST",
-					Message:   "Internal error happened",
-				},
-				Status: 400,
-			},
-		},
 		Resource: ResourcePermissions(),
-		State: map[string]interface{}{
-			"notebook_path": "/Development/Init",
-			"access_control": []interface{}{
-				map[string]interface{}{
-					"user_name":        TestingUser,
-					"permission_level": "CAN_USE",
-				},
-			},
-		},
+		HCL: `
+		authorization = "tokens"
+
+		access_control {
+			group_name = "users"
+			permission_level = "CAN_MANAGE"
+		}
+		`,
 		Create: true,
 	}.Apply(t)
-
-	assert.Error(t, err)
+	assert.EqualError(t, err, "Permission level CAN_MANAGE is not supported for tokens. Only CAN_USE allowed")
 }
 
-func TestResourcePermissionsCreate_NotebookPath(t *testing.T) {
+func TestResourcePermissionsCreate_Passwords(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
-			{
-				Method:   http.MethodGet,
-				Resource: "/api/2.0/workspace/get-status?path=%2FDevelopment%2FInit",
-				Response: workspace.ObjectStatus{
-					ObjectID:   988765,
-					ObjectType: "NOTEBOOK",
-				},
-			},
 			{
 				Method:   http.MethodPut,
-				Resource: "/api/2.0/preview/permissions/notebooks/988765",
+				Resource: "/api/2.0/preview/permissions/authorization/passwords",
 				ExpectedRequest: AccessControlChangeList{
 					AccessControlList: []AccessControlChange{
 						{
-							UserName:        TestingUser,
+							GroupName:       "admins",
 							PermissionLevel: "CAN_USE",
 						},
 					},
@@ -424,26 +1140,16 @@ func TestResourcePermissionsCreate_NotebookPath(t *testing.T) {
 			},
 			{
 				Method:   http.MethodGet,
-				Resource: "/api/2.0/preview/permissions/notebooks/988765",
+				Resource: "/api/2.0/preview/permissions/authorization/passwords",
 				Response: ObjectACL{
-					ObjectID:   "/notebooks/988765",
-					ObjectType: "notebook",
+					ObjectID:   "authorization/passwords",
+					ObjectType: "passwords",
 					AccessControlList: []AccessControl{
 						{
-							UserName: TestingUser,
+							GroupName: "admins",
 							AllPermissions: []Permission{
 								{
 									PermissionLevel: "CAN_USE",
-									Inherited:       false,
-								},
-							},
-						},
-						{
-							UserName: TestingAdminUser,
-							AllPermissions: []Permission{
-								{
-									PermissionLevel: "CAN_MANAGE",
-									Inherited:       false,
 								},
 							},
 						},
@@ -459,59 +1165,218 @@ func TestResourcePermissionsCreate_NotebookPath(t *testing.T) {
 			},
 		},
 		Resource: ResourcePermissions(),
-		State: map[string]interface{}{
-			"notebook_path": "/Development/Init",
-			"access_control": []interface{}{
-				map[string]interface{}{
-					"user_name":        TestingUser,
-					"permission_level": "CAN_USE",
-				},
-			},
-		},
+		HCL: `
+		authorization = "passwords"
+
+		access_control {
+			group_name = "admins"
+			permission_level = "CAN_USE"
+		}
+		`,
 		Create: true,
 	}.Apply(t)
-
-	assert.NoError(t, err, err)
+	require.NoError(t, err, err)
+	assert.Equal(t, "/authorization/passwords", d.Id())
+	assert.Equal(t, "passwords", d.Get("authorization"))
 	ac := d.Get("access_control").(*schema.Set)
-	require.Equal(t, 1, len(ac.List()))
-	firstElem := ac.List()[0].(map[string]interface{})
-	assert.Equal(t, TestingUser, firstElem["user_name"])
-	assert.Equal(t, "CAN_USE", firstElem["permission_level"])
+	require.Equal(t, 1, len(ac.List()), "admins group is managed for passwords")
+	assert.Equal(t, "admins", ac.List()[0].(map[string]interface{})["group_name"])
 }
 
-func TestResourcePermissionsCreate_error(t *testing.T) {
+func TestResourcePermissionsCreate_InvalidAuthorization(t *testing.T) {
 	_, err := qa.ResourceFixture{
-		Fixtures: []qa.HTTPFixture{
-			{
-				Method:   http.MethodPut,
-				Resource: "/api/2.0/preview/permissions/clusters/abc",
-				Response: common.APIErrorBody{
-					ErrorCode: "INVALID_REQUEST",
-					Message:   "Internal error happened",
-				},
-				Status: 400,
+		Resource: ResourcePermissions(),
+		HCL: `
+		authorization = "secrets"
+
+		access_control {
+			group_name = "users"
+			permission_level = "CAN_USE"
+		}
+		`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Invalid config supplied. [authorization] expected authorization to be one of")
+}
+
+func TestPermissionsUpdate_MandatoryEntries(t *testing.T) {
+	direct := func(level string) []Permission {
+		return []Permission{{PermissionLevel: level}}
+	}
+	tests := []struct {
+		objectID string
+		current  []AccessControl
+		config   []AccessControlChange
+		merged   []AccessControlChange
+		warnings int
+	}{
+		{
+			objectID: "/clusters/abc",
+			current: []AccessControl{
+				{GroupName: "admins", AllPermissions: direct("CAN_MANAGE")},
+				{UserName: "alice", AllPermissions: direct("CAN_ATTACH_TO")},
 			},
+			config: []AccessControlChange{
+				{UserName: "bob", PermissionLevel: "CAN_RESTART"},
+				{GroupName: "admins", PermissionLevel: "CAN_ATTACH_TO"},
+			},
+			merged: []AccessControlChange{
+				{UserName: "bob", PermissionLevel: "CAN_RESTART"},
+				{GroupName: "admins", PermissionLevel: "CAN_MANAGE"},
+			},
+			warnings: 1,
 		},
-		Resource: ResourcePermissions(),
-		State: map[string]interface{}{
-			"cluster_id": "abc",
-			"access_control": []interface{}{
-				map[string]interface{}{
-					"user_name":        TestingUser,
-					"permission_level": "CAN_USE",
-				},
+		{
+			objectID: "/notebooks/123",
+			current: []AccessControl{
+				{GroupName: "admins", AllPermissions: []Permission{
+					{PermissionLevel: "CAN_MANAGE", Inherited: true},
+				}},
+			},
+			config: []AccessControlChange{
+				{GroupName: "data-eng", PermissionLevel: "CAN_RUN"},
+			},
+			merged: []AccessControlChange{
+				{GroupName: "data-eng", PermissionLevel: "CAN_RUN"},
 			},
 		},
-		Create: true,
-	}.Apply(t)
-	if assert.Error(t, err) {
-		if e, ok := err.(common.APIError); ok {
-			assert.Equal(t, "INVALID_REQUEST", e.ErrorCode)
-		}
+		{
+			objectID: "/authorization/tokens",
+			config: []AccessControlChange{
+				{GroupName: "users", PermissionLevel: "CAN_USE"},
+				{GroupName: "admins", PermissionLevel: "CAN_USE"},
+			},
+			merged: []AccessControlChange{
+				{GroupName: "users", PermissionLevel: "CAN_USE"},
+				{GroupName: "admins", PermissionLevel: "CAN_MANAGE"},
+			},
+			warnings: 1,
+		},
+		{
+			objectID: "/authorization/passwords",
+			config: []AccessControlChange{
+				{GroupName: "admins", PermissionLevel: "CAN_USE"},
+			},
+			merged: []AccessControlChange{
+				{GroupName: "admins", PermissionLevel: "CAN_USE"},
+			},
+		},
+		{
+			objectID: "/jobs/9",
+			current: []AccessControl{
+				{UserName: "creator", AllPermissions: direct("IS_OWNER")},
+				{GroupName: "admins", AllPermissions: direct("CAN_MANAGE")},
+			},
+			config: []AccessControlChange{
+				{UserName: "creator", PermissionLevel: "CAN_VIEW"},
+				{GroupName: "oncall", PermissionLevel: "CAN_MANAGE_RUN"},
+			},
+			merged: []AccessControlChange{
+				{GroupName: "oncall", PermissionLevel: "CAN_MANAGE_RUN"},
+				{UserName: "creator", PermissionLevel: "IS_OWNER"},
+				{GroupName: "admins", PermissionLevel: "CAN_MANAGE"},
+			},
+			warnings: 1,
+		},
+		{
+			objectID: "/jobs/9",
+			current: []AccessControl{
+				{UserName: "creator", AllPermissions: direct("IS_OWNER")},
+			},
+			config: []AccessControlChange{
+				{ServicePrincipalName: "ci", PermissionLevel: "IS_OWNER"},
+			},
+			merged: []AccessControlChange{
+				{ServicePrincipalName: "ci", PermissionLevel: "IS_OWNER"},
+			},
+		},
+		{
+			objectID: "/sql/warehouses/abc",
+			current: []AccessControl{
+				{GroupName: "analysts", AllPermissions: direct("CAN_USE")},
+			},
+			config: []AccessControlChange{
+				{GroupName: "analysts", PermissionLevel: "CAN_USE"},
+			},
+			merged: []AccessControlChange{
+				{GroupName: "analysts", PermissionLevel: "CAN_USE"},
+				{UserName: TestingAdminUser, PermissionLevel: "IS_OWNER"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.objectID, func(t *testing.T) {
+			client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+				{
+					Method:   http.MethodGet,
+					Resource: "/api/2.0/preview/permissions" + tt.objectID,
+					Response: ObjectACL{
+						ObjectID:          tt.objectID,
+						AccessControlList: tt.current,
+					},
+				},
+				{
+					Method:   http.MethodGet,
+					Resource: "/api/2.0/preview/scim/v2/Me",
+					Response: identity.ScimUser{
+						UserName: TestingAdminUser,
+					},
+				},
+				{
+					Method:   http.MethodPut,
+					Resource: "/api/2.0/preview/permissions" + tt.objectID,
+					ExpectedRequest: AccessControlChangeList{
+						AccessControlList: tt.merged,
+					},
+				},
+			})
+			require.NoError(t, err)
+			defer server.Close()
+
+			warnings, err := NewPermissionsAPI(context.Background(), client).update(tt.objectID,
+				AccessControlChangeList{
+					AccessControlList: tt.config,
+				})
+			require.NoError(t, err)
+			assert.Len(t, warnings, tt.warnings, "%v", warnings)
+		})
 	}
 }
 
-func TestResourcePermissionsUpdate(t *testing.T) {
+func TestResourcePermissionsImport_ObjectID(t *testing.T) {
+	r := ResourcePermissions()
+	ctx := context.Background()
+	for _, id := range []string{
+		"/clusters/abc",
+		"/jobs/9",
+		"/sql/warehouses/abc",
+		"/notebooks/123",
+		"/authorization/tokens",
+		"/authorization/passwords",
+	} {
+		d := r.TestResourceData()
+		d.SetId(id)
+		imported, err := r.Importer.StateContext(ctx, d, nil)
+		assert.NoError(t, err, id)
+		assert.Len(t, imported, 1, id)
+	}
+	for _, id := range []string{
+		"/repos/123",
+		"/clusters/",
+		"/jobs/9/runs",
+		"/authorization/secrets",
+		"abc",
+	} {
+		d := r.TestResourceData()
+		d.SetId(id)
+		_, err := r.Importer.StateContext(ctx, d, nil)
+		qa.AssertErrorStartsWith(t, err, fmt.Sprintf("Unsupported object %s. ID has to be one of "+
+			"/cluster-policies/<cluster_policy_id>, /instance-pools/<instance_pool_id>", id))
+	}
+}
+
+func TestResourcePermissionsImport_Job(t *testing.T) {
+	r := ResourcePermissions()
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
 			{
@@ -522,11 +1387,27 @@ func TestResourcePermissionsUpdate(t *testing.T) {
 					ObjectType: "job",
 					AccessControlList: []AccessControl{
 						{
-							UserName: TestingUser,
+							GroupName: "oncall",
 							AllPermissions: []Permission{
 								{
-									PermissionLevel: "CAN_RUN",
-									Inherited:       false,
+									PermissionLevel: "CAN_MANAGE_RUN",
+								},
+							},
+						},
+						{
+							UserName: "creator",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "IS_OWNER",
+								},
+							},
+						},
+						{
+							GroupName: "admins",
+							AllPermissions: []Permission{
+								{
+									PermissionLevel: "CAN_MANAGE",
+									Inherited:       true,
 								},
 							},
 						},
@@ -535,7 +1416,6 @@ func TestResourcePermissionsUpdate(t *testing.T) {
 							AllPermissions: []Permission{
 								{
 									PermissionLevel: "CAN_MANAGE",
-									Inherited:       false,
 								},
 							},
 						},
@@ -543,49 +1423,35 @@ func TestResourcePermissionsUpdate(t *testing.T) {
 				},
 			},
 			{
-				Method:       http.MethodGet,
-				ReuseRequest: true,
-				Resource:     "/api/2.0/preview/scim/v2/Me",
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/preview/scim/v2/Me",
 				Response: identity.ScimUser{
 					UserName: TestingAdminUser,
 				},
 			},
-			{
-				Method:   http.MethodPut,
-				Resource: "/api/2.0/preview/permissions/jobs/9",
-				ExpectedRequest: AccessControlChangeList{
-					AccessControlList: []AccessControlChange{
-						{
-							UserName:        TestingUser,
-							PermissionLevel: "CAN_RUN",
-						},
-						{
-							UserName:        TestingAdminUser,
-							PermissionLevel: "IS_OWNER",
-						},
-					},
-				},
-			},
 		},
-		HCL: `
-		job_id = 9
-
-		access_control {
-			user_name = "ben"
-			permission_level = "CAN_RUN"
-		}
-		`,
-		Resource: ResourcePermissions(),
-		Update:   true,
+		Resource: r,
+		Read:     true,
 		ID:       "/jobs/9",
 	}.Apply(t)
-	assert.NoError(t, err, err)
-	assert.Equal(t, "/jobs/9", d.Id())
+	require.NoError(t, err, err)
+	assert.Equal(t, "9", d.Get("job_id"))
+	assert.Equal(t, "job", d.Get("object_type"))
 	ac := d.Get("access_control").(*schema.Set)
-	require.Equal(t, 1, len(ac.List()))
-	firstElem := ac.List()[0].(map[string]interface{})
-	assert.Equal(t, TestingUser, firstElem["user_name"])
-	assert.Equal(t, "CAN_RUN", firstElem["permission_level"])
+	require.Equal(t, 1, len(ac.List()), "owner, admins and current user must not be imported")
+	assert.Equal(t, "oncall", ac.List()[0].(map[string]interface{})["group_name"])
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"job_id": "9",
+		"access_control": []interface{}{
+			map[string]interface{}{
+				"group_name":       "oncall",
+				"permission_level": "CAN_MANAGE_RUN",
+			},
+		},
+	}), nil)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "plan after import is not empty: %v", diff)
 }
 
 func permissionsTestHelper(t *testing.T,
@@ -814,6 +1680,47 @@ func TestAccPermissionsJobs(t *testing.T) {
 	})
 }
 
+func TestAccPermissionsSQLEndpoints(t *testing.T) {
+	permissionsTestHelper(t, func(permissionsAPI PermissionsAPI, user, group string,
+		ef func(string) PermissionsEntity) {
+		ctx := context.Background()
+		var endpoint struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, permissionsAPI.client.Post(ctx, "/sql/warehouses", map[string]interface{}{
+			"name":             group,
+			"cluster_size":     "2X-Small",
+			"max_num_clusters": 1,
+			"auto_stop_mins":   10,
+		}, &endpoint))
+		defer func() {
+			assert.NoError(t, permissionsAPI.client.Delete(ctx, "/sql/warehouses/"+endpoint.ID, nil))
+		}()
+
+		objectID := fmt.Sprintf("/sql/warehouses/%s", endpoint.ID)
+		acl := AccessControlChangeList{
+			AccessControlList: []AccessControlChange{
+				{
+					GroupName:       group,
+					PermissionLevel: "CAN_USE",
+				},
+			},
+		}
+		require.NoError(t, permissionsAPI.Update(objectID, acl))
+		entity := ef(objectID)
+		assert.Equal(t, "warehouses", entity.ObjectType)
+		assert.Equal(t, acl.AccessControlList, entity.AccessControlList)
+
+		// applying the same permissions again doesn't change anything
+		require.NoError(t, permissionsAPI.Update(objectID, acl))
+		assert.Equal(t, entity, ef(objectID))
+
+		require.NoError(t, permissionsAPI.Delete(objectID))
+		entity = ef(objectID)
+		assert.Len(t, entity.AccessControlList, 0)
+	})
+}
+
 func TestAccPermissionsNotebooks(t *testing.T) {
 	permissionsTestHelper(t, func(permissionsAPI PermissionsAPI, user, group string,
 		ef func(string) PermissionsEntity) {
@@ -2,7 +2,10 @@ package access
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
+	"log"
 	"net/http"
 
 	"github.com/databrickslabs/databricks-terraform/common"
@@ -14,6 +17,7 @@ import (
 // SecretsRequest ...
 type SecretsRequest struct {
 	StringValue string `json:"string_value,omitempty" mask:"true"`
+	BytesValue  string `json:"bytes_value,omitempty" mask:"true"`
 	Scope       string `json:"scope,omitempty"`
 	Key         string `json:"key,omitempty"`
 }
@@ -68,11 +72,23 @@ type SecretsAPI struct {
 
 // Create creates or modifies a string secret depends on the type of scope backend
 func (a SecretsAPI) Create(stringValue, scope, key string) error {
-	return a.client.Post(a.context, "/secrets/put", SecretsRequest{
+	return a.Put(SecretsRequest{
 		StringValue: stringValue,
 		Scope:       scope,
 		Key:         key,
-	}, nil)
+	})
+}
+
+// Put creates or modifies a secret with either string or base64-encoded bytes value
+func (a SecretsAPI) Put(request SecretsRequest) error {
+	err := a.client.Post(a.context, "/secrets/put", request, nil)
+	if e, ok := err.(common.APIError); ok && e.ErrorCode == "PERMISSION_DENIED" {
+		// scopes created by admins without initial_manage_principal are manageable only by admins
+		return fmt.Errorf("%s. Current user needs WRITE or MANAGE permission on secret scope %s: either "+
+			"create the scope with initial_manage_principal = \"users\" or grant the permission with "+
+			"databricks_secret_acl", e.Message, request.Scope)
+	}
+	return err
 }
 
 // Delete deletes a secret depends on the type of scope backend
@@ -112,17 +128,65 @@ func (a SecretsAPI) Read(scope string, key string) (SecretMetadata, error) {
 	}
 }
 
+// secretValueHash returns the hash of the secret value, that is kept in the state instead of the value
+func secretValueHash(value []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(value))
+}
+
+// secretValueStateFunc hashes string and base64-encoded values, so that both of them
+// have the same hash for the same content
+func secretValueStateFunc(decode func(string) ([]byte, error)) schema.SchemaStateFunc {
+	return func(v interface{}) string {
+		value, err := decode(v.(string))
+		if err != nil {
+			// invalid values are reported by validation
+			return v.(string)
+		}
+		return secretValueHash(value)
+	}
+}
+
+// suppressLegacySecretValue prevents recreation of secrets, that have their values
+// in the state from earlier versions of the provider
+func suppressLegacySecretValue(k, old, new string, d *schema.ResourceData) bool {
+	return old != "" && old == d.Get(k).(string)
+}
+
+// putSecretRequest returns request to write secret with either string or bytes value
+func putSecretRequest(d *schema.ResourceData, scope, key string) SecretsRequest {
+	return SecretsRequest{
+		StringValue: d.Get("string_value").(string),
+		BytesValue:  d.Get("bytes_base64").(string),
+		Scope:       scope,
+		Key:         key,
+	}
+}
+
 // ResourceSecret manages secrets
 func ResourceSecret() *schema.Resource {
 	p := util.NewPairSeparatedID("scope", "key", "|||")
+	values := []string{"string_value", "bytes_base64"}
 	return util.CommonResource{
 		Schema: map[string]*schema.Schema{
 			"string_value": {
 				Type:         schema.TypeString,
 				ValidateFunc: validation.StringIsNotEmpty,
-				Required:     true,
-				ForceNew:     true,
+				Optional:     true,
 				Sensitive:    true,
+				ExactlyOneOf: values,
+				StateFunc: secretValueStateFunc(func(v string) ([]byte, error) {
+					return []byte(v), nil
+				}),
+				DiffSuppressFunc: suppressLegacySecretValue,
+			},
+			"bytes_base64": {
+				Type:             schema.TypeString,
+				ValidateFunc:     validation.StringIsBase64,
+				Optional:         true,
+				Sensitive:        true,
+				ExactlyOneOf:     values,
+				StateFunc:        secretValueStateFunc(base64.StdEncoding.DecodeString),
+				DiffSuppressFunc: suppressLegacySecretValue,
 			},
 			"scope": {
 				Type:         schema.TypeString,
@@ -140,10 +204,15 @@ func ResourceSecret() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"detect_external_changes": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			if err := NewSecretsAPI(ctx, c).Create(d.Get("string_value").(string), d.Get("scope").(string),
-				d.Get("key").(string)); err != nil {
+			err := NewSecretsAPI(ctx, c).Put(putSecretRequest(d,
+				d.Get("scope").(string), d.Get("key").(string)))
+			if err != nil {
 				return err
 			}
 			p.Pack(d)
@@ -158,8 +227,35 @@ func ResourceSecret() *schema.Resource {
 			if err != nil {
 				return err
 			}
+			written := d.Get("last_updated_timestamp").(int)
+			if d.Get("detect_external_changes").(bool) && written > 0 &&
+				m.LastUpdatedTimestamp > int64(written) {
+				log.Printf("[INFO] Secret %s in scope %s was changed outside of Terraform", key, scope)
+				// clearing value hashes makes the next plan write the configured value again
+				for _, k := range values {
+					if err = d.Set(k, ""); err != nil {
+						return err
+					}
+				}
+			}
 			return d.Set("last_updated_timestamp", m.LastUpdatedTimestamp)
 		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			if !d.HasChanges(values...) {
+				return nil
+			}
+			scope, key, err := p.Unpack(d)
+			if err != nil {
+				return err
+			}
+			// secret put overwrites the value in place
+			err = NewSecretsAPI(ctx, c).Put(putSecretRequest(d, scope, key))
+			if err != nil {
+				return err
+			}
+			// timestamp of this write is recorded by the following read
+			return d.Set("last_updated_timestamp", 0)
+		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			scope, key, err := p.Unpack(d)
 			if err != nil {
@@ -167,5 +263,13 @@ func ResourceSecret() *schema.Resource {
 			}
 			return NewSecretsAPI(ctx, c).Delete(scope, key)
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			scope := d.Get("scope").(string)
+			if scope == "" || (d.Id() != "" && !d.HasChange("scope")) {
+				// scope is not yet known or was already checked
+				return nil
+			}
+			return NewSecretScopesAPI(ctx, c).checkWritableScope(scope)
+		},
 	}.ToResource()
 }
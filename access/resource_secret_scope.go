@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"strings"
 
 	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/identity"
 	"github.com/databrickslabs/databricks-terraform/internal"
 	"github.com/databrickslabs/databricks-terraform/internal/util"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -72,7 +74,13 @@ func (a SecretScopesAPI) Create(s SecretScope) error {
 		req.BackendType = "AZURE_KEYVAULT"
 		req.BackendAzureKeyvault = s.KeyvaultMetadata
 	}
-	return a.client.Post(a.context, "/secrets/scopes/create", req, nil)
+	err := a.client.Post(a.context, "/secrets/scopes/create", req, nil)
+	if e, ok := err.(common.APIError); ok && strings.Contains(e.Message, "userAADToken") {
+		// personal access tokens are not accepted for Azure KeyVault backed scopes
+		return fmt.Errorf("Azure KeyVault backed secret scope can only be created with Azure Active Directory "+
+			"token of a user. Please use Azure CLI authentication instead of personal access token: %s", e.Message)
+	}
+	return err
 }
 
 // Delete deletes a secret scope
@@ -109,6 +117,47 @@ func (a SecretScopesAPI) Read(scopeName string) (SecretScope, error) {
 	}
 }
 
+// checkWritableScope fails, if secrets could not be put into the scope through Databricks API
+func (a SecretScopesAPI) checkWritableScope(scopeName string) error {
+	scope, err := a.Read(scopeName)
+	if e, ok := err.(common.APIError); ok && e.IsMissing() {
+		// scope may be created within the same apply
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if scope.KeyvaultMetadata == nil && scope.BackendType != "AZURE_KEYVAULT" {
+		return nil
+	}
+	return fmt.Errorf("Secret scope %s is backed by Azure KeyVault, where secrets are read-only "+
+		"for Databricks. Please add secrets directly to Azure KeyVault", scopeName)
+}
+
+// defaultManagePrincipal returns user name of the caller, if the caller is not an admin. Otherwise
+// the scope is manageable only by admins and subsequent writes of secrets by the caller fail.
+func defaultManagePrincipal(ctx context.Context, c *common.DatabricksClient) (string, error) {
+	me, err := identity.NewUsersAPI(ctx, c).Me()
+	if err != nil {
+		return "", err
+	}
+	for _, g := range me.Groups {
+		if g.Display == "admins" {
+			return "", nil
+		}
+	}
+	return me.UserName, nil
+}
+
+// suppressUsersManagePrincipal makes absence of initial_manage_principal and "users" the same for
+// existing scopes, as the API doesn't return the principal and every user can manage such scope anyway
+func suppressUsersManagePrincipal(k, old, new string, d *schema.ResourceData) bool {
+	if d.Id() == "" {
+		return false
+	}
+	return (old == "" && new == "users") || (old == "users" && new == "")
+}
+
 var validScope = validation.StringMatch(regexp.MustCompile(`^[\w\.@_-]{1,128}$`),
 	"Must consist of alphanumeric characters, dashes, underscores, and periods, "+
 		"and may not exceed 128 characters.")
@@ -116,11 +165,11 @@ var validScope = validation.StringMatch(regexp.MustCompile(`^[\w\.@_-]{1,128}$`)
 // ResourceSecretScope manages secret scopes
 func ResourceSecretScope() *schema.Resource {
 	s := internal.StructToSchema(SecretScope{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
-		// TODO: DiffSuppressFunc for initial_manage_principal & importing
 		s["name"].ForceNew = true
 		// nolint
 		s["name"].ValidateFunc = validScope
 		s["initial_manage_principal"].ForceNew = true
+		s["initial_manage_principal"].DiffSuppressFunc = suppressUsersManagePrincipal
 		s["keyvault_metadata"].ForceNew = true
 		return s
 	})
@@ -129,10 +178,18 @@ func ResourceSecretScope() *schema.Resource {
 		SchemaVersion: 2,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var scope SecretScope
-			if err := internal.DataToStructPointer(d, s, &scope); err != nil {
+			err := internal.DataToStructPointer(d, s, &scope)
+			if err != nil {
 				return err
 			}
-			if err := NewSecretScopesAPI(ctx, c).Create(scope); err != nil {
+			if scope.InitialManagePrincipal == "" && scope.KeyvaultMetadata == nil {
+				// defaulted principal is not kept in the state, so that it does not cause drift
+				scope.InitialManagePrincipal, err = defaultManagePrincipal(ctx, c)
+				if err != nil {
+					return err
+				}
+			}
+			if err = NewSecretScopesAPI(ctx, c).Create(scope); err != nil {
 				return err
 			}
 			d.SetId(scope.Name)
@@ -0,0 +1,147 @@
+package access
+
+import (
+	"context"
+	"sort"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/util"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// listMap returns ACLs of the scope as principal to permission map
+func (a SecretAclsAPI) listMap(scope string) (map[string]string, error) {
+	items, err := a.List(scope)
+	if err != nil {
+		return nil, err
+	}
+	acls := map[string]string{}
+	for _, item := range items {
+		acls[item.Principal] = string(item.Permission)
+	}
+	return acls, nil
+}
+
+// apply puts changed permissions first and deletes removed principals afterwards,
+// so that the scope doesn't lose its MANAGE principal in between
+func (a SecretAclsAPI) apply(scope string, current, desired map[string]string) error {
+	for _, principal := range sortedKeys(desired) {
+		if current[principal] == desired[principal] {
+			continue
+		}
+		err := a.Create(scope, principal, ACLPermission(desired[principal]))
+		if err != nil {
+			return err
+		}
+	}
+	for _, principal := range sortedKeys(current) {
+		if _, ok := desired[principal]; ok {
+			continue
+		}
+		err := a.Delete(scope, principal)
+		if e, ok := err.(common.APIError); ok && e.IsMissing() {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) (keys []string) {
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
+
+func aclsMap(v interface{}) map[string]string {
+	acls := map[string]string{}
+	for k, v := range v.(map[string]interface{}) {
+		acls[k] = v.(string)
+	}
+	return acls
+}
+
+// ResourceSecretScopeACLs manages all access control entries of a secret scope within a single resource
+func ResourceSecretScopeACLs() *schema.Resource {
+	return util.CommonResource{
+		Schema: map[string]*schema.Schema{
+			"scope": {
+				Type:         schema.TypeString,
+				ValidateFunc: validScope,
+				Required:     true,
+				ForceNew:     true,
+			},
+			"acls": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{
+						string(ACLPermissionRead),
+						string(ACLPermissionWrite),
+						string(ACLPermissionManage),
+					}, false),
+				},
+			},
+			"authoritative": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+		},
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			scope := d.Get("scope").(string)
+			a := NewSecretAclsAPI(ctx, c)
+			current := map[string]string{}
+			if d.Get("authoritative").(bool) {
+				var err error
+				if current, err = a.listMap(scope); err != nil {
+					return err
+				}
+			}
+			if err := a.apply(scope, current, aclsMap(d.Get("acls"))); err != nil {
+				return err
+			}
+			d.SetId(scope)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			listed, err := NewSecretAclsAPI(ctx, c).listMap(d.Id())
+			if err != nil {
+				return err
+			}
+			owned := aclsMap(d.Get("acls"))
+			acls := map[string]string{}
+			for principal, permission := range listed {
+				// principals of individual databricks_secret_acl resources are left alone,
+				// unless this resource is authoritative or being imported
+				if _, ok := owned[principal]; ok || len(owned) == 0 || d.Get("authoritative").(bool) {
+					acls[principal] = permission
+				}
+			}
+			if err = d.Set("scope", d.Id()); err != nil {
+				return err
+			}
+			return d.Set("acls", acls)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			a := NewSecretAclsAPI(ctx, c)
+			old, desired := d.GetChange("acls")
+			current := aclsMap(old)
+			if d.Get("authoritative").(bool) {
+				var err error
+				if current, err = a.listMap(d.Id()); err != nil {
+					return err
+				}
+			}
+			return a.apply(d.Id(), current, aclsMap(desired))
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			return NewSecretAclsAPI(ctx, c).apply(d.Id(), aclsMap(d.Get("acls")), map[string]string{})
+		},
+	}.ToResource()
+}
@@ -0,0 +1,241 @@
+package access
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func aclsListFixture(items ...ACLItem) qa.HTTPFixture {
+	return qa.HTTPFixture{
+		Method:       "GET",
+		Resource:     "/api/2.0/secrets/acls/list?scope=global",
+		ReuseRequest: true,
+		Response: map[string]interface{}{
+			"items": items,
+		},
+	}
+}
+
+func TestResourceSecretScopeACLsCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/secrets/acls/put",
+				ExpectedRequest: SecretACLRequest{
+					Scope:      "global",
+					Principal:  "data-engineers",
+					Permission: "WRITE",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/secrets/acls/put",
+				ExpectedRequest: SecretACLRequest{
+					Scope:      "global",
+					Principal:  "users",
+					Permission: "READ",
+				},
+			},
+			aclsListFixture(
+				ACLItem{Principal: "admin@example.com", Permission: "MANAGE"},
+				ACLItem{Principal: "data-engineers", Permission: "WRITE"},
+				ACLItem{Principal: "users", Permission: "READ"},
+			),
+		},
+		Resource: ResourceSecretScopeACLs(),
+		HCL: `
+		scope = "global"
+		acls = {
+			"users" = "READ"
+			"data-engineers" = "WRITE"
+		}`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "global", d.Id())
+	// principal of the other resource is left alone
+	assert.Equal(t, map[string]interface{}{
+		"users":          "READ",
+		"data-engineers": "WRITE",
+	}, d.Get("acls"))
+}
+
+func TestResourceSecretScopeACLsCreate_Authoritative(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/secrets/acls/list?scope=global",
+				Response: map[string]interface{}{
+					"items": []ACLItem{
+						{Principal: "admin@example.com", Permission: "MANAGE"},
+						{Principal: "users", Permission: "READ"},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/secrets/acls/put",
+				ExpectedRequest: SecretACLRequest{
+					Scope:      "global",
+					Principal:  "admins",
+					Permission: "MANAGE",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/secrets/acls/delete",
+				ExpectedRequest: SecretACLRequest{
+					Scope:     "global",
+					Principal: "admin@example.com",
+				},
+			},
+			aclsListFixture(
+				ACLItem{Principal: "admins", Permission: "MANAGE"},
+				ACLItem{Principal: "users", Permission: "READ"},
+			),
+		},
+		Resource: ResourceSecretScopeACLs(),
+		HCL: `
+		scope = "global"
+		authoritative = true
+		acls = {
+			"users" = "READ"
+			"admins" = "MANAGE"
+		}`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "global", d.Id())
+	assert.Len(t, d.Get("acls"), 2)
+}
+
+func TestResourceSecretScopeACLsRead_Import(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			aclsListFixture(
+				ACLItem{Principal: "admins", Permission: "MANAGE"},
+				ACLItem{Principal: "users", Permission: "READ"},
+			),
+		},
+		Resource: ResourceSecretScopeACLs(),
+		Read:     true,
+		New:      true,
+		ID:       "global",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "global", d.Get("scope"))
+	assert.Equal(t, map[string]interface{}{
+		"admins": "MANAGE",
+		"users":  "READ",
+	}, d.Get("acls"))
+}
+
+func TestResourceSecretScopeACLsUpdate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/secrets/acls/put",
+				ExpectedRequest: SecretACLRequest{
+					Scope:      "global",
+					Principal:  "users",
+					Permission: "WRITE",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/secrets/acls/delete",
+				ExpectedRequest: SecretACLRequest{
+					Scope:     "global",
+					Principal: "data-engineers",
+				},
+			},
+			aclsListFixture(
+				ACLItem{Principal: "admin@example.com", Permission: "MANAGE"},
+				ACLItem{Principal: "users", Permission: "WRITE"},
+			),
+		},
+		Resource: ResourceSecretScopeACLs(),
+		InstanceState: map[string]string{
+			"scope":               "global",
+			"acls.%":              "2",
+			"acls.users":          "READ",
+			"acls.data-engineers": "WRITE",
+		},
+		HCL: `
+		scope = "global"
+		acls = {
+			"users" = "WRITE"
+		}`,
+		Update: true,
+		ID:     "global",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, map[string]interface{}{
+		"users": "WRITE",
+	}, d.Get("acls"))
+}
+
+func TestResourceSecretScopeACLsDelete(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/secrets/acls/delete",
+				ExpectedRequest: SecretACLRequest{
+					Scope:     "global",
+					Principal: "users",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/secrets/acls/delete",
+				Status:   404,
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Principal writers not found",
+				},
+			},
+		},
+		Resource: ResourceSecretScopeACLs(),
+		HCL: `
+		scope = "global"
+		acls = {
+			"users" = "READ"
+			"writers" = "WRITE"
+		}`,
+		Delete: true,
+		ID:     "global",
+	}.Apply(t)
+	require.NoError(t, err, err)
+}
+
+func TestResourceSecretScopeACLsCreate_Error(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/secrets/acls/put",
+				Status:   400,
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+			},
+		},
+		Resource: ResourceSecretScopeACLs(),
+		HCL: `
+		scope = "global"
+		acls = {
+			"users" = "READ"
+		}`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+}
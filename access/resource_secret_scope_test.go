@@ -118,9 +118,27 @@ func TestResourceSecretScopeRead_Error(t *testing.T) {
 	assert.Equal(t, "abc", d.Id(), "Id should not be empty for error reads")
 }
 
+func meFixture(groups ...string) qa.HTTPFixture {
+	items := []map[string]string{}
+	for _, g := range groups {
+		items = append(items, map[string]string{
+			"display": g,
+		})
+	}
+	return qa.HTTPFixture{
+		Method:   http.MethodGet,
+		Resource: "/api/2.0/preview/scim/v2/Me",
+		Response: map[string]interface{}{
+			"userName": "me@example.com",
+			"groups":   items,
+		},
+	}
+}
+
 func TestResourceSecretScopeCreate(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			meFixture("admins", "users"),
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/secrets/scopes/create",
@@ -153,6 +171,51 @@ func TestResourceSecretScopeCreate(t *testing.T) {
 	assert.Equal(t, "Boom", d.Id())
 }
 
+func TestResourceSecretScopeCreate_NonAdmin(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			meFixture("users"),
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/secrets/scopes/create",
+				ExpectedRequest: map[string]string{
+					"scope":                    "Boom",
+					"initial_manage_principal": "me@example.com",
+					"scope_backend_type":       "DATABRICKS",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/secrets/scopes/list",
+				Response: SecretScopeList{
+					Scopes: []SecretScope{
+						{
+							Name:        "Boom",
+							BackendType: "DATABRICKS",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceSecretScope(),
+		HCL:      `name = "Boom"`,
+		Create:   true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "Boom", d.Id())
+	assert.Equal(t, "", d.Get("initial_manage_principal"))
+}
+
+func TestResourceSecretScopeDiff_UsersManagePrincipal(t *testing.T) {
+	r := ResourceSecretScope()
+	d := r.TestResourceData()
+	assert.False(t, suppressUsersManagePrincipal("initial_manage_principal", "", "users", d))
+	d.SetId("Boom")
+	assert.True(t, suppressUsersManagePrincipal("initial_manage_principal", "", "users", d))
+	assert.True(t, suppressUsersManagePrincipal("initial_manage_principal", "users", "", d))
+	assert.False(t, suppressUsersManagePrincipal("initial_manage_principal", "", "admins", d))
+}
+
 func TestResourceSecretScopeCreate_KeyVault(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -200,6 +263,33 @@ func TestResourceSecretScopeCreate_KeyVault(t *testing.T) {
 	assert.Equal(t, "Boom", d.Id())
 }
 
+func TestResourceSecretScopeCreate_KeyVaultWithToken(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/secrets/scopes/create",
+				Status:   400,
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_PARAMETER_VALUE",
+					Message:   "Scope with Azure KeyVault must have userAADToken defined!",
+				},
+			},
+		},
+		Resource: ResourceSecretScope(),
+		HCL: `
+		name = "Boom"
+		keyvault_metadata {
+			resource_id = "bcd"
+			dns_name = "def"
+		}`,
+		Azure:  true,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Azure KeyVault backed secret scope can only be created "+
+		"with Azure Active Directory token of a user")
+}
+
 func TestResourceSecretScopeCreate_Users(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -1,11 +1,17 @@
 package access
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"testing"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceSecretRead(t *testing.T) {
@@ -36,6 +42,114 @@ func TestResourceSecretRead(t *testing.T) {
 	assert.Equal(t, "", d.Get("string_value"))
 }
 
+func secretReadExternalChange(t *testing.T, detect string) *schema.ResourceData {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/secrets/scopes/list",
+				ReuseRequest: true,
+				Response: SecretScopeList{
+					Scopes: []SecretScope{
+						{
+							Name:        "foo",
+							BackendType: "DATABRICKS",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/secrets/list?scope=foo",
+				Response: SecretsList{
+					Secrets: []SecretMetadata{
+						{
+							Key:                  "bar",
+							LastUpdatedTimestamp: 200,
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceSecret(),
+		Read:     true,
+		ID:       "foo|||bar",
+		InstanceState: map[string]string{
+			"scope":                   "foo",
+			"key":                     "bar",
+			"string_value":            secretValueHash([]byte("abc")),
+			"last_updated_timestamp":  "100",
+			"detect_external_changes": detect,
+		},
+		HCL: `
+		scope = "foo"
+		key = "bar"
+		detect_external_changes = ` + detect,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, 200, d.Get("last_updated_timestamp"))
+	return d
+}
+
+func TestResourceSecretRead_ExternalChange(t *testing.T) {
+	d := secretReadExternalChange(t, "true")
+	assert.Equal(t, "", d.State().Attributes["string_value"])
+}
+
+func TestResourceSecretRead_ExternalChangeIgnored(t *testing.T) {
+	d := secretReadExternalChange(t, "false")
+	assert.Equal(t, secretValueHash([]byte("abc")), d.State().Attributes["string_value"])
+}
+
+func TestResourceSecretUpdate_OnlyDetection(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/secrets/scopes/list",
+				ReuseRequest: true,
+				Response: SecretScopeList{
+					Scopes: []SecretScope{
+						{
+							Name:        "foo",
+							BackendType: "DATABRICKS",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/secrets/list?scope=foo",
+				Response: SecretsList{
+					Secrets: []SecretMetadata{
+						{
+							Key:                  "bar",
+							LastUpdatedTimestamp: 100,
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceSecret(),
+		InstanceState: map[string]string{
+			"scope":                  "foo",
+			"key":                    "bar",
+			"string_value":           secretValueHash([]byte("abc")),
+			"last_updated_timestamp": "100",
+		},
+		HCL: `
+		scope = "foo"
+		key = "bar"
+		string_value = "abc"
+		detect_external_changes = true
+		`,
+		Update: true,
+		ID:     "foo|||bar",
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, true, d.Get("detect_external_changes"))
+}
+
 func TestResourceSecretRead_NotFound(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -83,6 +197,19 @@ func TestResourceSecretRead_Error(t *testing.T) {
 func TestResourceSecretCreate(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/secrets/scopes/list",
+				ReuseRequest: true,
+				Response: SecretScopeList{
+					Scopes: []SecretScope{
+						{
+							Name:        "foo",
+							BackendType: "DATABRICKS",
+						},
+					},
+				},
+			},
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/secrets/put",
@@ -120,6 +247,12 @@ func TestResourceSecretCreate(t *testing.T) {
 func TestResourceSecretCreate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/secrets/scopes/list",
+				ReuseRequest: true,
+				Response:     SecretScopeList{},
+			},
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/secrets/put",
@@ -142,6 +275,235 @@ func TestResourceSecretCreate_Error(t *testing.T) {
 	assert.Equal(t, "", d.Id(), "Id should be empty for error creates")
 }
 
+func TestResourceSecretCreate_KeyVaultScope(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/secrets/scopes/list",
+				ReuseRequest: true,
+				Response: SecretScopeList{
+					Scopes: []SecretScope{
+						{
+							Name:        "foo",
+							BackendType: "AZURE_KEYVAULT",
+							KeyvaultMetadata: &KeyvaultMetadata{
+								ResourceID: "bcd",
+								DNSName:    "def",
+							},
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceSecret(),
+		State: map[string]interface{}{
+			"scope":        "foo",
+			"key":          "bar",
+			"string_value": "SparkIsTh3Be$t",
+		},
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Secret scope foo is backed by Azure KeyVault")
+}
+
+func TestSecretValueHash_Bytes(t *testing.T) {
+	payload := make([]byte, 10*1024)
+	_, err := rand.Read(payload)
+	assert.NoError(t, err)
+	encoded := base64.StdEncoding.EncodeToString(payload)
+
+	s := ResourceSecret().Schema
+	hash := s["bytes_base64"].StateFunc(encoded)
+	assert.Equal(t, secretValueHash(payload), hash)
+	assert.Len(t, hash, len("sha256:")+64)
+
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, payload, decoded)
+	assert.Equal(t, hash, s["bytes_base64"].StateFunc(base64.StdEncoding.EncodeToString(decoded)))
+
+	payload[0]++
+	assert.NotEqual(t, hash, s["bytes_base64"].StateFunc(base64.StdEncoding.EncodeToString(payload)))
+	assert.Equal(t, secretValueHash([]byte("abc")), s["string_value"].StateFunc("abc"))
+}
+
+func TestResourceSecretCreate_Bytes(t *testing.T) {
+	payload := make([]byte, 10*1024)
+	_, err := rand.Read(payload)
+	assert.NoError(t, err)
+	encoded := base64.StdEncoding.EncodeToString(payload)
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/secrets/scopes/list",
+				ReuseRequest: true,
+				Response: SecretScopeList{
+					Scopes: []SecretScope{
+						{
+							Name:        "foo",
+							BackendType: "DATABRICKS",
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/secrets/put",
+				ExpectedRequest: SecretsRequest{
+					BytesValue: encoded,
+					Scope:      "foo",
+					Key:        "bar",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/secrets/list?scope=foo",
+				Response: SecretsList{
+					Secrets: []SecretMetadata{
+						{
+							Key:                  "bar",
+							LastUpdatedTimestamp: 12345678,
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceSecret(),
+		State: map[string]interface{}{
+			"scope":        "foo",
+			"key":          "bar",
+			"bytes_base64": encoded,
+		},
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "foo|||bar", d.Id())
+	assert.Equal(t, secretValueHash(payload), d.State().Attributes["bytes_base64"])
+}
+
+func TestResourceSecretCreate_BothValues(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceSecret(),
+		State: map[string]interface{}{
+			"scope":        "foo",
+			"key":          "bar",
+			"string_value": "abc",
+			"bytes_base64": "YWJj",
+		},
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Invalid config supplied. [bytes_base64] ExactlyOne. [string_value] ExactlyOne")
+}
+
+func TestSecretsAPIPut_PermissionDenied(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/secrets/put",
+			Status:   403,
+			Response: common.APIErrorBody{
+				ErrorCode: "PERMISSION_DENIED",
+				Message:   "User does not have permission",
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	err = NewSecretsAPI(context.Background(), client).Create("abc", "foo", "bar")
+	qa.AssertErrorStartsWith(t, err, "User does not have permission. Current user needs WRITE "+
+		"or MANAGE permission on secret scope foo")
+}
+
+func TestResourceSecretUpdate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/secrets/scopes/list",
+				ReuseRequest: true,
+				Response: SecretScopeList{
+					Scopes: []SecretScope{
+						{
+							Name:        "foo",
+							BackendType: "DATABRICKS",
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/secrets/put",
+				ExpectedRequest: SecretsRequest{
+					StringValue: "new value",
+					Scope:       "foo",
+					Key:         "bar",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/secrets/list?scope=foo",
+				Response: SecretsList{
+					Secrets: []SecretMetadata{
+						{
+							Key:                  "bar",
+							LastUpdatedTimestamp: 12345679,
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceSecret(),
+		InstanceState: map[string]string{
+			"scope":        "foo",
+			"key":          "bar",
+			"string_value": secretValueHash([]byte("old value")),
+		},
+		HCL: `
+		scope = "foo"
+		key = "bar"
+		string_value = "new value"
+		`,
+		Update: true,
+		ID:     "foo|||bar",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, secretValueHash([]byte("new value")), d.State().Attributes["string_value"])
+	assert.Equal(t, 12345679, d.Get("last_updated_timestamp"))
+}
+
+func secretDiff(t *testing.T, stateValue, configValue string) *terraform.InstanceDiff {
+	diff, err := ResourceSecret().Diff(context.Background(), &terraform.InstanceState{
+		ID: "foo|||bar",
+		Attributes: map[string]string{
+			"scope":        "foo",
+			"key":          "bar",
+			"string_value": stateValue,
+		},
+	}, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"scope":        "foo",
+		"key":          "bar",
+		"string_value": configValue,
+	}), nil)
+	require.NoError(t, err)
+	return diff
+}
+
+func TestResourceSecretDiff_Hash(t *testing.T) {
+	assert.True(t, secretDiff(t, secretValueHash([]byte("abc")), "abc").Empty())
+
+	diff := secretDiff(t, secretValueHash([]byte("abc")), "abd")
+	require.NotNil(t, diff)
+	assert.False(t, diff.RequiresNew())
+	assert.Equal(t, secretValueHash([]byte("abd")), diff.Attributes["string_value"].New)
+}
+
+func TestResourceSecretDiff_LegacyState(t *testing.T) {
+	assert.True(t, secretDiff(t, "abc", "abc").Empty())
+}
+
 func TestResourceSecretDelete(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
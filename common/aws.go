@@ -0,0 +1,5 @@
+package common
+
+// DatabricksAwsAccountID is the AWS account of Databricks, that has to be trusted by cross-account roles
+// and allowed by policies of root buckets and customer-managed keys
+const DatabricksAwsAccountID = "414351767826"
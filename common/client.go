@@ -19,25 +19,32 @@ import (
 
 // DatabricksClient is the client struct that contains clients for all the services available on Databricks
 type DatabricksClient struct {
-	Host               string
-	Token              string
-	Username           string
-	Password           string
-	Profile            string
-	ConfigFile         string
-	AzureAuth          AzureAuth
-	InsecureSkipVerify bool
-	TimeoutSeconds     int
-	DebugTruncateBytes int
-	DebugHeaders       bool
-	httpClient         *retryablehttp.Client
-	authMutex          sync.Mutex
-	authVisitor        func(r *http.Request) error
-	commandFactory     func(context.Context, *DatabricksClient) CommandExecutor
+	Host                 string
+	Token                string
+	Username             string
+	Password             string
+	Profile              string
+	ConfigFile           string
+	AccountID            string
+	AzureAuth            AzureAuth
+	InsecureSkipVerify   bool
+	TimeoutSeconds       int
+	DebugTruncateBytes   int
+	DebugHeaders         bool
+	RetryMaxAttempts     int
+	RetryInitialInterval time.Duration
+	RetryMaxElapsedTime  time.Duration
+	httpClient           *retryablehttp.Client
+	authMutex            sync.Mutex
+	authVisitor          func(r *http.Request) error
+	commandFactory       func(context.Context, *DatabricksClient) CommandExecutor
 }
 
 // Configure client to work
 func (c *DatabricksClient) Configure() error {
+	if err := c.configureRetries(); err != nil {
+		return err
+	}
 	c.configureHTTPCLient()
 	c.AzureAuth.databricksClient = c
 	if c.DebugTruncateBytes == 0 {
@@ -173,14 +180,35 @@ func (c *DatabricksClient) encodeBasicAuth(username, password string) string {
 	return base64.StdEncoding.EncodeToString([]byte(tokenUnB64))
 }
 
+func (c *DatabricksClient) configureRetries() error {
+	if c.RetryMaxAttempts == 0 {
+		c.RetryMaxAttempts = 30
+	}
+	if c.RetryInitialInterval == 0 {
+		c.RetryInitialInterval = 10 * time.Second
+	}
+	if c.RetryMaxElapsedTime == 0 {
+		c.RetryMaxElapsedTime = 5 * time.Minute
+	}
+	if c.RetryMaxAttempts < 1 {
+		return fmt.Errorf("retry_max_attempts must be at least 1, but got %d", c.RetryMaxAttempts)
+	}
+	if c.RetryInitialInterval < 0 {
+		return fmt.Errorf("retry_initial_interval must be positive, but got %s", c.RetryInitialInterval)
+	}
+	if c.RetryMaxElapsedTime < c.RetryInitialInterval {
+		return fmt.Errorf("retry_max_elapsed_time (%s) must not be shorter than retry_initial_interval (%s)",
+			c.RetryMaxElapsedTime, c.RetryInitialInterval)
+	}
+	return nil
+}
+
 func (c *DatabricksClient) configureHTTPCLient() {
 	if c.TimeoutSeconds == 0 {
 		c.TimeoutSeconds = 60
 	}
 	// Set up a retryable HTTP Client to handle cases where the service returns
 	// a transient error on initial creation
-	retryDelayDuration := 10 * time.Second
-	retryMaximumDuration := 5 * time.Minute
 	defaultTransport := http.DefaultTransport.(*http.Transport)
 	c.httpClient = &retryablehttp.Client{
 		HTTPClient: &http.Client{
@@ -200,17 +228,44 @@ func (c *DatabricksClient) configureHTTPCLient() {
 		CheckRetry: c.checkHTTPRetry,
 		// Using a linear retry rather than the default exponential retry
 		// as the creation condition is normally passed after 30-40 seconds
-		// Setting the retry interval to 10 seconds. Setting RetryWaitMin and RetryWaitMax
+		// Setting the retry interval to 10 seconds by default. Setting RetryWaitMin and RetryWaitMax
 		// to the same value removes jitter (which would be useful in a high-volume traffic scenario
-		// but wouldn't add much here)
+		// but wouldn't add much here). Elapsed time budget is enforced in checkHTTPRetry.
 		Backoff:      retryablehttp.LinearJitterBackoff,
-		RetryWaitMin: retryDelayDuration,
-		RetryWaitMax: retryDelayDuration,
-		RetryMax:     int(retryMaximumDuration / retryDelayDuration), // + request & response log hooks
+		RetryWaitMin: c.RetryInitialInterval,
+		RetryWaitMax: c.RetryInitialInterval,
+		RetryMax:     c.RetryMaxAttempts, // + request & response log hooks
 	}
 }
 
+// ClientForHost creates a new DatabricksClient instance with the same auth parameters,
+// but for the given host. It's used to call workspace APIs with account credentials.
+func (c *DatabricksClient) ClientForHost(url string) (*DatabricksClient, error) {
+	err := c.Authenticate()
+	if err != nil {
+		return nil, err
+	}
+	cc := &DatabricksClient{
+		Host:                 url,
+		InsecureSkipVerify:   c.InsecureSkipVerify,
+		TimeoutSeconds:       c.TimeoutSeconds,
+		DebugTruncateBytes:   c.DebugTruncateBytes,
+		DebugHeaders:         c.DebugHeaders,
+		RetryMaxAttempts:     c.RetryMaxAttempts,
+		RetryInitialInterval: c.RetryInitialInterval,
+		RetryMaxElapsedTime:  c.RetryMaxElapsedTime,
+		authVisitor:          c.authVisitor,
+		commandFactory:       c.commandFactory,
+	}
+	return cc, cc.Configure()
+}
+
 // IsAzure returns true if client is configured for Azure Databricks - either by using AAD auth or with host+token combination
 func (c *DatabricksClient) IsAzure() bool {
 	return c.AzureAuth.resourceID() != "" || strings.Contains(c.Host, "azuredatabricks.net")
 }
+
+// IsGcp returns true if client is configured for Databricks on Google Cloud
+func (c *DatabricksClient) IsGcp() bool {
+	return strings.Contains(c.Host, "gcp.databricks.com")
+}
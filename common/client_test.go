@@ -1,9 +1,11 @@
 package common
 
 import (
+	"net/http"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -151,3 +153,61 @@ func TestDatabricksClientConfigure_InvalidConfigFilePath(t *testing.T) {
 // 	})
 // 	assert.EqualError(t, err, ".")
 // }
+
+func TestDatabricksClientConfigure_RetryDefaults(t *testing.T) {
+	dc := &DatabricksClient{}
+	err := dc.Configure()
+	assert.NoError(t, err)
+	assert.Equal(t, 30, dc.RetryMaxAttempts)
+	assert.Equal(t, 10*time.Second, dc.RetryInitialInterval)
+	assert.Equal(t, 5*time.Minute, dc.RetryMaxElapsedTime)
+}
+
+func TestDatabricksClientConfigure_RetryElapsedShorterThanInterval(t *testing.T) {
+	err := (&DatabricksClient{
+		RetryInitialInterval: time.Minute,
+		RetryMaxElapsedTime:  30 * time.Second,
+	}).Configure()
+	assert.EqualError(t, err, "retry_max_elapsed_time (30s) must not be shorter than retry_initial_interval (1m0s)")
+}
+
+func TestDatabricksClientConfigure_RetryNegativeAttempts(t *testing.T) {
+	err := (&DatabricksClient{
+		RetryMaxAttempts: -1,
+	}).Configure()
+	assert.EqualError(t, err, "retry_max_attempts must be at least 1, but got -1")
+}
+
+func TestDatabricksClientClientForHost(t *testing.T) {
+	dc, err := configureAndAuthenticate(&DatabricksClient{
+		Host:             "https://accounts.cloud.databricks.com/",
+		Username:         "foo",
+		Password:         "bar",
+		RetryMaxAttempts: 3,
+	})
+	assert.NoError(t, err)
+
+	cc, err := dc.ClientForHost("https://abc.cloud.databricks.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://abc.cloud.databricks.com", cc.Host)
+	assert.Equal(t, 3, cc.RetryMaxAttempts)
+
+	req, _ := http.NewRequest("GET", cc.Host, nil)
+	err = cc.authVisitor(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "Basic Zm9vOmJhcg==", req.Header.Get("Authorization"))
+}
+
+func TestDatabricksClientClientForHost_NoAuth(t *testing.T) {
+	defer CleanupEnvironment()()
+	os.Setenv("PATH", "testdata:/bin")
+	dc := &DatabricksClient{}
+	assert.NoError(t, dc.Configure())
+	_, err := dc.ClientForHost("https://abc.cloud.databricks.com")
+	AssertErrorStartsWith(t, err, "Authentication is not configured for provider")
+}
+
+func TestDatabricksClientIsGcp(t *testing.T) {
+	assert.True(t, (&DatabricksClient{Host: "https://accounts.gcp.databricks.com/"}).IsGcp())
+	assert.False(t, (&DatabricksClient{Host: "https://accounts.cloud.databricks.com/"}).IsGcp())
+}
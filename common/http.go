@@ -13,6 +13,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 	"github.com/hashicorp/go-retryablehttp"
@@ -204,8 +205,49 @@ func (c *DatabricksClient) parseError(resp *http.Response) APIError {
 	}
 }
 
+// retryBudget tracks attempts and time spent on a single request
+type retryBudget struct {
+	started  time.Time
+	attempts int
+}
+
+type retryBudgetKey struct{}
+
 // checkHTTPRetry inspects HTTP errors from the Databricks API for known transient errors on Workspace creation
+// and stops retrying once retry budget (attempts or elapsed time) of current request is exhausted
 func (c *DatabricksClient) checkHTTPRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	retry, err := c.isTransient(resp, err)
+	if !retry {
+		return false, err
+	}
+	budget, ok := ctx.Value(retryBudgetKey{}).(*retryBudget)
+	if !ok {
+		return retry, err
+	}
+	budget.attempts++
+	elapsed := time.Since(budget.started)
+	// LinearJitterBackoff waits for initial interval multiplied by number of attempts
+	nextWait := c.RetryInitialInterval * time.Duration(budget.attempts)
+	if budget.attempts < c.RetryMaxAttempts && elapsed+nextWait <= c.RetryMaxElapsedTime {
+		return retry, err
+	}
+	lastError := APIError{Message: fmt.Sprintf("%v", err)}
+	if ae, ok := err.(APIError); ok {
+		lastError = ae
+	}
+	return false, APIError{
+		ErrorCode: "RETRY_BUDGET_EXHAUSTED",
+		Message: fmt.Sprintf("Retry budget exhausted after %d attempts in %s "+
+			"(retry_max_attempts=%d, retry_max_elapsed_time=%s). Last error: %s",
+			budget.attempts, elapsed.Round(time.Millisecond), c.RetryMaxAttempts,
+			c.RetryMaxElapsedTime, lastError.Message),
+		StatusCode: lastError.StatusCode,
+		Resource:   lastError.Resource,
+	}
+}
+
+// isTransient tells if response or connection error is worth retrying
+func (c *DatabricksClient) isTransient(resp *http.Response, err error) (bool, error) {
 	if ue, ok := err.(*url.Error); ok {
 		apiError := APIError{ErrorCode: "IO_ERROR", Message: ue.Error()}
 		return apiError.IsRetriable(), apiError
@@ -302,6 +344,23 @@ func (c *DatabricksClient) api2(r *http.Request) error {
 	return nil
 }
 
+func (c *DatabricksClient) api21(r *http.Request) error {
+	if r.URL == nil {
+		return fmt.Errorf("No URL found in request")
+	}
+	r.URL.Path = fmt.Sprintf("/api/2.1%s", r.URL.Path)
+	r.Header.Set("Content-Type", "application/json")
+
+	url, err := url.Parse(c.Host)
+	if err != nil {
+		return err
+	}
+	r.URL.Host = url.Host
+	r.URL.Scheme = url.Scheme
+
+	return nil
+}
+
 func (c *DatabricksClient) api12(r *http.Request) error {
 	if r.URL == nil {
 		return fmt.Errorf("No URL found in request")
@@ -331,6 +390,15 @@ func (c *DatabricksClient) Scim(ctx context.Context, method, path string, reques
 	return c.unmarshall(path, body, &response)
 }
 
+// API21 performs call on version 2.1 of REST API, which is needed for multi-task jobs
+func (c *DatabricksClient) API21(ctx context.Context, method, path string, request interface{}, response interface{}) error {
+	body, err := c.authenticatedQuery(ctx, method, path, request, c.api21)
+	if err != nil {
+		return err
+	}
+	return c.unmarshall(path, body, &response)
+}
+
 // OldAPI performs call on context api
 func (c *DatabricksClient) OldAPI(ctx context.Context, method, path string, request interface{}, response interface{}) error {
 	body, err := c.authenticatedQuery(ctx, method, path, request, c.api12)
@@ -417,6 +485,7 @@ func (c *DatabricksClient) genericQuery(ctx context.Context, method, requestURL
 	if err != nil {
 		return nil, err
 	}
+	ctx = context.WithValue(ctx, retryBudgetKey{}, &retryBudget{started: time.Now()})
 	request, err := http.NewRequestWithContext(ctx, method, requestURL, bytes.NewBuffer(requestBody))
 	if err != nil {
 		return nil, err
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -321,6 +322,16 @@ func TestOldAPI(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestAPI21(t *testing.T) {
+	ws, server := singleRequestServer(t, "GET", "/api/2.1/imaginary/endpoint", `{"a": "b"}`)
+	defer server.Close()
+
+	var resp map[string]string
+	err := ws.API21(context.Background(), "GET", "/imaginary/endpoint", nil, &resp)
+	require.NoError(t, err)
+	assert.Equal(t, "b", resp["a"])
+}
+
 func TestMakeRequestBody(t *testing.T) {
 	type x struct {
 		Scope string `json:"scope" url:"scope"`
@@ -417,3 +428,57 @@ func TestClient_HandleErrors(t *testing.T) {
 		})
 	}
 }
+
+func retryingServer(t *testing.T, client *DatabricksClient) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(rw http.ResponseWriter, req *http.Request) {
+			rw.WriteHeader(http.StatusBadRequest)
+			_, err := rw.Write([]byte(`{
+				"error_code": "INVALID_STATE",
+				"message": "ClusterNotReadyException: wait a bit"
+			}`))
+			assert.NoError(t, err)
+		}))
+	client.Host = server.URL
+	client.Token = "..."
+	err := client.Configure()
+	assert.NoError(t, err)
+	return server
+}
+
+func TestRetryBudget_MaxAttempts(t *testing.T) {
+	client := DatabricksClient{
+		RetryMaxAttempts:     3,
+		RetryInitialInterval: time.Millisecond,
+		RetryMaxElapsedTime:  time.Minute,
+	}
+	server := retryingServer(t, &client)
+	defer server.Close()
+
+	err := client.Get(context.Background(), "/imaginary/endpoint", nil, nil)
+	require.Error(t, err)
+	ae, ok := err.(APIError)
+	require.True(t, ok, "Actual error: %v", err)
+	assert.Equal(t, "RETRY_BUDGET_EXHAUSTED", ae.ErrorCode)
+	assert.Equal(t, 400, ae.StatusCode)
+	assert.True(t, strings.HasPrefix(ae.Message, "Retry budget exhausted after 3 attempts in "),
+		"Actual message: %s", ae.Message)
+	assert.True(t, strings.HasSuffix(ae.Message, "(retry_max_attempts=3, retry_max_elapsed_time=1m0s). "+
+		"Last error: ClusterNotReadyException: wait a bit"), "Actual message: %s", ae.Message)
+}
+
+func TestRetryBudget_MaxElapsedTime(t *testing.T) {
+	client := DatabricksClient{
+		RetryMaxAttempts:     100,
+		RetryInitialInterval: 20 * time.Millisecond,
+		RetryMaxElapsedTime:  50 * time.Millisecond,
+	}
+	server := retryingServer(t, &client)
+	defer server.Close()
+
+	err := client.Get(context.Background(), "/imaginary/endpoint", nil, nil)
+	require.Error(t, err)
+	// waits for 20ms before second and 40ms before third attempt, which exceeds 50ms
+	assert.True(t, strings.HasPrefix(err.Error(), "Retry budget exhausted after 2 attempts in "),
+		"Actual message: %s", err.Error())
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -89,6 +90,15 @@ func (a ClustersAPI) Edit(cluster Cluster) (info ClusterInfo, err error) {
 	return info, err
 }
 
+// Resize changes number of workers of running cluster without restarting it
+func (a ClustersAPI) Resize(resizeRequest ResizeRequest) (info ClusterInfo, err error) {
+	err = a.client.Post(a.context, "/clusters/resize", resizeRequest, nil)
+	if err != nil {
+		return
+	}
+	return a.waitForClusterStatus(resizeRequest.ClusterID, ClusterStateRunning)
+}
+
 // ListZones returns the zones info sent by the cloud service provider
 func (a ClustersAPI) ListZones() (ZonesInfo, error) {
 	var zonesInfo ZonesInfo
@@ -190,6 +200,26 @@ func (a ClustersAPI) waitForClusterStatus(clusterID string, desired ClusterState
 	})
 }
 
+// waitForLogDelivery waits until cluster logs are delivered after the given attempt timestamp
+// and fails with delivery exception, if that attempt was not successful
+func (a ClustersAPI) waitForLogDelivery(clusterID string, since int64, timeout time.Duration) error {
+	return resource.RetryContext(a.context, timeout, func() *resource.RetryError {
+		clusterInfo, err := a.Get(clusterID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		status := clusterInfo.ClusterLogStatus
+		if status == nil || status.LastAttempted <= since {
+			return resource.RetryableError(fmt.Errorf("Logs of cluster %s are not delivered yet", clusterID))
+		}
+		if status.LastException != "" {
+			return resource.NonRetryableError(fmt.Errorf("Cannot deliver logs of cluster %s: %s",
+				clusterID, status.LastException))
+		}
+		return nil
+	})
+}
+
 // Terminate terminates a Spark cluster given its ID
 func (a ClustersAPI) Terminate(clusterID string) error {
 	err := a.client.Post(a.context, "/clusters/delete", ClusterID{ClusterID: clusterID}, nil)
@@ -232,7 +262,20 @@ func (a ClustersAPI) Get(clusterID string) (ci ClusterInfo, err error) {
 
 // Pin ensure that an interactive cluster configuration is retained even after a cluster has been terminated for more than 30 days
 func (a ClustersAPI) Pin(clusterID string) error {
-	return a.client.Post(a.context, "/clusters/pin", ClusterID{ClusterID: clusterID}, nil)
+	err := a.client.Post(a.context, "/clusters/pin", ClusterID{ClusterID: clusterID}, nil)
+	if isPinnedClustersQuotaError(err) {
+		return fmt.Errorf("Cannot pin cluster %s: %s. Workspace has a limit on number of pinned clusters, "+
+			"so please unpin clusters, that are no longer needed, or set is_pinned = false",
+			clusterID, err.(common.APIError).Message)
+	}
+	return err
+}
+
+// isPinnedClustersQuotaError returns true, if workspace already has maximum number of pinned clusters
+func isPinnedClustersQuotaError(err error) bool {
+	e, ok := err.(common.APIError)
+	return ok && (e.ErrorCode == "QUOTA_EXCEEDED" ||
+		(e.StatusCode == 400 && strings.Contains(e.Message, "pinned clusters")))
 }
 
 // Unpin allows the cluster to eventually be removed from the list returned by the List API
@@ -265,6 +308,10 @@ func (a ClustersAPI) Events(eventsRequest EventsRequest) ([]ClusterEvent, error)
 		if err != nil {
 			return nil, err
 		}
+		if len(eventsResponse.Events) == 0 {
+			// otherwise we'll keep requesting the same page
+			break
+		}
 		startPos = curPos
 		curLen := len(eventsResponse.Events)
 		restItems := totalCount - startPos
@@ -345,54 +392,114 @@ func (a ClustersAPI) GetOrCreateRunningCluster(name string, custom ...Cluster) (
 
 // NodeTypeRequest is a wrapper for local filtering of node types
 type NodeTypeRequest struct {
-	MinMemoryGB int32  `json:"min_memory_gb,omitempty"`
-	GBPerCore   int32  `json:"gb_per_core,omitempty"`
-	MinCores    int32  `json:"min_cores,omitempty"`
-	MinGPUs     int32  `json:"min_gpus,omitempty"`
-	LocalDisk   bool   `json:"local_disk,omitempty"`
-	Category    string `json:"category,omitempty"`
+	MinMemoryGB           int32  `json:"min_memory_gb,omitempty"`
+	GBPerCore             int32  `json:"gb_per_core,omitempty"`
+	MinCores              int32  `json:"min_cores,omitempty"`
+	MinGPUs               int32  `json:"min_gpus,omitempty"`
+	LocalDisk             bool   `json:"local_disk,omitempty"`
+	Category              string `json:"category,omitempty"`
+	PhotonWorkerCapable   bool   `json:"photon_worker_capable,omitempty"`
+	PhotonDriverCapable   bool   `json:"photon_driver_capable,omitempty"`
+	Graviton              bool   `json:"graviton,omitempty"`
+	Fleet                 bool   `json:"fleet,omitempty"`
+	SupportPortForwarding bool   `json:"support_port_forwarding,omitempty"`
+}
+
+// matches tells if node type satisfies all criteria of the request
+func (r NodeTypeRequest) matches(nt NodeType) bool {
+	gbs := (nt.MemoryMB / 1024)
+	if r.MinMemoryGB > 0 && gbs < r.MinMemoryGB {
+		return false
+	}
+	if r.GBPerCore > 0 && (gbs/int32(nt.NumCores)) < r.GBPerCore {
+		return false
+	}
+	if r.MinCores > 0 && int32(nt.NumCores) < r.MinCores {
+		return false
+	}
+	if r.MinGPUs > 0 && nt.NumGPUs < r.MinGPUs {
+		return false
+	}
+	if r.LocalDisk && nt.NodeInstanceType != nil &&
+		(nt.NodeInstanceType.LocalDisks < 1 &&
+			nt.NodeInstanceType.LocalNVMeDisks < 1) {
+		return false
+	}
+	if r.Category != "" && nt.Category != r.Category {
+		return false
+	}
+	if r.PhotonWorkerCapable && !nt.PhotonWorkerCapable {
+		return false
+	}
+	if r.PhotonDriverCapable && !nt.PhotonDriverCapable {
+		return false
+	}
+	if r.Graviton && !nt.IsGraviton {
+		return false
+	}
+	if r.Fleet && !strings.Contains(nt.NodeTypeID, "-fleet.") {
+		return false
+	}
+	if r.SupportPortForwarding && !nt.SupportPortForwarding {
+		return false
+	}
+	return true
+}
+
+// String lists criteria of the request, that are set, e.g. for error messages
+func (r NodeTypeRequest) String() string {
+	filters := []string{}
+	add := func(name string, set bool, value interface{}) {
+		if set {
+			filters = append(filters, fmt.Sprintf("%s=%v", name, value))
+		}
+	}
+	add("min_memory_gb", r.MinMemoryGB > 0, r.MinMemoryGB)
+	add("gb_per_core", r.GBPerCore > 0, r.GBPerCore)
+	add("min_cores", r.MinCores > 0, r.MinCores)
+	add("min_gpus", r.MinGPUs > 0, r.MinGPUs)
+	add("local_disk", r.LocalDisk, r.LocalDisk)
+	add("category", r.Category != "", r.Category)
+	add("photon_worker_capable", r.PhotonWorkerCapable, r.PhotonWorkerCapable)
+	add("photon_driver_capable", r.PhotonDriverCapable, r.PhotonDriverCapable)
+	add("graviton", r.Graviton, r.Graviton)
+	add("fleet", r.Fleet, r.Fleet)
+	add("support_port_forwarding", r.SupportPortForwarding, r.SupportPortForwarding)
+	return strings.Join(filters, ", ")
+}
+
+func (a ClustersAPI) defaultNodeType() string {
+	if a.client.IsAzure() {
+		return "Standard_D3_v2"
+	}
+	return "i3.xlarge"
 }
 
 // GetSmallestNodeType returns smallest (or default) node type id given the criteria
 func (a ClustersAPI) GetSmallestNodeType(r NodeTypeRequest) string {
+	nodeType, err := a.findSmallestNodeType(r)
+	if err != nil {
+		return a.defaultNodeType()
+	}
+	return nodeType
+}
+
+// findSmallestNodeType returns smallest node type id given the criteria or an error,
+// if none of the node types match. Cloud default is returned, if node types cannot be listed.
+func (a ClustersAPI) findSmallestNodeType(r NodeTypeRequest) (string, error) {
 	list, _ := a.ListNodeTypes()
 	// error is explicitly ingored here, because Azure returns
 	// apparently too big of a JSON for Go to parse
 	if len(list.NodeTypes) == 0 {
-		if a.client.IsAzure() {
-			return "Standard_D3_v2"
-		}
-		return "i3.xlarge"
+		return a.defaultNodeType(), nil
 	}
 	list.Sort()
 	for _, nt := range list.NodeTypes {
-		gbs := (nt.MemoryMB / 1024)
-		if r.MinMemoryGB > 0 && gbs < r.MinMemoryGB {
-			continue
-		}
-		if r.GBPerCore > 0 && (gbs/int32(nt.NumCores)) < r.GBPerCore {
-			continue
-		}
-		if r.MinCores > 0 && int32(nt.NumCores) < r.MinCores {
-			continue
+		if r.matches(nt) {
+			return nt.NodeTypeID, nil
 		}
-		if r.MinGPUs > 0 && nt.NumGPUs < r.MinGPUs {
-			continue
-		}
-		if r.LocalDisk && nt.NodeInstanceType != nil &&
-			(nt.NodeInstanceType.LocalDisks < 1 &&
-				nt.NodeInstanceType.LocalNVMeDisks < 1) {
-			continue
-		}
-		if r.Category != "" && nt.Category != r.Category {
-			continue
-		}
-		return nt.NodeTypeID
-	}
-	if a.client.IsAzure() {
-		return "Standard_D3_v2"
 	}
-	return "i3.xlarge"
+	return "", fmt.Errorf("Cannot find node type matching: %s", r)
 }
 
 // ListSparkVersions returns smallest (or default) node type id given the criteria
@@ -412,6 +519,7 @@ func (sparkVersions SparkVersionsList) LatestSparkVersion(req SparkVersionReques
 				(strings.Contains(version.Version, "-ml-") == req.ML) &&
 				(strings.Contains(version.Version, "-hls-") == req.Genomics) &&
 				(strings.Contains(version.Version, "-gpu-") == req.GPU) &&
+				(strings.Contains(version.Version, "-photon-") == req.Photon) &&
 				(strings.Contains(version.Description, "Beta") == req.Beta))
 			if matches && req.LongTermSupport {
 				matches = (matches && strings.Contains(version.Description, "LTS"))
@@ -428,7 +536,9 @@ func (sparkVersions SparkVersionsList) LatestSparkVersion(req SparkVersionReques
 		return "", fmt.Errorf("Spark versions query returned no results. Please change your search criteria and try again")
 	} else if len(versions) > 1 {
 		if req.Latest {
-			sort.Sort(sort.Reverse(sort.StringSlice(versions)))
+			sort.Slice(versions, func(i, j int) bool {
+				return sparkVersionLess(versions[j], versions[i])
+			})
 		} else {
 			return "", fmt.Errorf("Spark versions query returned multiple results. Please change your search criteria and try again")
 		}
@@ -437,6 +547,28 @@ func (sparkVersions SparkVersionsList) LatestSparkVersion(req SparkVersionReques
 	return versions[0], nil
 }
 
+// sparkVersionLess compares runtime versions like `7.3.x-scala2.12` and `10.4.x-scala2.12` by their numeric
+// components, so that `10.4` is newer than `9.1`. Components, that are not numbers, are compared as strings.
+func sparkVersionLess(a, b string) bool {
+	aParts := strings.Split(strings.SplitN(a, "-", 2)[0], ".")
+	bParts := strings.Split(strings.SplitN(b, "-", 2)[0], ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if aParts[i] == bParts[i] {
+			continue
+		}
+		aNum, aErr := strconv.Atoi(aParts[i])
+		bNum, bErr := strconv.Atoi(bParts[i])
+		if aErr == nil && bErr == nil {
+			return aNum < bNum
+		}
+		return aParts[i] < bParts[i]
+	}
+	if len(aParts) != len(bParts) {
+		return len(aParts) < len(bParts)
+	}
+	return a < b
+}
+
 // LatestSparkVersion returns latest version matching the request parameters
 func (a ClustersAPI) LatestSparkVersion(svr SparkVersionRequest) (string, error) {
 	sparkVersions, err := a.ListSparkVersions()
@@ -0,0 +1,78 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// maximum number of events, that API returns in a single page
+const eventsPageLimit = 500
+
+type clusterEvent struct {
+	Timestamp int64  `json:"timestamp"`
+	Type      string `json:"type"`
+	Details   string `json:"details"`
+}
+
+type clusterEventsData struct {
+	ClusterID  string         `json:"cluster_id"`
+	EventTypes []string       `json:"event_types,omitempty" tf:"slice_set"`
+	Limit      int            `json:"limit,omitempty"`
+	Events     []clusterEvent `json:"events,omitempty" tf:"computed"`
+}
+
+// DataSourceClusterEvents returns the most recent events of a cluster
+func DataSourceClusterEvents() *schema.Resource {
+	s := internal.StructToSchema(clusterEventsData{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["limit"].Default = 50
+		s["limit"].ValidateFunc = validation.IntAtLeast(1)
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var data clusterEventsData
+			if err := internal.DataToStructPointer(d, s, &data); err != nil {
+				return diag.FromErr(err)
+			}
+			request := EventsRequest{
+				ClusterID: data.ClusterID,
+				Order:     SortDescending,
+				Limit:     int64(data.Limit),
+				MaxItems:  uint(data.Limit),
+			}
+			if request.Limit > eventsPageLimit {
+				request.Limit = eventsPageLimit
+			}
+			for _, eventType := range data.EventTypes {
+				request.EventTypes = append(request.EventTypes, ClusterEventType(eventType))
+			}
+			events, err := NewClustersAPI(ctx, m).Events(request)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			for _, event := range events {
+				details, err := json.Marshal(event.Details)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				data.Events = append(data.Events, clusterEvent{
+					Timestamp: event.Timestamp,
+					Type:      string(event.Type),
+					Details:   string(details),
+				})
+			}
+			if err = internal.StructToData(data, s, d); err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(data.ClusterID)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,120 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceClusterEvents(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				ExpectedRequest: EventsRequest{
+					ClusterID:  "abc",
+					Order:      SortDescending,
+					EventTypes: []ClusterEventType{EvTypeResizing},
+					Limit:      3,
+				},
+				Response: EventsResponse{
+					Events: []ClusterEvent{
+						{
+							ClusterID: "abc",
+							Timestamp: 3,
+							Type:      EvTypeResizing,
+							Details: EventDetails{
+								CurrentNumWorkers: 2,
+								TargetNumWorkers:  4,
+							},
+						},
+						{
+							ClusterID: "abc",
+							Timestamp: 2,
+							Type:      EvTypeResizing,
+						},
+					},
+					NextPage: &EventsRequest{
+						ClusterID:  "abc",
+						Order:      SortDescending,
+						EventTypes: []ClusterEventType{EvTypeResizing},
+						Offset:     2,
+						Limit:      3,
+					},
+					TotalCount: 10,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				ExpectedRequest: EventsRequest{
+					ClusterID:  "abc",
+					Order:      SortDescending,
+					EventTypes: []ClusterEventType{EvTypeResizing},
+					Offset:     2,
+					Limit:      3,
+				},
+				Response: EventsResponse{
+					Events: []ClusterEvent{
+						{
+							ClusterID: "abc",
+							Timestamp: 1,
+							Type:      EvTypeResizing,
+						},
+						{
+							ClusterID: "abc",
+							Timestamp: 0,
+							Type:      EvTypeResizing,
+						},
+					},
+					NextPage: &EventsRequest{
+						ClusterID: "abc",
+						Offset:    4,
+						Limit:     3,
+					},
+					TotalCount: 10,
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceClusterEvents(),
+		NonWritable: true,
+		HCL: `
+		cluster_id = "abc"
+		event_types = ["RESIZING"]
+		limit = 3`,
+		ID: ".",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 3, d.Get("events.#"))
+	assert.Equal(t, 3, d.Get("events.0.timestamp"))
+	assert.Equal(t, "RESIZING", d.Get("events.0.type"))
+	assert.Contains(t, d.Get("events.0.details"), `"target_num_workers":4`)
+	assert.Equal(t, 1, d.Get("events.2.timestamp"))
+}
+
+func TestDataSourceClusterEvents_Error(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_PARAMETER_VALUE",
+					Message:   "Cluster abc does not exist",
+				},
+				Status: 400,
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceClusterEvents(),
+		NonWritable: true,
+		HCL:         `cluster_id = "abc"`,
+		ID:          ".",
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Cluster abc does not exist")
+}
@@ -0,0 +1,167 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/identity"
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// clusterPermissions is the subset of permissions API response, that is needed to check cluster access.
+// Permissions package depends on this one, so it's not reused.
+type clusterPermissions struct {
+	AccessControlList []struct {
+		UserName             string `json:"user_name,omitempty"`
+		GroupName            string `json:"group_name,omitempty"`
+		ServicePrincipalName string `json:"service_principal_name,omitempty"`
+		AllPermissions       []struct {
+			PermissionLevel string `json:"permission_level"`
+		} `json:"all_permissions,omitempty"`
+	} `json:"access_control_list"`
+}
+
+// clusterAccessChecker tells if current user can attach notebooks to clusters
+type clusterAccessChecker struct {
+	ctx     context.Context
+	client  *common.DatabricksClient
+	me      identity.ScimUser
+	groups  map[string]bool
+	isAdmin bool
+}
+
+func newClusterAccessChecker(ctx context.Context, m interface{}) (*clusterAccessChecker, error) {
+	me, err := identity.NewUsersAPI(ctx, m).Me()
+	if err != nil {
+		return nil, err
+	}
+	checker := &clusterAccessChecker{
+		ctx:    ctx,
+		client: m.(*common.DatabricksClient),
+		me:     me,
+		// every workspace user is a member of this group
+		groups: map[string]bool{"users": true},
+	}
+	for _, g := range me.Groups {
+		checker.groups[g.Display] = true
+		if g.Display == "admins" {
+			checker.isAdmin = true
+		}
+	}
+	return checker, nil
+}
+
+func (c *clusterAccessChecker) canUse(clusterID string) (bool, error) {
+	if c.isAdmin {
+		return true, nil
+	}
+	var permissions clusterPermissions
+	err := c.client.Get(c.ctx, fmt.Sprintf("/permissions/clusters/%s", clusterID), nil, &permissions)
+	if apiErr, ok := err.(common.APIError); ok &&
+		(apiErr.StatusCode == http.StatusForbidden || apiErr.ErrorCode == "PERMISSION_DENIED") {
+		// users without any permission on a cluster cannot read its permissions either
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, ac := range permissions.AccessControlList {
+		isMe := (ac.UserName != "" && ac.UserName == c.me.UserName) ||
+			(ac.ServicePrincipalName != "" && ac.ServicePrincipalName == c.me.ApplicationID) ||
+			c.groups[ac.GroupName]
+		if !isMe {
+			continue
+		}
+		for _, p := range ac.AllPermissions {
+			switch p.PermissionLevel {
+			case "CAN_ATTACH_TO", "CAN_RESTART", "CAN_MANAGE":
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// DataSourceClusters returns clusters of the workspace, that match filters
+func DataSourceClusters() *schema.Resource {
+	type entity struct {
+		ClusterStates       []string          `json:"cluster_states,omitempty" tf:"slice_set"`
+		ClusterSources      []string          `json:"cluster_sources,omitempty" tf:"slice_set"`
+		ClusterNameContains string            `json:"cluster_name_contains,omitempty"`
+		CanUse              bool              `json:"can_use,omitempty"`
+		Ids                 []string          `json:"ids,omitempty" tf:"computed,slice_set"`
+		Names               map[string]string `json:"names,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		return s
+	})
+	contains := func(values []string, v string) bool {
+		if len(values) == 0 {
+			return true
+		}
+		for _, value := range values {
+			if strings.EqualFold(value, v) {
+				return true
+			}
+		}
+		return false
+	}
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			clusters, err := NewClustersAPI(ctx, m).List()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			var checker *clusterAccessChecker
+			if this.CanUse {
+				checker, err = newClusterAccessChecker(ctx, m)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+			}
+			nameContains := strings.ToLower(this.ClusterNameContains)
+			this.Ids = []string{}
+			this.Names = map[string]string{}
+			for _, cluster := range clusters {
+				if !contains(this.ClusterStates, string(cluster.State)) {
+					continue
+				}
+				if !contains(this.ClusterSources, string(cluster.ClusterSource)) {
+					continue
+				}
+				if !strings.Contains(strings.ToLower(cluster.ClusterName), nameContains) {
+					continue
+				}
+				if checker != nil {
+					canUse, err := checker.canUse(cluster.ClusterID)
+					if err != nil {
+						return diag.FromErr(err)
+					}
+					if !canUse {
+						continue
+					}
+				}
+				this.Ids = append(this.Ids, cluster.ClusterID)
+				this.Names[cluster.ClusterID] = cluster.ClusterName
+			}
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId("_")
+			return nil
+		},
+	}
+}
@@ -0,0 +1,196 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/identity"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+var clustersListFixture = qa.HTTPFixture{
+	Method:       "GET",
+	Resource:     "/api/2.0/clusters/list",
+	ReuseRequest: true,
+	Response: ClusterList{
+		Clusters: []ClusterInfo{
+			{
+				ClusterID:     "a",
+				ClusterName:   "Shared Analytics",
+				State:         ClusterStateRunning,
+				ClusterSource: "UI",
+			},
+			{
+				ClusterID:     "b",
+				ClusterName:   "job-12-run-1",
+				State:         ClusterStateTerminated,
+				ClusterSource: "JOB",
+			},
+			{
+				ClusterID:     "c",
+				ClusterName:   "Analytics Sandbox",
+				State:         ClusterStateRunning,
+				ClusterSource: "API",
+			},
+		},
+	},
+}
+
+func TestDataSourceClusters_Filters(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{clustersListFixture},
+		Read:        true,
+		Resource:    DataSourceClusters(),
+		NonWritable: true,
+		HCL: `
+		cluster_states = ["RUNNING"]
+		cluster_sources = ["UI", "API"]
+		cluster_name_contains = "analytics"`,
+		ID: ".",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	ids := d.Get("ids").(*schema.Set)
+	assert.Equal(t, 2, ids.Len())
+	assert.True(t, ids.Contains("a"))
+	assert.True(t, ids.Contains("c"))
+	assert.Equal(t, map[string]interface{}{
+		"a": "Shared Analytics",
+		"c": "Analytics Sandbox",
+	}, d.Get("names"))
+}
+
+func TestDataSourceClusters_NoFilters(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:    []qa.HTTPFixture{clustersListFixture},
+		Read:        true,
+		Resource:    DataSourceClusters(),
+		NonWritable: true,
+		ID:          ".",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 3, d.Get("ids").(*schema.Set).Len())
+}
+
+func TestDataSourceClusters_CanUse(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			clustersListFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Response: identity.ScimUser{
+					UserName: "me@example.com",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/permissions/clusters/a",
+				Response: clusterPermissionsFixture("user_name", "me@example.com", "CAN_RESTART"),
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/permissions/clusters/b",
+				Response: clusterPermissionsFixture("user_name", "other@example.com", "CAN_MANAGE"),
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/permissions/clusters/c",
+				Response: clusterPermissionsFixture("group_name", "users", "CAN_ATTACH_TO"),
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceClusters(),
+		NonWritable: true,
+		HCL:         `can_use = true`,
+		ID:          ".",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	ids := d.Get("ids").(*schema.Set)
+	assert.Equal(t, 2, ids.Len())
+	assert.False(t, ids.Contains("b"))
+}
+
+func TestDataSourceClusters_CanUsePermissionDenied(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			clustersListFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Response: identity.ScimUser{
+					UserName: "me@example.com",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/permissions/clusters/a",
+				Response: clusterPermissionsFixture("user_name", "me@example.com", "CAN_RESTART"),
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/permissions/clusters/b",
+				Response: common.APIErrorBody{
+					ErrorCode: "PERMISSION_DENIED",
+					Message:   "User me@example.com does not have Manage permissions on cluster b",
+				},
+				Status: 403,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/permissions/clusters/c",
+				Response: clusterPermissionsFixture("group_name", "users", "CAN_ATTACH_TO"),
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceClusters(),
+		NonWritable: true,
+		HCL:         `can_use = true`,
+		ID:          ".",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	ids := d.Get("ids").(*schema.Set)
+	assert.Equal(t, 2, ids.Len())
+	assert.False(t, ids.Contains("b"))
+}
+
+func TestDataSourceClusters_CanUseAdmin(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			clustersListFixture,
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Response: map[string]interface{}{
+					"userName": "admin@example.com",
+					"groups": []map[string]string{
+						{"display": "admins", "value": "1"},
+					},
+				},
+			},
+		},
+		Read:        true,
+		Resource:    DataSourceClusters(),
+		NonWritable: true,
+		HCL:         `can_use = true`,
+		ID:          ".",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 3, d.Get("ids").(*schema.Set).Len())
+}
+
+func clusterPermissionsFixture(principalType, principal, level string) map[string]interface{} {
+	return map[string]interface{}{
+		"access_control_list": []interface{}{
+			map[string]interface{}{
+				principalType: principal,
+				"all_permissions": []interface{}{
+					map[string]interface{}{
+						"permission_level": level,
+					},
+				},
+			},
+		},
+	}
+}
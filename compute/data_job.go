@@ -0,0 +1,68 @@
+package compute
+
+import (
+	"context"
+	"strings"
+
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceJob returns single job looked up by its name, so that jobs managed elsewhere could be referenced
+func DataSourceJob() *schema.Resource {
+	type entity struct {
+		JobName           string        `json:"job_name"`
+		JobID             string        `json:"job_id,omitempty" tf:"computed"`
+		CreatorUserName   string        `json:"creator_user_name,omitempty" tf:"computed"`
+		RunAsUserName     string        `json:"run_as_user_name,omitempty" tf:"computed"`
+		CreatedTime       int64         `json:"created_time,omitempty" tf:"computed"`
+		Format            string        `json:"format,omitempty" tf:"computed"`
+		MaxConcurrentRuns int32         `json:"max_concurrent_runs,omitempty" tf:"computed"`
+		TimeoutSeconds    int32         `json:"timeout_seconds,omitempty" tf:"computed"`
+		Schedule          *CronSchedule `json:"schedule,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			jobs, err := NewJobsAPI(ctx, m).ListByName(this.JobName)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if len(jobs) == 0 {
+				return diag.Errorf("Cannot find job %s", this.JobName)
+			}
+			if len(jobs) > 1 {
+				ids := []string{}
+				for _, job := range jobs {
+					ids = append(ids, job.ID())
+				}
+				return diag.Errorf("There are %d jobs named %s: %s", len(jobs), this.JobName, strings.Join(ids, ", "))
+			}
+			job := jobs[0]
+			this.JobID = job.ID()
+			this.CreatorUserName = job.CreatorUserName
+			this.RunAsUserName = job.RunAsUserName
+			this.CreatedTime = job.CreatedTime
+			this.Format = job.Settings.Format
+			this.MaxConcurrentRuns = job.Settings.MaxConcurrentRuns
+			this.TimeoutSeconds = job.Settings.TimeoutSeconds
+			this.Schedule = job.Settings.Schedule
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(this.JobID)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,106 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceJob(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/list?limit=25&name=Featurizer",
+				Response: JobList{
+					Jobs: []Job{
+						{
+							JobID:    123,
+							Settings: &JobSettings{Name: "Featurizer Old"},
+						},
+					},
+					HasMore:       true,
+					NextPageToken: "next",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/list?limit=25&name=Featurizer&page_token=next",
+				Response: JobList{
+					Jobs: []Job{
+						{
+							JobID:           789,
+							CreatorUserName: "ci@example.com",
+							CreatedTime:     1618263108000,
+							Settings: &JobSettings{
+								Name:              "Featurizer",
+								MaxConcurrentRuns: 1,
+								Format:            JobFormatMultiTask,
+								Schedule: &CronSchedule{
+									QuartzCronExpression: "0 15 22 ? * *",
+									TimezoneID:           "UTC",
+									PauseStatus:          ScheduleUnpaused,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceJob(),
+		ID:          ".",
+		HCL:         `job_name = "Featurizer"`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, "789", d.Get("job_id"))
+	assert.Equal(t, "ci@example.com", d.Get("creator_user_name"))
+	assert.Equal(t, 1618263108000, d.Get("created_time"))
+	assert.Equal(t, JobFormatMultiTask, d.Get("format"))
+	assert.Equal(t, "0 15 22 ? * *", d.Get("schedule.0.quartz_cron_expression"))
+}
+
+func TestDataSourceJob_Duplicates(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/list?limit=25&name=Featurizer",
+				Response: JobList{
+					Jobs: []Job{
+						{JobID: 123, Settings: &JobSettings{Name: "Featurizer"}},
+						{JobID: 789, Settings: &JobSettings{Name: "Featurizer"}},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceJob(),
+		ID:          ".",
+		HCL:         `job_name = "Featurizer"`,
+	}.Apply(t)
+	assert.EqualError(t, err, "There are 2 jobs named Featurizer: 123, 789")
+}
+
+func TestDataSourceJob_NotFound(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/list?limit=25&name=Featurizer",
+				Response: JobList{},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceJob(),
+		ID:          ".",
+		HCL:         `job_name = "Featurizer"`,
+	}.Apply(t)
+	assert.EqualError(t, err, "Cannot find job Featurizer")
+}
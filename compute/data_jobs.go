@@ -0,0 +1,61 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceJobs returns map of job names to their ids. Jobs with the same name are suffixed with their ids.
+func DataSourceJobs() *schema.Resource {
+	type entity struct {
+		JobNameContains string            `json:"job_name_contains,omitempty"`
+		Ids             map[string]string `json:"ids,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			nameContains := strings.ToLower(this.JobNameContains)
+			byName := map[string][]string{}
+			err = NewJobsAPI(ctx, m).visitJobs(JobListRequest{}, func(job Job) {
+				name := job.Settings.Name
+				if !strings.Contains(strings.ToLower(name), nameContains) {
+					return
+				}
+				byName[name] = append(byName[name], job.ID())
+			})
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.Ids = map[string]string{}
+			for name, ids := range byName {
+				if len(ids) == 1 {
+					this.Ids[name] = ids[0]
+					continue
+				}
+				for _, id := range ids {
+					this.Ids[fmt.Sprintf("%s-%s", name, id)] = id
+				}
+			}
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId("_")
+			return nil
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceJobs(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/list?limit=25",
+				Response: JobList{
+					Jobs: []Job{
+						{JobID: 123, Settings: &JobSettings{Name: "Featurizer"}},
+						{JobID: 234, Settings: &JobSettings{Name: "Report"}},
+					},
+					HasMore:       true,
+					NextPageToken: "next",
+				},
+			},
+			{
+				// job 123 was deleted between pages, but the page token keeps the position,
+				// so job 345 is not skipped
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/list?limit=25&page_token=next",
+				Response: JobList{
+					Jobs: []Job{
+						{JobID: 345, Settings: &JobSettings{Name: "Report"}},
+						{JobID: 456, Settings: &JobSettings{Name: "Backfill"}},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceJobs(),
+		ID:          ".",
+		HCL:         `job_name_contains = "rEp"`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, map[string]interface{}{
+		"Report-234": "234",
+		"Report-345": "345",
+	}, d.Get("ids"))
+}
+
+func TestDataSourceJobs_All(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/list?limit=25",
+				Response: JobList{
+					Jobs: []Job{
+						{JobID: 123, Settings: &JobSettings{Name: "Featurizer"}},
+						{JobID: 234, Settings: &JobSettings{Name: "Report"}},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceJobs(),
+		ID:          ".",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, map[string]interface{}{
+		"Featurizer": "123",
+		"Report":     "234",
+	}, d.Get("ids"))
+}
@@ -23,8 +23,11 @@ func DataSourceNodeType() *schema.Resource {
 			if err != nil {
 				return diag.FromErr(err)
 			}
-			clustersAPI := NewClustersAPI(ctx, m)
-			d.SetId(clustersAPI.GetSmallestNodeType(this))
+			nodeType, err := NewClustersAPI(ctx, m).findSmallestNodeType(this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(nodeType)
 			return nil
 		},
 	}
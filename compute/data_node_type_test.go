@@ -109,3 +109,113 @@ func TestNodeType(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "Random_03", d.Id())
 }
+
+func nodeTypeFixtures(nodeTypes ...NodeType) []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/list-node-types",
+			Response: NodeTypeList{
+				NodeTypes: nodeTypes,
+			},
+		},
+	}
+}
+
+func TestNodeTypePhotonGravitonFleet(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: nodeTypeFixtures(
+			NodeType{
+				NodeTypeID:          "m6gd.large",
+				InstanceTypeID:      "m6gd.large",
+				MemoryMB:            8192,
+				NumCores:            2,
+				PhotonWorkerCapable: true,
+				PhotonDriverCapable: true,
+				IsGraviton:          true,
+			},
+			NodeType{
+				NodeTypeID:          "m6gd-fleet.xlarge",
+				InstanceTypeID:      "m6gd-fleet.xlarge",
+				MemoryMB:            16384,
+				NumCores:            4,
+				PhotonWorkerCapable: true,
+				PhotonDriverCapable: true,
+				IsGraviton:          true,
+			},
+			NodeType{
+				NodeTypeID:          "m5d-fleet.large",
+				InstanceTypeID:      "m5d-fleet.large",
+				MemoryMB:            8192,
+				NumCores:            2,
+				PhotonWorkerCapable: true,
+				PhotonDriverCapable: true,
+			},
+		),
+		Read:        true,
+		Resource:    DataSourceNodeType(),
+		NonWritable: true,
+		State: map[string]interface{}{
+			"photon_worker_capable": true,
+			"photon_driver_capable": true,
+			"graviton":              true,
+			"fleet":                 true,
+		},
+		ID: ".",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "m6gd-fleet.xlarge", d.Id())
+}
+
+func TestNodeTypeSameSizeSortedByName(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: nodeTypeFixtures(
+			NodeType{
+				NodeTypeID:            "b.large",
+				InstanceTypeID:        "b.large",
+				MemoryMB:              8192,
+				NumCores:              2,
+				SupportPortForwarding: true,
+			},
+			NodeType{
+				NodeTypeID:            "a.large",
+				InstanceTypeID:        "a.large",
+				MemoryMB:              8192,
+				NumCores:              2,
+				SupportPortForwarding: true,
+			},
+		),
+		Read:        true,
+		Resource:    DataSourceNodeType(),
+		NonWritable: true,
+		State: map[string]interface{}{
+			"support_port_forwarding": true,
+		},
+		ID: ".",
+	}.Apply(t)
+	assert.NoError(t, err)
+	assert.Equal(t, "a.large", d.Id())
+}
+
+func TestNodeTypeNoMatches(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: nodeTypeFixtures(
+			NodeType{
+				NodeTypeID:     "m5d.large",
+				InstanceTypeID: "m5d.large",
+				MemoryMB:       8192,
+				NumCores:       2,
+			},
+		),
+		Read:        true,
+		Resource:    DataSourceNodeType(),
+		NonWritable: true,
+		State: map[string]interface{}{
+			"min_gpus": 1,
+			"graviton": true,
+		},
+		ID: ".",
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Cannot find node type matching: min_gpus=1, graviton=true")
+}
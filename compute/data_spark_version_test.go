@@ -194,3 +194,86 @@ func TestSparkVersionErrorBadAnswer(t *testing.T) {
 	assert.Error(t, err)
 	require.Equal(t, true, strings.Contains(err.Error(), "Invalid JSON received"))
 }
+
+func capturedVersionsFixtures() []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/clusters/spark-versions",
+			Response: SparkVersionsList{
+				SparkVersions: []SparkVersion{
+					{
+						Version:     "9.1.x-scala2.12",
+						Description: "9.1 LTS (includes Apache Spark 3.1.2, Scala 2.12)",
+					},
+					{
+						Version:     "9.1.x-photon-scala2.12",
+						Description: "9.1 LTS Photon (includes Apache Spark 3.1.2, Scala 2.12)",
+					},
+					{
+						Version:     "9.1.x-cpu-ml-scala2.12",
+						Description: "9.1 LTS ML (includes Apache Spark 3.1.2, Scala 2.12)",
+					},
+					{
+						Version:     "10.4.x-scala2.12",
+						Description: "10.4 LTS (includes Apache Spark 3.2.1, Scala 2.12)",
+					},
+					{
+						Version:     "10.4.x-photon-scala2.12",
+						Description: "10.4 LTS Photon (includes Apache Spark 3.2.1, Scala 2.12)",
+					},
+					{
+						Version:     "10.4.x-cpu-ml-scala2.12",
+						Description: "10.4 LTS ML (includes Apache Spark 3.2.1, Scala 2.12)",
+					},
+					{
+						Version:     "10.5.x-scala2.12",
+						Description: "10.5 (includes Apache Spark 3.2.1, Scala 2.12)",
+					},
+					{
+						Version:     "10.5.x-photon-scala2.12",
+						Description: "10.5 Photon (includes Apache Spark 3.2.1, Scala 2.12)",
+					},
+					{
+						Version:     "11.0.x-scala2.12",
+						Description: "11.0 Beta (includes Apache Spark 3.3.0, Scala 2.12)",
+					},
+					{
+						Version:     "11.0.x-photon-scala2.12",
+						Description: "11.0 Beta Photon (includes Apache Spark 3.3.0, Scala 2.12)",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSparkVersionCaptured(t *testing.T) {
+	for expected, state := range map[string]map[string]interface{}{
+		"10.5.x-scala2.12":        {},
+		"10.4.x-scala2.12":        {"long_term_support": true},
+		"10.5.x-photon-scala2.12": {"photon": true},
+		"10.4.x-photon-scala2.12": {"photon": true, "long_term_support": true},
+		"10.4.x-cpu-ml-scala2.12": {"ml": true, "long_term_support": true},
+		"11.0.x-photon-scala2.12": {"photon": true, "beta": true},
+		"9.1.x-photon-scala2.12":  {"photon": true, "spark_version": "3.1.2"},
+	} {
+		d, err := qa.ResourceFixture{
+			Fixtures:    capturedVersionsFixtures(),
+			Read:        true,
+			Resource:    DataSourceSparkVersion(),
+			NonWritable: true,
+			State:       state,
+			ID:          ".",
+		}.Apply(t)
+		assert.NoError(t, err, expected)
+		assert.Equal(t, expected, d.Id(), state)
+	}
+}
+
+func TestSparkVersionLess(t *testing.T) {
+	assert.True(t, sparkVersionLess("9.1.x-scala2.12", "10.4.x-scala2.12"))
+	assert.True(t, sparkVersionLess("7.3.x-scala2.12", "7.10.x-scala2.12"))
+	assert.False(t, sparkVersionLess("10.4.x-scala2.12", "10.4.x-scala2.12"))
+	assert.True(t, sparkVersionLess("10.4.x-photon-scala2.12", "10.4.x-scala2.12"))
+}
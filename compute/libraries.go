@@ -178,6 +178,30 @@ func (cls ClusterLibraryStatuses) ToLibraryList() ClusterLibraryList {
 	return cll
 }
 
+// only returns statuses of requested libraries or all statuses, if nothing is requested,
+// so that failures of libraries, which are managed elsewhere, don't fail the apply
+func (cls ClusterLibraryStatuses) only(requested ClusterLibraryList) ClusterLibraryStatuses {
+	if len(requested.Libraries) == 0 {
+		return cls
+	}
+	keys := map[string]bool{}
+	for _, lib := range requested.Libraries {
+		libraryType, key := lib.TypeAndKey()
+		keys[libraryType+key] = true
+	}
+	filtered := ClusterLibraryStatuses{ClusterID: cls.ClusterID}
+	for _, status := range cls.LibraryStatuses {
+		if status.Library == nil {
+			continue
+		}
+		libraryType, key := status.Library.TypeAndKey()
+		if keys[libraryType+key] {
+			filtered.LibraryStatuses = append(filtered.LibraryStatuses, status)
+		}
+	}
+	return filtered
+}
+
 // IsRetryNeeded returns first bool if there needs to be retry.
 // If there needs to be retry, error message will explain why.
 // If retry does not need to happen and error is not nil - it failed.
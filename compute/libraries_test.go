@@ -102,6 +102,28 @@ func TestClusterLibraryStatuses_Errors(t *testing.T) {
 	assert.False(t, need)
 }
 
+func TestClusterLibraryStatuses_Only(t *testing.T) {
+	cls := ClusterLibraryStatuses{
+		ClusterID: "abc",
+		LibraryStatuses: []LibraryStatus{
+			{
+				Status:  "INSTALLED",
+				Library: &Library{Whl: "a"},
+			},
+			{
+				Status:  "FAILED",
+				Library: &Library{Jar: "b"},
+			},
+		},
+	}
+	assert.Len(t, cls.only(ClusterLibraryList{}).LibraryStatuses, 2)
+	filtered := cls.only(ClusterLibraryList{
+		Libraries: []Library{{Whl: "a"}},
+	})
+	require.Len(t, filtered.LibraryStatuses, 1)
+	assert.Equal(t, "a", filtered.LibraryStatuses[0].Library.Whl)
+}
+
 func TestAccLibraryCreate(t *testing.T) {
 	cloud := os.Getenv("CLOUD_ENV")
 	if cloud == "" {
@@ -24,6 +24,32 @@ const (
 	AwsAvailabilitySpotWithFallback = "SPOT_WITH_FALLBACK"
 )
 
+// AzureAvailability is a type for describing Azure availability on cluster nodes
+type AzureAvailability string
+
+const (
+	// AzureAvailabilitySpot is spot instance type for clusters
+	AzureAvailabilitySpot = "SPOT_AZURE"
+	// AzureAvailabilityOnDemand is OnDemand instance type for clusters
+	AzureAvailabilityOnDemand = "ON_DEMAND_AZURE"
+	// AzureAvailabilitySpotWithFallback is Spot instance type for clusters with option
+	// to fallback into on-demand if instance cannot be acquired
+	AzureAvailabilitySpotWithFallback = "SPOT_WITH_FALLBACK_AZURE"
+)
+
+// GcpAvailability is a type for describing GCP availability on cluster nodes
+type GcpAvailability string
+
+const (
+	// GcpAvailabilityPreemptible is preemptible instance type for clusters
+	GcpAvailabilityPreemptible = "PREEMPTIBLE_GCP"
+	// GcpAvailabilityOnDemand is OnDemand instance type for clusters
+	GcpAvailabilityOnDemand = "ON_DEMAND_GCP"
+	// GcpAvailabilityPreemptibleWithFallback is preemptible instance type for clusters with option
+	// to fallback into on-demand if instance cannot be acquired
+	GcpAvailabilityPreemptibleWithFallback = "PREEMPTIBLE_WITH_FALLBACK_GCP"
+)
+
 // AzureDiskVolumeType is disk type on azure vms
 type AzureDiskVolumeType string
 
@@ -42,6 +68,8 @@ const (
 	EbsVolumeTypeGeneralPurposeSsd = "GENERAL_PURPOSE_SSD"
 	// EbsVolumeTypeThroughputOptimizedHdd is throughput optimized hdd (starts at 500 gb)
 	EbsVolumeTypeThroughputOptimizedHdd = "THROUGHPUT_OPTIMIZED_HDD"
+	// EbsVolumeTypeGeneralPurposeSsdGp3 is general purpose ssd with configurable iops and throughput
+	EbsVolumeTypeGeneralPurposeSsdGp3 = "GENERAL_PURPOSE_SSD_GP3"
 )
 
 // ClusterState is for describing possible cluster states
@@ -120,6 +148,24 @@ type AwsAttributes struct {
 	EbsVolumeType       EbsVolumeType   `json:"ebs_volume_type,omitempty" tf:"computed"`
 	EbsVolumeCount      int32           `json:"ebs_volume_count,omitempty" tf:"computed"`
 	EbsVolumeSize       int32           `json:"ebs_volume_size,omitempty" tf:"computed"`
+	EbsVolumeIops       int32           `json:"ebs_volume_iops,omitempty" tf:"computed"`
+	EbsVolumeThroughput int32           `json:"ebs_volume_throughput,omitempty" tf:"computed"`
+}
+
+// AzureAttributes encapsulates the Azure attributes for Azure based clusters
+// https://docs.microsoft.com/en-us/azure/databricks/dev-tools/api/latest/clusters#clusterazureattributes
+type AzureAttributes struct {
+	FirstOnDemand   int32             `json:"first_on_demand,omitempty" tf:"computed"`
+	Availability    AzureAvailability `json:"availability,omitempty" tf:"computed"`
+	SpotBidMaxPrice float64           `json:"spot_bid_max_price,omitempty" tf:"computed"`
+}
+
+// GcpAttributes encapsulates the GCP attributes for GCP based clusters
+type GcpAttributes struct {
+	UsePreemptibleExecutors bool            `json:"use_preemptible_executors,omitempty"`
+	GoogleServiceAccount    string          `json:"google_service_account,omitempty"`
+	Availability            GcpAvailability `json:"availability,omitempty" tf:"computed"`
+	BootDiskSize            int32           `json:"boot_disk_size,omitempty" tf:"computed"`
 }
 
 // DbfsStorageInfo contains the destination string for DBFS
@@ -145,6 +191,25 @@ type StorageInfo struct {
 	S3   *S3StorageInfo   `json:"s3,omitempty" tf:"group:storage"`
 }
 
+// WorkspaceStorageInfo contains the destination of a workspace file
+type WorkspaceStorageInfo struct {
+	Destination string `json:"destination"`
+}
+
+// GcsStorageInfo contains the destination string for Google Cloud Storage
+type GcsStorageInfo struct {
+	Destination string `json:"destination"`
+}
+
+// InitScriptStorageInfo contains the location of an init script, which can be either DBFS, S3,
+// workspace file or GCS. Only one of them can be set.
+type InitScriptStorageInfo struct {
+	Dbfs      *DbfsStorageInfo      `json:"dbfs,omitempty" tf:"group:storage"`
+	S3        *S3StorageInfo        `json:"s3,omitempty" tf:"group:storage"`
+	Workspace *WorkspaceStorageInfo `json:"workspace,omitempty" tf:"group:storage"`
+	Gcs       *GcsStorageInfo       `json:"gcs,omitempty" tf:"group:storage"`
+}
+
 // SparkNodeAwsAttributes is the struct that determines if the node is a spot instance or not
 type SparkNodeAwsAttributes struct {
 	IsSpot bool `json:"is_spot,omitempty"`
@@ -208,6 +273,9 @@ type NodeType struct {
 	DisplayOrder          int32                         `json:"display_order,omitempty"`
 	NodeInfo              *ClusterCloudProviderNodeInfo `json:"node_info,omitempty"`
 	NodeInstanceType      *NodeInstanceType             `json:"node_instance_type,omitempty"`
+	PhotonWorkerCapable   bool                          `json:"photon_worker_capable,omitempty"`
+	PhotonDriverCapable   bool                          `json:"photon_driver_capable,omitempty"`
+	IsGraviton            bool                          `json:"is_graviton,omitempty"`
 }
 
 // DockerBasicAuth contains the auth information when fetching containers
@@ -222,6 +290,22 @@ type DockerImage struct {
 	BasicAuth *DockerBasicAuth `json:"basic_auth,omitempty"`
 }
 
+// keepBasicAuth restores registry credentials from configuration, because API never returns the password.
+// Password could also be a reference to secret in {{secrets/scope/key}} format, which is resolved by
+// the platform, so that it is kept as is.
+func (di *DockerImage) keepBasicAuth(configured []DockerImage) {
+	for _, c := range configured {
+		if c.URL != di.URL || c.BasicAuth == nil {
+			continue
+		}
+		if di.BasicAuth == nil {
+			di.BasicAuth = &DockerBasicAuth{Username: c.BasicAuth.Username}
+		}
+		di.BasicAuth.Password = c.BasicAuth.Password
+		return
+	}
+}
+
 // Cluster contains the information when trying to submit api calls or editing a cluster
 type Cluster struct {
 	ClusterID   string `json:"cluster_id,omitempty"`
@@ -233,24 +317,56 @@ type Cluster struct {
 	EnableElasticDisk         bool       `json:"enable_elastic_disk,omitempty" tf:"computed"`
 	EnableLocalDiskEncryption bool       `json:"enable_local_disk_encryption,omitempty"`
 
-	NodeTypeID             string         `json:"node_type_id,omitempty" tf:"group:node_type,computed"`
-	DriverNodeTypeID       string         `json:"driver_node_type_id,omitempty" tf:"conflicts:instance_pool_id,computed"`
-	InstancePoolID         string         `json:"instance_pool_id,omitempty" tf:"group:node_type"`
-	PolicyID               string         `json:"policy_id,omitempty"`
-	AwsAttributes          *AwsAttributes `json:"aws_attributes,omitempty" tf:"conflicts:instance_pool_id"`
-	AutoterminationMinutes int32          `json:"autotermination_minutes,omitempty"`
+	NodeTypeID             string           `json:"node_type_id,omitempty" tf:"group:node_type,computed"`
+	DriverNodeTypeID       string           `json:"driver_node_type_id,omitempty" tf:"conflicts:instance_pool_id,computed"`
+	InstancePoolID         string           `json:"instance_pool_id,omitempty" tf:"group:node_type"`
+	PolicyID               string           `json:"policy_id,omitempty"`
+	AwsAttributes          *AwsAttributes   `json:"aws_attributes,omitempty" tf:"conflicts:instance_pool_id"`
+	AzureAttributes        *AzureAttributes `json:"azure_attributes,omitempty" tf:"conflicts:instance_pool_id"`
+	GcpAttributes          *GcpAttributes   `json:"gcp_attributes,omitempty" tf:"conflicts:instance_pool_id"`
+	AutoterminationMinutes int32            `json:"autotermination_minutes,omitempty"`
 
 	SparkConf    map[string]string `json:"spark_conf,omitempty"`
 	SparkEnvVars map[string]string `json:"spark_env_vars,omitempty"`
 	CustomTags   map[string]string `json:"custom_tags,omitempty"`
 
-	SSHPublicKeys  []string      `json:"ssh_public_keys,omitempty" tf:"max_items:10"`
-	InitScripts    []StorageInfo `json:"init_scripts,omitempty" tf:"max_items:10"` // TODO: tf:alias
-	ClusterLogConf *StorageInfo  `json:"cluster_log_conf,omitempty"`
-	DockerImage    *DockerImage  `json:"docker_image,omitempty"`
+	SSHPublicKeys  []string                `json:"ssh_public_keys,omitempty" tf:"max_items:10"`
+	InitScripts    []InitScriptStorageInfo `json:"init_scripts,omitempty" tf:"max_items:10"` // TODO: tf:alias
+	ClusterLogConf *StorageInfo            `json:"cluster_log_conf,omitempty"`
+	DockerImage    *DockerImage            `json:"docker_image,omitempty"`
 
 	SingleUserName   string `json:"single_user_name,omitempty"`
 	IdempotencyToken string `json:"idempotency_token,omitempty"`
+
+	ApplyPolicyDefaultValues bool `json:"apply_policy_default_values,omitempty"`
+}
+
+// singleNodeConf and singleNodeTags are required by single node clusters
+var (
+	singleNodeConf = map[string]string{
+		"spark.databricks.cluster.profile": "singleNode",
+		"spark.master":                     "local[*]",
+	}
+	singleNodeTags = map[string]string{
+		"ResourceClass": "SingleNode",
+	}
+)
+
+// setSingleNode adds configuration of single node cluster, so that users don't have to
+func (cluster *Cluster) setSingleNode() {
+	cluster.NumWorkers = 0
+	if cluster.SparkConf == nil {
+		cluster.SparkConf = map[string]string{}
+	}
+	for k, v := range singleNodeConf {
+		cluster.SparkConf[k] = v
+	}
+	if cluster.CustomTags == nil {
+		cluster.CustomTags = map[string]string{}
+	}
+	for k, v := range singleNodeTags {
+		cluster.CustomTags[k] = v
+	}
 }
 
 // ClusterList shows existing clusters
@@ -260,44 +376,46 @@ type ClusterList struct {
 
 // ClusterInfo contains the information when getting cluster info from the get request.
 type ClusterInfo struct {
-	NumWorkers                int32              `json:"num_workers,omitempty"`
-	AutoScale                 *AutoScale         `json:"autoscale,omitempty"`
-	ClusterID                 string             `json:"cluster_id,omitempty"`
-	CreatorUserName           string             `json:"creator_user_name,omitempty"`
-	Driver                    *SparkNode         `json:"driver,omitempty"`
-	Executors                 []SparkNode        `json:"executors,omitempty"`
-	SparkContextID            int64              `json:"spark_context_id,omitempty"`
-	JdbcPort                  int32              `json:"jdbc_port,omitempty"`
-	ClusterName               string             `json:"cluster_name,omitempty"`
-	SparkVersion              string             `json:"spark_version"`
-	SparkConf                 map[string]string  `json:"spark_conf,omitempty"`
-	AwsAttributes             *AwsAttributes     `json:"aws_attributes,omitempty"`
-	NodeTypeID                string             `json:"node_type_id,omitempty"`
-	DriverNodeTypeID          string             `json:"driver_node_type_id,omitempty"`
-	SSHPublicKeys             []string           `json:"ssh_public_keys,omitempty"`
-	CustomTags                map[string]string  `json:"custom_tags,omitempty"`
-	ClusterLogConf            *StorageInfo       `json:"cluster_log_conf,omitempty"`
-	InitScripts               []StorageInfo      `json:"init_scripts,omitempty"`
-	SparkEnvVars              map[string]string  `json:"spark_env_vars,omitempty"`
-	AutoterminationMinutes    int32              `json:"autotermination_minutes,omitempty"`
-	EnableElasticDisk         bool               `json:"enable_elastic_disk,omitempty"`
-	EnableLocalDiskEncryption bool               `json:"enable_local_disk_encryption,omitempty"`
-	InstancePoolID            string             `json:"instance_pool_id,omitempty"`
-	PolicyID                  string             `json:"policy_id,omitempty"`
-	SingleUserName            string             `json:"single_user_name,omitempty"`
-	ClusterSource             AwsAvailability    `json:"cluster_source,omitempty"`
-	DockerImage               *DockerImage       `json:"docker_image,omitempty"`
-	State                     ClusterState       `json:"state"`
-	StateMessage              string             `json:"state_message,omitempty"`
-	StartTime                 int64              `json:"start_time,omitempty"`
-	TerminateTime             int64              `json:"terminate_time,omitempty"`
-	LastStateLossTime         int64              `json:"last_state_loss_time,omitempty"`
-	LastActivityTime          int64              `json:"last_activity_time,omitempty"`
-	ClusterMemoryMb           int64              `json:"cluster_memory_mb,omitempty"`
-	ClusterCores              float32            `json:"cluster_cores,omitempty"`
-	DefaultTags               map[string]string  `json:"default_tags"`
-	ClusterLogStatus          *LogSyncStatus     `json:"cluster_log_status,omitempty"`
-	TerminationReason         *TerminationReason `json:"termination_reason,omitempty"`
+	NumWorkers                int32                   `json:"num_workers,omitempty"`
+	AutoScale                 *AutoScale              `json:"autoscale,omitempty"`
+	ClusterID                 string                  `json:"cluster_id,omitempty"`
+	CreatorUserName           string                  `json:"creator_user_name,omitempty"`
+	Driver                    *SparkNode              `json:"driver,omitempty"`
+	Executors                 []SparkNode             `json:"executors,omitempty"`
+	SparkContextID            int64                   `json:"spark_context_id,omitempty"`
+	JdbcPort                  int32                   `json:"jdbc_port,omitempty"`
+	ClusterName               string                  `json:"cluster_name,omitempty"`
+	SparkVersion              string                  `json:"spark_version"`
+	SparkConf                 map[string]string       `json:"spark_conf,omitempty"`
+	AwsAttributes             *AwsAttributes          `json:"aws_attributes,omitempty"`
+	AzureAttributes           *AzureAttributes        `json:"azure_attributes,omitempty"`
+	GcpAttributes             *GcpAttributes          `json:"gcp_attributes,omitempty"`
+	NodeTypeID                string                  `json:"node_type_id,omitempty"`
+	DriverNodeTypeID          string                  `json:"driver_node_type_id,omitempty"`
+	SSHPublicKeys             []string                `json:"ssh_public_keys,omitempty"`
+	CustomTags                map[string]string       `json:"custom_tags,omitempty"`
+	ClusterLogConf            *StorageInfo            `json:"cluster_log_conf,omitempty"`
+	InitScripts               []InitScriptStorageInfo `json:"init_scripts,omitempty"`
+	SparkEnvVars              map[string]string       `json:"spark_env_vars,omitempty"`
+	AutoterminationMinutes    int32                   `json:"autotermination_minutes,omitempty"`
+	EnableElasticDisk         bool                    `json:"enable_elastic_disk,omitempty"`
+	EnableLocalDiskEncryption bool                    `json:"enable_local_disk_encryption,omitempty"`
+	InstancePoolID            string                  `json:"instance_pool_id,omitempty"`
+	PolicyID                  string                  `json:"policy_id,omitempty"`
+	SingleUserName            string                  `json:"single_user_name,omitempty"`
+	ClusterSource             AwsAvailability         `json:"cluster_source,omitempty"`
+	DockerImage               *DockerImage            `json:"docker_image,omitempty"`
+	State                     ClusterState            `json:"state"`
+	StateMessage              string                  `json:"state_message,omitempty"`
+	StartTime                 int64                   `json:"start_time,omitempty"`
+	TerminateTime             int64                   `json:"terminate_time,omitempty"`
+	LastStateLossTime         int64                   `json:"last_state_loss_time,omitempty"`
+	LastActivityTime          int64                   `json:"last_activity_time,omitempty"`
+	ClusterMemoryMb           int64                   `json:"cluster_memory_mb,omitempty"`
+	ClusterCores              float32                 `json:"cluster_cores,omitempty"`
+	DefaultTags               map[string]string       `json:"default_tags"`
+	ClusterLogStatus          *LogSyncStatus          `json:"cluster_log_status"`
+	TerminationReason         *TerminationReason      `json:"termination_reason,omitempty"`
 }
 
 // IsRunningOrResizing returns true if cluster is running or resizing
@@ -305,11 +423,33 @@ func (ci *ClusterInfo) IsRunningOrResizing() bool {
 	return ci.State == ClusterStateRunning || ci.State == ClusterStateResizing
 }
 
+// removeSingleNode removes configuration added by setSingleNode, unless it was configured explicitly,
+// so that it doesn't show up as drift
+func (ci *ClusterInfo) removeSingleNode(configured Cluster) {
+	for k, v := range singleNodeConf {
+		if _, ok := configured.SparkConf[k]; !ok && ci.SparkConf[k] == v {
+			delete(ci.SparkConf, k)
+		}
+	}
+	for k, v := range singleNodeTags {
+		if _, ok := configured.CustomTags[k]; !ok && ci.CustomTags[k] == v {
+			delete(ci.CustomTags, k)
+		}
+	}
+}
+
 // ClusterID holds cluster ID
 type ClusterID struct {
 	ClusterID string `json:"cluster_id,omitempty" url:"cluster_id,omitempty"`
 }
 
+// ResizeRequest changes number of workers of running cluster
+type ResizeRequest struct {
+	ClusterID  string     `json:"cluster_id"`
+	NumWorkers int32      `json:"num_workers,omitempty"`
+	Autoscale  *AutoScale `json:"autoscale,omitempty"`
+}
+
 // ClusterPolicy defines cluster policy
 type ClusterPolicy struct {
 	PolicyID           string `json:"policy_id,omitempty"`
@@ -349,6 +489,17 @@ type InstancePoolAwsAttributes struct {
 	SpotBidPricePercent int32           `json:"spot_bid_price_percent,omitempty"`
 }
 
+// InstancePoolAzureAttributes contains Azure attributes for Azure Databricks deployments for instance pools
+type InstancePoolAzureAttributes struct {
+	Availability    AzureAvailability `json:"availability,omitempty" tf:"computed"`
+	SpotBidMaxPrice float64           `json:"spot_bid_max_price,omitempty" tf:"computed"`
+}
+
+// InstancePoolGcpAttributes contains GCP attributes for GCP Databricks deployments for instance pools
+type InstancePoolGcpAttributes struct {
+	Availability GcpAvailability `json:"gcp_availability,omitempty" tf:"computed"`
+}
+
 // InstancePoolDiskType contains disk type information for each of the different cloud service providers
 type InstancePoolDiskType struct {
 	AzureDiskVolumeType string `json:"azure_disk_volume_type,omitempty"`
@@ -357,24 +508,29 @@ type InstancePoolDiskType struct {
 
 // InstancePoolDiskSpec contains disk size, type and count information for the pool
 type InstancePoolDiskSpec struct {
-	DiskType  *InstancePoolDiskType `json:"disk_type,omitempty"`
-	DiskCount int32                 `json:"disk_count,omitempty"`
-	DiskSize  int32                 `json:"disk_size,omitempty"`
+	DiskType       *InstancePoolDiskType `json:"disk_type,omitempty"`
+	DiskCount      int32                 `json:"disk_count,omitempty"`
+	DiskSize       int32                 `json:"disk_size,omitempty"`
+	DiskIops       int32                 `json:"disk_iops,omitempty" tf:"computed"`
+	DiskThroughput int32                 `json:"disk_throughput,omitempty" tf:"computed"`
 }
 
 // InstancePool describes the instance pool object on Databricks
 type InstancePool struct {
-	InstancePoolID                     string                     `json:"instance_pool_id,omitempty" tf:"computed"`
-	InstancePoolName                   string                     `json:"instance_pool_name"`
-	MinIdleInstances                   int32                      `json:"min_idle_instances,omitempty"`
-	MaxCapacity                        int32                      `json:"max_capacity,omitempty"`
-	IdleInstanceAutoTerminationMinutes int32                      `json:"idle_instance_autotermination_minutes"`
-	AwsAttributes                      *InstancePoolAwsAttributes `json:"aws_attributes,omitempty"`
-	NodeTypeID                         string                     `json:"node_type_id"`
-	CustomTags                         map[string]string          `json:"custom_tags,omitempty"`
-	EnableElasticDisk                  bool                       `json:"enable_elastic_disk,omitempty"`
-	DiskSpec                           *InstancePoolDiskSpec      `json:"disk_spec,omitempty"`
-	PreloadedSparkVersions             []string                   `json:"preloaded_spark_versions,omitempty"`
+	InstancePoolID                     string                       `json:"instance_pool_id,omitempty" tf:"computed"`
+	InstancePoolName                   string                       `json:"instance_pool_name"`
+	MinIdleInstances                   int32                        `json:"min_idle_instances,omitempty"`
+	MaxCapacity                        int32                        `json:"max_capacity,omitempty"`
+	IdleInstanceAutoTerminationMinutes int32                        `json:"idle_instance_autotermination_minutes"`
+	AwsAttributes                      *InstancePoolAwsAttributes   `json:"aws_attributes,omitempty"`
+	AzureAttributes                    *InstancePoolAzureAttributes `json:"azure_attributes,omitempty"`
+	GcpAttributes                      *InstancePoolGcpAttributes   `json:"gcp_attributes,omitempty"`
+	NodeTypeID                         string                       `json:"node_type_id"`
+	CustomTags                         map[string]string            `json:"custom_tags,omitempty"`
+	EnableElasticDisk                  bool                         `json:"enable_elastic_disk,omitempty"`
+	DiskSpec                           *InstancePoolDiskSpec        `json:"disk_spec,omitempty"`
+	PreloadedSparkVersions             []string                     `json:"preloaded_spark_versions,omitempty" tf:"slice_set"`
+	PreloadedDockerImages              []DockerImage                `json:"preloaded_docker_images,omitempty" tf:"slice_set"`
 }
 
 // InstancePoolStats contains the stats on a given pool
@@ -387,20 +543,23 @@ type InstancePoolStats struct {
 
 // InstancePoolAndStats encapsulates a get response from the GET api for instance pools on Databricks
 type InstancePoolAndStats struct {
-	InstancePoolID                     string                     `json:"instance_pool_id,omitempty" tf:"computed"`
-	InstancePoolName                   string                     `json:"instance_pool_name"`
-	MinIdleInstances                   int32                      `json:"min_idle_instances,omitempty"`
-	MaxCapacity                        int32                      `json:"max_capacity,omitempty"`
-	AwsAttributes                      *InstancePoolAwsAttributes `json:"aws_attributes,omitempty"`
-	NodeTypeID                         string                     `json:"node_type_id"`
-	DefaultTags                        map[string]string          `json:"default_tags,omitempty" tf:"computed"`
-	CustomTags                         map[string]string          `json:"custom_tags,omitempty"`
-	IdleInstanceAutoTerminationMinutes int32                      `json:"idle_instance_autotermination_minutes"`
-	EnableElasticDisk                  bool                       `json:"enable_elastic_disk,omitempty"`
-	DiskSpec                           *InstancePoolDiskSpec      `json:"disk_spec,omitempty"`
-	PreloadedSparkVersions             []string                   `json:"preloaded_spark_versions,omitempty"`
-	State                              string                     `json:"state,omitempty"`
-	Stats                              *InstancePoolStats         `json:"stats,omitempty"`
+	InstancePoolID                     string                       `json:"instance_pool_id,omitempty" tf:"computed"`
+	InstancePoolName                   string                       `json:"instance_pool_name"`
+	MinIdleInstances                   int32                        `json:"min_idle_instances,omitempty"`
+	MaxCapacity                        int32                        `json:"max_capacity,omitempty"`
+	AwsAttributes                      *InstancePoolAwsAttributes   `json:"aws_attributes,omitempty"`
+	AzureAttributes                    *InstancePoolAzureAttributes `json:"azure_attributes,omitempty"`
+	GcpAttributes                      *InstancePoolGcpAttributes   `json:"gcp_attributes,omitempty"`
+	NodeTypeID                         string                       `json:"node_type_id"`
+	DefaultTags                        map[string]string            `json:"default_tags,omitempty" tf:"computed"`
+	CustomTags                         map[string]string            `json:"custom_tags,omitempty"`
+	IdleInstanceAutoTerminationMinutes int32                        `json:"idle_instance_autotermination_minutes"`
+	EnableElasticDisk                  bool                         `json:"enable_elastic_disk,omitempty"`
+	DiskSpec                           *InstancePoolDiskSpec        `json:"disk_spec,omitempty"`
+	PreloadedSparkVersions             []string                     `json:"preloaded_spark_versions,omitempty" tf:"slice_set"`
+	PreloadedDockerImages              []DockerImage                `json:"preloaded_docker_images,omitempty" tf:"slice_set"`
+	State                              string                       `json:"state,omitempty"`
+	Stats                              *InstancePoolStats           `json:"stats,omitempty"`
 }
 
 // InstancePoolList shows list of instance pools
@@ -441,7 +600,10 @@ func (l *NodeTypeList) Sort() {
 		if l.NodeTypes[i].NumGPUs != l.NodeTypes[j].NumGPUs {
 			return l.NodeTypes[i].NumGPUs < l.NodeTypes[j].NumGPUs
 		}
-		return l.NodeTypes[i].InstanceTypeID < l.NodeTypes[j].InstanceTypeID
+		if l.NodeTypes[i].InstanceTypeID != l.NodeTypes[j].InstanceTypeID {
+			return l.NodeTypes[i].InstanceTypeID < l.NodeTypes[j].InstanceTypeID
+		}
+		return l.NodeTypes[i].NodeTypeID < l.NodeTypes[j].NodeTypeID
 	})
 }
 
@@ -449,6 +611,23 @@ func (l *NodeTypeList) Sort() {
 type NotebookTask struct {
 	NotebookPath   string            `json:"notebook_path"`
 	BaseParameters map[string]string `json:"base_parameters,omitempty"`
+	Source         string            `json:"source,omitempty"`
+}
+
+// Sources of notebook task
+const (
+	NotebookSourceWorkspace = "WORKSPACE"
+	NotebookSourceGit       = "GIT"
+)
+
+// GitSource is a remote Git repository, from which notebooks of the job are taken. Notebook paths are
+// relative to the root of the repository.
+type GitSource struct {
+	URL      string `json:"git_url"`
+	Provider string `json:"git_provider"`
+	Branch   string `json:"git_branch,omitempty" tf:"alias:branch"`
+	Tag      string `json:"git_tag,omitempty" tf:"alias:tag"`
+	Commit   string `json:"git_commit,omitempty" tf:"alias:commit"`
 }
 
 // SparkPythonTask contains the information for python jobs
@@ -469,6 +648,61 @@ type SparkSubmitTask struct {
 	Parameters []string `json:"parameters,omitempty"`
 }
 
+// PythonWheelTask contains the information for python wheel jobs
+type PythonWheelTask struct {
+	EntryPoint      string            `json:"entry_point,omitempty"`
+	PackageName     string            `json:"package_name,omitempty"`
+	Parameters      []string          `json:"parameters,omitempty"`
+	NamedParameters map[string]string `json:"named_parameters,omitempty"`
+}
+
+// TaskDependency points to the task, that has to finish before the dependent task starts
+type TaskDependency struct {
+	TaskKey string `json:"task_key"`
+}
+
+// DbtTask runs dbt commands from the project in Git or workspace, optionally against SQL endpoint
+type DbtTask struct {
+	Commands          []string `json:"commands"`
+	ProjectDirectory  string   `json:"project_directory,omitempty"`
+	Schema            string   `json:"schema,omitempty" tf:"default:default"`
+	WarehouseID       string   `json:"warehouse_id,omitempty"`
+	ProfilesDirectory string   `json:"profiles_directory,omitempty"`
+}
+
+// JobTaskSettings contains the information for a single task of multi-task job
+type JobTaskSettings struct {
+	TaskKey     string           `json:"task_key"`
+	Description string           `json:"description,omitempty"`
+	DependsOn   []TaskDependency `json:"depends_on,omitempty"`
+
+	ExistingClusterID string   `json:"existing_cluster_id,omitempty" tf:"group:cluster_type"`
+	NewCluster        *Cluster `json:"new_cluster,omitempty" tf:"group:cluster_type"`
+	JobClusterKey     string   `json:"job_cluster_key,omitempty" tf:"group:cluster_type"`
+
+	Libraries []Library `json:"libraries,omitempty" tf:"slice_set,alias:library"`
+
+	NotebookTask    *NotebookTask    `json:"notebook_task,omitempty" tf:"group:task_type"`
+	SparkJarTask    *SparkJarTask    `json:"spark_jar_task,omitempty" tf:"group:task_type"`
+	SparkPythonTask *SparkPythonTask `json:"spark_python_task,omitempty" tf:"group:task_type"`
+	SparkSubmitTask *SparkSubmitTask `json:"spark_submit_task,omitempty" tf:"group:task_type"`
+	PythonWheelTask *PythonWheelTask `json:"python_wheel_task,omitempty" tf:"group:task_type"`
+	DbtTask         *DbtTask         `json:"dbt_task,omitempty" tf:"group:task_type"`
+
+	EmailNotifications     *JobEmailNotifications `json:"email_notifications,omitempty"`
+	WebhookNotifications   *WebhookNotifications  `json:"webhook_notifications,omitempty"`
+	TimeoutSeconds         int32                  `json:"timeout_seconds,omitempty"`
+	MaxRetries             int32                  `json:"max_retries,omitempty"`
+	MinRetryIntervalMillis int32                  `json:"min_retry_interval_millis,omitempty"`
+	RetryOnTimeout         bool                   `json:"retry_on_timeout,omitempty"`
+}
+
+// JobCluster is a cluster definition, that can be shared by tasks of multi-task job
+type JobCluster struct {
+	JobClusterKey string   `json:"job_cluster_key"`
+	NewCluster    *Cluster `json:"new_cluster"`
+}
+
 // JobEmailNotifications contains the information for email notifications after job completion
 type JobEmailNotifications struct {
 	OnStart               []string `json:"on_start,omitempty"`
@@ -477,6 +711,18 @@ type JobEmailNotifications struct {
 	NoAlertForSkippedRuns bool     `json:"no_alert_for_skipped_runs,omitempty"`
 }
 
+// Webhook references notification destination, that is called by job
+type Webhook struct {
+	ID string `json:"id"`
+}
+
+// WebhookNotifications contains notification destinations, that are called on job events
+type WebhookNotifications struct {
+	OnStart   []Webhook `json:"on_start,omitempty"`
+	OnSuccess []Webhook `json:"on_success,omitempty"`
+	OnFailure []Webhook `json:"on_failure,omitempty"`
+}
+
 // CronSchedule contains the information for the quartz cron expression
 type CronSchedule struct {
 	QuartzCronExpression string `json:"quartz_cron_expression"`
@@ -484,6 +730,33 @@ type CronSchedule struct {
 	PauseStatus          string `json:"pause_status,omitempty" tf:"computed"`
 }
 
+// Pause statuses of job schedule
+const (
+	SchedulePaused   = "PAUSED"
+	ScheduleUnpaused = "UNPAUSED"
+)
+
+// JobFormatMultiTask is the format of jobs with tasks, that are only available in version 2.1 of Jobs API
+const JobFormatMultiTask = "MULTI_TASK"
+
+// JobQueue tells if runs, that exceed max concurrent runs of the job, are queued instead of being skipped
+type JobQueue struct {
+	Enabled bool `json:"enabled"`
+}
+
+// JobRunAs is the identity, which runs of the job are executed with. Jobs run as their creator by default.
+type JobRunAs struct {
+	UserName             string `json:"user_name,omitempty"`
+	ServicePrincipalName string `json:"service_principal_name,omitempty"`
+}
+
+func (ra JobRunAs) String() string {
+	if ra.ServicePrincipalName != "" {
+		return "service principal " + ra.ServicePrincipalName
+	}
+	return "user " + ra.UserName
+}
+
 // JobSettings contains the information for configuring a job on databricks
 type JobSettings struct {
 	Name string `json:"name,omitempty" tf:"default:Untitled"`
@@ -495,6 +768,7 @@ type JobSettings struct {
 	SparkJarTask    *SparkJarTask    `json:"spark_jar_task,omitempty" tf:"group:task_type"`
 	SparkPythonTask *SparkPythonTask `json:"spark_python_task,omitempty" tf:"group:task_type"`
 	SparkSubmitTask *SparkSubmitTask `json:"spark_submit_task,omitempty" tf:"group:task_type"`
+	PythonWheelTask *PythonWheelTask `json:"python_wheel_task,omitempty" tf:"group:task_type"`
 
 	Libraries              []Library     `json:"libraries,omitempty" tf:"slice_set,alias:library"`
 	TimeoutSeconds         int32         `json:"timeout_seconds,omitempty"`
@@ -504,13 +778,28 @@ type JobSettings struct {
 	Schedule               *CronSchedule `json:"schedule,omitempty"`
 	MaxConcurrentRuns      int32         `json:"max_concurrent_runs,omitempty"`
 
-	EmailNotifications *JobEmailNotifications `json:"email_notifications,omitempty"`
+	EmailNotifications   *JobEmailNotifications `json:"email_notifications,omitempty"`
+	WebhookNotifications *WebhookNotifications  `json:"webhook_notifications,omitempty"`
+
+	GitSource *GitSource `json:"git_source,omitempty"`
+	RunAs     *JobRunAs  `json:"run_as,omitempty"`
+	Queue     *JobQueue  `json:"queue,omitempty"`
+
+	Tasks       []JobTaskSettings `json:"tasks,omitempty" tf:"alias:task"`
+	JobClusters []JobCluster      `json:"job_clusters,omitempty" tf:"alias:job_cluster"`
+	Format      string            `json:"format,omitempty" tf:"computed"`
+}
+
+// isMultiTask tells if job has to be managed with version 2.1 of Jobs API
+func (js *JobSettings) isMultiTask() bool {
+	return js.Format == JobFormatMultiTask || len(js.Tasks) > 0
 }
 
 // Job contains the information when using a GET request from the Databricks Jobs api
 type Job struct {
 	JobID           int64        `json:"job_id,omitempty"`
 	CreatorUserName string       `json:"creator_user_name,omitempty"`
+	RunAsUserName   string       `json:"run_as_user_name,omitempty"`
 	Settings        *JobSettings `json:"settings,omitempty"`
 	CreatedTime     int64        `json:"created_time,omitempty"`
 }
@@ -526,6 +815,67 @@ type UpdateJobRequest struct {
 	NewSettings *JobSettings `json:"new_settings,omitempty" url:"new_settings,omitempty"`
 }
 
+// JobListRequest is a page of jobs, optionally filtered by exact name, requested from version 2.1 of Jobs API
+type JobListRequest struct {
+	Name      string `url:"name,omitempty"`
+	Limit     int    `url:"limit,omitempty"`
+	PageToken string `url:"page_token,omitempty"`
+}
+
+// JobList is a page of jobs
+type JobList struct {
+	Jobs          []Job  `json:"jobs,omitempty"`
+	HasMore       bool   `json:"has_more,omitempty"`
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
+
+// Life cycle states of job run
+const (
+	RunLifeCycleStatePending       = "PENDING"
+	RunLifeCycleStateRunning       = "RUNNING"
+	RunLifeCycleStateTerminating   = "TERMINATING"
+	RunLifeCycleStateTerminated    = "TERMINATED"
+	RunLifeCycleStateSkipped       = "SKIPPED"
+	RunLifeCycleStateInternalError = "INTERNAL_ERROR"
+)
+
+// RunState is the state of job run
+type RunState struct {
+	LifeCycleState string `json:"life_cycle_state,omitempty"`
+	ResultState    string `json:"result_state,omitempty"`
+	StateMessage   string `json:"state_message,omitempty"`
+}
+
+// isTerminal tells if run has already finished
+func (rs RunState) isTerminal() bool {
+	switch rs.LifeCycleState {
+	case RunLifeCycleStateTerminated, RunLifeCycleStateSkipped, RunLifeCycleStateInternalError:
+		return true
+	}
+	return false
+}
+
+// JobRun is a single run of the job
+type JobRun struct {
+	JobID int64    `json:"job_id,omitempty"`
+	RunID int64    `json:"run_id,omitempty"`
+	State RunState `json:"state,omitempty"`
+}
+
+// JobRunsListRequest requests runs of the job
+type JobRunsListRequest struct {
+	JobID      int64 `url:"job_id,omitempty"`
+	ActiveOnly bool  `url:"active_only,omitempty"`
+	Offset     int   `url:"offset,omitempty"`
+	Limit      int   `url:"limit,omitempty"`
+}
+
+// JobRunsList is a page of job runs
+type JobRunsList struct {
+	Runs    []JobRun `json:"runs,omitempty"`
+	HasMore bool     `json:"has_more,omitempty"`
+}
+
 // PyPi is a python library hosted on PYPI
 type PyPi struct {
 	Package string `json:"package"`
@@ -659,6 +1009,7 @@ type SparkVersionRequest struct {
 	ML              bool   `json:"ml,omitempty" tf:"optional,default:false"`
 	Genomics        bool   `json:"genomics,omitempty" tf:"optional,default:false"`
 	GPU             bool   `json:"gpu,omitempty" tf:"optional,default:false"`
+	Photon          bool   `json:"photon,omitempty" tf:"optional,default:false"`
 	Scala           string `json:"scala,omitempty" tf:"optional,default:2.12"`
 	SparkVersion    string `json:"spark_version,omitempty" tf:"optional,default:"`
 }
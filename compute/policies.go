@@ -0,0 +1,266 @@
+package compute
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// List of policy element types from cluster policy definition language
+const (
+	PolicyElementFixed     = "fixed"
+	PolicyElementForbidden = "forbidden"
+	PolicyElementAllowlist = "allowlist"
+	PolicyElementBlocklist = "blocklist"
+	PolicyElementRegex     = "regex"
+	PolicyElementRange     = "range"
+	PolicyElementUnlimited = "unlimited"
+)
+
+// PolicyElement limits single cluster attribute
+type PolicyElement struct {
+	Type         string        `json:"type"`
+	Value        interface{}   `json:"value,omitempty"`
+	Values       []interface{} `json:"values,omitempty"`
+	Pattern      string        `json:"pattern,omitempty"`
+	MinValue     *float64      `json:"minValue,omitempty"`
+	MaxValue     *float64      `json:"maxValue,omitempty"`
+	DefaultValue interface{}   `json:"defaultValue,omitempty"`
+	IsOptional   bool          `json:"isOptional,omitempty"`
+	Hidden       bool          `json:"hidden,omitempty"`
+}
+
+// PolicyDefinition maps cluster attribute paths, like `spark_conf.spark.databricks.cluster.profile`
+// or `aws_attributes.availability`, to their limiting elements
+type PolicyDefinition map[string]PolicyElement
+
+// parsePolicyDefinition parses definition JSON of the cluster policy
+func parsePolicyDefinition(definition string) (pd PolicyDefinition, err error) {
+	err = json.Unmarshal([]byte(definition), &pd)
+	return
+}
+
+// policyTarget is satisfied by schema.ResourceDiff, so that cluster could be checked at plan time
+type policyTarget interface {
+	GetOk(key string) (interface{}, bool)
+	GetOkExists(key string) (interface{}, bool)
+	NewValueKnown(key string) bool
+}
+
+// policyAttributeKey converts policy path to the resource key and, for maps like spark_conf
+// or custom_tags, the key within map. Paths, which are not part of the schema, are not supported.
+func policyAttributeKey(s map[string]*schema.Schema, path string) (key, mapKey string, ok bool) {
+	parts := strings.SplitN(path, ".", 2)
+	field, found := s[parts[0]]
+	if !found {
+		return
+	}
+	if len(parts) == 1 {
+		return parts[0], "", true
+	}
+	switch field.Type {
+	case schema.TypeMap:
+		return parts[0], parts[1], true
+	case schema.TypeList:
+		nested, isResource := field.Elem.(*schema.Resource)
+		if !isResource {
+			return
+		}
+		index, rest := "0", parts[1]
+		if field.MaxItems != 1 {
+			// blocks like init_scripts are addressed by index, e.g. `init_scripts.0.workspace.destination`
+			indexed := strings.SplitN(parts[1], ".", 2)
+			if _, err := strconv.Atoi(indexed[0]); err != nil || len(indexed) == 1 {
+				return
+			}
+			index, rest = indexed[0], indexed[1]
+		}
+		key, mapKey, ok = policyAttributeKey(nested.Schema, rest)
+		return parts[0] + "." + index + "." + key, mapKey, ok
+	}
+	return
+}
+
+// policyAttribute returns configured value of the attribute. ok is false for the attributes,
+// that are either unknown during plan or not supported by client-side validation.
+func policyAttribute(d policyTarget, path string) (value interface{}, isSet, ok bool) {
+	key, mapKey, ok := policyAttributeKey(clusterSchema, path)
+	if !ok || !d.NewValueKnown(key) {
+		return nil, false, false
+	}
+	// nolint GetOkExists is deprecated, but configured zero values, like num_workers = 0, have to be checked
+	v, isSet := d.GetOkExists(key)
+	if mapKey == "" || !isSet {
+		return v, isSet, true
+	}
+	value, isSet = v.(map[string]interface{})[mapKey]
+	return value, isSet, true
+}
+
+func policyValueString(v interface{}) string {
+	switch x := v.(type) {
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+func policyValuesContain(values []interface{}, v interface{}) bool {
+	for _, allowed := range values {
+		if policyValueString(allowed) == policyValueString(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func policyValuesString(values []interface{}) string {
+	s := []string{}
+	for _, v := range values {
+		s = append(s, policyValueString(v))
+	}
+	return strings.Join(s, ", ")
+}
+
+// check returns description of violation or an empty string, if the value satisfies the element
+func (e PolicyElement) check(path string, value interface{}, isSet, applyDefaults bool) string {
+	if !isSet {
+		switch {
+		case e.Type == PolicyElementFixed || e.Type == PolicyElementForbidden:
+			return ""
+		case e.IsOptional:
+			return ""
+		case applyDefaults && e.DefaultValue != nil:
+			return ""
+		}
+		return fmt.Sprintf("%s is required", path)
+	}
+	actual := policyValueString(value)
+	switch e.Type {
+	case PolicyElementFixed:
+		if actual != policyValueString(e.Value) {
+			return fmt.Sprintf("%s must be %s, but is %s", path, policyValueString(e.Value), actual)
+		}
+	case PolicyElementForbidden:
+		return fmt.Sprintf("%s is forbidden", path)
+	case PolicyElementAllowlist:
+		if !policyValuesContain(e.Values, value) {
+			return fmt.Sprintf("%s must be one of %s, but is %s", path, policyValuesString(e.Values), actual)
+		}
+	case PolicyElementBlocklist:
+		if policyValuesContain(e.Values, value) {
+			return fmt.Sprintf("%s must not be one of %s, but is %s", path, policyValuesString(e.Values), actual)
+		}
+	case PolicyElementRegex:
+		re, err := regexp.Compile(e.Pattern)
+		if err == nil && !re.MatchString(actual) {
+			return fmt.Sprintf("%s must match %s, but is %s", path, e.Pattern, actual)
+		}
+	case PolicyElementRange:
+		number, err := strconv.ParseFloat(actual, 64)
+		if err != nil {
+			return fmt.Sprintf("%s must be a number, but is %s", path, actual)
+		}
+		if e.MinValue != nil && number < *e.MinValue {
+			return fmt.Sprintf("%s must be at least %s, but is %s", path, policyValueString(*e.MinValue), actual)
+		}
+		if e.MaxValue != nil && number > *e.MaxValue {
+			return fmt.Sprintf("%s must be at most %s, but is %s", path, policyValueString(*e.MaxValue), actual)
+		}
+	}
+	return ""
+}
+
+// violations returns sorted list of policy elements, that planned cluster does not satisfy
+func (pd PolicyDefinition) violations(d policyTarget, applyDefaults bool) (violations []string) {
+	for path, element := range pd {
+		value, isSet, ok := policyAttribute(d, path)
+		if !ok {
+			continue
+		}
+		if violation := element.check(path, value, isSet, applyDefaults); violation != "" {
+			violations = append(violations, violation)
+		}
+	}
+	sort.Strings(violations)
+	return
+}
+
+// checkClusterPolicy validates planned cluster against its policy, so that violations are reported
+// during plan instead of failing the apply
+func checkClusterPolicy(policies ClusterPoliciesAPI, d policyTarget) error {
+	if !d.NewValueKnown("policy_id") {
+		return nil
+	}
+	policyID, ok := d.GetOk("policy_id")
+	if !ok {
+		return nil
+	}
+	policy, err := policies.Get(policyID.(string))
+	if err != nil {
+		return err
+	}
+	pd, err := parsePolicyDefinition(policy.Definition)
+	if err != nil {
+		return fmt.Errorf("Cannot parse definition of cluster policy %s: %w", policy.Name, err)
+	}
+	_, applyDefaults := d.GetOk("apply_policy_default_values")
+	violations := pd.violations(d, applyDefaults)
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("Cluster does not comply with cluster policy %s: %s",
+		policy.Name, strings.Join(violations, "; "))
+}
+
+// removeInjectedValues removes spark_conf, spark_env_vars and custom_tags entries, that were added to the
+// cluster by its policy and are not configured, so that they don't show up as a drift. Attributes, that
+// are not configured at all, are already skipped while reading the cluster.
+func (pd PolicyDefinition) removeInjectedValues(ci *ClusterInfo, configured Cluster) {
+	for path := range pd {
+		key, mapKey, ok := policyAttributeKey(clusterSchema, path)
+		if !ok || mapKey == "" {
+			continue
+		}
+		var actual, expected map[string]string
+		switch key {
+		case "spark_conf":
+			actual, expected = ci.SparkConf, configured.SparkConf
+		case "spark_env_vars":
+			actual, expected = ci.SparkEnvVars, configured.SparkEnvVars
+		case "custom_tags":
+			actual, expected = ci.CustomTags, configured.CustomTags
+		default:
+			continue
+		}
+		if _, isConfigured := expected[mapKey]; !isConfigured {
+			delete(actual, mapKey)
+		}
+	}
+}
+
+// removePolicyInjectedValues fetches policy of the cluster and removes values injected by it.
+// Policy may be deleted or not readable by the current user, which should not fail the refresh.
+func removePolicyInjectedValues(policies ClusterPoliciesAPI, ci *ClusterInfo, configured Cluster) {
+	if ci.PolicyID == "" {
+		return
+	}
+	policy, err := policies.Get(ci.PolicyID)
+	if err != nil {
+		log.Printf("[WARN] Cannot get cluster policy %s: %s", ci.PolicyID, err)
+		return
+	}
+	pd, err := parsePolicyDefinition(policy.Definition)
+	if err != nil {
+		log.Printf("[WARN] Cannot parse definition of cluster policy %s: %s", policy.Name, err)
+		return
+	}
+	pd.removeInjectedValues(ci, configured)
+}
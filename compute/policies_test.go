@@ -0,0 +1,365 @@
+package compute
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func policyConstraintsFixture(t *testing.T) qa.HTTPFixture {
+	definition, err := ioutil.ReadFile("testdata/policy-constraints.json")
+	require.NoError(t, err)
+	return qa.HTTPFixture{
+		Method:       "GET",
+		ReuseRequest: true,
+		Resource:     "/api/2.0/policies/clusters/get?policy_id=def",
+		Response: ClusterPolicy{
+			PolicyID:   "def",
+			Name:       "Team clusters",
+			Definition: string(definition),
+		},
+	}
+}
+
+func TestPolicyAttributeKey(t *testing.T) {
+	for path, expected := range map[string][]string{
+		"node_type_id":                         {"node_type_id", ""},
+		"autoscale.max_workers":                {"autoscale.0.max_workers", ""},
+		"aws_attributes.availability":          {"aws_attributes.0.availability", ""},
+		"spark_conf.spark.databricks.repl":     {"spark_conf", "spark.databricks.repl"},
+		"docker_image.basic_auth.username":     {"docker_image.0.basic_auth.0.username", ""},
+		"init_scripts.1.workspace.destination": {"init_scripts.1.workspace.0.destination", ""},
+	} {
+		key, mapKey, ok := policyAttributeKey(clusterSchema, path)
+		assert.True(t, ok, path)
+		assert.Equal(t, expected, []string{key, mapKey}, path)
+	}
+	for _, path := range []string{"cluster_type", "dbus_per_hour", "init_scripts.*.s3.destination"} {
+		_, _, ok := policyAttributeKey(clusterSchema, path)
+		assert.False(t, ok, path)
+	}
+}
+
+func TestPolicyElementCheck(t *testing.T) {
+	min, max := 10.0, 120.0
+	tests := []struct {
+		element  PolicyElement
+		value    interface{}
+		isSet    bool
+		defaults bool
+		expected string
+	}{
+		{PolicyElement{Type: PolicyElementFixed, Value: true}, "true", true, false, ""},
+		{PolicyElement{Type: PolicyElementFixed, Value: "a"}, "b", true, false, "x must be a, but is b"},
+		{PolicyElement{Type: PolicyElementFixed, Value: "a"}, nil, false, false, ""},
+		{PolicyElement{Type: PolicyElementForbidden}, "a", true, false, "x is forbidden"},
+		{PolicyElement{Type: PolicyElementAllowlist, Values: []interface{}{"a", "b"}}, "c", true, false,
+			"x must be one of a, b, but is c"},
+		{PolicyElement{Type: PolicyElementAllowlist, Values: []interface{}{"a"}}, nil, false, false, "x is required"},
+		{PolicyElement{Type: PolicyElementAllowlist, Values: []interface{}{"a"}, DefaultValue: "a"},
+			nil, false, false, "x is required"},
+		{PolicyElement{Type: PolicyElementAllowlist, Values: []interface{}{"a"}, DefaultValue: "a"},
+			nil, false, true, ""},
+		{PolicyElement{Type: PolicyElementBlocklist, Values: []interface{}{"a"}}, "a", true, false,
+			"x must not be one of a, but is a"},
+		{PolicyElement{Type: PolicyElementRegex, Pattern: "^a+$"}, "ab", true, false, "x must match ^a+$, but is ab"},
+		{PolicyElement{Type: PolicyElementRange, MinValue: &min, MaxValue: &max}, 15, true, false, ""},
+		{PolicyElement{Type: PolicyElementRange, MinValue: &min}, 5, true, false, "x must be at least 10, but is 5"},
+		{PolicyElement{Type: PolicyElementRange, MaxValue: &max}, 150, true, false, "x must be at most 120, but is 150"},
+		{PolicyElement{Type: PolicyElementRange, MaxValue: &max}, "a", true, false, "x must be a number, but is a"},
+		{PolicyElement{Type: PolicyElementUnlimited, IsOptional: true}, nil, false, false, ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, tt.element.check("x", tt.value, tt.isSet, tt.defaults))
+	}
+}
+
+func TestResourceClusterCreate_PolicyViolations(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			policyConstraintsFixture(t),
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		cluster_name = "Shared Autoscaling"
+		spark_version = "7.1.x-scala2.12"
+		node_type_id = "m4.large"
+		policy_id = "def"
+		autotermination_minutes = 180
+		autoscale {
+			min_workers = 1
+			max_workers = 20
+		}
+		spark_conf = {
+			"spark.databricks.cluster.profile" = "singleNode"
+		}
+		`,
+	}.Apply(t)
+	assert.EqualError(t, err, "Cluster does not comply with cluster policy Team clusters: "+
+		"autoscale.max_workers must be at most 10, but is 20; "+
+		"autotermination_minutes must be at most 120, but is 180; "+
+		"custom_tags.team is required; "+
+		"node_type_id must be one of i3.xlarge, i3.2xlarge, but is m4.large; "+
+		"spark_conf.spark.databricks.cluster.profile is forbidden; "+
+		"spark_version must be 7.3.x-scala2.12, but is 7.1.x-scala2.12")
+}
+
+func TestResourceClusterDiff_PolicyCheckedOnClusterChanges(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		policyConstraintsFixture(t),
+	})
+	require.NoError(t, err)
+	defer server.Close()
+	r := ResourceCluster()
+	state := &terraform.InstanceState{
+		ID: "abc",
+		Attributes: map[string]string{
+			"cluster_name":            "Shared Autoscaling",
+			"spark_version":           "7.1.x-scala2.12",
+			"node_type_id":            "m4.large",
+			"policy_id":               "def",
+			"autotermination_minutes": "180",
+			"num_workers":             "1",
+			"is_pinned":               "false",
+		},
+	}
+	config := map[string]interface{}{
+		"cluster_name":            "Shared Autoscaling",
+		"spark_version":           "7.1.x-scala2.12",
+		"node_type_id":            "m4.large",
+		"policy_id":               "def",
+		"autotermination_minutes": 180,
+		"num_workers":             1,
+		"is_pinned":               true,
+	}
+	// pinning doesn't change the cluster, so existing violations are not reported
+	_, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), client)
+	assert.NoError(t, err, err)
+
+	config["num_workers"] = 2
+	_, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), client)
+	qa.AssertErrorStartsWith(t, err, "Cluster does not comply with cluster policy Team clusters")
+}
+
+func TestResourceClusterDiff_PolicyZeroValues(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/policies/clusters/get?policy_id=def",
+			Response: ClusterPolicy{
+				PolicyID: "def",
+				Name:     "Single node",
+				Definition: `{
+					"num_workers": {"type": "range", "maxValue": 0},
+					"enable_local_disk_encryption": {"type": "fixed", "value": true}
+				}`,
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+	r := ResourceCluster()
+	config := map[string]interface{}{
+		"spark_version":                "7.3.x-scala2.12",
+		"node_type_id":                 "i3.xlarge",
+		"policy_id":                    "def",
+		"num_workers":                  0,
+		"enable_local_disk_encryption": true,
+	}
+	// zero number of workers is configured and satisfies the range
+	_, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), client)
+	assert.NoError(t, err, err)
+
+	config["enable_local_disk_encryption"] = false
+	_, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), client)
+	assert.EqualError(t, err, "Cluster does not comply with cluster policy Single node: "+
+		"enable_local_disk_encryption must be true, but is false")
+}
+
+func TestResourceClusterCreate_PolicyError(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/policies/clusters/get?policy_id=def",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Policy def does not exist",
+				},
+				Status: 404,
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		spark_version = "7.3.x-scala2.12"
+		policy_id = "def"
+		num_workers = 1
+		`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Policy def does not exist")
+}
+
+func TestResourceClusterCreate_PolicyDefaultValues(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			policyConstraintsFixture(t),
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					SparkVersion:             "7.3.x-scala2.12",
+					NumWorkers:               1,
+					PolicyID:                 "def",
+					AutoterminationMinutes:   60,
+					CustomTags:               map[string]string{"team": "data"},
+					ApplyPolicyDefaultValues: true,
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					SparkVersion:           "7.3.x-scala2.12",
+					NodeTypeID:             "i3.xlarge",
+					PolicyID:               "def",
+					AutoterminationMinutes: 60,
+					CustomTags:             map[string]string{"team": "data"},
+					State:                  ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events: []ClusterEvent{},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		spark_version = "7.3.x-scala2.12"
+		num_workers = 1
+		policy_id = "def"
+		apply_policy_default_values = true
+		custom_tags = {
+			"team" = "data"
+		}
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "i3.xlarge", d.Get("node_type_id"))
+}
+
+func TestResourceClusterCreate_PolicyInjectedValues(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					NumWorkers:             1,
+					SparkVersion:           "7.3.x-scala2.12",
+					NodeTypeID:             "i3.xlarge",
+					PolicyID:               "def",
+					AutoterminationMinutes: 60,
+					SparkConf:              map[string]string{"spark.foo": "bar"},
+					CustomTags:             map[string]string{"team": "data"},
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					SparkVersion:           "7.3.x-scala2.12",
+					NodeTypeID:             "i3.xlarge",
+					PolicyID:               "def",
+					AutoterminationMinutes: 60,
+					SparkConf: map[string]string{
+						"spark.databricks.acl.dfsAuthEnabled": "true",
+						"spark.foo":                           "bar",
+					},
+					CustomTags: map[string]string{
+						"team":        "data",
+						"cost_center": "123",
+					},
+					State: ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/policies/clusters/get?policy_id=def",
+				Response: ClusterPolicy{
+					PolicyID: "def",
+					Name:     "Team clusters",
+					Definition: `{
+						"spark_conf.spark.databricks.acl.dfsAuthEnabled": {"type": "fixed", "value": "true"},
+						"custom_tags.cost_center": {"type": "fixed", "value": "123"},
+						"custom_tags.team": {"type": "regex", "pattern": "^[a-z]+$"}
+					}`,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `
+		spark_version = "7.3.x-scala2.12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		policy_id = "def"
+		spark_conf = {
+			"spark.foo" = "bar"
+		}
+		custom_tags = {
+			"team" = "data"
+		}
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, map[string]interface{}{"spark.foo": "bar"}, d.Get("spark_conf"))
+	assert.Equal(t, map[string]interface{}{"team": "data"}, d.Get("custom_tags"))
+}
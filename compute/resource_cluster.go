@@ -2,11 +2,14 @@ package compute
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal"
@@ -22,19 +25,86 @@ func ResourceCluster() *schema.Resource {
 		Read:   resourceClusterRead,
 		Update: resourceClusterUpdate,
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			return NewClustersAPI(ctx, c).PermanentDelete(d.Id())
+			clusters := NewClustersAPI(ctx, c)
+			if d.Get("is_pinned").(bool) {
+				// otherwise terminated cluster stays in the list
+				if err := clusters.Unpin(d.Id()); err != nil {
+					return err
+				}
+			}
+			return clusters.PermanentDelete(d.Id())
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			err := checkGp3Settings(d, "aws_attributes.0.ebs_volume_type",
+				"aws_attributes.0.ebs_volume_iops", "aws_attributes.0.ebs_volume_throughput")
+			if err != nil {
+				return err
+			}
+			err = checkSpotBidMaxPrice(d, "azure_attributes.0")
+			if err != nil {
+				return err
+			}
+			err = checkInitScripts(d)
+			if err != nil {
+				return err
+			}
+			if d.Get("is_single_node").(bool) && d.Get("num_workers").(int) > 0 {
+				return fmt.Errorf("num_workers must be 0 for single node clusters")
+			}
+			// policies may govern any of cluster attributes, so policy is fetched only when they change
+			if d.Id() != "" && !hasClusterConfigChanged(d) {
+				return nil
+			}
+			return checkClusterPolicy(NewClusterPoliciesAPI(ctx, c), d)
 		},
 		Schema: clusterSchema,
 	}.ToResource()
 	s.SchemaVersion = 2
 	s.Timeouts = &schema.ResourceTimeout{
 		Create: schema.DefaultTimeout(30 * time.Minute),
+		Read:   schema.DefaultTimeout(30 * time.Minute),
 		Update: schema.DefaultTimeout(30 * time.Minute),
 		Delete: schema.DefaultTimeout(30 * time.Minute),
 	}
 	return s
 }
 
+// customizeClusterSchema adjusts cluster attributes, that are the same for databricks_cluster and for
+// new_cluster blocks of databricks_job, so that they don't diverge. Conflicts are set by the callers,
+// because they are addressed by absolute keys.
+func customizeClusterSchema(s map[string]*schema.Schema) {
+	p, err := internal.SchemaPath(s, "docker_image", "basic_auth", "password")
+	if err == nil {
+		p.Sensitive = true
+	}
+	if v, err := internal.SchemaPath(s, "azure_attributes", "availability"); err == nil {
+		// nolint
+		v.ValidateFunc = validation.StringInSlice([]string{
+			AzureAvailabilitySpot,
+			AzureAvailabilityOnDemand,
+			AzureAvailabilitySpotWithFallback,
+		}, false)
+	}
+	if v, err := internal.SchemaPath(s, "gcp_attributes", "availability"); err == nil {
+		// nolint
+		v.ValidateFunc = validation.StringInSlice([]string{
+			GcpAvailabilityPreemptible,
+			GcpAvailabilityOnDemand,
+			GcpAvailabilityPreemptibleWithFallback,
+		}, false)
+	}
+	if v, err := internal.SchemaPath(s, "init_scripts", "dbfs", "destination"); err == nil {
+		v.DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+			return strings.TrimPrefix(old, "dbfs:") == strings.TrimPrefix(new, "dbfs:")
+		}
+	}
+	if v, err := internal.SchemaPath(s, "init_scripts", "workspace", "destination"); err == nil {
+		v.DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+			return strings.TrimPrefix(old, "/Workspace") == strings.TrimPrefix(new, "/Workspace")
+		}
+	}
+}
+
 func resourceClusterSchema() map[string]*schema.Schema {
 	return internal.StructToSchema(Cluster{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
 		s["spark_conf"].DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
@@ -52,10 +122,10 @@ func resourceClusterSchema() map[string]*schema.Schema {
 				return ss
 			})["library"]
 
-		p, err := internal.SchemaPath(s, "docker_image", "basic_auth", "password")
-		if err == nil {
-			p.Sensitive = true
-		}
+		s["aws_attributes"].ConflictsWith = []string{"azure_attributes", "gcp_attributes"}
+		s["azure_attributes"].ConflictsWith = []string{"aws_attributes", "gcp_attributes"}
+		s["gcp_attributes"].ConflictsWith = []string{"aws_attributes", "azure_attributes"}
+		customizeClusterSchema(s)
 		s["autotermination_minutes"].Default = 60
 		s["idempotency_token"].ForceNew = true
 		s["cluster_id"] = &schema.Schema{
@@ -74,6 +144,11 @@ func resourceClusterSchema() map[string]*schema.Schema {
 				return old == new
 			},
 		}
+		s["is_single_node"] = &schema.Schema{
+			Type:          schema.TypeBool,
+			Optional:      true,
+			ConflictsWith: []string{"autoscale"},
+		}
 		s["state"] = &schema.Schema{
 			Type:     schema.TypeString,
 			Computed: true,
@@ -82,6 +157,25 @@ func resourceClusterSchema() map[string]*schema.Schema {
 			Type:     schema.TypeMap,
 			Computed: true,
 		}
+		s["verify_log_delivery"] = &schema.Schema{
+			Type:         schema.TypeBool,
+			Optional:     true,
+			RequiredWith: []string{"cluster_log_conf"},
+		}
+		// status of log delivery changes between refreshes, so it's never part of the diff
+		s["cluster_log_status"] = &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: internal.StructToSchema(LogSyncStatus{},
+					func(ss map[string]*schema.Schema) map[string]*schema.Schema {
+						for _, v := range ss {
+							v.Computed = true
+						}
+						return ss
+					}),
+			},
+		}
 		return s
 	})
 }
@@ -93,6 +187,9 @@ func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, c *commo
 	if err != nil {
 		return err
 	}
+	if d.Get("is_single_node").(bool) {
+		cluster.setSingleNode()
+	}
 	modifyClusterRequest(&cluster)
 	clusterInfo, err := clusters.Create(cluster)
 	if err != nil {
@@ -109,6 +206,9 @@ func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, c *commo
 			return err
 		}
 	}
+	if err = verifyLogDelivery(clusters, d, clusterInfo, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
 	var libraryList ClusterLibraryList
 	if err = internal.DataToStructPointer(d, clusterSchema, &libraryList); err != nil {
 		return err
@@ -118,7 +218,8 @@ func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, c *commo
 		if err = librariesAPI.Install(libraryList); err != nil {
 			return err
 		}
-		if _, err := waitForLibrariesInstalled(librariesAPI, clusterInfo); err != nil {
+		_, err = waitForLibrariesInstalled(librariesAPI, clusterInfo, d.Timeout(schema.TimeoutCreate), libraryList)
+		if err != nil {
 			return err
 		}
 	}
@@ -149,14 +250,30 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, c *common.
 	if err != nil {
 		return err
 	}
+	var configured Cluster
+	if err = internal.DataToStructPointer(d, clusterSchema, &configured); err != nil {
+		return err
+	}
+	if clusterInfo.DockerImage != nil && configured.DockerImage != nil {
+		clusterInfo.DockerImage.keepBasicAuth([]DockerImage{*configured.DockerImage})
+	}
+	if d.Get("is_single_node").(bool) {
+		clusterInfo.removeSingleNode(configured)
+	}
+	removePolicyInjectedValues(NewClusterPoliciesAPI(ctx, c), &clusterInfo, configured)
 	if err = internal.StructToData(clusterInfo, clusterSchema, d); err != nil {
 		return err
 	}
 	if err = setPinnedStatus(d, clusterAPI); err != nil {
 		return err
 	}
+	var libraryList ClusterLibraryList
+	if err = internal.DataToStructPointer(d, clusterSchema, &libraryList); err != nil {
+		return err
+	}
 	librariesAPI := NewLibrariesAPI(ctx, c)
-	libsClusterStatus, err := waitForLibrariesInstalled(librariesAPI, clusterInfo)
+	libsClusterStatus, err := waitForLibrariesInstalled(librariesAPI, clusterInfo,
+		d.Timeout(schema.TimeoutRead), libraryList)
 	if err != nil {
 		return err
 	}
@@ -164,9 +281,12 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, c *common.
 	return internal.StructToData(libList, clusterSchema, d)
 }
 
-func waitForLibrariesInstalled(
-	libraries LibrariesAPI, clusterInfo ClusterInfo) (result *ClusterLibraryStatuses, err error) {
-	err = resource.RetryContext(libraries.context, 30*time.Minute, func() *resource.RetryError {
+// waitForLibrariesInstalled waits until requested libraries are either installed or failed on running cluster.
+// All libraries are checked, if none are requested. Libraries of clusters, that are not running, are installed
+// only once cluster starts, so their pending statuses are returned as is and reconciled by the next read.
+func waitForLibrariesInstalled(libraries LibrariesAPI, clusterInfo ClusterInfo,
+	timeout time.Duration, requested ClusterLibraryList) (result *ClusterLibraryStatuses, err error) {
+	err = resource.RetryContext(libraries.context, timeout, func() *resource.RetryError {
 		libsClusterStatus, err := libraries.ClusterStatus(clusterInfo.ClusterID)
 		if ae, ok := err.(common.APIError); ok && ae.IsMissing() {
 			// eventual consistency error
@@ -181,7 +301,7 @@ func waitForLibrariesInstalled(
 			result = &libsClusterStatus
 			return nil
 		}
-		retry, err := libsClusterStatus.IsRetryNeeded()
+		retry, err := libsClusterStatus.only(requested).IsRetryNeeded()
 		if retry {
 			return resource.RetryableError(err)
 		}
@@ -194,10 +314,19 @@ func waitForLibrariesInstalled(
 	return
 }
 
-func hasClusterConfigChanged(d *schema.ResourceData) bool {
+// nonClusterConfigKeys are attributes, that are managed by the provider and are not part of cluster config
+var nonClusterConfigKeys = map[string]bool{
+	"library":             true,
+	"is_pinned":           true,
+	"verify_log_delivery": true,
+	"cluster_log_status":  true,
+}
+
+// hasClusterConfigChanged returns true, if any of cluster config attributes has changed. It accepts both
+// ResourceData and ResourceDiff.
+func hasClusterConfigChanged(d interface{ HasChange(string) bool }) bool {
 	for k := range clusterSchema {
-		// TODO: create a map if we'll add more non-cluster config parameters in the future
-		if k == "library" || k == "is_pinned" {
+		if nonClusterConfigKeys[k] {
 			continue
 		}
 		if d.HasChange(k) {
@@ -207,6 +336,19 @@ func hasClusterConfigChanged(d *schema.ResourceData) bool {
 	return false
 }
 
+// hasOnlySizeChanged returns true, if cluster could be resized without restart
+func hasOnlySizeChanged(d *schema.ResourceData) bool {
+	for k := range clusterSchema {
+		if nonClusterConfigKeys[k] || k == "num_workers" || k == "autoscale" {
+			continue
+		}
+		if d.HasChange(k) {
+			return false
+		}
+	}
+	return d.HasChange("num_workers") || d.HasChange("autoscale")
+}
+
 func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 	clusters := NewClustersAPI(ctx, c)
 	clusterID := d.Id()
@@ -215,11 +357,13 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 	if err != nil {
 		return err
 	}
+	if d.Get("is_single_node").(bool) {
+		cluster.setSingleNode()
+	}
 	var clusterInfo ClusterInfo
 	if hasClusterConfigChanged(d) {
 		log.Printf("[DEBUG] Cluster state has changed!")
-		modifyClusterRequest(&cluster)
-		clusterInfo, err = clusters.Edit(cluster)
+		clusterInfo, err = resizeOrEditCluster(clusters, d, cluster)
 		if err != nil {
 			return err
 		}
@@ -238,9 +382,14 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 			err = clusters.Unpin(clusterID)
 		}
 		if err != nil {
+			// otherwise failed change gets into state and won't be retried
+			d.Set("is_pinned", oldPinned)
 			return err
 		}
 	}
+	if err = verifyLogDelivery(clusters, d, clusterInfo, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return err
+	}
 
 	var libraryList ClusterLibraryList
 	if err = internal.DataToStructPointer(d, clusterSchema, &libraryList); err != nil {
@@ -261,7 +410,9 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 				return err
 			}
 		}
-		if err = updateLibraries(librariesAPI, tmpClusterInfo, libsToInstall, libsToUninstall); err != nil {
+		err = updateLibraries(librariesAPI, tmpClusterInfo, libsToInstall, libsToUninstall,
+			d.Timeout(schema.TimeoutUpdate), libraryList)
+		if err != nil {
 			return err
 		}
 		if clusterInfo.State == ClusterStateTerminated {
@@ -274,8 +425,101 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, c *commo
 	return nil
 }
 
-// modifyClusterRequest helps remove all request fields that should not be submitted when instance pool is selected.
+// resizeOrEditCluster resizes running cluster in place, when only number of workers has changed,
+// so that attached notebooks and jobs are not interrupted. Otherwise cluster is edited and restarted.
+func resizeOrEditCluster(clusters ClustersAPI, d *schema.ResourceData, cluster Cluster) (ClusterInfo, error) {
+	// clusters without workers are single node and require edit to change spark_conf
+	canResize := cluster.NumWorkers > 0 || cluster.Autoscale != nil
+	if canResize && hasOnlySizeChanged(d) {
+		clusterInfo, err := clusters.Get(cluster.ClusterID)
+		if err != nil {
+			return clusterInfo, err
+		}
+		if clusterInfo.State == ClusterStateRunning {
+			log.Printf("[INFO] Resizing cluster %s", cluster.ClusterID)
+			return clusters.Resize(ResizeRequest{
+				ClusterID:  cluster.ClusterID,
+				NumWorkers: cluster.NumWorkers,
+				Autoscale:  cluster.Autoscale,
+			})
+		}
+	}
+	modifyClusterRequest(&cluster)
+	return clusters.Edit(cluster)
+}
+
+// checkGp3Settings fails the plan, if iops or throughput are changed for volumes other than gp3.
+// Unchanged values are ignored, as they may remain in state after changing the volume type.
+func checkGp3Settings(d *schema.ResourceDiff, volumeType string, settings ...string) error {
+	if d.Get(volumeType).(string) == EbsVolumeTypeGeneralPurposeSsdGp3 {
+		return nil
+	}
+	for _, setting := range settings {
+		if !d.HasChange(setting) || d.Get(setting).(int) == 0 {
+			continue
+		}
+		return fmt.Errorf("%s can only be set for %s volumes", setting, EbsVolumeTypeGeneralPurposeSsdGp3)
+	}
+	return nil
+}
+
+// checkSpotBidMaxPrice fails the plan, if spot_bid_max_price is changed for on-demand Azure instances
+func checkSpotBidMaxPrice(d *schema.ResourceDiff, prefix string) error {
+	price := prefix + ".spot_bid_max_price"
+	if !d.HasChange(price) || d.Get(price).(float64) == 0 {
+		return nil
+	}
+	availability := d.Get(prefix + ".availability").(string)
+	if availability == AzureAvailabilitySpot || availability == AzureAvailabilitySpotWithFallback {
+		return nil
+	}
+	return fmt.Errorf("%s can only be set with %s or %s availability",
+		price, AzureAvailabilitySpot, AzureAvailabilitySpotWithFallback)
+}
+
+// verifyLogDelivery waits for the next log delivery attempt of a running cluster, when `verify_log_delivery`
+// is set, so that wrong destination or credentials fail the apply instead of silently losing the logs
+func verifyLogDelivery(clusters ClustersAPI, d *schema.ResourceData, clusterInfo ClusterInfo,
+	timeout time.Duration) error {
+	if !d.Get("verify_log_delivery").(bool) {
+		return nil
+	}
+	if clusterInfo.State != ClusterStateRunning {
+		log.Printf("[INFO] Cluster %s is %s, so its logs are not delivered", d.Id(), clusterInfo.State)
+		return nil
+	}
+	since := int64(d.Get("cluster_log_status.0.last_attempted").(int))
+	return clusters.waitForLogDelivery(d.Id(), since, timeout)
+}
+
+// checkInitScripts makes sure, that every init script has exactly one location
+func checkInitScripts(d *schema.ResourceDiff) error {
+	for i, v := range d.Get("init_scripts").([]interface{}) {
+		script, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("init_scripts.%d must have exactly one of dbfs, s3, workspace or gcs", i)
+		}
+		locations := 0
+		for _, location := range []string{"dbfs", "s3", "workspace", "gcs"} {
+			if l, ok := script[location].([]interface{}); ok && len(l) > 0 {
+				locations++
+			}
+		}
+		if locations != 1 {
+			return fmt.Errorf("init_scripts.%d must have exactly one of dbfs, s3, workspace or gcs", i)
+		}
+	}
+	return nil
+}
+
+// modifyClusterRequest helps remove all request fields that should not be submitted, e.g. when instance pool is selected.
 func modifyClusterRequest(clusterModel *Cluster) {
+	if clusterModel.AwsAttributes != nil &&
+		clusterModel.AwsAttributes.EbsVolumeType != EbsVolumeTypeGeneralPurposeSsdGp3 {
+		// values from gp3 volumes may stay in state after changing volume type
+		clusterModel.AwsAttributes.EbsVolumeIops = 0
+		clusterModel.AwsAttributes.EbsVolumeThroughput = 0
+	}
 	// Instance profile id does not exist or not set
 	if clusterModel.InstancePoolID == "" {
 		return
@@ -287,13 +531,16 @@ func modifyClusterRequest(clusterModel *Cluster) {
 		}
 		clusterModel.AwsAttributes = &awsAttributes
 	}
+	clusterModel.AzureAttributes = nil
+	clusterModel.GcpAttributes = nil
 	clusterModel.EnableElasticDisk = false
 	clusterModel.NodeTypeID = ""
 	clusterModel.DriverNodeTypeID = ""
 }
 
 func updateLibraries(libraries LibrariesAPI, clusterInfo ClusterInfo,
-	libsToInstall, libsToUninstall ClusterLibraryList) error {
+	libsToInstall, libsToUninstall ClusterLibraryList,
+	timeout time.Duration, requested ClusterLibraryList) error {
 	if len(libsToUninstall.Libraries) > 0 {
 		err := libraries.Uninstall(libsToUninstall)
 		if err != nil {
@@ -306,6 +553,6 @@ func updateLibraries(libraries LibrariesAPI, clusterInfo ClusterInfo,
 			return err
 		}
 	}
-	_, err := waitForLibrariesInstalled(libraries, clusterInfo)
+	_, err := waitForLibrariesInstalled(libraries, clusterInfo, timeout, requested)
 	return err
 }
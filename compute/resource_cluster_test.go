@@ -1,11 +1,14 @@
 package compute
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -453,6 +456,63 @@ func TestResourceClusterRead(t *testing.T) {
 	}
 }
 
+func TestResourceClusterRead_DockerBasicAuth(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					SparkVersion:           "7.3.x-scala2.12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+					DockerImage: &DockerImage{
+						URL: "acme.azurecr.io/runtime:latest",
+						BasicAuth: &DockerBasicAuth{
+							Username: "acme",
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{
+					Events: []ClusterEvent{},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Resource: ResourceCluster(),
+		Read:     true,
+		ID:       "abc",
+		HCL: `
+		spark_version = "7.3.x-scala2.12"
+		node_type_id = "i3.xlarge"
+		num_workers = 1
+		docker_image {
+			url = "acme.azurecr.io/runtime:latest"
+			basic_auth {
+				username = "acme"
+				password = "{{secrets/registry/password}}"
+			}
+		}
+		`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "acme", d.Get("docker_image.0.basic_auth.0.username"))
+	assert.Equal(t, "{{secrets/registry/password}}", d.Get("docker_image.0.basic_auth.0.password"))
+}
+
 func TestResourceClusterRead_NotFound(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -908,3 +968,1206 @@ func TestResourceClusterDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
+
+func TestResourceClusterCreatePinned_QuotaExceeded(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/pin",
+				Response: common.APIErrorBody{
+					ErrorCode: "QUOTA_EXCEEDED",
+					Message:   "Maximum number of pinned clusters reached",
+				},
+				Status: 400,
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 100
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		is_pinned = true`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Cannot pin cluster abc: Maximum number of pinned clusters reached. "+
+		"Workspace has a limit on number of pinned clusters")
+}
+
+func TestResourceClusterDelete_Pinned(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:          "POST",
+				Resource:        "/api/2.0/clusters/unpin",
+				ExpectedRequest: ClusterID{ClusterID: "abc"},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/delete",
+				ExpectedRequest: map[string]string{
+					"cluster_id": "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					State: ClusterStateTerminated,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/permanent-delete",
+				ExpectedRequest: map[string]string{
+					"cluster_id": "abc",
+				},
+			},
+		},
+		Resource: ResourceCluster(),
+		Delete:   true,
+		ID:       "abc",
+		InstanceState: map[string]string{
+			"num_workers":   "100",
+			"spark_version": "7.1-scala12",
+			"node_type_id":  "i3.xlarge",
+			"is_pinned":     "true",
+		},
+		HCL: `num_workers = 100
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		is_pinned = true`,
+	}.ApplyNoError(t)
+}
+
+func TestIsPinnedClustersQuotaError(t *testing.T) {
+	assert.True(t, isPinnedClustersQuotaError(common.APIError{
+		ErrorCode: "QUOTA_EXCEEDED",
+	}))
+	assert.True(t, isPinnedClustersQuotaError(common.APIError{
+		StatusCode: 400,
+		Message:    "Cannot pin more than 70 pinned clusters",
+	}))
+	assert.False(t, isPinnedClustersQuotaError(common.APIError{
+		StatusCode: 400,
+		Message:    "Cluster abc does not exist",
+	}))
+	assert.False(t, isPinnedClustersQuotaError(nil))
+}
+
+func TestResourceClusterCreate_Gp3(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					AwsAttributes: &AwsAttributes{
+						EbsVolumeType:       EbsVolumeTypeGeneralPurposeSsdGp3,
+						EbsVolumeCount:      1,
+						EbsVolumeSize:       100,
+						EbsVolumeIops:       4000,
+						EbsVolumeThroughput: 250,
+					},
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+					AwsAttributes: &AwsAttributes{
+						EbsVolumeType:       EbsVolumeTypeGeneralPurposeSsdGp3,
+						EbsVolumeCount:      1,
+						EbsVolumeSize:       100,
+						EbsVolumeIops:       4000,
+						EbsVolumeThroughput: 250,
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 1
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		aws_attributes {
+			ebs_volume_type = "GENERAL_PURPOSE_SSD_GP3"
+			ebs_volume_count = 1
+			ebs_volume_size = 100
+			ebs_volume_iops = 4000
+			ebs_volume_throughput = 250
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 4000, d.Get("aws_attributes.0.ebs_volume_iops"))
+	assert.Equal(t, 250, d.Get("aws_attributes.0.ebs_volume_throughput"))
+}
+
+func TestResourceClusterCreate_ThroughputWithoutGp3(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 1
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		aws_attributes {
+			ebs_volume_type = "GENERAL_PURPOSE_SSD"
+			ebs_volume_count = 1
+			ebs_volume_size = 100
+			ebs_volume_throughput = 250
+		}`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "aws_attributes.0.ebs_volume_throughput can only be set for GENERAL_PURPOSE_SSD_GP3 volumes")
+}
+
+func TestResourceClusterRead_NonGp3NoDrift(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+					AwsAttributes: &AwsAttributes{
+						EbsVolumeType:  EbsVolumeTypeGeneralPurposeSsd,
+						EbsVolumeCount: 1,
+						EbsVolumeSize:  100,
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{},
+			},
+		},
+		Read:     true,
+		ID:       "abc",
+		Resource: ResourceCluster(),
+		InstanceState: map[string]string{
+			"num_workers":                            "1",
+			"spark_version":                          "7.1-scala12",
+			"node_type_id":                           "i3.xlarge",
+			"aws_attributes.#":                       "1",
+			"aws_attributes.0.ebs_volume_type":       "GENERAL_PURPOSE_SSD",
+			"aws_attributes.0.ebs_volume_count":      "1",
+			"aws_attributes.0.ebs_volume_size":       "100",
+			"aws_attributes.0.ebs_volume_iops":       "0",
+			"aws_attributes.0.ebs_volume_throughput": "0",
+		},
+		HCL: `num_workers = 1
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		aws_attributes {
+			ebs_volume_type = "GENERAL_PURPOSE_SSD"
+			ebs_volume_count = 1
+			ebs_volume_size = 100
+		}`,
+	}.ApplyNoError(t)
+}
+
+func TestModifyClusterRequest_ResetsGp3Settings(t *testing.T) {
+	cluster := Cluster{
+		AwsAttributes: &AwsAttributes{
+			EbsVolumeType:       EbsVolumeTypeGeneralPurposeSsd,
+			EbsVolumeIops:       3000,
+			EbsVolumeThroughput: 125,
+		},
+	}
+	modifyClusterRequest(&cluster)
+	assert.Equal(t, int32(0), cluster.AwsAttributes.EbsVolumeIops)
+	assert.Equal(t, int32(0), cluster.AwsAttributes.EbsVolumeThroughput)
+}
+
+func TestResourceClusterCreate_AzureSpot(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "Standard_DS3_v2",
+					AutoterminationMinutes: 60,
+					AzureAttributes: &AzureAttributes{
+						FirstOnDemand:   1,
+						Availability:    AzureAvailabilitySpotWithFallback,
+						SpotBidMaxPrice: -1,
+					},
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "Standard_DS3_v2",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+					AzureAttributes: &AzureAttributes{
+						FirstOnDemand:   1,
+						Availability:    AzureAvailabilitySpotWithFallback,
+						SpotBidMaxPrice: -1,
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 1
+		spark_version = "7.1-scala12"
+		node_type_id = "Standard_DS3_v2"
+		azure_attributes {
+			first_on_demand = 1
+			availability = "SPOT_WITH_FALLBACK_AZURE"
+			spot_bid_max_price = -1
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "SPOT_WITH_FALLBACK_AZURE", d.Get("azure_attributes.0.availability"))
+	assert.Equal(t, float64(-1), d.Get("azure_attributes.0.spot_bid_max_price"))
+}
+
+func TestResourceClusterCreate_AzureOnDemandWithSpotBid(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 1
+		spark_version = "7.1-scala12"
+		node_type_id = "Standard_DS3_v2"
+		azure_attributes {
+			availability = "ON_DEMAND_AZURE"
+			spot_bid_max_price = 0.5
+		}`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "azure_attributes.0.spot_bid_max_price can only be set "+
+		"with SPOT_AZURE or SPOT_WITH_FALLBACK_AZURE availability")
+}
+
+func TestResourceClusterCreate_AwsAndAzureAttributes(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceCluster(),
+		State: map[string]interface{}{
+			"num_workers":   1,
+			"spark_version": "7.1-scala12",
+			"node_type_id":  "Standard_DS3_v2",
+			"aws_attributes": []interface{}{
+				map[string]interface{}{
+					"availability": "SPOT",
+				},
+			},
+			"azure_attributes": []interface{}{
+				map[string]interface{}{
+					"availability": "SPOT_AZURE",
+				},
+			},
+		},
+	}.Apply(t)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicts with")
+}
+
+func TestResourceClusterCreate_Gcp(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "n1-standard-4",
+					AutoterminationMinutes: 60,
+					GcpAttributes: &GcpAttributes{
+						UsePreemptibleExecutors: true,
+						GoogleServiceAccount:    "cluster@acme.iam.gserviceaccount.com",
+						Availability:            GcpAvailabilityPreemptibleWithFallback,
+						BootDiskSize:            100,
+					},
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "n1-standard-4",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+					GcpAttributes: &GcpAttributes{
+						UsePreemptibleExecutors: true,
+						GoogleServiceAccount:    "cluster@acme.iam.gserviceaccount.com",
+						Availability:            GcpAvailabilityPreemptibleWithFallback,
+						BootDiskSize:            100,
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 1
+		spark_version = "7.1-scala12"
+		node_type_id = "n1-standard-4"
+		gcp_attributes {
+			use_preemptible_executors = true
+			google_service_account = "cluster@acme.iam.gserviceaccount.com"
+			availability = "PREEMPTIBLE_WITH_FALLBACK_GCP"
+			boot_disk_size = 100
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "cluster@acme.iam.gserviceaccount.com", d.Get("gcp_attributes.0.google_service_account"))
+	assert.Equal(t, 100, d.Get("gcp_attributes.0.boot_disk_size"))
+}
+
+func TestResourceClusterCreate_AwsAndGcpAttributes(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 1
+		spark_version = "7.1-scala12"
+		node_type_id = "n1-standard-4"
+		aws_attributes {
+			availability = "SPOT"
+		}
+		gcp_attributes {
+			use_preemptible_executors = true
+		}`,
+	}.Apply(t)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicts with")
+}
+
+func TestResourceClusterRead_WithoutGcpAttributes(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{},
+			},
+		},
+		Read:     true,
+		ID:       "abc",
+		Resource: ResourceCluster(),
+		InstanceState: map[string]string{
+			"num_workers":   "1",
+			"spark_version": "7.1-scala12",
+			"node_type_id":  "i3.xlarge",
+		},
+		HCL: `num_workers = 1
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 0, d.Get("gcp_attributes.#"))
+	assert.Equal(t, 0, d.Get("azure_attributes.#"))
+}
+
+func TestResourceClusterUpdate_ResizeOrEdit(t *testing.T) {
+	baseState := map[string]string{
+		"autotermination_minutes": "60",
+		"spark_version":           "7.1-scala12",
+		"node_type_id":            "i3.xlarge",
+		"num_workers":             "10",
+	}
+	autoscaleState := map[string]string{
+		"autotermination_minutes": "60",
+		"spark_version":           "7.1-scala12",
+		"node_type_id":            "i3.xlarge",
+		"autoscale.#":             "1",
+		"autoscale.0.min_workers": "1",
+		"autoscale.0.max_workers": "10",
+	}
+	resize := func(r ResizeRequest) qa.HTTPFixture {
+		return qa.HTTPFixture{
+			Method:          "POST",
+			Resource:        "/api/2.0/clusters/resize",
+			ExpectedRequest: r,
+		}
+	}
+	edit := func(c Cluster) qa.HTTPFixture {
+		c.ClusterID = "abc"
+		c.NodeTypeID = "i3.xlarge"
+		c.AutoterminationMinutes = 60
+		return qa.HTTPFixture{
+			Method:          "POST",
+			Resource:        "/api/2.0/clusters/edit",
+			ExpectedRequest: c,
+		}
+	}
+	start := qa.HTTPFixture{
+		Method:          "POST",
+		Resource:        "/api/2.0/clusters/start",
+		ExpectedRequest: ClusterID{ClusterID: "abc"},
+	}
+	tests := []struct {
+		name          string
+		instanceState map[string]string
+		hcl           string
+		state         ClusterState
+		fixtures      []qa.HTTPFixture
+	}{
+		{
+			name:          "num_workers on running cluster",
+			instanceState: baseState,
+			hcl:           `num_workers = 20`,
+			state:         ClusterStateRunning,
+			fixtures: []qa.HTTPFixture{
+				resize(ResizeRequest{ClusterID: "abc", NumWorkers: 20}),
+			},
+		},
+		{
+			name:          "autoscale on running cluster",
+			instanceState: autoscaleState,
+			hcl: `autoscale {
+				min_workers = 1
+				max_workers = 20
+			}`,
+			state: ClusterStateRunning,
+			fixtures: []qa.HTTPFixture{
+				resize(ResizeRequest{ClusterID: "abc", Autoscale: &AutoScale{MinWorkers: 1, MaxWorkers: 20}}),
+			},
+		},
+		{
+			name:          "num_workers and spark_version on running cluster",
+			instanceState: baseState,
+			hcl: `num_workers = 20
+			spark_version = "7.3-scala12"`,
+			state: ClusterStateRunning,
+			fixtures: []qa.HTTPFixture{
+				edit(Cluster{NumWorkers: 20, SparkVersion: "7.3-scala12"}),
+				start,
+			},
+		},
+		{
+			name:          "num_workers on terminated cluster",
+			instanceState: baseState,
+			hcl:           `num_workers = 20`,
+			state:         ClusterStateTerminated,
+			fixtures: []qa.HTTPFixture{
+				edit(Cluster{NumWorkers: 20, SparkVersion: "7.1-scala12"}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hcl := tt.hcl
+			if !strings.Contains(hcl, "spark_version") {
+				hcl += "\nspark_version = \"7.1-scala12\""
+			}
+			qa.ResourceFixture{
+				Fixtures: append([]qa.HTTPFixture{
+					{
+						Method:       "GET",
+						Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+						ReuseRequest: true,
+						Response: ClusterInfo{
+							ClusterID: "abc",
+							State:     tt.state,
+						},
+					},
+					{
+						Method:       "GET",
+						Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+						ReuseRequest: true,
+						Response: ClusterLibraryStatuses{
+							LibraryStatuses: []LibraryStatus{},
+						},
+					},
+					{
+						Method:   "POST",
+						Resource: "/api/2.0/clusters/events",
+						Response: EventsResponse{},
+					},
+				}, tt.fixtures...),
+				ID:            "abc",
+				Update:        true,
+				Resource:      ResourceCluster(),
+				InstanceState: tt.instanceState,
+				HCL: hcl + `
+				node_type_id = "i3.xlarge"
+				autotermination_minutes = 60`,
+			}.ApplyNoError(t)
+		})
+	}
+}
+
+func TestResourceClusterCreate_LibraryFailed(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/install",
+				ExpectedRequest: ClusterLibraryList{
+					ClusterID: "abc",
+					Libraries: []Library{
+						{
+							Whl: "dbfs:/FileStore/broken.whl",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{
+						{
+							Library: &Library{
+								Whl: "dbfs:/FileStore/broken.whl",
+							},
+							Status:   "FAILED",
+							Messages: []string{"Invalid wheel filename"},
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 1
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		library {
+			whl = "dbfs:/FileStore/broken.whl"
+		}`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "library_whl[dbfs:/FileStore/broken.whl] failed: Invalid wheel filename")
+}
+
+func TestResourceClusterCreate_UnrelatedLibraryFailed(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/libraries/install",
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				ReuseRequest: true,
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{
+						{
+							Library: &Library{
+								Whl: "dbfs:/FileStore/good.whl",
+							},
+							Status: "INSTALLED",
+						},
+						{
+							Library: &Library{
+								Jar: "dbfs:/FileStore/installed-from-ui.jar",
+							},
+							Status:   "FAILED",
+							Messages: []string{"Not found"},
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 1
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		library {
+			whl = "dbfs:/FileStore/good.whl"
+		}`,
+	}.ApplyNoError(t)
+}
+
+func TestResourceClusterCreate_SingleNode(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					ClusterName:            "Single Node",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					SparkConf: map[string]string{
+						"spark.databricks.cluster.profile": "singleNode",
+						"spark.master":                     "local[*]",
+						"spark.foo":                        "bar",
+					},
+					CustomTags: map[string]string{
+						"ResourceClass": "SingleNode",
+					},
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					ClusterName:            "Single Node",
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+					SparkConf: map[string]string{
+						"spark.databricks.cluster.profile": "singleNode",
+						"spark.master":                     "local[*]",
+						"spark.foo":                        "bar",
+					},
+					CustomTags: map[string]string{
+						"ResourceClass": "SingleNode",
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `cluster_name = "Single Node"
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		is_single_node = true
+		spark_conf = {
+			"spark.foo" = "bar"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, map[string]interface{}{"spark.foo": "bar"}, d.Get("spark_conf"))
+	assert.Equal(t, map[string]interface{}{}, d.Get("custom_tags"))
+}
+
+func TestResourceClusterCreate_SingleNodeWithAutoscale(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		is_single_node = true
+		autoscale {
+			min_workers = 1
+			max_workers = 2
+		}`,
+	}.Apply(t)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicts with autoscale")
+}
+
+func TestResourceClusterCreate_SingleNodeWithWorkers(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		is_single_node = true
+		num_workers = 2`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "num_workers must be 0 for single node clusters")
+}
+
+func TestResourceClusterCreate_WorkspaceInitScript(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/create",
+				ExpectedRequest: Cluster{
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					InitScripts: []InitScriptStorageInfo{
+						{
+							Workspace: &WorkspaceStorageInfo{
+								Destination: "/Shared/init.sh",
+							},
+						},
+						{
+							Gcs: &GcsStorageInfo{
+								Destination: "gs://bucket/init.sh",
+							},
+						},
+					},
+				},
+				Response: ClusterInfo{
+					ClusterID: "abc",
+					State:     ClusterStateRunning,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateRunning,
+					InitScripts: []InitScriptStorageInfo{
+						{
+							Workspace: &WorkspaceStorageInfo{
+								Destination: "/Workspace/Shared/init.sh",
+							},
+						},
+						{
+							Gcs: &GcsStorageInfo{
+								Destination: "gs://bucket/init.sh",
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/clusters/events",
+				Response: EventsResponse{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 1
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		init_scripts {
+			workspace {
+				destination = "/Shared/init.sh"
+			}
+		}
+		init_scripts {
+			gcs {
+				destination = "gs://bucket/init.sh"
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "/Workspace/Shared/init.sh", d.Get("init_scripts.0.workspace.0.destination"))
+}
+
+func TestResourceClusterCreate_InitScriptWithTwoLocations(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 1
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		init_scripts {
+			dbfs {
+				destination = "dbfs:/init.sh"
+			}
+			workspace {
+				destination = "/Shared/init.sh"
+			}
+		}`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "init_scripts.0 must have exactly one of dbfs, s3, workspace or gcs")
+}
+
+func TestResourceClusterInitScriptsDiffSuppress(t *testing.T) {
+	r := ResourceCluster()
+	d := r.TestResourceData()
+	d.SetId("abc")
+	err := d.Set("init_scripts", []interface{}{
+		map[string]interface{}{
+			"workspace": []interface{}{
+				map[string]interface{}{"destination": "/Workspace/Shared/init.sh"},
+			},
+		},
+		map[string]interface{}{
+			"dbfs": []interface{}{
+				map[string]interface{}{"destination": "dbfs:/init.sh"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"num_workers":   1,
+		"spark_version": "7.1-scala12",
+		"node_type_id":  "i3.xlarge",
+		"init_scripts": []interface{}{
+			map[string]interface{}{
+				"workspace": []interface{}{
+					map[string]interface{}{"destination": "/Shared/init.sh"},
+				},
+			},
+			map[string]interface{}{
+				"dbfs": []interface{}{
+					map[string]interface{}{"destination": "/init.sh"},
+				},
+			},
+		},
+	}), &common.DatabricksClient{})
+	require.NoError(t, err)
+	for k := range diff.Attributes {
+		assert.NotContains(t, k, "init_scripts")
+	}
+}
+
+func clusterLogDeliveryFixtures(status *LogSyncStatus) []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/create",
+			ExpectedRequest: Cluster{
+				NumWorkers:             1,
+				SparkVersion:           "7.1-scala12",
+				NodeTypeID:             "i3.xlarge",
+				AutoterminationMinutes: 60,
+				ClusterLogConf: &StorageInfo{
+					Dbfs: &DbfsStorageInfo{
+						Destination: "dbfs:/cluster-logs",
+					},
+				},
+			},
+			Response: ClusterInfo{
+				ClusterID: "abc",
+				State:     ClusterStateRunning,
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+			Response: ClusterInfo{
+				ClusterID:              "abc",
+				NumWorkers:             1,
+				SparkVersion:           "7.1-scala12",
+				NodeTypeID:             "i3.xlarge",
+				AutoterminationMinutes: 60,
+				State:                  ClusterStateRunning,
+				ClusterLogConf: &StorageInfo{
+					Dbfs: &DbfsStorageInfo{
+						Destination: "dbfs:/cluster-logs",
+					},
+				},
+				ClusterLogStatus: status,
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/clusters/events",
+			Response: EventsResponse{},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/libraries/cluster-status?cluster_id=abc",
+			Response: ClusterLibraryStatuses{
+				LibraryStatuses: []LibraryStatus{},
+			},
+		},
+	}
+}
+
+func TestResourceClusterCreate_VerifyLogDelivery(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: clusterLogDeliveryFixtures(&LogSyncStatus{
+			LastAttempted: 1617000000000,
+		}),
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 1
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		verify_log_delivery = true
+		cluster_log_conf {
+			dbfs {
+				destination = "dbfs:/cluster-logs"
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 1617000000000, d.Get("cluster_log_status.0.last_attempted"))
+}
+
+func TestResourceClusterCreate_VerifyLogDeliveryFailed(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: clusterLogDeliveryFixtures(&LogSyncStatus{
+			LastAttempted: 1617000000000,
+			LastException: "AccessDenied: Access Denied",
+		}),
+		Create:   true,
+		Resource: ResourceCluster(),
+		HCL: `num_workers = 1
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		verify_log_delivery = true
+		cluster_log_conf {
+			dbfs {
+				destination = "dbfs:/cluster-logs"
+			}
+		}`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Cannot deliver logs of cluster abc: AccessDenied: Access Denied")
+}
+
+func TestResourceClusterUpdate_VerifyLogDeliveryOnly(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/clusters/get?cluster_id=abc",
+				ReuseRequest: true,
+				Response: ClusterInfo{
+					ClusterID:              "abc",
+					NumWorkers:             1,
+					SparkVersion:           "7.1-scala12",
+					NodeTypeID:             "i3.xlarge",
+					AutoterminationMinutes: 60,
+					State:                  ClusterStateTerminated,
+					ClusterLogConf: &StorageInfo{
+						Dbfs: &DbfsStorageInfo{
+							Destination: "dbfs:/cluster-logs",
+						},
+					},
+				},
+			},
+			{
+				Method:       "POST",
+				Resource:     "/api/2.0/clusters/events",
+				ReuseRequest: true,
+				Response:     EventsResponse{},
+			},
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/libraries/cluster-status?cluster_id=abc",
+				ReuseRequest: true,
+				Response: ClusterLibraryStatuses{
+					LibraryStatuses: []LibraryStatus{},
+				},
+			},
+		},
+		ID:       "abc",
+		Update:   true,
+		Resource: ResourceCluster(),
+		InstanceState: map[string]string{
+			"autotermination_minutes":               "60",
+			"spark_version":                         "7.1-scala12",
+			"node_type_id":                          "i3.xlarge",
+			"num_workers":                           "1",
+			"cluster_log_conf.#":                    "1",
+			"cluster_log_conf.0.dbfs.#":             "1",
+			"cluster_log_conf.0.dbfs.0.destination": "dbfs:/cluster-logs",
+			"cluster_log_status.#":                  "1",
+			"cluster_log_status.0.last_attempted":   "1617000000000",
+		},
+		HCL: `num_workers = 1
+		spark_version = "7.1-scala12"
+		node_type_id = "i3.xlarge"
+		verify_log_delivery = true
+		cluster_log_conf {
+			dbfs {
+				destination = "dbfs:/cluster-logs"
+			}
+		}`,
+	}.Apply(t)
+	// cluster is not edited, as no cluster configuration has changed
+	assert.NoError(t, err, err)
+}
+
+func TestResourceClusterRead_LogStatusNoDrift(t *testing.T) {
+	r := ResourceCluster()
+	d := r.TestResourceData()
+	d.SetId("abc")
+	for k, v := range map[string]interface{}{
+		"num_workers":   1,
+		"spark_version": "7.1-scala12",
+		"node_type_id":  "i3.xlarge",
+		"cluster_log_status": []interface{}{
+			map[string]interface{}{
+				"last_attempted": 1617000000000,
+				"last_exception": "AccessDenied: Access Denied",
+			},
+		},
+	} {
+		require.NoError(t, d.Set(k, v))
+	}
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"num_workers":   1,
+		"spark_version": "7.1-scala12",
+		"node_type_id":  "i3.xlarge",
+	}), &common.DatabricksClient{})
+	require.NoError(t, err)
+	for k := range diff.Attributes {
+		assert.NotContains(t, k, "cluster_log_status")
+	}
+}
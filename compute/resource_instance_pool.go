@@ -59,21 +59,49 @@ func (a InstancePoolsAPI) Delete(instancePoolID string) error {
 func ResourceInstancePool() *schema.Resource {
 	s := internal.StructToSchema(InstancePool{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
 		s["aws_attributes"].ForceNew = true
+		s["aws_attributes"].ConflictsWith = []string{"azure_attributes", "gcp_attributes"}
+		s["azure_attributes"].ForceNew = true
+		s["azure_attributes"].ConflictsWith = []string{"aws_attributes", "gcp_attributes"}
+		s["gcp_attributes"].ForceNew = true
+		s["gcp_attributes"].ConflictsWith = []string{"aws_attributes", "azure_attributes"}
+		if v, err := internal.SchemaPath(s, "gcp_attributes", "gcp_availability"); err == nil {
+			v.ForceNew = true
+			// nolint
+			v.ValidateFunc = validation.StringInSlice([]string{
+				GcpAvailabilityPreemptible,
+				GcpAvailabilityOnDemand,
+				GcpAvailabilityPreemptibleWithFallback,
+			}, false)
+		}
+		if v, err := internal.SchemaPath(s, "azure_attributes", "availability"); err == nil {
+			v.ForceNew = true
+			// nolint
+			v.ValidateFunc = validation.StringInSlice([]string{
+				AzureAvailabilitySpot,
+				AzureAvailabilityOnDemand,
+			}, false)
+		}
+		if v, err := internal.SchemaPath(s, "azure_attributes", "spot_bid_max_price"); err == nil {
+			v.ForceNew = true
+		}
 		s["node_type_id"].ForceNew = true
 		s["custom_tags"].ForceNew = true
 		s["enable_elastic_disk"].ForceNew = true
 		s["enable_elastic_disk"].Default = true
 		// TODO: check if it's really force new...
-		if v, err := internal.SchemaPath(s, "aws_attributes", "availability"); err != nil {
+		if v, err := internal.SchemaPath(s, "aws_attributes", "availability"); err == nil {
 			v.ForceNew = true
 		}
-		if v, err := internal.SchemaPath(s, "aws_attributes", "zone_id"); err != nil {
+		if v, err := internal.SchemaPath(s, "aws_attributes", "zone_id"); err == nil {
 			v.ForceNew = true
 		}
-		if v, err := internal.SchemaPath(s, "aws_attributes", "spot_bid_price_percent"); err != nil {
+		if v, err := internal.SchemaPath(s, "aws_attributes", "spot_bid_price_percent"); err == nil {
 			v.ForceNew = true
 		}
-		if v, err := internal.SchemaPath(s, "disk_spec", "disk_type", "azure_disk_volume_type"); err != nil {
+		if v, err := internal.SchemaPath(s, "preloaded_docker_images", "basic_auth", "password"); err == nil {
+			v.Sensitive = true
+		}
+		if v, err := internal.SchemaPath(s, "disk_spec", "disk_type", "azure_disk_volume_type"); err == nil {
 			v.ForceNew = true
 			// nolint
 			v.ValidateFunc = validation.StringInSlice([]string{
@@ -81,12 +109,13 @@ func ResourceInstancePool() *schema.Resource {
 				AzureDiskVolumeTypeStandard,
 			}, false)
 		}
-		if v, err := internal.SchemaPath(s, "disk_spec", "disk_type", "ebs_volume_type"); err != nil {
+		if v, err := internal.SchemaPath(s, "disk_spec", "disk_type", "ebs_volume_type"); err == nil {
 			v.ForceNew = true
 			// nolint
 			v.ValidateFunc = validation.StringInSlice([]string{
 				EbsVolumeTypeGeneralPurposeSsd,
 				EbsVolumeTypeThroughputOptimizedHdd,
+				EbsVolumeTypeGeneralPurposeSsdGp3,
 			}, false)
 		}
 		return s
@@ -110,6 +139,13 @@ func ResourceInstancePool() *schema.Resource {
 			if err != nil {
 				return err
 			}
+			var configured InstancePool
+			if err = internal.DataToStructPointer(d, s, &configured); err != nil {
+				return err
+			}
+			for i := range ip.PreloadedDockerImages {
+				ip.PreloadedDockerImages[i].keepBasicAuth(configured.PreloadedDockerImages)
+			}
 			return internal.StructToData(ip, s, d)
 		},
 		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -123,5 +159,13 @@ func ResourceInstancePool() *schema.Resource {
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			return NewInstancePoolsAPI(ctx, c).Delete(d.Id())
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			err := checkGp3Settings(d, "disk_spec.0.disk_type.0.ebs_volume_type",
+				"disk_spec.0.disk_iops", "disk_spec.0.disk_throughput")
+			if err != nil {
+				return err
+			}
+			return checkSpotBidMaxPrice(d, "azure_attributes.0")
+		},
 	}.ToResource()
 }
@@ -7,7 +7,10 @@ import (
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccInstancePools(t *testing.T) {
@@ -132,6 +135,279 @@ func TestResourceInstancePoolCreate(t *testing.T) {
 	assert.Equal(t, "abc", d.Id())
 }
 
+func TestResourceInstancePoolCreate_PreloadedDockerImages(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-pools/create",
+				ExpectedRequest: InstancePool{
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					PreloadedDockerImages: []DockerImage{
+						{
+							URL: "acme.azurecr.io/runtime:latest",
+							BasicAuth: &DockerBasicAuth{
+								Username: "acme",
+								Password: "{{secrets/registry/password}}",
+							},
+						},
+					},
+				},
+				Response: InstancePoolAndStats{
+					InstancePoolID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					PreloadedDockerImages: []DockerImage{
+						{
+							URL: "acme.azurecr.io/runtime:latest",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "i3.xlarge"
+		idle_instance_autotermination_minutes = 15
+		preloaded_docker_images {
+			url = "acme.azurecr.io/runtime:latest"
+			basic_auth {
+				username = "acme"
+				password = "{{secrets/registry/password}}"
+			}
+		}
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	images := d.Get("preloaded_docker_images").(*schema.Set).List()
+	require.Len(t, images, 1)
+	basicAuth := images[0].(map[string]interface{})["basic_auth"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "acme", basicAuth["username"])
+	assert.Equal(t, "{{secrets/registry/password}}", basicAuth["password"])
+}
+
+func TestResourceInstancePoolCreate_Gp3(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-pools/create",
+				ExpectedRequest: InstancePool{
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					DiskSpec: &InstancePoolDiskSpec{
+						DiskType: &InstancePoolDiskType{
+							EbsVolumeType: EbsVolumeTypeGeneralPurposeSsdGp3,
+						},
+						DiskCount:      1,
+						DiskSize:       100,
+						DiskIops:       4000,
+						DiskThroughput: 250,
+					},
+				},
+				Response: InstancePoolAndStats{
+					InstancePoolID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					DiskSpec: &InstancePoolDiskSpec{
+						DiskType: &InstancePoolDiskType{
+							EbsVolumeType: EbsVolumeTypeGeneralPurposeSsdGp3,
+						},
+						DiskCount:      1,
+						DiskSize:       100,
+						DiskIops:       4000,
+						DiskThroughput: 250,
+					},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "i3.xlarge"
+		idle_instance_autotermination_minutes = 15
+		disk_spec {
+			disk_type {
+				ebs_volume_type = "GENERAL_PURPOSE_SSD_GP3"
+			}
+			disk_count = 1
+			disk_size = 100
+			disk_iops = 4000
+			disk_throughput = 250
+		}
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 4000, d.Get("disk_spec.0.disk_iops"))
+	assert.Equal(t, 250, d.Get("disk_spec.0.disk_throughput"))
+}
+
+func TestResourceInstancePoolCreate_IopsWithoutGp3(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "i3.xlarge"
+		idle_instance_autotermination_minutes = 15
+		disk_spec {
+			disk_type {
+				ebs_volume_type = "GENERAL_PURPOSE_SSD"
+			}
+			disk_count = 1
+			disk_size = 100
+			disk_iops = 4000
+		}
+		`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "disk_spec.0.disk_iops can only be set for GENERAL_PURPOSE_SSD_GP3 volumes")
+}
+
+func TestResourceInstancePoolCreate_AzureSpot(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-pools/create",
+				ExpectedRequest: InstancePool{
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "Standard_DS3_v2",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					AzureAttributes: &InstancePoolAzureAttributes{
+						Availability:    AzureAvailabilitySpot,
+						SpotBidMaxPrice: 0.5,
+					},
+				},
+				Response: InstancePoolAndStats{
+					InstancePoolID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "Standard_DS3_v2",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					AzureAttributes: &InstancePoolAzureAttributes{
+						Availability:    AzureAvailabilitySpot,
+						SpotBidMaxPrice: 0.5,
+					},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "Standard_DS3_v2"
+		idle_instance_autotermination_minutes = 15
+		azure_attributes {
+			availability = "SPOT_AZURE"
+			spot_bid_max_price = 0.5
+		}
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 0.5, d.Get("azure_attributes.0.spot_bid_max_price"))
+}
+
+func TestResourceInstancePoolCreate_AzureOnDemandWithSpotBid(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "Standard_DS3_v2"
+		idle_instance_autotermination_minutes = 15
+		azure_attributes {
+			availability = "ON_DEMAND_AZURE"
+			spot_bid_max_price = 0.5
+		}
+		`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "azure_attributes.0.spot_bid_max_price can only be set")
+}
+
+func TestResourceInstancePoolCreate_Gcp(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-pools/create",
+				ExpectedRequest: InstancePool{
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "n1-standard-4",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					GcpAttributes: &InstancePoolGcpAttributes{
+						Availability: GcpAvailabilityPreemptible,
+					},
+				},
+				Response: InstancePoolAndStats{
+					InstancePoolID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "n1-standard-4",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					GcpAttributes: &InstancePoolGcpAttributes{
+						Availability: GcpAvailabilityPreemptible,
+					},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "n1-standard-4"
+		idle_instance_autotermination_minutes = 15
+		gcp_attributes {
+			gcp_availability = "PREEMPTIBLE_GCP"
+		}
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "PREEMPTIBLE_GCP", d.Get("gcp_attributes.0.gcp_availability"))
+}
+
 func TestResourceInstancePoolCreate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -190,6 +466,71 @@ func TestResourceInstancePoolRead(t *testing.T) {
 	assert.Equal(t, "i3.xlarge", d.Get("node_type_id"))
 }
 
+func TestResourceInstancePoolRead_AzureDefaults(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "Standard_DS3_v2",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					AzureAttributes: &InstancePoolAzureAttributes{
+						Availability: AzureAvailabilityOnDemand,
+					},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		InstanceState: map[string]string{
+			"instance_pool_name":                    "Shared Pool",
+			"node_type_id":                          "Standard_DS3_v2",
+			"idle_instance_autotermination_minutes": "15",
+			"enable_elastic_disk":                   "true",
+		},
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "Standard_DS3_v2"
+		idle_instance_autotermination_minutes = 15
+		`,
+		Read: true,
+		ID:   "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 0, d.Get("azure_attributes.#"))
+}
+
+func TestResourceInstancePoolRead_AzureSpotImported(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "Standard_DS3_v2",
+					IdleInstanceAutoTerminationMinutes: 15,
+					AzureAttributes: &InstancePoolAzureAttributes{
+						Availability:    AzureAvailabilitySpot,
+						SpotBidMaxPrice: -1,
+					},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		Read:     true,
+		New:      true,
+		ID:       "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "SPOT_AZURE", d.Get("azure_attributes.0.availability"))
+	assert.Equal(t, float64(-1), d.Get("azure_attributes.0.spot_bid_max_price"))
+}
+
 func TestResourceInstancePoolRead_NotFound(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -274,6 +615,122 @@ func TestResourceInstancePoolUpdate(t *testing.T) {
 	assert.NoError(t, err, err)
 	assert.Equal(t, "abc", d.Id(), "Id should be the same as in reading")
 }
+func TestResourceInstancePoolCreate_PreloadedInArbitraryOrder(t *testing.T) {
+	hcl := `
+	instance_pool_name = "Shared Pool"
+	node_type_id = "i3.xlarge"
+	idle_instance_autotermination_minutes = 15
+	preloaded_spark_versions = ["7.3.x-scala2.12", "6.4.x-scala2.11"]
+	preloaded_docker_images {
+		url = "acme.azurecr.io/runtime:a"
+	}
+	preloaded_docker_images {
+		url = "acme.azurecr.io/runtime:b"
+	}
+	`
+	r := ResourceInstancePool()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-pools/create",
+				Response: InstancePoolAndStats{
+					InstancePoolID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					PreloadedSparkVersions:             []string{"6.4.x-scala2.11", "7.3.x-scala2.12"},
+					PreloadedDockerImages: []DockerImage{
+						{
+							URL: "acme.azurecr.io/runtime:b",
+						},
+						{
+							URL: "acme.azurecr.io/runtime:a",
+						},
+					},
+				},
+			},
+		},
+		Resource: r,
+		HCL:      hcl,
+		Create:   true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+
+	diff, err := r.Diff(context.Background(), d.State(),
+		terraform.NewResourceConfigRaw(map[string]interface{}{
+			"instance_pool_name":                    "Shared Pool",
+			"node_type_id":                          "i3.xlarge",
+			"idle_instance_autotermination_minutes": 15,
+			"preloaded_spark_versions":              []interface{}{"7.3.x-scala2.12", "6.4.x-scala2.11"},
+			"preloaded_docker_images": []interface{}{
+				map[string]interface{}{"url": "acme.azurecr.io/runtime:a"},
+				map[string]interface{}{"url": "acme.azurecr.io/runtime:b"},
+			},
+		}), nil)
+	require.NoError(t, err, err)
+	assert.True(t, diff == nil || diff.Empty(), "order of preloaded items must not cause drift")
+}
+
+func TestResourceInstancePoolUpdate_PreloadedDockerImages(t *testing.T) {
+	assert.False(t, ResourceInstancePool().Schema["preloaded_docker_images"].ForceNew)
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/instance-pools/edit",
+				ExpectedRequest: InstancePool{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					PreloadedDockerImages: []DockerImage{
+						{
+							URL: "acme.azurecr.io/runtime:c",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-pools/get?instance_pool_id=abc",
+				Response: InstancePoolAndStats{
+					InstancePoolID:                     "abc",
+					InstancePoolName:                   "Shared Pool",
+					NodeTypeID:                         "i3.xlarge",
+					IdleInstanceAutoTerminationMinutes: 15,
+					EnableElasticDisk:                  true,
+					PreloadedDockerImages: []DockerImage{
+						{
+							URL: "acme.azurecr.io/runtime:c",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceInstancePool(),
+		HCL: `
+		instance_pool_name = "Shared Pool"
+		node_type_id = "i3.xlarge"
+		idle_instance_autotermination_minutes = 15
+		preloaded_docker_images {
+			url = "acme.azurecr.io/runtime:c"
+		}
+		`,
+		Update: true,
+		ID:     "abc",
+	}.ApplyNoError(t)
+}
+
 func TestResourceInstancePoolUpdate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -4,10 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
+	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal"
@@ -25,11 +32,53 @@ type JobsAPI struct {
 	context context.Context
 }
 
-// Create creates a job on the workspace given the job settings
+// adjustNotebookSource makes notebook tasks without explicit source to take notebooks from Git, when job has
+// git_source. Otherwise source, that is kept in the state after git_source is removed, is cleared, so that
+// notebooks are taken from the workspace.
+func (js *JobSettings) adjustNotebookSource() {
+	notebookTasks := []*NotebookTask{js.NotebookTask}
+	for i := range js.Tasks {
+		notebookTasks = append(notebookTasks, js.Tasks[i].NotebookTask)
+	}
+	for _, nt := range notebookTasks {
+		if nt == nil {
+			continue
+		}
+		if js.GitSource != nil && nt.Source == "" {
+			nt.Source = NotebookSourceGit
+		}
+		if js.GitSource == nil && nt.Source == NotebookSourceGit {
+			nt.Source = ""
+		}
+	}
+}
+
+// suppressDefaultNotebookSource hides source returned by the API, when it's not configured and matches
+// presence of git_source in configuration, so that adding git_source derives the default source again.
+// Removal of git_source is handled by adjustNotebookSource, as removed blocks are still read from the state.
+func suppressDefaultNotebookSource(k, old, new string, d *schema.ResourceData) bool {
+	if new != "" {
+		return false
+	}
+	if len(d.Get("git_source").([]interface{})) > 0 {
+		return old == NotebookSourceGit
+	}
+	return old == NotebookSourceWorkspace
+}
+
+// Create creates a job on the workspace given the job settings. Jobs with tasks are created
+// with version 2.1 of Jobs API.
 func (a JobsAPI) Create(jobSettings JobSettings) (Job, error) {
 	var job Job
+	jobSettings.adjustNotebookSource()
+	if len(jobSettings.Tasks) > 0 {
+		jobSettings.Format = JobFormatMultiTask
+		err := a.client.API21(a.context, http.MethodPost, "/jobs/create", jobSettings, &job)
+		return job, wrapRunAsError(err, jobSettings.RunAs)
+	}
+	jobSettings.Format = ""
 	err := a.client.Post(a.context, "/jobs/create", jobSettings, &job)
-	return job, err
+	return job, wrapRunAsError(err, jobSettings.RunAs)
 }
 
 // Update updates a job given the id and a new set of job settings
@@ -38,10 +87,21 @@ func (a JobsAPI) Update(id string, jobSettings JobSettings) error {
 	if err != nil {
 		return err
 	}
-	return wrapMissingJobError(a.client.Post(a.context, "/jobs/reset", UpdateJobRequest{
+	jobSettings.adjustNotebookSource()
+	if len(jobSettings.Tasks) > 0 {
+		jobSettings.Format = JobFormatMultiTask
+		err = a.client.API21(a.context, http.MethodPost, "/jobs/reset", UpdateJobRequest{
+			JobID:       jobID,
+			NewSettings: &jobSettings,
+		}, nil)
+		return wrapMissingJobError(wrapRunAsError(err, jobSettings.RunAs), id)
+	}
+	jobSettings.Format = ""
+	err = a.client.Post(a.context, "/jobs/reset", UpdateJobRequest{
 		JobID:       jobID,
 		NewSettings: &jobSettings,
-	}, nil), id)
+	}, nil)
+	return wrapMissingJobError(wrapRunAsError(err, jobSettings.RunAs), id)
 }
 
 // Read returns the job object with all the attributes
@@ -56,6 +116,76 @@ func (a JobsAPI) Read(id string) (job Job, err error) {
 	return
 }
 
+// UpdateSchedule changes only the schedule of a job, so that the rest of job settings are kept as is
+func (a JobsAPI) UpdateSchedule(id string, jobSettings JobSettings) error {
+	jobID, err := strconv.ParseInt(id, 10, 32)
+	if err != nil {
+		return err
+	}
+	request := UpdateJobRequest{
+		JobID: jobID,
+		NewSettings: &JobSettings{
+			Schedule: jobSettings.Schedule,
+		},
+	}
+	if len(jobSettings.Tasks) > 0 {
+		return wrapMissingJobError(a.client.API21(a.context, http.MethodPost, "/jobs/update", request, nil), id)
+	}
+	return wrapMissingJobError(a.client.Post(a.context, "/jobs/update", request, nil), id)
+}
+
+// ReadMultiTask returns the job object with tasks, that are only available in version 2.1 of Jobs API
+func (a JobsAPI) ReadMultiTask(id string) (job Job, err error) {
+	jobID, err := strconv.ParseInt(id, 10, 32)
+	if err != nil {
+		return
+	}
+	err = wrapMissingJobError(a.client.API21(a.context, http.MethodGet, "/jobs/get", map[string]int64{
+		"job_id": jobID,
+	}, &job), id)
+	return
+}
+
+// jobsPageLimit is the maximum number of jobs, that API returns in a single page
+const jobsPageLimit = 25
+
+// visitJobs requests jobs page by page and passes them to the visitor, so that workspaces with thousands
+// of jobs are not loaded into memory at once. Pages are requested with tokens instead of offsets, so jobs,
+// that are deleted between pages, don't shift remaining jobs into pages, that were already read.
+func (a JobsAPI) visitJobs(request JobListRequest, visit func(job Job)) error {
+	request.Limit = jobsPageLimit
+	visited := map[int64]bool{}
+	for {
+		var page JobList
+		err := a.client.API21(a.context, http.MethodGet, "/jobs/list", request, &page)
+		if err != nil {
+			return err
+		}
+		for _, job := range page.Jobs {
+			if visited[job.JobID] || job.Settings == nil {
+				continue
+			}
+			visited[job.JobID] = true
+			visit(job)
+		}
+		if !page.HasMore || page.NextPageToken == "" {
+			return nil
+		}
+		request.PageToken = page.NextPageToken
+	}
+}
+
+// ListByName returns jobs with exactly the given name
+func (a JobsAPI) ListByName(name string) (jobs []Job, err error) {
+	err = a.visitJobs(JobListRequest{Name: name}, func(job Job) {
+		// name filter may be ignored by older API versions
+		if job.Settings.Name == name {
+			jobs = append(jobs, job)
+		}
+	})
+	return
+}
+
 // Delete deletes the job given a job id
 func (a JobsAPI) Delete(id string) error {
 	jobID, err := strconv.ParseInt(id, 10, 32)
@@ -67,6 +197,136 @@ func (a JobsAPI) Delete(id string) error {
 	}, nil), id)
 }
 
+// ActiveRuns returns runs of the job, that are not yet finished
+func (a JobsAPI) ActiveRuns(jobID int64) (runs []JobRun, err error) {
+	request := JobRunsListRequest{
+		JobID:      jobID,
+		ActiveOnly: true,
+		Limit:      jobsPageLimit,
+	}
+	for {
+		var page JobRunsList
+		err = a.client.Get(a.context, "/jobs/runs/list", request, &page)
+		if err != nil {
+			return
+		}
+		runs = append(runs, page.Runs...)
+		if !page.HasMore || len(page.Runs) == 0 {
+			return
+		}
+		request.Offset += len(page.Runs)
+	}
+}
+
+// RunNow triggers a new run of the job and returns its ID
+func (a JobsAPI) RunNow(jobID int64) (int64, error) {
+	var run JobRun
+	err := a.client.Post(a.context, "/jobs/run-now", map[string]int64{
+		"job_id": jobID,
+	}, &run)
+	return run.RunID, err
+}
+
+// CancelRun cancels the run and waits until it is finished
+func (a JobsAPI) CancelRun(runID int64, timeout time.Duration) error {
+	err := a.client.Post(a.context, "/jobs/runs/cancel", map[string]int64{
+		"run_id": runID,
+	}, nil)
+	if err != nil {
+		return err
+	}
+	return a.waitForRunState(runID, timeout, func(state RunState) (bool, error) {
+		return state.isTerminal(), nil
+	})
+}
+
+// waitForRunState polls the run until check tells that it reached the desired state or returns an error
+func (a JobsAPI) waitForRunState(runID int64, timeout time.Duration,
+	check func(state RunState) (bool, error)) error {
+	return resource.RetryContext(a.context, timeout, func() *resource.RetryError {
+		var run JobRun
+		err := a.client.Get(a.context, "/jobs/runs/get", map[string]int64{
+			"run_id": runID,
+		}, &run)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		done, err := check(run.State)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if !done {
+			return resource.RetryableError(fmt.Errorf("Run %d is %s", runID, run.State.LifeCycleState))
+		}
+		return nil
+	})
+}
+
+// Restart cancels active runs of the job and triggers a new one, which is awaited to be running, so that
+// always running jobs, e.g. streaming, pick up the latest settings. New run is not triggered, if it would
+// exceed max concurrent runs of the job.
+func (a JobsAPI) Restart(id string, maxConcurrentRuns int32, timeout time.Duration) error {
+	jobID, err := strconv.ParseInt(id, 10, 32)
+	if err != nil {
+		return err
+	}
+	if err = a.cancelActiveRuns(jobID, timeout); err != nil {
+		return err
+	}
+	// runs may be triggered by schedule or by other users while previous ones are cancelled
+	active, err := a.ActiveRuns(jobID)
+	if err != nil {
+		return err
+	}
+	if maxConcurrentRuns < 1 {
+		maxConcurrentRuns = 1
+	}
+	if len(active) >= int(maxConcurrentRuns) {
+		return fmt.Errorf("Cannot start job %s, because it has %d active runs, which reach max_concurrent_runs of %d",
+			id, len(active), maxConcurrentRuns)
+	}
+	runID, err := a.RunNow(jobID)
+	if err != nil {
+		return err
+	}
+	return a.waitForRunState(runID, timeout, func(state RunState) (bool, error) {
+		if state.isTerminal() {
+			return false, fmt.Errorf("Run %d of job %s is %s: %s",
+				runID, id, state.LifeCycleState, state.StateMessage)
+		}
+		return state.LifeCycleState == RunLifeCycleStateRunning, nil
+	})
+}
+
+func (a JobsAPI) cancelActiveRuns(jobID int64, timeout time.Duration) error {
+	runs, err := a.ActiveRuns(jobID)
+	if err != nil {
+		return err
+	}
+	for _, run := range runs {
+		log.Printf("[INFO] Cancelling run %d of job %d", run.RunID, jobID)
+		if err = a.CancelRun(run.RunID, timeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wrapRunAsError explains, why job cannot be created or updated with run_as, because API error
+// doesn't mention it
+func wrapRunAsError(err error, runAs *JobRunAs) error {
+	if err == nil || runAs == nil {
+		return err
+	}
+	apiErr, ok := err.(common.APIError)
+	if !ok || (apiErr.StatusCode != http.StatusForbidden && apiErr.ErrorCode != "PERMISSION_DENIED") {
+		return err
+	}
+	return fmt.Errorf("Cannot run job as %s: %s. Only workspace admins can run jobs as other users. "+
+		"Other users can run jobs only as themselves or as service principals, on which they have "+
+		"Service Principal User role", runAs, apiErr.Message)
+}
+
 func wrapMissingJobError(err error, id string) error {
 	if err == nil {
 		return nil
@@ -87,6 +347,202 @@ func wrapMissingJobError(err error, id string) error {
 	return err
 }
 
+// legacyTaskAttributes are top-level attributes of single-task jobs, that cannot be used with tasks
+var legacyTaskAttributes = []string{"existing_cluster_id", "new_cluster", "notebook_task",
+	"spark_jar_task", "spark_python_task", "spark_submit_task", "python_wheel_task", "library"}
+
+// taskTypes are mutually exclusive blocks of job or its task, that tell what it runs
+var taskTypes = []string{"notebook_task", "spark_jar_task", "spark_python_task",
+	"spark_submit_task", "python_wheel_task"}
+
+// multiTaskTypes are only available for tasks of multi-task jobs
+var multiTaskTypes = append(taskTypes, "dbt_task")
+
+// configuredOrder returns less function, that keeps configured values in the same order as in configuration.
+// Values, that are not configured, e.g. added in UI, go last.
+func configuredOrder(configured []string) func(a, b string) bool {
+	order := map[string]int{}
+	for i, v := range configured {
+		order[v] = i
+	}
+	return func(a, b string) bool {
+		i, aConfigured := order[a]
+		j, bConfigured := order[b]
+		if aConfigured && bConfigured {
+			return i < j
+		}
+		if aConfigured != bConfigured {
+			return aConfigured
+		}
+		return a < b
+	}
+}
+
+// sortTasks keeps tasks in the same order as they are configured, because API returns them sorted
+// by task key.
+func (js *JobSettings) sortTasks(configured []JobTaskSettings) {
+	keys := []string{}
+	for _, task := range configured {
+		keys = append(keys, task.TaskKey)
+	}
+	less := configuredOrder(keys)
+	sort.SliceStable(js.Tasks, func(i, j int) bool {
+		return less(js.Tasks[i].TaskKey, js.Tasks[j].TaskKey)
+	})
+}
+
+func sortEmails(emails, configured []string) {
+	less := configuredOrder(configured)
+	sort.SliceStable(emails, func(i, j int) bool {
+		return less(emails[i], emails[j])
+	})
+}
+
+func sortWebhooks(webhooks, configured []Webhook) {
+	ids := []string{}
+	for _, w := range configured {
+		ids = append(ids, w.ID)
+	}
+	less := configuredOrder(ids)
+	sort.SliceStable(webhooks, func(i, j int) bool {
+		return less(webhooks[i].ID, webhooks[j].ID)
+	})
+}
+
+func (n *JobEmailNotifications) sortLike(configured *JobEmailNotifications) {
+	if n == nil || configured == nil {
+		return
+	}
+	sortEmails(n.OnStart, configured.OnStart)
+	sortEmails(n.OnSuccess, configured.OnSuccess)
+	sortEmails(n.OnFailure, configured.OnFailure)
+}
+
+func (n *WebhookNotifications) sortLike(configured *WebhookNotifications) {
+	if n == nil || configured == nil {
+		return
+	}
+	sortWebhooks(n.OnStart, configured.OnStart)
+	sortWebhooks(n.OnSuccess, configured.OnSuccess)
+	sortWebhooks(n.OnFailure, configured.OnFailure)
+}
+
+// sortNotifications keeps email addresses and webhooks of the job and its tasks in the configured order,
+// because API doesn't preserve it. Tasks have to be sorted before.
+func (js *JobSettings) sortNotifications(configured JobSettings) {
+	js.EmailNotifications.sortLike(configured.EmailNotifications)
+	js.WebhookNotifications.sortLike(configured.WebhookNotifications)
+	tasks := map[string]JobTaskSettings{}
+	for _, task := range configured.Tasks {
+		tasks[task.TaskKey] = task
+	}
+	for i := range js.Tasks {
+		task := tasks[js.Tasks[i].TaskKey]
+		js.Tasks[i].EmailNotifications.sortLike(task.EmailNotifications)
+		js.Tasks[i].WebhookNotifications.sortLike(task.WebhookNotifications)
+	}
+}
+
+// readJob reads job with version of Jobs API matching its format. Multi-task jobs cannot be read
+// into configuration with top-level task attributes, so it fails instead of losing the tasks.
+func readJob(jobsAPI JobsAPI, d *schema.ResourceData) (job Job, err error) {
+	if len(d.Get("task").([]interface{})) > 0 {
+		return jobsAPI.ReadMultiTask(d.Id())
+	}
+	job, err = jobsAPI.Read(d.Id())
+	if err != nil || !job.Settings.isMultiTask() {
+		return
+	}
+	configured := []string{}
+	for _, attr := range legacyTaskAttributes {
+		if _, ok := d.GetOk(attr); ok {
+			configured = append(configured, attr)
+		}
+	}
+	if len(configured) > 0 {
+		err = fmt.Errorf("Job %s has multiple tasks, which cannot be represented with %s. "+
+			"Please replace them with task blocks", d.Id(), strings.Join(configured, ", "))
+		return
+	}
+	return jobsAPI.ReadMultiTask(d.Id())
+}
+
+// defaultPysparkPython is added to spark_env_vars of every cluster, that doesn't specify it
+const defaultPysparkPython = "/databricks/python3/bin/python3"
+
+// isJobImported tells if the job is read right after import, when its state has nothing but the ID.
+// Every job has either a cluster or a task, so it's enough to check for their absence.
+func isJobImported(d *schema.ResourceData) bool {
+	for _, attr := range append([]string{"task", "job_cluster"}, legacyTaskAttributes...) {
+		if _, ok := d.GetOk(attr); ok {
+			return false
+		}
+	}
+	return true
+}
+
+// skipServerDefaults removes values, that the API returns for attributes not specified on cluster
+// creation, so that the cluster doesn't differ from the configuration without them
+func (c *Cluster) skipServerDefaults() {
+	if c == nil {
+		return
+	}
+	if c.SparkEnvVars["PYSPARK_PYTHON"] == defaultPysparkPython {
+		delete(c.SparkEnvVars, "PYSPARK_PYTHON")
+	}
+	if aws := c.AwsAttributes; aws != nil && aws.InstanceProfileArn == "" &&
+		aws.Availability == AwsAvailabilitySpotWithFallback && aws.FirstOnDemand <= 1 &&
+		aws.SpotBidPricePercent == 100 && aws.EbsVolumeCount == 0 {
+		// zone is chosen by the server, if not specified
+		c.AwsAttributes = nil
+	}
+	if azure := c.AzureAttributes; azure != nil && azure.Availability == AzureAvailabilityOnDemand &&
+		azure.FirstOnDemand <= 1 && azure.SpotBidMaxPrice == -1 {
+		c.AzureAttributes = nil
+	}
+}
+
+// skipServerDefaults removes values, that the API returns for attributes not specified on job
+// creation. Otherwise imported job would differ from configuration, that matches the Jobs UI.
+func (js *JobSettings) skipServerDefaults(creatorUserName string) {
+	js.NewCluster.skipServerDefaults()
+	for i := range js.Tasks {
+		js.Tasks[i].NewCluster.skipServerDefaults()
+	}
+	for i := range js.JobClusters {
+		js.JobClusters[i].NewCluster.skipServerDefaults()
+	}
+	if js.MaxConcurrentRuns == 1 {
+		js.MaxConcurrentRuns = 0
+	}
+	if js.EmailNotifications != nil && reflect.DeepEqual(*js.EmailNotifications, JobEmailNotifications{}) {
+		js.EmailNotifications = nil
+	}
+	if js.WebhookNotifications != nil && reflect.DeepEqual(*js.WebhookNotifications, WebhookNotifications{}) {
+		js.WebhookNotifications = nil
+	}
+	if js.RunAs != nil && js.RunAs.UserName == creatorUserName && js.RunAs.ServicePrincipalName == "" {
+		js.RunAs = nil
+	}
+}
+
+// gitProviders are supported by git_source of the job
+var gitProviders = []string{"gitHub", "gitHubEnterprise", "bitbucketCloud", "bitbucketServer",
+	"gitLab", "gitLabEnterpriseEdition", "azureDevOpsServices", "awsCodeCommit"}
+
+// hasOnlyPauseStatusChanged tells if schedule could be paused or unpaused without resetting job settings
+func hasOnlyPauseStatusChanged(d *schema.ResourceData) bool {
+	for k := range jobSchema {
+		if k != "schedule" && d.HasChange(k) {
+			return false
+		}
+	}
+	return d.HasChange("schedule.0.pause_status") &&
+		!d.HasChange("schedule.0.quartz_cron_expression") &&
+		!d.HasChange("schedule.0.timezone_id") &&
+		len(d.Get("schedule").([]interface{})) > 0
+}
+
 var jobSchema = internal.StructToSchema(JobSettings{},
 	func(s map[string]*schema.Schema) map[string]*schema.Schema {
 		s["existing_cluster_id"].Description = "If existing_cluster_id, the ID " +
@@ -113,6 +569,8 @@ var jobSchema = internal.StructToSchema(JobSettings{},
 		s["email_notifications"].Description = "An optional set of email addresses " +
 			"notified when runs of this job begin and complete and when this job is " +
 			"deleted. The default behavior is to not send any emails."
+		s["webhook_notifications"].Description = "An optional set of notification destinations, " +
+			"e.g. PagerDuty or Slack, that are called when runs of this job begin and complete."
 		s["timeout_seconds"].Description = "An optional timeout applied to each run " +
 			"of this job. The default behavior is to have no timeout."
 		s["max_retries"].Description = "An optional maximum number of times to retry " +
@@ -130,12 +588,226 @@ var jobSchema = internal.StructToSchema(JobSettings{},
 			"Run Now in the Jobs UI or sending an API request to runNow."
 		s["max_concurrent_runs"].Description = "An optional maximum allowed number of " +
 			"concurrent runs of the job."
+		if v, err := internal.SchemaPath(s, "schedule", "pause_status"); err == nil {
+			v.ValidateFunc = validation.StringInSlice([]string{SchedulePaused, ScheduleUnpaused}, false)
+		}
+		s["git_source"].Description = "Remote Git repository, from which notebooks of the job are taken. " +
+			"Notebook paths are relative to the root of the repository."
+		gitRefs := []string{"git_source.0.branch", "git_source.0.tag", "git_source.0.commit"}
+		for _, ref := range []string{"branch", "tag", "commit"} {
+			if v, err := internal.SchemaPath(s, "git_source", ref); err == nil {
+				v.ExactlyOneOf = gitRefs
+			}
+		}
+		if v, err := internal.SchemaPath(s, "git_source", "git_provider"); err == nil {
+			v.ValidateFunc = validation.StringInSlice(gitProviders, true)
+			v.DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+				return strings.EqualFold(old, new)
+			}
+		}
+		notebookSources := []string{NotebookSourceWorkspace, NotebookSourceGit}
+		if v, err := internal.SchemaPath(s, "notebook_task", "source"); err == nil {
+			v.ValidateFunc = validation.StringInSlice(notebookSources, false)
+			v.DiffSuppressFunc = suppressDefaultNotebookSource
+		}
+		if v, err := internal.SchemaPath(s, "task", "notebook_task", "source"); err == nil {
+			v.ValidateFunc = validation.StringInSlice(notebookSources, false)
+			v.DiffSuppressFunc = suppressDefaultNotebookSource
+		}
+		s["run_as"].Description = "Identity, which runs of the job are executed with. " +
+			"Jobs run as their creator by default."
+		runAsIdentities := []string{"run_as.0.user_name", "run_as.0.service_principal_name"}
+		for _, identity := range []string{"user_name", "service_principal_name"} {
+			if v, err := internal.SchemaPath(s, "run_as", identity); err == nil {
+				v.ExactlyOneOf = runAsIdentities
+			}
+		}
+		s["run_as_user_name"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "User name or application ID of service principal, which runs of the job are executed with.",
+		}
+		s["queue"].Description = "Queue runs, that exceed max_concurrent_runs, instead of skipping them."
+		s["always_running"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			Description: "Restart the job after every create or update, so that the only run " +
+				"always uses the latest settings. Active runs are cancelled before the job is deleted.",
+		}
+		for _, path := range [][]string{{"new_cluster"}, {"task", "new_cluster"}, {"job_cluster", "new_cluster"}} {
+			if v, err := internal.SchemaPath(s, path...); err == nil {
+				customizeClusterSchema(v.Elem.(*schema.Resource).Schema)
+			}
+		}
+		s["task"].Description = "Tasks of multi-task job, that are run according to " +
+			"their dependencies. Conflicts with top-level task and cluster attributes."
+		s["task"].ConflictsWith = legacyTaskAttributes
+		s["job_cluster"].Description = "Cluster definitions, that can be shared by tasks " +
+			"of multi-task job through job_cluster_key."
+		s["job_cluster"].ConflictsWith = legacyTaskAttributes
 		return s
 	})
 
+// checkJobClusterKeys makes sure, that tasks reference only job clusters defined in the same job
+func checkJobClusterKeys(d *schema.ResourceDiff) error {
+	defined := map[string]bool{}
+	for i, v := range d.Get("job_cluster").([]interface{}) {
+		jc, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if !d.NewValueKnown(fmt.Sprintf("job_cluster.%d.job_cluster_key", i)) {
+			// keys are not known until apply
+			return nil
+		}
+		defined[jc["job_cluster_key"].(string)] = true
+	}
+	for _, v := range d.Get("task").([]interface{}) {
+		task, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		key := task["job_cluster_key"].(string)
+		if key != "" && !defined[key] {
+			return fmt.Errorf("Task %s uses job_cluster_key %s, which is not defined in job_cluster blocks",
+				task["task_key"], key)
+		}
+	}
+	return nil
+}
+
+// checkTaskType makes sure, that job or its task, addressed by prefix, runs only one type of task
+func checkTaskType(d *schema.ResourceDiff, prefix, name string, types []string) error {
+	configured := []string{}
+	for _, taskType := range types {
+		if v, ok := d.GetOk(prefix + taskType); ok && len(v.([]interface{})) > 0 {
+			configured = append(configured, taskType)
+		}
+	}
+	if len(configured) > 1 {
+		return fmt.Errorf("%s must have only one of %s, but has %s",
+			name, strings.Join(types, ", "), strings.Join(configured, " and "))
+	}
+	_, hasParameters := d.GetOk(prefix + "python_wheel_task.0.parameters")
+	_, hasNamedParameters := d.GetOk(prefix + "python_wheel_task.0.named_parameters")
+	if hasParameters && hasNamedParameters {
+		return fmt.Errorf("python_wheel_task of %s must have either parameters or named_parameters", name)
+	}
+	return nil
+}
+
+// wheelFileName normalizes the package name the same way as it is done in the file name of the wheel
+func wheelFileName(packageName string) string {
+	return strings.NewReplacer("-", "_", ".", "_").Replace(strings.ToLower(packageName)) + "-"
+}
+
+// hasWheel tells if the package is among libraries as either wheel file or package from PyPI
+func hasWheel(libraries []interface{}, packageName string) bool {
+	for _, v := range libraries {
+		library, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		whl, _ := library["whl"].(string)
+		fileName := strings.ToLower(whl[strings.LastIndex(whl, "/")+1:])
+		if whl != "" && strings.HasPrefix(fileName, wheelFileName(packageName)) {
+			return true
+		}
+		pypis, _ := library["pypi"].([]interface{})
+		for _, p := range pypis {
+			pypi, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name := strings.FieldsFunc(pypi["package"].(string), func(r rune) bool {
+				return strings.ContainsRune("=<>~![ ", r)
+			})
+			if len(name) > 0 && wheelFileName(name[0]) == wheelFileName(packageName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// missingWheelWarnings warns, if python_wheel_task runs a package, that is not among libraries. Libraries
+// could be already installed on existing cluster, so it is not an error.
+func missingWheelWarnings(d *schema.ResourceData) (diags diag.Diagnostics) {
+	check := func(prefix, name string) {
+		packageName, ok := d.GetOk(prefix + "python_wheel_task.0.package_name")
+		if !ok {
+			return
+		}
+		libraries := d.Get(prefix + "library").(*schema.Set).List()
+		if hasWheel(libraries, packageName.(string)) {
+			return
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary: fmt.Sprintf("Package %s of python_wheel_task of %s is not in library blocks",
+				packageName, name),
+			Detail: "Job fails, unless the package is already installed on the cluster",
+		})
+	}
+	check("", "job")
+	for i, v := range d.Get("task").([]interface{}) {
+		task, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		check(fmt.Sprintf("task.%d.", i), fmt.Sprintf("task %s", task["task_key"]))
+	}
+	return
+}
+
+// checkDbtWarehouse makes sure, that dbt_task of the task, addressed by prefix, runs on SQL endpoint
+func checkDbtWarehouse(ctx context.Context, d *schema.ResourceDiff, c interface{}, prefix, name string) error {
+	key := prefix + "dbt_task.0.warehouse_id"
+	warehouseID, ok := d.GetOk(key)
+	if !ok || !d.NewValueKnown(key) {
+		return nil
+	}
+	err := c.(*common.DatabricksClient).Get(ctx, "/sql/endpoints/"+warehouseID.(string), nil, nil)
+	if apiErr, ok := err.(common.APIError); ok && apiErr.IsMissing() {
+		return fmt.Errorf("warehouse_id %s of %s is not a SQL endpoint", warehouseID, name)
+	}
+	return err
+}
+
+// checkTaskTypes validates task types of the job and all of its tasks
+func checkTaskTypes(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+	if err := checkTaskType(d, "", "job", taskTypes); err != nil {
+		return err
+	}
+	for i, v := range d.Get("task").([]interface{}) {
+		task, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		prefix := fmt.Sprintf("task.%d.", i)
+		name := fmt.Sprintf("task %s", task["task_key"])
+		if err := checkTaskType(d, prefix, name, multiTaskTypes); err != nil {
+			return err
+		}
+		if err := checkDbtWarehouse(ctx, d, c, prefix, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restartAlwaysRunning restarts the job, if it has always_running flag
+func restartAlwaysRunning(jobsAPI JobsAPI, d *schema.ResourceData, timeout time.Duration) error {
+	if !d.Get("always_running").(bool) {
+		return nil
+	}
+	return jobsAPI.Restart(d.Id(), int32(d.Get("max_concurrent_runs").(int)), timeout)
+}
+
 // ResourceJob ...
 func ResourceJob() *schema.Resource {
-	return util.CommonResource{
+	r := util.CommonResource{
 		Schema:        jobSchema,
 		SchemaVersion: 2,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -144,18 +816,36 @@ func ResourceJob() *schema.Resource {
 			if err != nil {
 				return err
 			}
-			job, err := NewJobsAPI(ctx, c).Create(js)
+			jobsAPI := NewJobsAPI(ctx, c)
+			job, err := jobsAPI.Create(js)
 			if err != nil {
 				return err
 			}
 			d.SetId(job.ID())
-			return nil
+			return restartAlwaysRunning(jobsAPI, d, d.Timeout(schema.TimeoutCreate))
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			job, err := NewJobsAPI(ctx, c).Read(d.Id())
+			job, err := readJob(NewJobsAPI(ctx, c), d)
 			if err != nil {
 				return err
 			}
+			var configured JobSettings
+			if err = internal.DataToStructPointer(d, jobSchema, &configured); err != nil {
+				return err
+			}
+			if !d.IsNewResource() && isJobImported(d) {
+				// everything, but server defaults, has to be read into the state of imported job
+				job.Settings.skipServerDefaults(job.CreatorUserName)
+				d.MarkNewResource()
+				if err = d.Set("always_running", false); err != nil {
+					return err
+				}
+			}
+			job.Settings.sortTasks(configured.Tasks)
+			job.Settings.sortNotifications(configured)
+			if err = d.Set("run_as_user_name", job.RunAsUserName); err != nil {
+				return err
+			}
 			return internal.StructToData(*job.Settings, jobSchema, d)
 		},
 		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -164,10 +854,56 @@ func ResourceJob() *schema.Resource {
 			if err != nil {
 				return err
 			}
-			return NewJobsAPI(ctx, c).Update(d.Id(), js)
+			jobsAPI := NewJobsAPI(ctx, c)
+			if hasOnlyPauseStatusChanged(d) {
+				return jobsAPI.UpdateSchedule(d.Id(), js)
+			}
+			if err = jobsAPI.Update(d.Id(), js); err != nil {
+				return err
+			}
+			return restartAlwaysRunning(jobsAPI, d, d.Timeout(schema.TimeoutUpdate))
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			if err := checkTaskTypes(ctx, d, c); err != nil {
+				return err
+			}
+			return checkJobClusterKeys(d)
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
-			return NewJobsAPI(ctx, c).Delete(d.Id())
+			jobsAPI := NewJobsAPI(ctx, c)
+			if d.Get("always_running").(bool) {
+				jobID, err := strconv.ParseInt(d.Id(), 10, 32)
+				if err != nil {
+					return err
+				}
+				err = jobsAPI.cancelActiveRuns(jobID, d.Timeout(schema.TimeoutDelete))
+				if err != nil {
+					return err
+				}
+			}
+			return jobsAPI.Delete(d.Id())
 		},
 	}.ToResource()
+	r.Timeouts = &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(20 * time.Minute),
+		Update: schema.DefaultTimeout(20 * time.Minute),
+		Delete: schema.DefaultTimeout(20 * time.Minute),
+	}
+	// CustomizeDiff cannot return warnings, so missing wheels are reported once the job is applied
+	create, update := r.CreateContext, r.UpdateContext
+	r.CreateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		diags := create(ctx, d, m)
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, missingWheelWarnings(d)...)
+	}
+	r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		diags := update(ctx, d, m)
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, missingWheelWarnings(d)...)
+	}
+	return r
 }
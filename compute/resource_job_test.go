@@ -7,7 +7,12 @@ import (
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAwsAccJobsCreate(t *testing.T) {
@@ -248,6 +253,134 @@ func TestResourceJobRead(t *testing.T) {
 	assert.Equal(t, "abc", d.Get("existing_cluster_id"))
 }
 
+// importedJob is the response of Jobs API for a job, that was created in the UI
+// with only the name, cluster and notebook specified
+func importedJob() Job {
+	return Job{
+		JobID:           123,
+		CreatorUserName: "someone@example.com",
+		Settings: &JobSettings{
+			Name: "Nightly",
+			NewCluster: &Cluster{
+				SparkVersion:      "7.3.x-scala2.12",
+				NodeTypeID:        "i3.xlarge",
+				NumWorkers:        2,
+				EnableElasticDisk: true,
+				SparkConf:         map[string]string{},
+				SparkEnvVars: map[string]string{
+					"PYSPARK_PYTHON": "/databricks/python3/bin/python3",
+					"ENVIRONMENT":    "prod",
+				},
+				AwsAttributes: &AwsAttributes{
+					ZoneID:              "us-west-2a",
+					Availability:        AwsAvailabilitySpotWithFallback,
+					FirstOnDemand:       1,
+					SpotBidPricePercent: 100,
+				},
+			},
+			NotebookTask: &NotebookTask{
+				NotebookPath: "/Production/Nightly",
+			},
+			EmailNotifications: &JobEmailNotifications{},
+			RunAs: &JobRunAs{
+				UserName: "someone@example.com",
+			},
+			MaxConcurrentRuns: 1,
+		},
+	}
+}
+
+func TestResourceJobRead_ImportServerDefaults(t *testing.T) {
+	r := ResourceJob()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=123",
+				Response: importedJob(),
+			},
+		},
+		Resource: r,
+		Read:     true,
+		ID:       "123",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "Nightly", d.Get("name"))
+	assert.Equal(t, "prod", d.Get("new_cluster.0.spark_env_vars.ENVIRONMENT"))
+	assert.Len(t, d.Get("new_cluster.0.spark_env_vars"), 1)
+	assert.Equal(t, 0, d.Get("new_cluster.0.aws_attributes.#"))
+	assert.Equal(t, 0, d.Get("email_notifications.#"))
+	assert.Equal(t, 0, d.Get("run_as.#"))
+
+	planFor := func(config string) *terraform.InstanceDiff {
+		var out interface{}
+		require.NoError(t, hcl.Decode(&out, config))
+		diff, err := r.Diff(context.Background(), d.State(),
+			terraform.NewResourceConfigRaw(jobConfigFromHCL(out).(map[string]interface{})), nil)
+		require.NoError(t, err)
+		return diff
+	}
+	diff := planFor(`
+	name = "Nightly"
+	new_cluster {
+		spark_version = "7.3.x-scala2.12"
+		node_type_id = "i3.xlarge"
+		num_workers = 2
+		spark_env_vars = {
+			ENVIRONMENT = "prod"
+		}
+	}
+	notebook_task {
+		notebook_path = "/Production/Nightly"
+	}`)
+	assert.True(t, diff.Empty(), "plan after import is not empty: %v", diff)
+
+	diff = planFor(`
+	name = "Nightly"
+	new_cluster {
+		spark_version = "7.3.x-scala2.12"
+		node_type_id = "i3.xlarge"
+		num_workers = 4
+		spark_env_vars = {
+			ENVIRONMENT = "prod"
+		}
+	}
+	notebook_task {
+		notebook_path = "/Production/Nightly"
+	}`)
+	require.NotNil(t, diff)
+	assert.Len(t, diff.Attributes, 1)
+	assert.Equal(t, "4", diff.Attributes["new_cluster.0.num_workers"].New)
+}
+
+func TestResourceJobRead_KeepsConfiguredServerDefaults(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=123",
+				Response: importedJob(),
+			},
+		},
+		Resource: ResourceJob(),
+		Read:     true,
+		ID:       "123",
+		HCL: `
+		name = "Nightly"
+		max_concurrent_runs = 1
+		new_cluster {
+			spark_version = "7.3.x-scala2.12"
+			node_type_id = "i3.xlarge"
+			num_workers = 2
+		}
+		notebook_task {
+			notebook_path = "/Production/Nightly"
+		}`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, 1, d.Get("max_concurrent_runs"))
+}
+
 func TestResourceJobRead_NotFound(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -452,3 +585,1817 @@ func TestResourceJobDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "789", d.Id())
 }
+
+func multiTaskJobSettings() JobSettings {
+	return JobSettings{
+		Name: "DAG",
+		JobClusters: []JobCluster{
+			{
+				JobClusterKey: "shared",
+				NewCluster: &Cluster{
+					NumWorkers:   2,
+					SparkVersion: "7.3.x-scala2.12",
+					NodeTypeID:   "i3.xlarge",
+				},
+			},
+		},
+		Tasks: []JobTaskSettings{
+			{
+				TaskKey:       "ingest",
+				JobClusterKey: "shared",
+				NotebookTask: &NotebookTask{
+					NotebookPath: "/Stuff/Ingest",
+				},
+				MaxRetries: 1,
+			},
+			{
+				TaskKey: "transform",
+				DependsOn: []TaskDependency{
+					{TaskKey: "ingest"},
+				},
+				ExistingClusterID: "abc",
+				SparkJarTask: &SparkJarTask{
+					MainClassName: "com.labs.Transform",
+				},
+				Libraries: []Library{
+					{Jar: "dbfs://aa/bb/cc.jar"},
+				},
+				TimeoutSeconds: 3600,
+			},
+			{
+				TaskKey: "report",
+				DependsOn: []TaskDependency{
+					{TaskKey: "transform"},
+				},
+				NewCluster: &Cluster{
+					NumWorkers:   1,
+					SparkVersion: "7.3.x-scala2.12",
+					NodeTypeID:   "i3.xlarge",
+				},
+				PythonWheelTask: &PythonWheelTask{
+					PackageName: "reports",
+					EntryPoint:  "daily",
+				},
+			},
+		},
+	}
+}
+
+const multiTaskJobHCL = `name = "DAG"
+job_cluster {
+	job_cluster_key = "shared"
+	new_cluster {
+		num_workers = 2
+		spark_version = "7.3.x-scala2.12"
+		node_type_id = "i3.xlarge"
+	}
+}
+task {
+	task_key = "ingest"
+	job_cluster_key = "shared"
+	max_retries = 1
+	notebook_task {
+		notebook_path = "/Stuff/Ingest"
+	}
+}
+task {
+	task_key = "transform"
+	depends_on {
+		task_key = "ingest"
+	}
+	existing_cluster_id = "abc"
+	timeout_seconds = 3600
+	spark_jar_task {
+		main_class_name = "com.labs.Transform"
+	}
+	library {
+		jar = "dbfs://aa/bb/cc.jar"
+	}
+}
+task {
+	task_key = "report"
+	depends_on {
+		task_key = "transform"
+	}
+	new_cluster {
+		num_workers = 1
+		spark_version = "7.3.x-scala2.12"
+		node_type_id = "i3.xlarge"
+	}
+	python_wheel_task {
+		package_name = "reports"
+		entry_point = "daily"
+	}
+}`
+
+// API returns tasks sorted by their keys
+func multiTaskJobFromAPI() Job {
+	settings := multiTaskJobSettings()
+	settings.Format = JobFormatMultiTask
+	settings.Tasks = []JobTaskSettings{settings.Tasks[0], settings.Tasks[2], settings.Tasks[1]}
+	return Job{
+		JobID:    789,
+		Settings: &settings,
+	}
+}
+
+func TestResourceJobCreate_MultiTask(t *testing.T) {
+	expected := multiTaskJobSettings()
+	expected.Format = JobFormatMultiTask
+	r := ResourceJob()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:          "POST",
+				Resource:        "/api/2.1/jobs/create",
+				ExpectedRequest: expected,
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: multiTaskJobFromAPI(),
+			},
+		},
+		Create:   true,
+		Resource: r,
+		HCL:      multiTaskJobHCL,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, "MULTI_TASK", d.Get("format"))
+	assert.Equal(t, 3, d.Get("task.#"))
+	assert.Equal(t, "ingest", d.Get("task.0.task_key"))
+	assert.Equal(t, "transform", d.Get("task.1.task_key"))
+	assert.Equal(t, "ingest", d.Get("task.1.depends_on.0.task_key"))
+	assert.Equal(t, "report", d.Get("task.2.task_key"))
+	assert.Equal(t, "reports", d.Get("task.2.python_wheel_task.0.package_name"))
+
+	var out interface{}
+	require.NoError(t, hcl.Decode(&out, multiTaskJobHCL))
+	diff, err := r.Diff(context.Background(), d.State(),
+		terraform.NewResourceConfigRaw(jobConfigFromHCL(out).(map[string]interface{})), nil)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "plan is not stable: %v", diff)
+}
+
+// jobConfigFromHCL turns blocks decoded by HCL1 from lists of maps into lists of objects
+func jobConfigFromHCL(v interface{}) interface{} {
+	switch x := v.(type) {
+	case []map[string]interface{}:
+		list := []interface{}{}
+		for _, m := range x {
+			list = append(list, jobConfigFromHCL(m))
+		}
+		return list
+	case []interface{}:
+		list := []interface{}{}
+		for _, e := range x {
+			list = append(list, jobConfigFromHCL(e))
+		}
+		return list
+	case map[string]interface{}:
+		m := map[string]interface{}{}
+		for k, e := range x {
+			m[k] = jobConfigFromHCL(e)
+		}
+		return m
+	}
+	return v
+}
+
+func TestResourceJobUpdate_MultiTask(t *testing.T) {
+	expected := multiTaskJobSettings()
+	expected.Format = JobFormatMultiTask
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/reset",
+				ExpectedRequest: UpdateJobRequest{
+					JobID:       789,
+					NewSettings: &expected,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: multiTaskJobFromAPI(),
+			},
+		},
+		ID:       "789",
+		Update:   true,
+		Resource: ResourceJob(),
+		HCL:      multiTaskJobHCL,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "transform", d.Get("task.1.task_key"))
+}
+
+func TestResourceJobRead_ImportMultiTask(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name:   "DAG",
+						Format: JobFormatMultiTask,
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: multiTaskJobFromAPI(),
+			},
+		},
+		Resource: ResourceJob(),
+		Read:     true,
+		New:      true,
+		ID:       "789",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, 3, d.Get("task.#"))
+	assert.Equal(t, "ingest", d.Get("task.0.task_key"))
+	assert.Equal(t, "report", d.Get("task.1.task_key"))
+	assert.Equal(t, "transform", d.Get("task.2.task_key"))
+}
+
+func TestResourceJobRead_MultiTaskIntoLegacyConfig(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name:   "DAG",
+						Format: JobFormatMultiTask,
+					},
+				},
+			},
+		},
+		Resource: ResourceJob(),
+		Read:     true,
+		ID:       "789",
+		HCL: `name = "DAG"
+		existing_cluster_id = "abc"
+		notebook_task {
+			notebook_path = "/Stuff/Ingest"
+		}`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Job 789 has multiple tasks, which cannot be represented "+
+		"with existing_cluster_id, notebook_task. Please replace them with task blocks")
+}
+
+func TestResourceJobCreate_TaskConflictsWithLegacy(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "/Stuff/Ingest"
+			}
+		}`,
+	}.Apply(t)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicts with existing_cluster_id")
+}
+
+func TestResourceJobUpdate_OnlyPauseStatus(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/update",
+				ExpectedRequest: UpdateJobRequest{
+					JobID: 789,
+					NewSettings: &JobSettings{
+						Schedule: &CronSchedule{
+							QuartzCronExpression: "0 15 22 ? * *",
+							TimezoneID:           "America/Los_Angeles",
+							PauseStatus:          SchedulePaused,
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						ExistingClusterID: "abc",
+						Name:              "Featurizer",
+						MaxConcurrentRuns: 1,
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Stuff",
+						},
+						Schedule: &CronSchedule{
+							QuartzCronExpression: "0 15 22 ? * *",
+							TimezoneID:           "America/Los_Angeles",
+							PauseStatus:          SchedulePaused,
+						},
+					},
+				},
+			},
+		},
+		ID:       "789",
+		Update:   true,
+		Resource: ResourceJob(),
+		InstanceState: map[string]string{
+			"existing_cluster_id":               "abc",
+			"name":                              "Featurizer",
+			"max_concurrent_runs":               "1",
+			"notebook_task.#":                   "1",
+			"notebook_task.0.notebook_path":     "/Stuff",
+			"schedule.#":                        "1",
+			"schedule.0.quartz_cron_expression": "0 15 22 ? * *",
+			"schedule.0.timezone_id":            "America/Los_Angeles",
+			"schedule.0.pause_status":           ScheduleUnpaused,
+		},
+		HCL: `existing_cluster_id = "abc"
+		name = "Featurizer"
+		max_concurrent_runs = 1
+		notebook_task {
+			notebook_path = "/Stuff"
+		}
+		schedule {
+			quartz_cron_expression = "0 15 22 ? * *"
+			timezone_id = "America/Los_Angeles"
+			pause_status = "PAUSED"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, SchedulePaused, d.Get("schedule.0.pause_status"))
+}
+
+func TestResourceJobUpdate_PauseStatusWithOtherChanges(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/reset",
+				ExpectedRequest: UpdateJobRequest{
+					JobID: 789,
+					NewSettings: &JobSettings{
+						ExistingClusterID: "abc",
+						Name:              "Featurizer New",
+						MaxConcurrentRuns: 1,
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Stuff",
+						},
+						Schedule: &CronSchedule{
+							QuartzCronExpression: "0 15 22 ? * *",
+							TimezoneID:           "America/Los_Angeles",
+							PauseStatus:          SchedulePaused,
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						ExistingClusterID: "abc",
+						Name:              "Featurizer New",
+						MaxConcurrentRuns: 1,
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Stuff",
+						},
+						Schedule: &CronSchedule{
+							QuartzCronExpression: "0 15 22 ? * *",
+							TimezoneID:           "America/Los_Angeles",
+							PauseStatus:          SchedulePaused,
+						},
+					},
+				},
+			},
+		},
+		ID:       "789",
+		Update:   true,
+		Resource: ResourceJob(),
+		InstanceState: map[string]string{
+			"existing_cluster_id":               "abc",
+			"name":                              "Featurizer",
+			"max_concurrent_runs":               "1",
+			"notebook_task.#":                   "1",
+			"notebook_task.0.notebook_path":     "/Stuff",
+			"schedule.#":                        "1",
+			"schedule.0.quartz_cron_expression": "0 15 22 ? * *",
+			"schedule.0.timezone_id":            "America/Los_Angeles",
+			"schedule.0.pause_status":           ScheduleUnpaused,
+		},
+		HCL: `existing_cluster_id = "abc"
+		name = "Featurizer New"
+		max_concurrent_runs = 1
+		notebook_task {
+			notebook_path = "/Stuff"
+		}
+		schedule {
+			quartz_cron_expression = "0 15 22 ? * *"
+			timezone_id = "America/Los_Angeles"
+			pause_status = "PAUSED"
+		}`,
+	}.ApplyNoError(t)
+}
+
+func TestResourceJobCreate_InvalidPauseStatus(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceJob(),
+		Create:   true,
+		HCL: `existing_cluster_id = "abc"
+		notebook_task {
+			notebook_path = "/Stuff"
+		}
+		schedule {
+			quartz_cron_expression = "0 15 22 ? * *"
+			timezone_id = "America/Los_Angeles"
+			pause_status = "STOPPED"
+		}`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Invalid config supplied. [schedule.#.pause_status] expected schedule.0.pause_status to be one of [PAUSED UNPAUSED], got STOPPED")
+}
+
+func TestResourceJobCreate_Notifications(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/create",
+				ExpectedRequest: JobSettings{
+					ExistingClusterID: "abc",
+					NotebookTask: &NotebookTask{
+						NotebookPath: "/Stuff",
+					},
+					Name:              "Untitled",
+					MaxConcurrentRuns: 1,
+					EmailNotifications: &JobEmailNotifications{
+						OnFailure:             []string{"b@example.com", "a@example.com"},
+						NoAlertForSkippedRuns: true,
+					},
+					WebhookNotifications: &WebhookNotifications{
+						OnFailure: []Webhook{{ID: "pagerduty"}, {ID: "slack"}},
+					},
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						ExistingClusterID: "abc",
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Stuff",
+						},
+						MaxConcurrentRuns: 1,
+						EmailNotifications: &JobEmailNotifications{
+							OnFailure:             []string{"a@example.com", "b@example.com"},
+							NoAlertForSkippedRuns: true,
+						},
+						WebhookNotifications: &WebhookNotifications{
+							OnFailure: []Webhook{{ID: "slack"}, {ID: "pagerduty"}},
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		max_concurrent_runs = 1
+		notebook_task {
+			notebook_path = "/Stuff"
+		}
+		email_notifications {
+			on_failure = ["b@example.com", "a@example.com"]
+			no_alert_for_skipped_runs = true
+		}
+		webhook_notifications {
+			on_failure {
+				id = "pagerduty"
+			}
+			on_failure {
+				id = "slack"
+			}
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, "b@example.com", d.Get("email_notifications.0.on_failure.0"))
+	assert.Equal(t, "pagerduty", d.Get("webhook_notifications.0.on_failure.0.id"))
+	assert.Equal(t, "slack", d.Get("webhook_notifications.0.on_failure.1.id"))
+}
+
+func TestResourceJobSortNotifications_Tasks(t *testing.T) {
+	js := JobSettings{
+		Tasks: []JobTaskSettings{
+			{
+				TaskKey: "a",
+				EmailNotifications: &JobEmailNotifications{
+					OnStart: []string{"x@example.com", "z@example.com", "y@example.com"},
+				},
+				WebhookNotifications: &WebhookNotifications{
+					OnSuccess: []Webhook{{ID: "1"}, {ID: "2"}},
+				},
+			},
+			{
+				TaskKey: "b",
+			},
+		},
+	}
+	js.sortNotifications(JobSettings{
+		Tasks: []JobTaskSettings{
+			{
+				TaskKey: "a",
+				EmailNotifications: &JobEmailNotifications{
+					OnStart: []string{"y@example.com", "x@example.com"},
+				},
+				WebhookNotifications: &WebhookNotifications{
+					OnSuccess: []Webhook{{ID: "2"}, {ID: "1"}},
+				},
+			},
+		},
+	})
+	assert.Equal(t, []string{"y@example.com", "x@example.com", "z@example.com"},
+		js.Tasks[0].EmailNotifications.OnStart)
+	assert.Equal(t, []Webhook{{ID: "2"}, {ID: "1"}}, js.Tasks[0].WebhookNotifications.OnSuccess)
+}
+
+func TestResourceJobCreate_GitSource(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name:              "Untitled",
+					ExistingClusterID: "abc",
+					MaxConcurrentRuns: 1,
+					NotebookTask: &NotebookTask{
+						NotebookPath: "notebooks/etl",
+						Source:       NotebookSourceGit,
+					},
+					GitSource: &GitSource{
+						URL:      "https://github.com/example/etl",
+						Provider: "gitHub",
+						Tag:      "v1.2.0",
+					},
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name:              "Untitled",
+						ExistingClusterID: "abc",
+						MaxConcurrentRuns: 1,
+						NotebookTask: &NotebookTask{
+							NotebookPath: "notebooks/etl",
+							Source:       NotebookSourceGit,
+						},
+						GitSource: &GitSource{
+							URL:      "https://github.com/example/etl",
+							Provider: "gitHub",
+							Tag:      "v1.2.0",
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		max_concurrent_runs = 1
+		notebook_task {
+			notebook_path = "notebooks/etl"
+		}
+		git_source {
+			git_url = "https://github.com/example/etl"
+			git_provider = "gitHub"
+			tag = "v1.2.0"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, NotebookSourceGit, d.Get("notebook_task.0.source"))
+	assert.Equal(t, "v1.2.0", d.Get("git_source.0.tag"))
+}
+
+func TestResourceJobUpdate_FromGitSourceToWorkspace(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/reset",
+				ExpectedRequest: UpdateJobRequest{
+					JobID: 789,
+					NewSettings: &JobSettings{
+						Name:              "Untitled",
+						ExistingClusterID: "abc",
+						MaxConcurrentRuns: 1,
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Repos/etl/notebooks/etl",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name:              "Untitled",
+						ExistingClusterID: "abc",
+						MaxConcurrentRuns: 1,
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Repos/etl/notebooks/etl",
+							Source:       NotebookSourceWorkspace,
+						},
+					},
+				},
+			},
+		},
+		ID:       "789",
+		Update:   true,
+		Resource: ResourceJob(),
+		InstanceState: map[string]string{
+			"name":                          "Untitled",
+			"existing_cluster_id":           "abc",
+			"max_concurrent_runs":           "1",
+			"notebook_task.#":               "1",
+			"notebook_task.0.notebook_path": "notebooks/etl",
+			"notebook_task.0.source":        NotebookSourceGit,
+			"git_source.#":                  "1",
+			"git_source.0.git_url":          "https://github.com/example/etl",
+			"git_source.0.git_provider":     "gitHub",
+			"git_source.0.tag":              "v1.2.0",
+		},
+		HCL: `existing_cluster_id = "abc"
+		max_concurrent_runs = 1
+		notebook_task {
+			notebook_path = "/Repos/etl/notebooks/etl"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, NotebookSourceWorkspace, d.Get("notebook_task.0.source"))
+	assert.Equal(t, 0, d.Get("git_source.#"))
+}
+
+func TestResourceJobUpdate_FromWorkspaceToGitSource(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/reset",
+				ExpectedRequest: UpdateJobRequest{
+					JobID: 789,
+					NewSettings: &JobSettings{
+						Name:              "Untitled",
+						ExistingClusterID: "abc",
+						MaxConcurrentRuns: 1,
+						NotebookTask: &NotebookTask{
+							NotebookPath: "notebooks/etl",
+							Source:       NotebookSourceGit,
+						},
+						GitSource: &GitSource{
+							URL:      "https://github.com/example/etl",
+							Provider: "gitHub",
+							Tag:      "v1.2.0",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name:              "Untitled",
+						ExistingClusterID: "abc",
+						MaxConcurrentRuns: 1,
+						NotebookTask: &NotebookTask{
+							NotebookPath: "notebooks/etl",
+							Source:       NotebookSourceGit,
+						},
+						GitSource: &GitSource{
+							URL:      "https://github.com/example/etl",
+							Provider: "gitHub",
+							Tag:      "v1.2.0",
+						},
+					},
+				},
+			},
+		},
+		ID:       "789",
+		Update:   true,
+		Resource: ResourceJob(),
+		InstanceState: map[string]string{
+			"name":                          "Untitled",
+			"existing_cluster_id":           "abc",
+			"max_concurrent_runs":           "1",
+			"notebook_task.#":               "1",
+			"notebook_task.0.notebook_path": "notebooks/etl",
+			"notebook_task.0.source":        NotebookSourceWorkspace,
+		},
+		HCL: `existing_cluster_id = "abc"
+		max_concurrent_runs = 1
+		notebook_task {
+			notebook_path = "notebooks/etl"
+		}
+		git_source {
+			git_url = "https://github.com/example/etl"
+			git_provider = "gitHub"
+			tag = "v1.2.0"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, NotebookSourceGit, d.Get("notebook_task.0.source"))
+	assert.Equal(t, "v1.2.0", d.Get("git_source.0.tag"))
+}
+
+func TestResourceJobCreate_GitSourceWithBranchAndTag(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		notebook_task {
+			notebook_path = "notebooks/etl"
+		}
+		git_source {
+			git_url = "https://github.com/example/etl"
+			git_provider = "gitHub"
+			branch = "main"
+			tag = "v1.2.0"
+		}`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Invalid config supplied. [git_source.#.branch] ExactlyOne")
+}
+
+func TestResourceJobCreate_TaskNotebookInvalidSource(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `task {
+			task_key = "a"
+			existing_cluster_id = "abc"
+			notebook_task {
+				notebook_path = "notebooks/etl"
+				source = "S3"
+			}
+		}`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Invalid config supplied. [task.#.notebook_task.#.source]")
+}
+
+func TestResourceJobCreate_RunAs(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name:              "Untitled",
+					ExistingClusterID: "abc",
+					MaxConcurrentRuns: 1,
+					NotebookTask: &NotebookTask{
+						NotebookPath: "/Stuff",
+					},
+					RunAs: &JobRunAs{
+						ServicePrincipalName: "3f670caf-9a4b-4479-8143-1a0878da8f57",
+					},
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID:           789,
+					CreatorUserName: "ci@example.com",
+					RunAsUserName:   "3f670caf-9a4b-4479-8143-1a0878da8f57",
+					Settings: &JobSettings{
+						Name:              "Untitled",
+						ExistingClusterID: "abc",
+						MaxConcurrentRuns: 1,
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Stuff",
+						},
+						RunAs: &JobRunAs{
+							ServicePrincipalName: "3f670caf-9a4b-4479-8143-1a0878da8f57",
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		max_concurrent_runs = 1
+		notebook_task {
+			notebook_path = "/Stuff"
+		}
+		run_as {
+			service_principal_name = "3f670caf-9a4b-4479-8143-1a0878da8f57"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, "3f670caf-9a4b-4479-8143-1a0878da8f57", d.Get("run_as_user_name"))
+}
+
+func TestResourceJobUpdate_RunAs(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/reset",
+				ExpectedRequest: UpdateJobRequest{
+					JobID: 789,
+					NewSettings: &JobSettings{
+						Name:              "Untitled",
+						ExistingClusterID: "abc",
+						MaxConcurrentRuns: 1,
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Stuff",
+						},
+						RunAs: &JobRunAs{
+							UserName: "jane@example.com",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID:         789,
+					RunAsUserName: "jane@example.com",
+					Settings: &JobSettings{
+						Name:              "Untitled",
+						ExistingClusterID: "abc",
+						MaxConcurrentRuns: 1,
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Stuff",
+						},
+						RunAs: &JobRunAs{
+							UserName: "jane@example.com",
+						},
+					},
+				},
+			},
+		},
+		ID:       "789",
+		Update:   true,
+		Resource: ResourceJob(),
+		InstanceState: map[string]string{
+			"name":                            "Untitled",
+			"existing_cluster_id":             "abc",
+			"max_concurrent_runs":             "1",
+			"notebook_task.#":                 "1",
+			"notebook_task.0.notebook_path":   "/Stuff",
+			"run_as.#":                        "1",
+			"run_as.0.service_principal_name": "3f670caf-9a4b-4479-8143-1a0878da8f57",
+			"run_as_user_name":                "3f670caf-9a4b-4479-8143-1a0878da8f57",
+		},
+		HCL: `existing_cluster_id = "abc"
+		max_concurrent_runs = 1
+		notebook_task {
+			notebook_path = "/Stuff"
+		}
+		run_as {
+			user_name = "jane@example.com"
+		}`,
+	}.ApplyNoError(t)
+}
+
+func TestResourceJobCreate_RunAsPermissionDenied(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/create",
+				Response: common.APIErrorBody{
+					ErrorCode: "PERMISSION_DENIED",
+					Message:   "User ci@example.com is not authorized",
+				},
+				Status: 403,
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		notebook_task {
+			notebook_path = "/Stuff"
+		}
+		run_as {
+			user_name = "jane@example.com"
+		}`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Cannot run job as user jane@example.com: User ci@example.com is not authorized. "+
+		"Only workspace admins can run jobs as other users")
+}
+
+func TestResourceJobCreate_RunAsBothIdentities(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		notebook_task {
+			notebook_path = "/Stuff"
+		}
+		run_as {
+			user_name = "jane@example.com"
+			service_principal_name = "3f670caf-9a4b-4479-8143-1a0878da8f57"
+		}`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Invalid config supplied. [run_as.#.service_principal_name] ExactlyOne")
+}
+
+func TestResourceJobCreate_AlwaysRunning(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name:              "Untitled",
+					ExistingClusterID: "abc",
+					MaxConcurrentRuns: 1,
+					NotebookTask: &NotebookTask{
+						NotebookPath: "/Stream",
+					},
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/list?active_only=true&job_id=789&limit=25",
+				Response: JobRunsList{
+					Runs: []JobRun{
+						{
+							JobID: 789,
+							RunID: 1,
+							State: RunState{LifeCycleState: RunLifeCycleStateRunning},
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/runs/cancel",
+				ExpectedRequest: map[string]int64{
+					"run_id": 1,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/get?run_id=1",
+				Response: JobRun{
+					RunID: 1,
+					State: RunState{LifeCycleState: RunLifeCycleStateTerminated},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/list?active_only=true&job_id=789&limit=25",
+				Response: JobRunsList{},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/run-now",
+				ExpectedRequest: map[string]int64{
+					"job_id": 789,
+				},
+				Response: JobRun{
+					RunID: 2,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/get?run_id=2",
+				Response: JobRun{
+					RunID: 2,
+					State: RunState{LifeCycleState: RunLifeCycleStateRunning},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name:              "Untitled",
+						ExistingClusterID: "abc",
+						MaxConcurrentRuns: 1,
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Stream",
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		max_concurrent_runs = 1
+		always_running = true
+		notebook_task {
+			notebook_path = "/Stream"
+		}`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "789", d.Id())
+	assert.Equal(t, true, d.Get("always_running"))
+}
+
+func TestResourceJobUpdate_AlwaysRunningMaxConcurrentRuns(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/reset",
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/list?active_only=true&job_id=789&limit=25",
+				Response: JobRunsList{},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/list?active_only=true&job_id=789&limit=25",
+				Response: JobRunsList{
+					Runs: []JobRun{
+						{
+							JobID: 789,
+							RunID: 3,
+							State: RunState{LifeCycleState: RunLifeCycleStatePending},
+						},
+					},
+				},
+			},
+		},
+		ID:       "789",
+		Update:   true,
+		Resource: ResourceJob(),
+		InstanceState: map[string]string{
+			"name":                          "Untitled",
+			"existing_cluster_id":           "abc",
+			"max_concurrent_runs":           "1",
+			"always_running":                "true",
+			"notebook_task.#":               "1",
+			"notebook_task.0.notebook_path": "/Stream",
+		},
+		HCL: `existing_cluster_id = "abc"
+		max_concurrent_runs = 1
+		always_running = true
+		notebook_task {
+			notebook_path = "/Stream/v2"
+		}`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Cannot start job 789, because it has 1 active runs, which reach max_concurrent_runs of 1")
+}
+
+func TestResourceJobDelete_AlwaysRunning(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/list?active_only=true&job_id=789&limit=25",
+				Response: JobRunsList{
+					Runs: []JobRun{
+						{
+							JobID: 789,
+							RunID: 2,
+							State: RunState{LifeCycleState: RunLifeCycleStateRunning},
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/runs/cancel",
+				ExpectedRequest: map[string]int64{
+					"run_id": 2,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/runs/get?run_id=2",
+				Response: JobRun{
+					RunID: 2,
+					State: RunState{LifeCycleState: RunLifeCycleStateTerminated},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/delete",
+				ExpectedRequest: map[string]int64{
+					"job_id": 789,
+				},
+			},
+		},
+		ID:       "789",
+		Delete:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		always_running = true
+		notebook_task {
+			notebook_path = "/Stream"
+		}`,
+	}.ApplyNoError(t)
+}
+
+func TestResourceJobCreate_SharedJobCluster(t *testing.T) {
+	settings := JobSettings{
+		Name: "Shared",
+		JobClusters: []JobCluster{
+			{
+				JobClusterKey: "shared",
+				NewCluster: &Cluster{
+					NumWorkers:   2,
+					SparkVersion: "7.3.x-scala2.12",
+					NodeTypeID:   "i3.xlarge",
+					InitScripts: []InitScriptStorageInfo{
+						{
+							Dbfs: &DbfsStorageInfo{
+								Destination: "dbfs:/init/setup.sh",
+							},
+						},
+					},
+				},
+			},
+		},
+		Tasks: []JobTaskSettings{
+			{
+				TaskKey:       "ingest",
+				JobClusterKey: "shared",
+				NotebookTask: &NotebookTask{
+					NotebookPath: "/Stuff/Ingest",
+				},
+			},
+			{
+				TaskKey:       "transform",
+				JobClusterKey: "shared",
+				DependsOn: []TaskDependency{
+					{TaskKey: "ingest"},
+				},
+				NotebookTask: &NotebookTask{
+					NotebookPath: "/Stuff/Transform",
+				},
+			},
+			{
+				TaskKey:           "report",
+				ExistingClusterID: "abc",
+				DependsOn: []TaskDependency{
+					{TaskKey: "transform"},
+				},
+				NotebookTask: &NotebookTask{
+					NotebookPath: "/Stuff/Report",
+				},
+			},
+		},
+	}
+	hclConfig := `name = "Shared"
+	job_cluster {
+		job_cluster_key = "shared"
+		new_cluster {
+			num_workers = 2
+			spark_version = "7.3.x-scala2.12"
+			node_type_id = "i3.xlarge"
+			init_scripts {
+				dbfs {
+					destination = "/init/setup.sh"
+				}
+			}
+		}
+	}
+	task {
+		task_key = "ingest"
+		job_cluster_key = "shared"
+		notebook_task {
+			notebook_path = "/Stuff/Ingest"
+		}
+	}
+	task {
+		task_key = "transform"
+		job_cluster_key = "shared"
+		depends_on {
+			task_key = "ingest"
+		}
+		notebook_task {
+			notebook_path = "/Stuff/Transform"
+		}
+	}
+	task {
+		task_key = "report"
+		existing_cluster_id = "abc"
+		depends_on {
+			task_key = "transform"
+		}
+		notebook_task {
+			notebook_path = "/Stuff/Report"
+		}
+	}`
+	expected := settings
+	expected.Format = JobFormatMultiTask
+	expected.JobClusters = []JobCluster{{
+		JobClusterKey: "shared",
+		NewCluster: &Cluster{
+			NumWorkers:   2,
+			SparkVersion: "7.3.x-scala2.12",
+			NodeTypeID:   "i3.xlarge",
+			InitScripts: []InitScriptStorageInfo{
+				{
+					Dbfs: &DbfsStorageInfo{
+						Destination: "/init/setup.sh",
+					},
+				},
+			},
+		},
+	}}
+	fromAPI := settings
+	fromAPI.Format = JobFormatMultiTask
+	r := ResourceJob()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:          "POST",
+				Resource:        "/api/2.1/jobs/create",
+				ExpectedRequest: expected,
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID:    789,
+					Settings: &fromAPI,
+				},
+			},
+		},
+		Create:   true,
+		Resource: r,
+		HCL:      hclConfig,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "shared", d.Get("task.0.job_cluster_key"))
+	assert.Equal(t, "shared", d.Get("task.1.job_cluster_key"))
+	assert.Equal(t, "abc", d.Get("task.2.existing_cluster_id"))
+
+	var out interface{}
+	require.NoError(t, hcl.Decode(&out, hclConfig))
+	diff, err := r.Diff(context.Background(), d.State(),
+		terraform.NewResourceConfigRaw(jobConfigFromHCL(out).(map[string]interface{})), nil)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "plan is not stable: %v", diff)
+}
+
+func TestResourceJobCreate_UndefinedJobClusterKey(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `job_cluster {
+			job_cluster_key = "shared"
+			new_cluster {
+				num_workers = 2
+				spark_version = "7.3.x-scala2.12"
+				node_type_id = "i3.xlarge"
+			}
+		}
+		task {
+			task_key = "ingest"
+			job_cluster_key = "sharde"
+			notebook_task {
+				notebook_path = "/Stuff/Ingest"
+			}
+		}`,
+	}.Apply(t)
+	assert.EqualError(t, err, "Task ingest uses job_cluster_key sharde, which is not defined in job_cluster blocks")
+}
+
+func TestResourceJobCreate_QueueWithDefaultMaxConcurrentRuns(t *testing.T) {
+	hclConfig := `existing_cluster_id = "abc"
+	notebook_task {
+		notebook_path = "/Backfill"
+	}
+	queue {
+		enabled = true
+	}`
+	r := ResourceJob()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/create",
+				ExpectedRequest: JobSettings{
+					Name:              "Untitled",
+					ExistingClusterID: "abc",
+					NotebookTask: &NotebookTask{
+						NotebookPath: "/Backfill",
+					},
+					Queue: &JobQueue{
+						Enabled: true,
+					},
+				},
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID: 789,
+					Settings: &JobSettings{
+						Name:              "Untitled",
+						ExistingClusterID: "abc",
+						// default of the backend
+						MaxConcurrentRuns: 1,
+						NotebookTask: &NotebookTask{
+							NotebookPath: "/Backfill",
+						},
+						Queue: &JobQueue{
+							Enabled: true,
+						},
+					},
+				},
+			},
+		},
+		Create:   true,
+		Resource: r,
+		HCL:      hclConfig,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, true, d.Get("queue.0.enabled"))
+
+	var out interface{}
+	require.NoError(t, hcl.Decode(&out, hclConfig))
+	diff, err := r.Diff(context.Background(), d.State(),
+		terraform.NewResourceConfigRaw(jobConfigFromHCL(out).(map[string]interface{})), nil)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "plan is not stable: %v", diff)
+}
+
+func TestResourceJobUpdate_MaxConcurrentRunsKeepsQueue(t *testing.T) {
+	settings := JobSettings{
+		Name:              "Untitled",
+		ExistingClusterID: "abc",
+		MaxConcurrentRuns: 5,
+		NotebookTask: &NotebookTask{
+			NotebookPath: "/Backfill",
+		},
+		Queue: &JobQueue{
+			Enabled: true,
+		},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/jobs/reset",
+				ExpectedRequest: UpdateJobRequest{
+					JobID:       789,
+					NewSettings: &settings,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID:    789,
+					Settings: &settings,
+				},
+			},
+		},
+		ID:       "789",
+		Update:   true,
+		Resource: ResourceJob(),
+		InstanceState: map[string]string{
+			"name":                          "Untitled",
+			"existing_cluster_id":           "abc",
+			"max_concurrent_runs":           "1",
+			"notebook_task.#":               "1",
+			"notebook_task.0.notebook_path": "/Backfill",
+			"queue.#":                       "1",
+			"queue.0.enabled":               "true",
+		},
+		HCL: `existing_cluster_id = "abc"
+		max_concurrent_runs = 5
+		notebook_task {
+			notebook_path = "/Backfill"
+		}
+		queue {
+			enabled = true
+		}`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, 5, d.Get("max_concurrent_runs"))
+	assert.Equal(t, true, d.Get("queue.0.enabled"))
+}
+
+func TestResourceJobCreate_PythonWheelTask(t *testing.T) {
+	settings := JobSettings{
+		Name:              "Untitled",
+		ExistingClusterID: "abc",
+		MaxConcurrentRuns: 1,
+		PythonWheelTask: &PythonWheelTask{
+			PackageName: "my-reports",
+			EntryPoint:  "daily",
+			NamedParameters: map[string]string{
+				"date":   "2021-01-01",
+				"region": "emea",
+			},
+		},
+		Libraries: []Library{
+			{Whl: "dbfs:/wheels/my_reports-1.0.0-py3-none-any.whl"},
+		},
+	}
+	hclConfig := `existing_cluster_id = "abc"
+	max_concurrent_runs = 1
+	python_wheel_task {
+		package_name = "my-reports"
+		entry_point = "daily"
+		named_parameters = {
+			date = "2021-01-01"
+			region = "emea"
+		}
+	}
+	library {
+		whl = "dbfs:/wheels/my_reports-1.0.0-py3-none-any.whl"
+	}`
+	r := ResourceJob()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:          "POST",
+				Resource:        "/api/2.0/jobs/create",
+				ExpectedRequest: settings,
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/jobs/get?job_id=789",
+				Response: Job{
+					JobID:    789,
+					Settings: &settings,
+				},
+			},
+		},
+		Create:   true,
+		Resource: r,
+		HCL:      hclConfig,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "my-reports", d.Get("python_wheel_task.0.package_name"))
+	assert.Equal(t, map[string]interface{}{
+		"date":   "2021-01-01",
+		"region": "emea",
+	}, d.Get("python_wheel_task.0.named_parameters"))
+}
+
+func TestResourceJobCreate_MultipleTaskTypes(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `existing_cluster_id = "abc"
+		notebook_task {
+			notebook_path = "/Stuff"
+		}
+		python_wheel_task {
+			package_name = "reports"
+			entry_point = "daily"
+		}`,
+	}.Apply(t)
+	assert.EqualError(t, err, "job must have only one of notebook_task, spark_jar_task, "+
+		"spark_python_task, spark_submit_task, python_wheel_task, but has notebook_task and python_wheel_task")
+}
+
+func TestResourceJobCreate_TaskWithMultipleTaskTypes(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `task {
+			task_key = "report"
+			existing_cluster_id = "abc"
+			spark_python_task {
+				python_file = "dbfs:/reports/daily.py"
+			}
+			python_wheel_task {
+				package_name = "reports"
+				entry_point = "daily"
+			}
+		}`,
+	}.Apply(t)
+	assert.EqualError(t, err, "task report must have only one of notebook_task, spark_jar_task, "+
+		"spark_python_task, spark_submit_task, python_wheel_task, dbt_task, but has spark_python_task and python_wheel_task")
+}
+
+func TestResourceJobCreate_PythonWheelTaskWithBothParameters(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `task {
+			task_key = "report"
+			existing_cluster_id = "abc"
+			python_wheel_task {
+				package_name = "reports"
+				entry_point = "daily"
+				parameters = ["--date", "2021-01-01"]
+				named_parameters = {
+					date = "2021-01-01"
+				}
+			}
+		}`,
+	}.Apply(t)
+	assert.EqualError(t, err, "python_wheel_task of task report must have either parameters or named_parameters")
+}
+
+func TestHasWheel(t *testing.T) {
+	libraries := []interface{}{
+		map[string]interface{}{
+			"whl": "dbfs:/wheels/my_reports-1.0.0-py3-none-any.whl",
+		},
+		map[string]interface{}{
+			"pypi": []interface{}{
+				map[string]interface{}{
+					"package": "Data.Quality>=0.3",
+				},
+			},
+		},
+	}
+	assert.True(t, hasWheel(libraries, "my-reports"))
+	assert.True(t, hasWheel(libraries, "my_reports"))
+	assert.True(t, hasWheel(libraries, "data-quality"))
+	assert.False(t, hasWheel(libraries, "my"))
+	assert.False(t, hasWheel(libraries, "reports"))
+}
+
+func TestMissingWheelWarnings(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, jobSchema, map[string]interface{}{
+		"python_wheel_task": []interface{}{
+			map[string]interface{}{
+				"package_name": "my-reports",
+			},
+		},
+		"library": []interface{}{
+			map[string]interface{}{
+				"whl": "dbfs:/wheels/my_reports-1.0.0-py3-none-any.whl",
+			},
+		},
+		"task": []interface{}{
+			map[string]interface{}{
+				"task_key": "quality",
+				"python_wheel_task": []interface{}{
+					map[string]interface{}{
+						"package_name": "data-quality",
+					},
+				},
+			},
+		},
+	})
+	diags := missingWheelWarnings(d)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "Package data-quality of python_wheel_task of task quality is not in library blocks",
+		diags[0].Summary)
+}
+
+func TestResourceJobCreate_DbtTask(t *testing.T) {
+	settings := JobSettings{
+		Name:   "dbt",
+		Format: JobFormatMultiTask,
+		Tasks: []JobTaskSettings{
+			{
+				TaskKey:           "transform",
+				ExistingClusterID: "abc",
+				DbtTask: &DbtTask{
+					Commands:         []string{"dbt deps", "dbt seed", "dbt run"},
+					ProjectDirectory: "analytics",
+					Schema:           "default",
+					WarehouseID:      "w1",
+				},
+				Libraries: []Library{
+					{Pypi: &PyPi{Package: "dbt-databricks>=1.0.0"}},
+				},
+			},
+		},
+		GitSource: &GitSource{
+			URL:      "https://github.com/example/analytics",
+			Provider: "gitHub",
+			Branch:   "main",
+		},
+	}
+	hclConfig := `name = "dbt"
+	git_source {
+		git_url = "https://github.com/example/analytics"
+		git_provider = "gitHub"
+		branch = "main"
+	}
+	task {
+		task_key = "transform"
+		existing_cluster_id = "abc"
+		dbt_task {
+			commands = ["dbt deps", "dbt seed", "dbt run"]
+			project_directory = "analytics"
+			warehouse_id = "w1"
+		}
+		library {
+			pypi {
+				package = "dbt-databricks>=1.0.0"
+			}
+		}
+	}`
+	r := ResourceJob()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				Resource:     "/api/2.0/sql/endpoints/w1",
+				Response:     map[string]string{"id": "w1"},
+				ReuseRequest: true,
+			},
+			{
+				Method:          "POST",
+				Resource:        "/api/2.1/jobs/create",
+				ExpectedRequest: settings,
+				Response: Job{
+					JobID: 789,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID:    789,
+					Settings: &settings,
+				},
+			},
+		},
+		Create:   true,
+		Resource: r,
+		HCL:      hclConfig,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "dbt seed", d.Get("task.0.dbt_task.0.commands.1"))
+	assert.Equal(t, "default", d.Get("task.0.dbt_task.0.schema"))
+}
+
+func TestResourceJobCreate_DbtTaskNotSQLEndpoint(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/sql/endpoints/abc",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Endpoint abc does not exist",
+				},
+				Status: 404,
+			},
+		},
+		Create:   true,
+		Resource: ResourceJob(),
+		HCL: `task {
+			task_key = "transform"
+			existing_cluster_id = "abc"
+			dbt_task {
+				commands = ["dbt run"]
+				warehouse_id = "abc"
+			}
+		}`,
+	}.Apply(t)
+	assert.EqualError(t, err, "warehouse_id abc of task transform is not a SQL endpoint")
+}
+
+func TestResourceJobUpdate_TaskRetrySettings(t *testing.T) {
+	settings := JobSettings{
+		Name:   "Retries",
+		Format: JobFormatMultiTask,
+		Tasks: []JobTaskSettings{
+			{
+				TaskKey:           "ingest",
+				ExistingClusterID: "abc",
+				NotebookTask: &NotebookTask{
+					NotebookPath: "/Stuff/Ingest",
+				},
+				MaxRetries:     3,
+				RetryOnTimeout: true,
+				// zero interval is the default, so it is not sent and
+				// reset of job settings makes failed runs to be retried immediately
+				MinRetryIntervalMillis: 0,
+			},
+			{
+				TaskKey:           "report",
+				ExistingClusterID: "abc",
+				NotebookTask: &NotebookTask{
+					NotebookPath: "/Stuff/Report",
+				},
+				MaxRetries:             1,
+				MinRetryIntervalMillis: 60000,
+			},
+		},
+	}
+	hclConfig := `name = "Retries"
+	task {
+		task_key = "ingest"
+		existing_cluster_id = "abc"
+		max_retries = 3
+		retry_on_timeout = true
+		min_retry_interval_millis = 0
+		notebook_task {
+			notebook_path = "/Stuff/Ingest"
+		}
+	}
+	task {
+		task_key = "report"
+		existing_cluster_id = "abc"
+		max_retries = 1
+		min_retry_interval_millis = 60000
+		notebook_task {
+			notebook_path = "/Stuff/Report"
+		}
+	}`
+	r := ResourceJob()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.1/jobs/reset",
+				ExpectedRequest: UpdateJobRequest{
+					JobID:       789,
+					NewSettings: &settings,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.1/jobs/get?job_id=789",
+				Response: Job{
+					JobID:    789,
+					Settings: &settings,
+				},
+			},
+		},
+		ID:       "789",
+		Update:   true,
+		Resource: r,
+		InstanceState: map[string]string{
+			"name":                                 "Retries",
+			"format":                               JobFormatMultiTask,
+			"task.#":                               "2",
+			"task.0.task_key":                      "ingest",
+			"task.0.existing_cluster_id":           "abc",
+			"task.0.max_retries":                   "3",
+			"task.0.min_retry_interval_millis":     "5000",
+			"task.0.notebook_task.#":               "1",
+			"task.0.notebook_task.0.notebook_path": "/Stuff/Ingest",
+			"task.1.task_key":                      "report",
+			"task.1.existing_cluster_id":           "abc",
+			"task.1.max_retries":                   "1",
+			"task.1.notebook_task.#":               "1",
+			"task.1.notebook_task.0.notebook_path": "/Stuff/Report",
+		},
+		HCL: hclConfig,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, 0, d.Get("task.0.min_retry_interval_millis"))
+	assert.Equal(t, true, d.Get("task.0.retry_on_timeout"))
+	assert.Equal(t, 60000, d.Get("task.1.min_retry_interval_millis"))
+
+	var out interface{}
+	require.NoError(t, hcl.Decode(&out, hclConfig))
+	diff, err := r.Diff(context.Background(), d.State(),
+		terraform.NewResourceConfigRaw(jobConfigFromHCL(out).(map[string]interface{})), nil)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "plan is not stable: %v", diff)
+}
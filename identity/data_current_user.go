@@ -0,0 +1,58 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var nonAlphanumeric = regexp.MustCompile(`\W`)
+
+// alphanumericName returns local part of user name, that is safe to use in names of other resources
+func alphanumericName(userName string) string {
+	local := strings.Split(userName, "@")[0]
+	return strings.ToLower(nonAlphanumeric.ReplaceAllLiteralString(local, "_"))
+}
+
+// DataSourceCurrentUser returns information about the user or service principal, that is authenticated with provider
+func DataSourceCurrentUser() *schema.Resource {
+	type entity struct {
+		UserName     string `json:"user_name,omitempty" tf:"computed"`
+		Home         string `json:"home,omitempty" tf:"computed"`
+		Repos        string `json:"repos,omitempty" tf:"computed"`
+		Alphanumeric string `json:"alphanumeric,omitempty" tf:"computed"`
+		ExternalID   string `json:"external_id,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			// everything is derived from a single call, so that the data source is cheap to use
+			me, err := NewUsersAPI(ctx, m).Me()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this := entity{
+				UserName:     me.UserName,
+				Home:         fmt.Sprintf("/Users/%s", me.UserName),
+				Repos:        fmt.Sprintf("/Repos/%s", me.UserName),
+				Alphanumeric: alphanumericName(me.UserName),
+				ExternalID:   me.ExternalID,
+			}
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(me.ID)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceCurrentUser(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Me",
+				Response: ScimUser{
+					ID:         "123",
+					UserName:   "mr.test+tf@example.com",
+					ExternalID: "ext-123",
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceCurrentUser(),
+		ID:          ".",
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "123", d.Id())
+	assert.Equal(t, "mr.test+tf@example.com", d.Get("user_name"))
+	assert.Equal(t, "/Users/mr.test+tf@example.com", d.Get("home"))
+	assert.Equal(t, "/Repos/mr.test+tf@example.com", d.Get("repos"))
+	assert.Equal(t, "mr_test_tf", d.Get("alphanumeric"))
+	assert.Equal(t, "ext-123", d.Get("external_id"))
+}
+
+func TestAlphanumericName(t *testing.T) {
+	for userName, expected := range map[string]string{
+		"me@example.com":                       "me",
+		"First.Last@example.com":               "first_last",
+		"first.last+dev@example.com":           "first_last_dev",
+		"a-b@example.com":                      "a_b",
+		"00000000-0000-0000-0000-000000000000": "00000000_0000_0000_0000_000000000000",
+	} {
+		assert.Equal(t, expected, alphanumericName(userName), userName)
+	}
+}
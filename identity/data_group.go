@@ -3,6 +3,7 @@ package identity
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/databrickslabs/databricks-terraform/internal"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -10,14 +11,87 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// childGroups returns identifiers of groups, that are members of the group
+func childGroups(g ScimGroup) (ids []string) {
+	for _, x := range g.Members {
+		if strings.HasPrefix(x.Ref, "Groups/") {
+			ids = append(ids, x.Value)
+		}
+	}
+	return
+}
+
+// parentGroups returns identifiers of groups, that the group is member of
+func parentGroups(g ScimGroup) (ids []string) {
+	for _, x := range g.Groups {
+		ids = append(ids, x.Value)
+	}
+	return
+}
+
+// lookup finds exactly one group either by external ID or by display name. Display name is matched
+// by SCIM filter first and case-insensitively among all groups, if the filter found nothing.
+func (a GroupsAPI) lookup(displayName, externalID string) (group ScimGroup, diags diag.Diagnostics, err error) {
+	name := displayName
+	filter := fmt.Sprintf(`displayName eq "%s"`, displayName)
+	if externalID != "" {
+		name = fmt.Sprintf("with external_id %s", externalID)
+		filter = fmt.Sprintf(`externalId eq "%s"`, externalID)
+	}
+	groups, err := a.list(filter, "")
+	if err != nil {
+		return
+	}
+	if len(groups) == 0 && externalID == "" {
+		all, err := a.list("", "id,displayName,externalId")
+		if err != nil {
+			return group, diags, err
+		}
+		for _, g := range all {
+			if strings.EqualFold(g.DisplayName, displayName) {
+				groups = append(groups, g)
+			}
+		}
+		if len(groups) == 1 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary: fmt.Sprintf("Group %s is found as %s with case-insensitive match. "+
+					"Please use exact display name", displayName, groups[0].DisplayName),
+			})
+			groups[0], err = a.Read(groups[0].ID)
+			if err != nil {
+				return group, diags, err
+			}
+		}
+	}
+	if len(groups) == 0 {
+		err = fmt.Errorf("Cannot find group %s", name)
+		return
+	}
+	if len(groups) > 1 {
+		found := []string{}
+		for _, g := range groups {
+			found = append(found, fmt.Sprintf("%s (external_id: %s)", g.ID, g.ExternalID))
+		}
+		err = fmt.Errorf("There are %d groups named %s: %s. Please use external_id to look up the group",
+			len(groups), name, strings.Join(found, ", "))
+		return
+	}
+	return groups[0], diags, nil
+}
+
 // DataSourceGroup returns information about group specified by display name
 func DataSourceGroup() *schema.Resource {
 	type entity struct {
-		DisplayName             string   `json:"display_name"`
+		DisplayName             string   `json:"display_name,omitempty" tf:"computed"`
 		Recursive               bool     `json:"recursive,omitempty"`
 		Members                 []string `json:"members,omitempty" tf:"slice_set,computed"`
+		Users                   []string `json:"users,omitempty" tf:"slice_set,computed"`
+		ServicePrincipals       []string `json:"service_principals,omitempty" tf:"slice_set,computed"`
+		ChildGroups             []string `json:"child_groups,omitempty" tf:"slice_set,computed"`
 		Groups                  []string `json:"groups,omitempty" tf:"slice_set,computed"`
 		InstanceProfiles        []string `json:"instance_profiles,omitempty" tf:"slice_set,computed"`
+		ExternalID              string   `json:"external_id,omitempty" tf:"computed"`
 		AllowClusterCreate      bool     `json:"allow_cluster_create,omitempty" tf:"computed"`
 		AllowInstancePoolCreate bool     `json:"allow_instance_pool_create,omitempty" tf:"computed"`
 	}
@@ -27,6 +101,8 @@ func DataSourceGroup() *schema.Resource {
 		// nolint once SDKv2 has Diagnostics-returning validators, change
 		s["display_name"].ValidateFunc = validation.StringIsNotEmpty
 		s["recursive"].Default = true
+		s["display_name"].ExactlyOneOf = []string{"display_name", "external_id"}
+		s["external_id"].ExactlyOneOf = []string{"display_name", "external_id"}
 		return s
 	})
 
@@ -39,18 +115,15 @@ func DataSourceGroup() *schema.Resource {
 				return diag.FromErr(err)
 			}
 			groupsAPI := NewGroupsAPI(ctx, m)
-			groupList, err := groupsAPI.Filter(fmt.Sprintf("displayName eq %s", this.DisplayName))
+			group, diags, err := groupsAPI.lookup(this.DisplayName, this.ExternalID)
 			if err != nil {
 				return diag.FromErr(err)
 			}
-			if len(groupList.Resources) == 0 {
-				return diag.FromErr(fmt.Errorf("Cannot find group %s", this.DisplayName))
-			}
-			d.SetId(groupList.Resources[0].ID)
-			queue := []ScimGroup{groupList.Resources[0]}
-			for len(queue) > 0 {
-				current := queue[0]
-				queue = queue[1:]
+			d.SetId(group.ID)
+			this.DisplayName = group.DisplayName
+			this.ExternalID = group.ExternalID
+			// members, entitlements and instance profiles are collected from parent groups
+			err = groupsAPI.visitNested(group, this.Recursive, parentGroups, func(current ScimGroup) {
 				for _, x := range current.Members {
 					this.Members = append(this.Members, x.Value)
 				}
@@ -65,22 +138,32 @@ func DataSourceGroup() *schema.Resource {
 						this.AllowInstancePoolCreate = true
 					}
 				}
-				for _, x := range current.Groups {
-					this.Groups = append(this.Groups, x.Value)
-					if this.Recursive {
-						childGroup, err := groupsAPI.Read(x.Value)
-						if err != nil {
-							return diag.FromErr(err)
-						}
-						queue = append(queue, childGroup)
+				this.Groups = append(this.Groups, parentGroups(current)...)
+			})
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			// members of child groups are members of the group as well
+			err = groupsAPI.visitNested(group, this.Recursive, childGroups, func(current ScimGroup) {
+				for _, x := range current.Members {
+					switch {
+					case strings.HasPrefix(x.Ref, "Users/"):
+						this.Users = append(this.Users, x.Value)
+					case strings.HasPrefix(x.Ref, "ServicePrincipals/"):
+						this.ServicePrincipals = append(this.ServicePrincipals, x.Value)
+					case strings.HasPrefix(x.Ref, "Groups/"):
+						this.ChildGroups = append(this.ChildGroups, x.Value)
 					}
 				}
+			})
+			if err != nil {
+				return diag.FromErr(err)
 			}
 			err = internal.StructToData(this, s, d)
 			if err != nil {
 				return diag.FromErr(err)
 			}
-			return nil
+			return diags
 		},
 	}
 }
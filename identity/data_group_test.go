@@ -1,10 +1,13 @@
 package identity
 
 import (
+	"context"
 	"testing"
 
 	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -18,7 +21,7 @@ func TestDataSourceGroup(t *testing.T) {
 		Fixtures: []qa.HTTPFixture{
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/preview/scim/v2/Groups?filter=displayName%20eq%20ds",
+				Resource: "/api/2.0/preview/scim/v2/Groups?count=100&filter=displayName+eq+%22ds%22&startIndex=1",
 				Response: GroupList{
 					Resources: []ScimGroup{
 						{
@@ -90,3 +93,270 @@ func TestDataSourceGroup(t *testing.T) {
 	assert.Equal(t, true, d.Get("allow_instance_pool_create"))
 	assert.Equal(t, true, d.Get("allow_cluster_create"))
 }
+
+func TestDataSourceGroup_NestedMembers(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups?count=100&filter=displayName+eq+%22team-x%22&startIndex=1",
+				Response: GroupList{
+					Resources: []ScimGroup{
+						{
+							DisplayName: "team-x",
+							ID:          "100",
+							ExternalID:  "aad-100",
+							Members: []GroupMember{
+								{
+									Value: "1",
+									Ref:   "Users/1",
+								},
+								{
+									Value: "2",
+									Ref:   "ServicePrincipals/2",
+								},
+								{
+									Value: "200",
+									Ref:   "Groups/200",
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/200",
+				Response: ScimGroup{
+					DisplayName: "team-x-oncall",
+					ID:          "200",
+					Members: []GroupMember{
+						{
+							Value: "3",
+							Ref:   "Users/3",
+						},
+						{
+							// cycle back to the top-level group is not followed
+							Value: "100",
+							Ref:   "Groups/100",
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceGroup(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"display_name": "team-x",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "100", d.Id())
+	assert.Equal(t, "aad-100", d.Get("external_id"))
+	assert.Equal(t, 2, d.Get("users").(*schema.Set).Len())
+	assertContains(t, d.Get("users"), "1")
+	assertContains(t, d.Get("users"), "3")
+	assert.Equal(t, 1, d.Get("service_principals").(*schema.Set).Len())
+	assertContains(t, d.Get("service_principals"), "2")
+	assert.Equal(t, 2, d.Get("child_groups").(*schema.Set).Len())
+	assertContains(t, d.Get("child_groups"), "200")
+	assertContains(t, d.Get("child_groups"), "100")
+	// members of child groups are exposed only through users, service_principals and child_groups
+	assert.Equal(t, 3, d.Get("members").(*schema.Set).Len())
+}
+
+func TestDataSourceGroup_NotRecursive(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups?count=100&filter=displayName+eq+%22team-x%22&startIndex=1",
+				Response: GroupList{
+					Resources: []ScimGroup{
+						{
+							DisplayName: "team-x",
+							ID:          "100",
+							Members: []GroupMember{
+								{
+									Value: "1",
+									Ref:   "Users/1",
+								},
+								{
+									Value: "200",
+									Ref:   "Groups/200",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceGroup(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"display_name": "team-x",
+			"recursive":    false,
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, 1, d.Get("users").(*schema.Set).Len())
+	assertContains(t, d.Get("child_groups"), "200")
+	assert.Equal(t, 2, d.Get("members").(*schema.Set).Len())
+}
+
+func TestDataSourceGroup_CaseInsensitiveFallback(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Groups?count=100&filter=displayName+eq+%22data+science%22&startIndex=1",
+			Response: GroupList{},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Groups?attributes=id%2CdisplayName%2CexternalId&count=100&startIndex=1",
+			Response: GroupList{
+				TotalResults: 3,
+				Resources: []ScimGroup{
+					{
+						DisplayName: "admins",
+						ID:          "1",
+					},
+					{
+						DisplayName: "Data Science",
+						ID:          "2",
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Groups?attributes=id%2CdisplayName%2CexternalId&count=100&startIndex=3",
+			Response: GroupList{
+				TotalResults: 3,
+				Resources: []ScimGroup{
+					{
+						DisplayName: "users",
+						ID:          "3",
+					},
+				},
+			},
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Groups/2",
+			Response: ScimGroup{
+				DisplayName: "Data Science",
+				ID:          "2",
+				ExternalID:  "ext-2",
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	group, diags, err := NewGroupsAPI(context.Background(), client).lookup("data science", "")
+	require.NoError(t, err)
+	assert.Equal(t, "2", group.ID)
+	assert.Equal(t, "ext-2", group.ExternalID)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "Group data science is found as Data Science with case-insensitive match. "+
+		"Please use exact display name", diags[0].Summary)
+}
+
+func TestDataSourceGroup_Ambiguous(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups?count=100&filter=displayName+eq+%22ds%22&startIndex=1",
+				Response: GroupList{
+					TotalResults: 2,
+					Resources: []ScimGroup{
+						{
+							DisplayName: "ds",
+							ID:          "1",
+							ExternalID:  "aad-1",
+						},
+						{
+							DisplayName: "ds",
+							ID:          "2",
+							ExternalID:  "okta-2",
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceGroup(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"display_name": "ds",
+		},
+	}.Apply(t)
+	assert.EqualError(t, err, "There are 2 groups named ds: 1 (external_id: aad-1), "+
+		"2 (external_id: okta-2). Please use external_id to look up the group")
+}
+
+func TestDataSourceGroup_ExternalID(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups?count=100&filter=externalId+eq+%22okta-2%22&startIndex=1",
+				Response: GroupList{
+					TotalResults: 1,
+					Resources: []ScimGroup{
+						{
+							DisplayName: "ds",
+							ID:          "2",
+							ExternalID:  "okta-2",
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceGroup(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"external_id": "okta-2",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "2", d.Id())
+	assert.Equal(t, "ds", d.Get("display_name"))
+}
+
+func TestDataSourceGroup_ExternalIDNotFound(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups?count=100&filter=externalId+eq+%22okta-2%22&startIndex=1",
+				Response: GroupList{},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceGroup(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"external_id": "okta-2",
+		},
+	}.Apply(t)
+	assert.EqualError(t, err, "Cannot find group with external_id okta-2")
+}
+
+func TestDataSourceGroup_NoLookupAttribute(t *testing.T) {
+	diags := DataSourceGroup().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"recursive": false,
+	}))
+	assert.True(t, diags.HasError(), "either display_name or external_id is required")
+}
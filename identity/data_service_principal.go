@@ -0,0 +1,48 @@
+package identity
+
+import (
+	"context"
+
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceServicePrincipal returns information about service principal specified by application ID
+func DataSourceServicePrincipal() *schema.Resource {
+	type entity struct {
+		ApplicationID string `json:"application_id"`
+		DisplayName   string `json:"display_name,omitempty" tf:"computed"`
+		ExternalID    string `json:"external_id,omitempty" tf:"computed"`
+		Active        bool   `json:"active,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["application_id"].ValidateFunc = validation.StringIsNotEmpty
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			sp, err := NewServicePrincipalsAPI(ctx, m).ReadByApplicationID(this.ApplicationID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.DisplayName = sp.DisplayName
+			this.ExternalID = sp.ExternalID
+			this.Active = sp.Active
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(sp.ID)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceServicePrincipal(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/ServicePrincipals?filter=applicationId%20eq%2000000000-0000-0000-0000-000000000000",
+				Response: UserList{
+					Resources: []ScimUser{
+						{
+							ID:            "abc",
+							ApplicationID: "00000000-0000-0000-0000-000000000000",
+							DisplayName:   "Automation",
+							ExternalID:    "ext-1",
+							Active:        true,
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceServicePrincipal(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"application_id": "00000000-0000-0000-0000-000000000000",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "Automation", d.Get("display_name"))
+	assert.Equal(t, "ext-1", d.Get("external_id"))
+	assert.Equal(t, true, d.Get("active"))
+}
+
+func TestDataSourceServicePrincipal_NotFound(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/ServicePrincipals?filter=applicationId%20eq%20abc",
+				Response: UserList{},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceServicePrincipal(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"application_id": "abc",
+		},
+	}.Apply(t)
+	assert.EqualError(t, err, "Cannot find service principal with application_id abc")
+}
@@ -0,0 +1,95 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ManagedTokenInfo is metadata of any token in the workspace, as returned by token management API
+type ManagedTokenInfo struct {
+	TokenInfo
+	CreatedByID       int64  `json:"created_by_id,omitempty"`
+	CreatedByUsername string `json:"created_by_username,omitempty"`
+	OwnerID           int64  `json:"owner_id,omitempty"`
+}
+
+// List returns metadata of all tokens in the workspace. Only admins can call it.
+func (a TokenManagementAPI) List() ([]ManagedTokenInfo, error) {
+	var r struct {
+		TokenInfos []ManagedTokenInfo `json:"token_infos,omitempty"`
+	}
+	err := a.client.Get(a.context, "/token-management/tokens", nil, &r)
+	if e, ok := err.(common.APIError); ok && e.StatusCode == http.StatusForbidden {
+		err = fmt.Errorf("%s. Only workspace admins can list tokens of all users, "+
+			"please remove admin = true to list only your own tokens", e.Message)
+	}
+	return r.TokenInfos, err
+}
+
+// DataSourceTokens returns metadata of tokens of the current user or of all users for admins.
+// Token values are never exposed.
+func DataSourceTokens() *schema.Resource {
+	type token struct {
+		TokenID           string `json:"token_id,omitempty" tf:"computed"`
+		Comment           string `json:"comment,omitempty" tf:"computed"`
+		CreationTime      int64  `json:"creation_time,omitempty" tf:"computed"`
+		ExpiryTime        int64  `json:"expiry_time,omitempty" tf:"computed"`
+		CreatedByUsername string `json:"created_by_username,omitempty" tf:"computed"`
+	}
+	type entity struct {
+		Admin  bool     `json:"admin,omitempty"`
+		Ids    []string `json:"ids,omitempty" tf:"computed"`
+		Tokens []token  `json:"tokens,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			var tokens []ManagedTokenInfo
+			if this.Admin {
+				tokens, err = NewTokenManagementAPI(ctx, m).List()
+			} else {
+				var own []TokenInfo
+				own, err = NewTokensAPI(ctx, m).List()
+				for _, ti := range own {
+					tokens = append(tokens, ManagedTokenInfo{TokenInfo: ti})
+				}
+			}
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.Ids = []string{}
+			this.Tokens = []token{}
+			for _, ti := range tokens {
+				this.Ids = append(this.Ids, ti.TokenID)
+				this.Tokens = append(this.Tokens, token{
+					TokenID:           ti.TokenID,
+					Comment:           ti.Comment,
+					CreationTime:      ti.CreationTime,
+					ExpiryTime:        ti.ExpiryTime,
+					CreatedByUsername: ti.CreatedByUsername,
+				})
+			}
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId("_")
+			return nil
+		},
+	}
+}
@@ -0,0 +1,105 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceTokens_Own(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/token/list",
+				Response: TokenList{
+					TokenInfos: []TokenInfo{
+						{
+							TokenID:      "abc",
+							Comment:      "ci",
+							CreationTime: 10,
+							ExpiryTime:   -1,
+						},
+						{
+							TokenID:      "bcd",
+							CreationTime: 20,
+							ExpiryTime:   30,
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceTokens(),
+		ID:          ".",
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "_", d.Id())
+	assert.Equal(t, []interface{}{"abc", "bcd"}, d.Get("ids"))
+	assert.Equal(t, "ci", d.Get("tokens.0.comment"))
+	assert.Equal(t, -1, d.Get("tokens.0.expiry_time"))
+	assert.Equal(t, 30, d.Get("tokens.1.expiry_time"))
+	assert.Equal(t, "", d.Get("tokens.1.created_by_username"))
+}
+
+func TestDataSourceTokens_Admin(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/token-management/tokens",
+				Response: map[string]interface{}{
+					"token_infos": []ManagedTokenInfo{
+						{
+							TokenInfo: TokenInfo{
+								TokenID:      "abc",
+								Comment:      "ci",
+								CreationTime: 10,
+								ExpiryTime:   -1,
+							},
+							CreatedByID:       123,
+							CreatedByUsername: "someone@example.com",
+							OwnerID:           123,
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceTokens(),
+		ID:          ".",
+		HCL:         `admin = true`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, 1, d.Get("tokens.#"))
+	assert.Equal(t, "abc", d.Get("tokens.0.token_id"))
+	assert.Equal(t, "someone@example.com", d.Get("tokens.0.created_by_username"))
+}
+
+func TestDataSourceTokens_AdminForbidden(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/token-management/tokens",
+				Response: common.APIErrorBody{
+					ErrorCode: "PERMISSION_DENIED",
+					Message:   "Only Admins can access token management APIs",
+				},
+				Status: 403,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceTokens(),
+		ID:          ".",
+		HCL:         `admin = true`,
+	}.Apply(t)
+	assert.EqualError(t, err, "Only Admins can access token management APIs. Only workspace admins "+
+		"can list tokens of all users, please remove admin = true to list only your own tokens")
+}
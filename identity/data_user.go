@@ -0,0 +1,57 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceUser returns information about user specified by user name
+func DataSourceUser() *schema.Resource {
+	type entity struct {
+		UserName    string `json:"user_name"`
+		DisplayName string `json:"display_name,omitempty" tf:"computed"`
+		Active      bool   `json:"active,omitempty" tf:"computed"`
+		Home        string `json:"home,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["user_name"].ValidateFunc = validation.StringIsNotEmpty
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			users, err := NewUsersAPI(ctx, m).List(fmt.Sprintf(`userName eq "%s"`, this.UserName))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			for _, user := range users {
+				// user names are case-insensitive in SCIM filters
+				if !strings.EqualFold(user.UserName, this.UserName) {
+					continue
+				}
+				this.DisplayName = user.DisplayName
+				this.Active = user.Active
+				this.Home = fmt.Sprintf("/Users/%s", user.UserName)
+				err = internal.StructToData(this, s, d)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				d.SetId(user.ID)
+				return nil
+			}
+			return diag.Errorf("Cannot find user %s", this.UserName)
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceUser(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?count=100&filter=userName+eq+%22Me%40example.com%22&startIndex=1",
+				Response: UserList{
+					TotalResults: 1,
+					Resources: []ScimUser{
+						{
+							ID:          "123",
+							UserName:    "me@example.com",
+							DisplayName: "Me",
+							Active:      true,
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceUser(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"user_name": "Me@example.com",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "123", d.Id())
+	assert.Equal(t, "Me", d.Get("display_name"))
+	assert.Equal(t, "/Users/me@example.com", d.Get("home"))
+	assert.Equal(t, true, d.Get("active"))
+}
+
+func TestDataSourceUser_NotFound(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?count=100&filter=userName+eq+%22me%40example.com%22&startIndex=1",
+				Response: UserList{},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceUser(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"user_name": "me@example.com",
+		},
+	}.Apply(t)
+	assert.EqualError(t, err, "Cannot find user me@example.com")
+}
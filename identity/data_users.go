@@ -0,0 +1,126 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// usersQuery is a combination of raw SCIM filter and convenience arguments of databricks_users
+type usersQuery struct {
+	filter           string
+	userNameContains string
+	active           *bool
+}
+
+// scimFilter combines all arguments into a single SCIM filter expression
+func (q usersQuery) scimFilter() string {
+	var parts []string
+	if q.userNameContains != "" {
+		parts = append(parts, fmt.Sprintf(`userName co "%s"`, q.userNameContains))
+	}
+	if q.active != nil {
+		parts = append(parts, fmt.Sprintf("active eq %t", *q.active))
+	}
+	if q.filter == "" {
+		return strings.Join(parts, " and ")
+	}
+	if len(parts) == 0 {
+		return q.filter
+	}
+	return fmt.Sprintf("(%s) and %s", q.filter, strings.Join(parts, " and "))
+}
+
+// matches applies convenience arguments on the client side
+func (q usersQuery) matches(user ScimUser) bool {
+	if !strings.Contains(strings.ToLower(user.UserName), strings.ToLower(q.userNameContains)) {
+		return false
+	}
+	return q.active == nil || *q.active == user.Active
+}
+
+// list returns users matching the query. If workspace doesn't support SCIM filtering
+// on given attributes, convenience arguments are applied on the client side.
+func (q usersQuery) list(a UsersAPI) (users []ScimUser, diags diag.Diagnostics, err error) {
+	users, err = a.List(q.scimFilter())
+	e, ok := err.(common.APIError)
+	if !ok || e.StatusCode != http.StatusBadRequest || q.filter != "" || q.scimFilter() == "" {
+		return
+	}
+	log.Printf("[WARN] SCIM filter is not supported: %s", e.Message)
+	all, err := a.List("")
+	if err != nil {
+		return nil, nil, err
+	}
+	users = nil
+	for _, user := range all {
+		if q.matches(user) {
+			users = append(users, user)
+		}
+	}
+	diags = append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary: fmt.Sprintf("SCIM filter `%s` is not supported by the workspace, "+
+			"so all users were fetched and filtered by the provider", q.scimFilter()),
+	})
+	return users, diags, nil
+}
+
+// DataSourceUsers returns all users of the workspace, that match SCIM filter
+func DataSourceUsers() *schema.Resource {
+	type entity struct {
+		Filter           string            `json:"filter,omitempty"`
+		UserNameContains string            `json:"user_name_contains,omitempty"`
+		Active           bool              `json:"active,omitempty"`
+		Ids              []string          `json:"ids,omitempty" tf:"computed,slice_set"`
+		UserNames        map[string]string `json:"user_names,omitempty" tf:"computed"`
+		DisplayNames     map[string]string `json:"display_names,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			q := usersQuery{
+				filter:           this.Filter,
+				userNameContains: this.UserNameContains,
+			}
+			// nolint GetOkExists is deprecated, but false is a meaningful value of active
+			if _, ok := d.GetOkExists("active"); ok {
+				q.active = &this.Active
+			}
+			users, diags, err := q.list(NewUsersAPI(ctx, m))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.Ids = []string{}
+			this.UserNames = map[string]string{}
+			this.DisplayNames = map[string]string{}
+			for _, user := range users {
+				this.Ids = append(this.Ids, user.ID)
+				this.UserNames[user.ID] = user.UserName
+				this.DisplayNames[user.ID] = user.DisplayName
+			}
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId("_")
+			return diags
+		},
+	}
+}
@@ -0,0 +1,186 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceUsers_Paginated(t *testing.T) {
+	page := func(startIndex int32, ids ...string) UserList {
+		list := UserList{
+			TotalResults: 5,
+			StartIndex:   startIndex,
+			ItemsPerPage: int32(len(ids)),
+		}
+		for _, id := range ids {
+			list.Resources = append(list.Resources, ScimUser{
+				ID:       id,
+				UserName: id + "@example.com",
+			})
+		}
+		return list
+	}
+	// server returns less items per page, than requested
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?count=100&filter=active+eq+true&startIndex=1",
+				Response: page(1, "a", "b"),
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?count=100&filter=active+eq+true&startIndex=3",
+				Response: page(3, "c", "d"),
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?count=100&filter=active+eq+true&startIndex=5",
+				Response: page(5, "e"),
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceUsers(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"filter": "active eq true",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "_", d.Id())
+	assert.Equal(t, 5, d.Get("ids").(*schema.Set).Len())
+	assert.Len(t, d.Get("user_names"), 5)
+	assert.Equal(t, "e@example.com", d.Get("user_names.e"))
+}
+
+func TestDataSourceUsers_Error(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?count=100&startIndex=1",
+				Status:   500,
+				Response: common.APIErrorBody{
+					ErrorCode: "SERVER_ERROR",
+					Message:   "Nope",
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceUsers(),
+		ID:          ".",
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Nope")
+}
+
+func TestDataSourceUsers_ConvenienceArguments(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method: "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?count=100&filter=%28displayName+sw+%22Data%22%29+and+" +
+					"userName+co+%22example.com%22+and+active+eq+false&startIndex=1",
+				Response: UserList{
+					TotalResults: 1,
+					Resources: []ScimUser{
+						{
+							ID:          "a",
+							UserName:    "a@example.com",
+							DisplayName: "Data A",
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceUsers(),
+		ID:          ".",
+		HCL: `
+		filter = "displayName sw \"Data\""
+		user_name_contains = "example.com"
+		active = false
+		`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "a@example.com", d.Get("user_names.a"))
+	assert.Equal(t, "Data A", d.Get("display_names.a"))
+}
+
+func TestDataSourceUsers_ClientSideFiltering(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?count=100&filter=userName+co+%22example.com%22+and+active+eq+true&startIndex=1",
+				Status:   400,
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_PARAMETER_VALUE",
+					Message:   "Unsupported filter",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?count=100&startIndex=1",
+				Response: UserList{
+					TotalResults: 3,
+					Resources: []ScimUser{
+						{
+							ID:       "a",
+							UserName: "a@Example.com",
+							Active:   true,
+						},
+						{
+							ID:       "b",
+							UserName: "b@example.com",
+						},
+						{
+							ID:       "c",
+							UserName: "c@example.org",
+							Active:   true,
+						},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceUsers(),
+		ID:          ".",
+		HCL: `
+		user_name_contains = "example.com"
+		active = true
+		`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"a"}, d.Get("ids").(*schema.Set).List())
+}
+
+func TestDataSourceUsers_UnsupportedRawFilter(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?count=100&filter=title+eq+%22x%22&startIndex=1",
+				Status:   400,
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_PARAMETER_VALUE",
+					Message:   "Unsupported filter",
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceUsers(),
+		ID:          ".",
+		HCL:         `filter = "title eq \"x\""`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Unsupported filter")
+}
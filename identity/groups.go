@@ -62,6 +62,42 @@ func (a GroupsAPI) Read(groupID string) (group ScimGroup, err error) {
 	return
 }
 
+// readWithoutMembers reads the group without its members, which could be a large list
+// for groups like "users" and is not needed to manage group attributes
+func (a GroupsAPI) readWithoutMembers(groupID string) (group ScimGroup, err error) {
+	err = a.client.Scim(a.context, http.MethodGet, fmt.Sprintf("/preview/scim/v2/Groups/%v", groupID),
+		scimReadRequest{ExcludedAttributes: "members"}, &group)
+	return
+}
+
+// visitNested visits the group and, if recursive, all groups reachable from it through next.
+// Every group is read and visited only once, so that cycles in group nesting are not a problem.
+func (a GroupsAPI) visitNested(group ScimGroup, recursive bool,
+	next func(ScimGroup) []string, visit func(ScimGroup)) error {
+	visited := map[string]bool{group.ID: true}
+	queue := []ScimGroup{group}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		visit(current)
+		if !recursive {
+			continue
+		}
+		for _, groupID := range next(current) {
+			if visited[groupID] {
+				continue
+			}
+			visited[groupID] = true
+			nested, err := a.Read(groupID)
+			if err != nil {
+				return err
+			}
+			queue = append(queue, nested)
+		}
+	}
+	return nil
+}
+
 // Filter returns groups matching the filter
 func (a GroupsAPI) Filter(filter string) (GroupList, error) {
 	var groups GroupList
@@ -73,6 +109,29 @@ func (a GroupsAPI) Filter(filter string) (GroupList, error) {
 	return groups, err
 }
 
+// list returns all groups matching the filter, going through all pages of SCIM API response.
+// Only given attributes are returned, if they are specified.
+func (a GroupsAPI) list(filter, attributes string) (groups []ScimGroup, err error) {
+	req := scimListRequest{
+		Filter:     filter,
+		Attributes: attributes,
+		StartIndex: 1,
+		Count:      scimPageSize,
+	}
+	for {
+		var page GroupList
+		err = a.client.Scim(a.context, http.MethodGet, "/preview/scim/v2/Groups", req, &page)
+		if err != nil {
+			return
+		}
+		groups = append(groups, page.Resources...)
+		req.StartIndex += len(page.Resources)
+		if len(page.Resources) == 0 || req.StartIndex > int(page.TotalResults) {
+			return
+		}
+	}
+}
+
 // PatchR ...
 func (a GroupsAPI) PatchR(groupID string, r patchRequest) error {
 	return a.client.Scim(a.context, http.MethodPatch, fmt.Sprintf("/preview/scim/v2/Groups/%v", groupID), r, nil)
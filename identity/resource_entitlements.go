@@ -0,0 +1,205 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/databrickslabs/databricks-terraform/internal/util"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// EntitlementsEntity is the entitlements of a single user, group or service principal
+type EntitlementsEntity struct {
+	UserID                  string `json:"user_id,omitempty"`
+	GroupID                 string `json:"group_id,omitempty"`
+	ServicePrincipalID      string `json:"service_principal_id,omitempty"`
+	AllowClusterCreate      bool   `json:"allow_cluster_create,omitempty"`
+	AllowInstancePoolCreate bool   `json:"allow_instance_pool_create,omitempty"`
+	DatabricksSQLAccess     bool   `json:"databricks_sql_access,omitempty"`
+	WorkspaceAccess         bool   `json:"workspace_access,omitempty"`
+}
+
+// entitlementFields maps resource attributes to entitlements
+var entitlementFields = []struct {
+	field       string
+	entitlement Entitlement
+}{
+	{"allow_cluster_create", AllowClusterCreateEntitlement},
+	{"allow_instance_pool_create", AllowInstancePoolCreateEntitlement},
+	{"databricks_sql_access", DatabricksSQLAccessEntitlement},
+	{"workspace_access", WorkspaceAccessEntitlement},
+}
+
+// principalTypes maps the prefix of resource ID to principal attribute and SCIM endpoint
+var principalTypes = []struct {
+	prefix, field, endpoint string
+}{
+	{"user", "user_id", "Users"},
+	{"group", "group_id", "Groups"},
+	{"spn", "service_principal_id", "ServicePrincipals"},
+}
+
+// entitlementsPath returns SCIM path of the principal, that has entitlements with given resource ID
+func entitlementsPath(id string) (string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) == 2 && parts[1] != "" {
+		for _, pt := range principalTypes {
+			if pt.prefix == parts[0] {
+				return fmt.Sprintf("/preview/scim/v2/%s/%s", pt.endpoint, parts[1]), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("Invalid ID: %s. ID has to be in <user|group|spn>/<id> format", id)
+}
+
+// NewEntitlementsAPI creates EntitlementsAPI instance from provider meta
+func NewEntitlementsAPI(ctx context.Context, m interface{}) EntitlementsAPI {
+	return EntitlementsAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// EntitlementsAPI manages only entitlements of users, groups and service principals,
+// leaving all other attributes of the principal untouched
+type EntitlementsAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+// Read returns entitlements of the principal
+func (a EntitlementsAPI) Read(id string) (entitlements map[Entitlement]bool, err error) {
+	path, err := entitlementsPath(id)
+	if err != nil {
+		return
+	}
+	var principal struct {
+		Entitlements []entitlementsListItem `json:"entitlements,omitempty"`
+	}
+	err = a.client.Scim(a.context, http.MethodGet, path, nil, &principal)
+	if err != nil {
+		return
+	}
+	entitlements = map[Entitlement]bool{}
+	for _, e := range principal.Entitlements {
+		entitlements[e.Value] = true
+	}
+	return
+}
+
+// Patch adds and removes entitlements of the principal
+func (a EntitlementsAPI) Patch(id string, add, remove []Entitlement) error {
+	path, err := entitlementsPath(id)
+	if err != nil {
+		return err
+	}
+	request := GroupPatchRequest{
+		Schemas: []URN{PatchOp},
+	}
+	if len(add) > 0 {
+		operation := GroupPatchOperations{
+			Op:   "add",
+			Path: GroupEntitlementsPath,
+		}
+		for _, e := range add {
+			operation.Value = append(operation.Value, ValueListItem{Value: string(e)})
+		}
+		request.Operations = append(request.Operations, operation)
+	}
+	for _, e := range remove {
+		request.Operations = append(request.Operations, GroupPatchOperations{
+			Op:   "remove",
+			Path: GroupPathType(fmt.Sprintf("%s[value eq \"%s\"]", GroupEntitlementsPath, e)),
+		})
+	}
+	if len(request.Operations) == 0 {
+		return nil
+	}
+	return a.client.Scim(a.context, http.MethodPatch, path, request, nil)
+}
+
+// enabledEntitlements returns entitlements, that are set to true in resource data
+func enabledEntitlements(d *schema.ResourceData) (enabled []Entitlement) {
+	for _, ef := range entitlementFields {
+		if d.Get(ef.field).(bool) {
+			enabled = append(enabled, ef.entitlement)
+		}
+	}
+	return
+}
+
+// ResourceEntitlements manages entitlements of principals, that are managed elsewhere, e.g. provisioned by SCIM
+func ResourceEntitlements() *schema.Resource {
+	s := internal.StructToSchema(EntitlementsEntity{},
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			principals := []string{}
+			for _, pt := range principalTypes {
+				principals = append(principals, pt.field)
+			}
+			for _, pt := range principalTypes {
+				m[pt.field].ForceNew = true
+				m[pt.field].ExactlyOneOf = principals
+			}
+			return m
+		})
+	r := util.CommonResource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			for _, pt := range principalTypes {
+				if v, ok := d.GetOk(pt.field); ok {
+					d.SetId(fmt.Sprintf("%s/%s", pt.prefix, v))
+				}
+			}
+			return NewEntitlementsAPI(ctx, c).Patch(d.Id(), enabledEntitlements(d), nil)
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			entitlements, err := NewEntitlementsAPI(ctx, c).Read(d.Id())
+			if err != nil {
+				return err
+			}
+			parts := strings.SplitN(d.Id(), "/", 2)
+			for _, pt := range principalTypes {
+				if pt.prefix == parts[0] {
+					if err = d.Set(pt.field, parts[1]); err != nil {
+						return err
+					}
+				}
+			}
+			for _, ef := range entitlementFields {
+				if err = d.Set(ef.field, entitlements[ef.entitlement]); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var add, remove []Entitlement
+			for _, ef := range entitlementFields {
+				if !d.HasChange(ef.field) {
+					continue
+				}
+				if d.Get(ef.field).(bool) {
+					add = append(add, ef.entitlement)
+				} else {
+					remove = append(remove, ef.entitlement)
+				}
+			}
+			return NewEntitlementsAPI(ctx, c).Patch(d.Id(), add, remove)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			// only entitlements, that were set by this resource, are removed
+			return NewEntitlementsAPI(ctx, c).Patch(d.Id(), nil, enabledEntitlements(d))
+		},
+	}.ToResource()
+	r.Importer = &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData,
+			m interface{}) ([]*schema.ResourceData, error) {
+			if _, err := entitlementsPath(d.Id()); err != nil {
+				return nil, err
+			}
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+	return r
+}
@@ -0,0 +1,208 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceEntitlementsCreate_Group(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: GroupPatchRequest{
+					Schemas: []URN{PatchOp},
+					Operations: []GroupPatchOperations{
+						{
+							Op:   "add",
+							Path: GroupEntitlementsPath,
+							Value: []ValueListItem{
+								{Value: "allow-cluster-create"},
+								{Value: "databricks-sql-access"},
+							},
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Response: ScimGroup{
+					ID:          "abc",
+					DisplayName: "provisioned",
+					Members: []GroupMember{
+						{Value: "1"},
+					},
+					Entitlements: []entitlementsListItem{
+						{Value: AllowClusterCreateEntitlement},
+						{Value: DatabricksSQLAccessEntitlement},
+					},
+				},
+			},
+		},
+		Resource: ResourceEntitlements(),
+		Create:   true,
+		HCL: `
+		group_id = "abc"
+		allow_cluster_create = true
+		databricks_sql_access = true
+		`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "group/abc", d.Id())
+	assert.Equal(t, true, d.Get("allow_cluster_create"))
+	assert.Equal(t, true, d.Get("databricks_sql_access"))
+	assert.Equal(t, false, d.Get("workspace_access"))
+}
+
+func TestResourceEntitlementsRead_User(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/123",
+				Response: ScimUser{
+					ID:          "123",
+					UserName:    "someone@example.com",
+					DisplayName: "Someone",
+					Entitlements: []entitlementsListItem{
+						{Value: WorkspaceAccessEntitlement},
+					},
+				},
+			},
+		},
+		Resource: ResourceEntitlements(),
+		Read:     true,
+		New:      true,
+		ID:       "user/123",
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "123", d.Get("user_id"))
+	assert.Equal(t, true, d.Get("workspace_access"))
+	assert.Equal(t, false, d.Get("allow_cluster_create"))
+}
+
+func TestResourceEntitlementsRead_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/ServicePrincipals/123",
+				Status:   404,
+			},
+		},
+		Resource: ResourceEntitlements(),
+		Read:     true,
+		Removed:  true,
+		ID:       "spn/123",
+	}.ApplyNoError(t)
+}
+
+func TestResourceEntitlementsRead_InvalidID(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceEntitlements(),
+		Read:     true,
+		ID:       "123",
+	}.Apply(t)
+	assert.EqualError(t, err, "Invalid ID: 123. ID has to be in <user|group|spn>/<id> format")
+}
+
+func TestResourceEntitlementsUpdate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/ServicePrincipals/123",
+				ExpectedRequest: GroupPatchRequest{
+					Schemas: []URN{PatchOp},
+					Operations: []GroupPatchOperations{
+						{
+							Op:   "add",
+							Path: GroupEntitlementsPath,
+							Value: []ValueListItem{
+								{Value: "workspace-access"},
+							},
+						},
+						{
+							Op:   "remove",
+							Path: `entitlements[value eq "allow-cluster-create"]`,
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/ServicePrincipals/123",
+				Response: ScimUser{
+					ID: "123",
+					Entitlements: []entitlementsListItem{
+						{Value: DatabricksSQLAccessEntitlement},
+						{Value: WorkspaceAccessEntitlement},
+					},
+				},
+			},
+		},
+		Resource: ResourceEntitlements(),
+		Update:   true,
+		ID:       "spn/123",
+		InstanceState: map[string]string{
+			"service_principal_id":  "123",
+			"allow_cluster_create":  "true",
+			"databricks_sql_access": "true",
+		},
+		HCL: `
+		service_principal_id = "123"
+		databricks_sql_access = true
+		workspace_access = true
+		`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, false, d.Get("allow_cluster_create"))
+	assert.Equal(t, true, d.Get("workspace_access"))
+}
+
+func TestResourceEntitlementsDelete(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: GroupPatchRequest{
+					Schemas: []URN{PatchOp},
+					Operations: []GroupPatchOperations{
+						{
+							Op:   "remove",
+							Path: `entitlements[value eq "databricks-sql-access"]`,
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceEntitlements(),
+		Delete:   true,
+		ID:       "group/abc",
+		HCL: `
+		group_id = "abc"
+		databricks_sql_access = true
+		`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+}
+
+func TestResourceEntitlementsCreate_ManyPrincipals(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceEntitlements(),
+		Create:   true,
+		HCL: `
+		group_id = "abc"
+		user_id = "123"
+		workspace_access = true
+		`,
+	}.Apply(t)
+	assert.EqualError(t, err, "Invalid config supplied. [group_id] ExactlyOne. "+
+		"[service_principal_id] ExactlyOne. [user_id] ExactlyOne")
+}
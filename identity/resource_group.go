@@ -12,7 +12,7 @@ import (
 // ResourceGroup manages user groups
 func ResourceGroup() *schema.Resource {
 	readContext := func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-		group, err := NewGroupsAPI(ctx, m).Read(d.Id())
+		group, err := NewGroupsAPI(ctx, m).readWithoutMembers(d.Id())
 		if err != nil {
 			if e, ok := err.(common.APIError); ok && e.IsMissing() {
 				log.Printf("missing resource due to error: %v\n", e)
@@ -24,27 +24,21 @@ func ResourceGroup() *schema.Resource {
 		if err = d.Set("display_name", group.DisplayName); err != nil {
 			return diag.FromErr(err)
 		}
-		if err = d.Set("allow_cluster_create", isGroupClusterCreateEntitled(&group)); err != nil {
-			return diag.FromErr(err)
-		}
-		if err = d.Set("allow_instance_pool_create", isGroupInstancePoolCreateEntitled(&group)); err != nil {
-			return diag.FromErr(err)
+		// only entitlements, that are assigned to the group directly, are returned by SCIM API,
+		// so that entitlements inherited from parent groups don't cause configuration drift
+		for _, ef := range entitlementFields {
+			if err = d.Set(ef.field, isGroupEntitled(&group, ef.entitlement)); err != nil {
+				return diag.FromErr(err)
+			}
 		}
 		return nil
 	}
 	return &schema.Resource{
 		CreateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 			groupName := d.Get("display_name").(string)
-			allowClusterCreate := d.Get("allow_cluster_create").(bool)
-			allowInstancePoolCreate := d.Get("allow_instance_pool_create").(bool)
-
-			// If entitlement flags are set to be true
 			var entitlementsList []string
-			if allowClusterCreate {
-				entitlementsList = append(entitlementsList, string(AllowClusterCreateEntitlement))
-			}
-			if allowInstancePoolCreate {
-				entitlementsList = append(entitlementsList, string(AllowInstancePoolCreateEntitlement))
+			for _, e := range enabledEntitlements(d) {
+				entitlementsList = append(entitlementsList, string(e))
 			}
 			group, err := NewGroupsAPI(ctx, m).Create(groupName, nil, nil, entitlementsList)
 			if err != nil {
@@ -57,25 +51,16 @@ func ResourceGroup() *schema.Resource {
 			// Handle entitlements update
 			var entitlementsAddList []string
 			var entitlementsRemoveList []string
-			// If allow_cluster_create has changed
-			if d.HasChange("allow_cluster_create") {
-				allowClusterCreate := d.Get("allow_cluster_create").(bool)
-				// Changed to true
-				if allowClusterCreate {
-					entitlementsAddList = append(entitlementsAddList, string(AllowClusterCreateEntitlement))
+			for _, ef := range entitlementFields {
+				if !d.HasChange(ef.field) {
+					continue
 				}
-				// Changed to false
-				entitlementsRemoveList = append(entitlementsRemoveList, string(AllowClusterCreateEntitlement))
-			}
-			// If allow_instance_pool_create has changed
-			if d.HasChange("allow_instance_pool_create") {
-				allowClusterCreate := d.Get("allow_instance_pool_create").(bool)
-				// Changed to true
-				if allowClusterCreate {
-					entitlementsAddList = append(entitlementsAddList, string(AllowClusterCreateEntitlement))
+				if d.Get(ef.field).(bool) {
+					entitlementsAddList = append(entitlementsAddList, string(ef.entitlement))
+				} else {
+					// explicit remove operation, otherwise entitlement stays assigned
+					entitlementsRemoveList = append(entitlementsRemoveList, string(ef.entitlement))
 				}
-				// Changed to false
-				entitlementsRemoveList = append(entitlementsRemoveList, string(AllowClusterCreateEntitlement))
 			}
 			// TODO: not currently possible to update group display name
 			if entitlementsAddList != nil || entitlementsRemoveList != nil {
@@ -111,22 +96,21 @@ func ResourceGroup() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"databricks_sql_access": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"workspace_access": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
 		},
 	}
 }
 
-func isGroupClusterCreateEntitled(group *ScimGroup) bool {
-	for _, entitlement := range group.Entitlements {
-		if entitlement.Value == AllowClusterCreateEntitlement {
-			return true
-		}
-	}
-	return false
-}
-
-func isGroupInstancePoolCreateEntitled(group *ScimGroup) bool {
+func isGroupEntitled(group *ScimGroup, e Entitlement) bool {
 	for _, entitlement := range group.Entitlements {
-		if entitlement.Value == AllowClusterCreateEntitlement {
+		if entitlement.Value == e {
 			return true
 		}
 	}
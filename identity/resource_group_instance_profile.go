@@ -17,7 +17,7 @@ func ResourceGroupInstanceProfile() *schema.Resource {
 		return m
 	}).BindResource(util.BindResource{
 		ReadContext: func(ctx context.Context, groupID, roleARN string, c *common.DatabricksClient) error {
-			group, err := NewGroupsAPI(ctx, c).Read(groupID)
+			group, err := NewGroupsAPI(ctx, c).readWithoutMembers(groupID)
 			if err == nil && !group.HasRole(roleARN) {
 				return common.NotFound("Group has no instance profile")
 			}
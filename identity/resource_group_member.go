@@ -6,12 +6,44 @@ import (
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal/util"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// adminsGroupName is the display name of system group, that grants workspace admin privileges
+const adminsGroupName = "admins"
+
+// checkAdminsMemberRemoval fails, if member is about to be removed from the admins group without force,
+// because removing the wrong members could lock everyone out of the workspace administration
+func checkAdminsMemberRemoval(ctx context.Context, m interface{}, groupID string, force bool) error {
+	if force {
+		return nil
+	}
+	group, err := NewGroupsAPI(ctx, m).readWithoutMembers(groupID)
+	if e, ok := err.(common.APIError); ok && e.IsMissing() {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if group.DisplayName != adminsGroupName {
+		return nil
+	}
+	return fmt.Errorf("Removing members from %s group (id: %s) could revoke administrative access "+
+		"to the workspace. Please set force = true and apply it before removing the member", adminsGroupName, groupID)
+}
+
 // ResourceGroupMember bind group with member
 func ResourceGroupMember() *schema.Resource {
-	return util.NewPairID("group_id", "member_id").BindResource(util.BindResource{
+	p := util.NewPairID("group_id", "member_id").Schema(func(
+		m map[string]*schema.Schema) map[string]*schema.Schema {
+		m["force"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+		}
+		return m
+	})
+	r := p.BindResource(util.BindResource{
 		CreateContext: func(ctx context.Context, groupID, memberID string, c *common.DatabricksClient) error {
 			return NewGroupsAPI(ctx, c).PatchR(groupID, scimPatchRequest("add", "members", memberID))
 		},
@@ -27,4 +59,31 @@ func ResourceGroupMember() *schema.Resource {
 				"remove", fmt.Sprintf(`members[value eq "%s"]`, memberID), ""))
 		},
 	})
+	// force is the only attribute, that could be changed without re-creating the membership
+	r.Schema["force"].ForceNew = false
+	r.UpdateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		return nil
+	}
+	// replacement of membership removes member from the previous group
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if d.Id() == "" || (!d.HasChange("group_id") && !d.HasChange("member_id")) {
+			return nil
+		}
+		groupID, _ := d.GetChange("group_id")
+		force, _ := d.GetChange("force")
+		return checkAdminsMemberRemoval(ctx, m, groupID.(string), force.(bool))
+	}
+	deleteContext := r.DeleteContext
+	r.DeleteContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		groupID, _, err := p.Unpack(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = checkAdminsMemberRemoval(ctx, m, groupID, d.Get("force").(bool))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		return deleteContext(ctx, d, m)
+	}
+	return r
 }
@@ -1,11 +1,14 @@
 package identity
 
 import (
+	"context"
 	"testing"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceGroupMemberCreate(t *testing.T) {
@@ -159,6 +162,14 @@ func TestResourceGroupMemberRead_Error(t *testing.T) {
 func TestResourceGroupMemberDelete(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?excludedAttributes=members",
+				Response: ScimGroup{
+					ID:          "abc",
+					DisplayName: "Data Scientists",
+				},
+			},
 			{
 				Method:   "PATCH",
 				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
@@ -179,6 +190,14 @@ func TestResourceGroupMemberDelete(t *testing.T) {
 func TestResourceGroupMemberDelete_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?excludedAttributes=members",
+				Response: ScimGroup{
+					ID:          "abc",
+					DisplayName: "Data Scientists",
+				},
+			},
 			{
 				Method:   "PATCH",
 				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
@@ -196,3 +215,73 @@ func TestResourceGroupMemberDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc|bcd", d.Id())
 }
+
+func TestResourceGroupMemberDelete_Admins(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?excludedAttributes=members",
+				Response: ScimGroup{
+					ID:          "abc",
+					DisplayName: "admins",
+				},
+			},
+		},
+		Resource: ResourceGroupMember(),
+		Delete:   true,
+		ID:       "abc|bcd",
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Removing members from admins group (id: abc) could revoke")
+}
+
+func TestResourceGroupMemberDelete_AdminsForced(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				ExpectedRequest: scimPatchRequest(
+					"remove",
+					`members[value eq "bcd"]`,
+					""),
+			},
+		},
+		Resource: ResourceGroupMember(),
+		Delete:   true,
+		ID:       "abc|bcd",
+		HCL: `
+		group_id = "abc"
+		member_id = "bcd"
+		force = true
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+}
+
+func TestResourceGroupMemberReplace_Admins(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/Groups/abc?excludedAttributes=members",
+			Response: ScimGroup{
+				ID:          "abc",
+				DisplayName: "admins",
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+	_, err = ResourceGroupMember().Diff(context.Background(), &terraform.InstanceState{
+		ID: "abc|bcd",
+		Attributes: map[string]string{
+			"group_id":  "abc",
+			"member_id": "bcd",
+		},
+	}, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"group_id":  "abc",
+		"member_id": "cde",
+		"force":     true,
+	}), client)
+	qa.AssertErrorStartsWith(t, err, "Removing members from admins group (id: abc) could revoke")
+}
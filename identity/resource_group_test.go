@@ -1,11 +1,14 @@
 package identity
 
 import (
+	"context"
 	"testing"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceGroupCreate(t *testing.T) {
@@ -24,7 +27,7 @@ func TestResourceGroupCreate(t *testing.T) {
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?excludedAttributes=members",
 				Response: ScimGroup{
 					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
 					DisplayName: "Data Scientists",
@@ -42,6 +45,61 @@ func TestResourceGroupCreate(t *testing.T) {
 	assert.Equal(t, "abc", d.Id())
 }
 
+func TestResourceGroupCreate_Entitlements(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/preview/scim/v2/Groups",
+				ExpectedRequest: ScimGroup{
+					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					DisplayName: "Data Analysts",
+					Entitlements: []entitlementsListItem{
+						{
+							Value: DatabricksSQLAccessEntitlement,
+						},
+						{
+							Value: WorkspaceAccessEntitlement,
+						},
+					},
+				},
+				Response: ScimGroup{
+					ID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?excludedAttributes=members",
+				Response: ScimGroup{
+					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
+					DisplayName: "Data Analysts",
+					ID:          "abc",
+					Entitlements: []entitlementsListItem{
+						{
+							Value: DatabricksSQLAccessEntitlement,
+						},
+						{
+							Value: WorkspaceAccessEntitlement,
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceGroup(),
+		HCL: `
+		display_name = "Data Analysts"
+		databricks_sql_access = true
+		workspace_access = true
+		`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, true, d.Get("databricks_sql_access"))
+	assert.Equal(t, true, d.Get("workspace_access"))
+	assert.Equal(t, false, d.Get("allow_cluster_create"))
+}
+
 func TestResourceGroupCreate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -70,7 +128,7 @@ func TestResourceGroupRead(t *testing.T) {
 		Fixtures: []qa.HTTPFixture{
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?excludedAttributes=members",
 				Response: ScimGroup{
 					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
 					DisplayName: "Data Scientists",
@@ -89,12 +147,47 @@ func TestResourceGroupRead(t *testing.T) {
 	assert.Equal(t, "Data Scientists", d.Get("display_name"))
 }
 
+func TestResourceGroupRead_RolesFromInstanceProfileResource(t *testing.T) {
+	r := ResourceGroup()
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?excludedAttributes=members",
+				Response: ScimGroup{
+					DisplayName: "Data Scientists",
+					ID:          "abc",
+					Roles: []roleListItem{
+						{
+							Value: "arn:aws:iam::999999999999:instance-profile/my-fake-instance-profile",
+						},
+					},
+				},
+			},
+		},
+		Resource: r,
+		Read:     true,
+		ID:       "abc",
+		State: map[string]interface{}{
+			"display_name": "Data Scientists",
+		},
+	}.Apply(t)
+	require.NoError(t, err, err)
+
+	// roles are managed by databricks_group_instance_profile, so they must not show up in the plan
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"display_name": "Data Scientists",
+	}), nil)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "plan is not stable: %v", diff)
+}
+
 func TestResourceGroupRead_NotFound(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?excludedAttributes=members",
 				Response: common.APIErrorBody{
 					ErrorCode: "NOT_FOUND",
 					Message:   "Item not found",
@@ -114,7 +207,7 @@ func TestResourceGroupRead_Error(t *testing.T) {
 		Fixtures: []qa.HTTPFixture{
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?excludedAttributes=members",
 				Response: common.APIErrorBody{
 					ErrorCode: "INVALID_REQUEST",
 					Message:   "Internal error happened",
@@ -136,7 +229,7 @@ func TestResourceGroupUpdate(t *testing.T) {
 			{
 				Method:   "PATCH",
 				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
-				Response: GroupPatchRequest{
+				ExpectedRequest: GroupPatchRequest{
 					Schemas: []URN{"urn:ietf:params:scim:api:messages:2.0:PatchOp"},
 					Operations: []GroupPatchOperations{
 						{
@@ -144,37 +237,57 @@ func TestResourceGroupUpdate(t *testing.T) {
 							Path: "entitlements",
 							Value: []ValueListItem{
 								{
-									Value: "allow-cluster-create",
+									Value: "allow-instance-pool-create",
+								},
+								{
+									Value: "workspace-access",
 								},
 							},
 						},
 						{
 							Op:   "remove",
-							Path: "entitlements[value eq \"allow-cluster-create\"]",
+							Path: "entitlements[value eq \"databricks-sql-access\"]",
 						},
 					},
 				},
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/preview/scim/v2/Groups/abc",
+				Resource: "/api/2.0/preview/scim/v2/Groups/abc?excludedAttributes=members",
 				Response: ScimGroup{
 					Schemas:     []URN{"urn:ietf:params:scim:schemas:core:2.0:Group"},
 					DisplayName: "Data Ninjas",
 					ID:          "abc",
+					Entitlements: []entitlementsListItem{
+						{
+							Value: AllowInstancePoolCreateEntitlement,
+						},
+						{
+							Value: WorkspaceAccessEntitlement,
+						},
+					},
 				},
 			},
 		},
 		Resource: ResourceGroup(),
-		State: map[string]interface{}{
-			"display_name":               "Data Ninjas",
-			"allow_instance_pool_create": true,
+		InstanceState: map[string]string{
+			"display_name":          "Data Ninjas",
+			"databricks_sql_access": "true",
 		},
+		HCL: `
+		display_name = "Data Ninjas"
+		allow_instance_pool_create = true
+		workspace_access = true
+		`,
 		Update: true,
 		ID:     "abc",
 	}.Apply(t)
-	assert.NoError(t, err, err)
+	require.NoError(t, err, err)
 	assert.Equal(t, "abc", d.Id(), "Id should be the same as in reading")
+	assert.Equal(t, false, d.Get("allow_cluster_create"))
+	assert.Equal(t, true, d.Get("allow_instance_pool_create"))
+	assert.Equal(t, false, d.Get("databricks_sql_access"))
+	assert.Equal(t, true, d.Get("workspace_access"))
 }
 
 func TestResourceGroupUpdate_Error(t *testing.T) {
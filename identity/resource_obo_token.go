@@ -0,0 +1,108 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/databrickslabs/databricks-terraform/internal/util"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// OboToken is a request for token on behalf of service principal
+type OboToken struct {
+	ApplicationID   string `json:"application_id"`
+	Comment         string `json:"comment,omitempty"`
+	LifetimeSeconds int32  `json:"lifetime_seconds,omitempty"`
+}
+
+// TokenManagementAPI exposes tokens of all users and service principals in the workspace
+type TokenManagementAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+// NewTokenManagementAPI creates TokenManagementAPI instance from provider meta
+func NewTokenManagementAPI(ctx context.Context, m interface{}) TokenManagementAPI {
+	return TokenManagementAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// CreateOnBehalfOf creates token for service principal
+func (a TokenManagementAPI) CreateOnBehalfOf(request OboToken) (r TokenResponse, err error) {
+	err = a.client.Post(a.context, "/token-management/on-behalf-of/tokens", request, &r)
+	if e, ok := err.(common.APIError); ok && e.StatusCode == http.StatusForbidden {
+		err = fmt.Errorf("%s. Service principal %s needs CAN_USE permission on tokens, that could be "+
+			"granted with databricks_permissions resource and authorization = \"tokens\"",
+			e.Message, request.ApplicationID)
+	}
+	return
+}
+
+// Read returns metadata of the token
+func (a TokenManagementAPI) Read(tokenID string) (ti TokenInfo, err error) {
+	var r TokenResponse
+	err = a.client.Get(a.context, fmt.Sprintf("/token-management/tokens/%s", tokenID), nil, &r)
+	if err != nil {
+		return
+	}
+	if r.TokenInfo != nil {
+		ti = *r.TokenInfo
+	}
+	return
+}
+
+// Delete revokes the token
+func (a TokenManagementAPI) Delete(tokenID string) error {
+	return a.client.Delete(a.context, fmt.Sprintf("/token-management/tokens/%s", tokenID), nil)
+}
+
+// ResourceOboToken manages tokens of service principals
+func ResourceOboToken() *schema.Resource {
+	s := internal.StructToSchema(OboToken{},
+		func(m map[string]*schema.Schema) map[string]*schema.Schema {
+			m["token_value"] = &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			}
+			return m
+		})
+	r := util.CommonResource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var request OboToken
+			if err := internal.DataToStructPointer(d, s, &request); err != nil {
+				return err
+			}
+			ot, err := NewTokenManagementAPI(ctx, c).CreateOnBehalfOf(request)
+			if err != nil {
+				return err
+			}
+			if ot.TokenInfo == nil {
+				return fmt.Errorf("Token for %s is created without token info", request.ApplicationID)
+			}
+			d.SetId(ot.TokenInfo.TokenID)
+			return d.Set("token_value", ot.TokenValue)
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			// token, that is revoked elsewhere, is not found and has to be created again
+			tokenInfo, err := NewTokenManagementAPI(ctx, c).Read(d.Id())
+			if err != nil {
+				return err
+			}
+			if tokenInfo.expiresWithin(0) {
+				// expired tokens are still returned for some time, but cannot be used anymore
+				return common.NotFound(fmt.Sprintf("Token %s has expired", d.Id()))
+			}
+			return nil
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			return NewTokenManagementAPI(ctx, c).Delete(d.Id())
+		},
+	}.ToResource()
+	// token value is only available right after creation
+	r.Importer = nil
+	return r
+}
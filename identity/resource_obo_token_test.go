@@ -0,0 +1,148 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceOboTokenCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token-management/on-behalf-of/tokens",
+				ExpectedRequest: OboToken{
+					ApplicationID:   "abc",
+					Comment:         "bootstrap",
+					LifetimeSeconds: 3600,
+				},
+				Response: TokenResponse{
+					TokenValue: "dapi...",
+					TokenInfo: &TokenInfo{
+						TokenID: "bcd",
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/token-management/tokens/bcd",
+				Response: TokenResponse{
+					TokenInfo: &TokenInfo{
+						TokenID: "bcd",
+						Comment: "bootstrap",
+					},
+				},
+			},
+		},
+		Resource: ResourceOboToken(),
+		HCL: `
+		application_id = "abc"
+		comment = "bootstrap"
+		lifetime_seconds = 3600
+		`,
+		Create: true,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "bcd", d.Id())
+	assert.Equal(t, "dapi...", d.Get("token_value"))
+}
+
+func TestResourceOboTokenCreate_NoTokenPermission(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token-management/on-behalf-of/tokens",
+				Status:   403,
+				Response: common.APIErrorBody{
+					ErrorCode: "PERMISSION_DENIED",
+					Message:   "User abc does not have permission to use tokens",
+				},
+			},
+		},
+		Resource: ResourceOboToken(),
+		HCL:      `application_id = "abc"`,
+		Create:   true,
+	}.Apply(t)
+	assert.EqualError(t, err, "User abc does not have permission to use tokens. Service principal abc "+
+		"needs CAN_USE permission on tokens, that could be granted with databricks_permissions "+
+		"resource and authorization = \"tokens\"")
+}
+
+func TestResourceOboTokenCreate_NoTokenInfo(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token-management/on-behalf-of/tokens",
+				Response: TokenResponse{
+					TokenValue: "dapi...",
+				},
+			},
+		},
+		Resource: ResourceOboToken(),
+		HCL:      `application_id = "abc"`,
+		Create:   true,
+	}.Apply(t)
+	assert.EqualError(t, err, "Token for abc is created without token info")
+}
+
+func TestResourceOboTokenRead_Revoked(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/token-management/tokens/bcd",
+				Status:   404,
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Token bcd does not exist",
+				},
+			},
+		},
+		Resource: ResourceOboToken(),
+		Read:     true,
+		Removed:  true,
+		ID:       "bcd",
+	}.ApplyNoError(t)
+}
+
+func TestResourceOboTokenRead_Expired(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/token-management/tokens/bcd",
+				Response: TokenResponse{
+					TokenInfo: &TokenInfo{
+						TokenID:    "bcd",
+						ExpiryTime: 1617000000000,
+					},
+				},
+			},
+		},
+		Resource: ResourceOboToken(),
+		Read:     true,
+		Removed:  true,
+		ID:       "bcd",
+	}.ApplyNoError(t)
+}
+
+func TestResourceOboTokenDelete(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/token-management/tokens/bcd",
+			},
+		},
+		Resource: ResourceOboToken(),
+		Delete:   true,
+		ID:       "bcd",
+	}.Apply(t)
+	require.NoError(t, err, err)
+}
@@ -3,6 +3,9 @@ package identity
 import (
 	"context"
 	"fmt"
+	"log"
+	"net/http"
+	"strings"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal"
@@ -23,8 +26,9 @@ type ServicePrincipalsAPI struct {
 
 // ServicePrincipalEntity entity from which resource schema is made
 type ServicePrincipalEntity struct {
-	ApplicationID           string `json:"application_id"`
+	ApplicationID           string `json:"application_id,omitempty" tf:"computed"`
 	DisplayName             string `json:"display_name,omitempty" tf:"computed"`
+	ExternalID              string `json:"external_id,omitempty"`
 	Active                  bool   `json:"active,omitempty"`
 	AllowClusterCreate      bool   `json:"allow_cluster_create,omitempty"`
 	AllowInstancePoolCreate bool   `json:"allow_instance_pool_create,omitempty"`
@@ -45,12 +49,25 @@ func (sp ServicePrincipalEntity) toRequest() ScimUser {
 	return ScimUser{
 		Schemas:       []URN{ServicePrincipalSchema},
 		ApplicationID: sp.ApplicationID,
+		ExternalID:    sp.ExternalID,
 		Active:        sp.Active,
 		DisplayName:   sp.DisplayName,
 		Entitlements:  entitlements,
 	}
 }
 
+// validate checks identifiers of service principal. Azure Databricks uses applications from AAD,
+// where others generate application ID and need a display name instead.
+func (sp ServicePrincipalEntity) validate(isAzure bool) error {
+	if isAzure && sp.ApplicationID == "" {
+		return fmt.Errorf("application_id is required for service principals in Azure Databricks")
+	}
+	if sp.ApplicationID == "" && sp.DisplayName == "" {
+		return fmt.Errorf("display_name is required for service principals without application_id")
+	}
+	return nil
+}
+
 // CreateR ..
 func (a ServicePrincipalsAPI) CreateR(rsp ServicePrincipalEntity) (sp ScimUser, err error) {
 	err = a.client.Scim(a.context, "POST", "/preview/scim/v2/ServicePrincipals", rsp.toRequest(), &sp)
@@ -65,6 +82,7 @@ func (a ServicePrincipalsAPI) ReadR(servicePrincipalID string) (rsp ServicePrinc
 	}
 	rsp.ApplicationID = servicePrincipal.ApplicationID
 	rsp.DisplayName = servicePrincipal.DisplayName
+	rsp.ExternalID = servicePrincipal.ExternalID
 	rsp.Active = servicePrincipal.Active
 	for _, ent := range servicePrincipal.Entitlements {
 		switch ent.Value {
@@ -83,6 +101,22 @@ func (a ServicePrincipalsAPI) read(servicePrincipalID string) (sp ScimUser, err
 	return
 }
 
+// ReadByApplicationID returns service principal with given application ID
+func (a ServicePrincipalsAPI) ReadByApplicationID(applicationID string) (sp ScimUser, err error) {
+	var servicePrincipals UserList
+	err = a.client.Scim(a.context, "GET", "/preview/scim/v2/ServicePrincipals", map[string]string{
+		"filter": fmt.Sprintf("applicationId eq %s", applicationID),
+	}, &servicePrincipals)
+	if err != nil {
+		return
+	}
+	if len(servicePrincipals.Resources) == 0 {
+		err = fmt.Errorf("Cannot find service principal with application_id %s", applicationID)
+		return
+	}
+	return servicePrincipals.Resources[0], nil
+}
+
 // UpdateR replaces resource-friendly-entity
 func (a ServicePrincipalsAPI) UpdateR(servicePrincipalID string, rsp ServicePrincipalEntity) error {
 	servicePrincipal, err := a.read(servicePrincipalID)
@@ -103,10 +137,25 @@ func (a ServicePrincipalsAPI) PatchR(servicePrincipalID string, r patchRequest)
 			servicePrincipalID), r, nil)
 }
 
-// Delete will delete the servicePrincipal given the servicePrincipal id
+// Delete will delete the servicePrincipal given the servicePrincipal id. Service principals,
+// that are not allowed to be deleted, e.g. synced from identity provider, are deactivated instead.
 func (a ServicePrincipalsAPI) Delete(servicePrincipalID string) error {
 	servicePrincipalPath := fmt.Sprintf("/preview/scim/v2/ServicePrincipals/%v", servicePrincipalID)
-	return a.client.Scim(a.context, "DELETE", servicePrincipalPath, nil, nil)
+	err := a.client.Scim(a.context, "DELETE", servicePrincipalPath, nil, nil)
+	if e, ok := err.(common.APIError); !ok || e.StatusCode != http.StatusForbidden {
+		return err
+	}
+	log.Printf("[WARN] Cannot delete service principal %s, deactivating it: %s", servicePrincipalID, err)
+	return a.PatchR(servicePrincipalID, patchRequest{
+		Schemas: []URN{PatchOp},
+		Operations: []patchOperation{
+			{
+				Op:    "replace",
+				Path:  "active",
+				Value: false,
+			},
+		},
+	})
 }
 
 // ResourceServicePrincipal manages service principals within workspace
@@ -117,13 +166,16 @@ func ResourceServicePrincipal() *schema.Resource {
 		s["active"].Default = true
 		return s
 	})
-	return util.CommonResource{
+	r := util.CommonResource{
 		Schema: servicePrincipalSchema,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var sp ServicePrincipalEntity
 			if err := internal.DataToStructPointer(d, servicePrincipalSchema, &sp); err != nil {
 				return err
 			}
+			if err := sp.validate(c.IsAzure()); err != nil {
+				return err
+			}
 			servicePrincipal, err := NewServicePrincipalsAPI(ctx, c).CreateR(sp)
 			if err != nil {
 				return err
@@ -149,4 +201,20 @@ func ResourceServicePrincipal() *schema.Resource {
 			return NewServicePrincipalsAPI(ctx, c).Delete(d.Id())
 		},
 	}.ToResource()
+	r.Importer = &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData,
+			m interface{}) ([]*schema.ResourceData, error) {
+			if !strings.Contains(d.Id(), "-") {
+				// SCIM identifiers are numeric, where application IDs are UUIDs
+				return []*schema.ResourceData{d}, nil
+			}
+			sp, err := NewServicePrincipalsAPI(ctx, m).ReadByApplicationID(d.Id())
+			if err != nil {
+				return nil, err
+			}
+			d.SetId(sp.ID)
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+	return r
 }
@@ -0,0 +1,43 @@
+package identity
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/util"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ResourceServicePrincipalRole binds service principal and instance profile
+func ResourceServicePrincipalRole() *schema.Resource {
+	return util.NewPairID("service_principal_id", "role").Schema(func(
+		m map[string]*schema.Schema) map[string]*schema.Schema {
+		m["role"].ValidateDiagFunc = ValidInstanceProfile
+		return m
+	}).BindResource(util.BindResource{
+		CreateContext: func(ctx context.Context, servicePrincipalID, roleARN string, c *common.DatabricksClient) error {
+			_, err := NewInstanceProfilesAPI(ctx, c).Read(roleARN)
+			if e, ok := err.(common.APIError); ok && e.IsMissing() {
+				return fmt.Errorf("Instance profile %s is not registered in the workspace. "+
+					"Please add it with databricks_instance_profile resource first", roleARN)
+			}
+			if err != nil {
+				return err
+			}
+			return NewServicePrincipalsAPI(ctx, c).PatchR(servicePrincipalID,
+				scimPatchRequest("add", "roles", roleARN))
+		},
+		ReadContext: func(ctx context.Context, servicePrincipalID, roleARN string, c *common.DatabricksClient) error {
+			servicePrincipal, err := NewServicePrincipalsAPI(ctx, c).read(servicePrincipalID)
+			if err == nil && !servicePrincipal.HasRole(roleARN) {
+				return common.NotFound("Service principal has no role")
+			}
+			return err
+		},
+		DeleteContext: func(ctx context.Context, servicePrincipalID, roleARN string, c *common.DatabricksClient) error {
+			return NewServicePrincipalsAPI(ctx, c).PatchR(servicePrincipalID, scimPatchRequest(
+				"remove", fmt.Sprintf(`roles[value eq "%s"]`, roleARN), ""))
+		},
+	})
+}
@@ -0,0 +1,109 @@
+package identity
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+const testRoleARN = "arn:aws:iam::999999999999:instance-profile/my-fake-instance-profile"
+
+func TestResourceServicePrincipalRoleCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-profiles/list",
+				Response: InstanceProfileList{
+					InstanceProfiles: []InstanceProfileInfo{
+						{
+							InstanceProfileArn: testRoleARN,
+						},
+					},
+				},
+			},
+			{
+				Method:          "PATCH",
+				Resource:        "/api/2.0/preview/scim/v2/ServicePrincipals/abc",
+				ExpectedRequest: scimPatchRequest("add", "roles", testRoleARN),
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/ServicePrincipals/abc",
+				Response: ScimUser{
+					ID: "abc",
+					Roles: []roleListItem{
+						{testRoleARN},
+					},
+				},
+			},
+		},
+		Resource: ResourceServicePrincipalRole(),
+		State: map[string]interface{}{
+			"service_principal_id": "abc",
+			"role":                 testRoleARN,
+		},
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc|"+testRoleARN, d.Id())
+}
+
+func TestResourceServicePrincipalRoleCreate_NotRegistered(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/instance-profiles/list",
+				Response: InstanceProfileList{},
+			},
+		},
+		Resource: ResourceServicePrincipalRole(),
+		State: map[string]interface{}{
+			"service_principal_id": "abc",
+			"role":                 testRoleARN,
+		},
+		Create: true,
+	}.Apply(t)
+	assert.EqualError(t, err, "Instance profile "+testRoleARN+" is not registered in the workspace. "+
+		"Please add it with databricks_instance_profile resource first")
+}
+
+func TestResourceServicePrincipalRoleRead_NoRole(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/ServicePrincipals/abc",
+				Response: ScimUser{
+					ID: "abc",
+					Roles: []roleListItem{
+						{"arn:aws:iam::999999999999:instance-profile/other"},
+					},
+				},
+			},
+		},
+		Resource: ResourceServicePrincipalRole(),
+		Read:     true,
+		Removed:  true,
+		ID:       "abc|" + testRoleARN,
+	}.ApplyNoError(t)
+}
+
+func TestResourceServicePrincipalRoleDelete(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/ServicePrincipals/abc",
+				ExpectedRequest: scimPatchRequest(
+					"remove", `roles[value eq "`+testRoleARN+`"]`, ""),
+			},
+		},
+		Resource: ResourceServicePrincipalRole(),
+		Delete:   true,
+		ID:       "abc|" + testRoleARN,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+}
@@ -1,6 +1,7 @@
 package identity
 
 import (
+	"context"
 	"testing"
 
 	"github.com/databrickslabs/databricks-terraform/common"
@@ -343,3 +344,136 @@ func TestResourceServicePrincipalDelete_Error(t *testing.T) {
 	}.Apply(t)
 	require.Error(t, err, err)
 }
+
+func TestResourceServicePrincipalCreate_AzureRequiresApplicationID(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceServicePrincipal(),
+		Create:   true,
+		Azure:    true,
+		HCL: `
+		display_name = "Automation"
+		`,
+	}.Apply(t)
+	assert.EqualError(t, err, "application_id is required for service principals in Azure Databricks")
+}
+
+func TestResourceServicePrincipalCreate_GeneratedApplicationID(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/preview/scim/v2/ServicePrincipals",
+				ExpectedRequest: ScimUser{
+					DisplayName:  "Automation",
+					ExternalID:   "ext-1",
+					Active:       true,
+					Entitlements: []entitlementsListItem{},
+					Schemas:      []URN{ServicePrincipalSchema},
+				},
+				Response: ScimUser{
+					ID: "abc",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/ServicePrincipals/abc",
+				Response: ScimUser{
+					ID:            "abc",
+					DisplayName:   "Automation",
+					ExternalID:    "ext-1",
+					Active:        true,
+					ApplicationID: "00000000-0000-0000-0000-000000000001",
+				},
+			},
+		},
+		Resource: ResourceServicePrincipal(),
+		Create:   true,
+		HCL: `
+		display_name = "Automation"
+		external_id = "ext-1"
+		`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", d.Get("application_id"))
+	assert.Equal(t, "ext-1", d.Get("external_id"))
+}
+
+func TestResourceServicePrincipalCreate_NoIdentifiers(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceServicePrincipal(),
+		Create:   true,
+		HCL: `
+		allow_cluster_create = true
+		`,
+	}.Apply(t)
+	assert.EqualError(t, err, "display_name is required for service principals without application_id")
+}
+
+func TestResourceServicePrincipalDelete_Deactivates(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/preview/scim/v2/ServicePrincipals/abc",
+				Status:   403,
+				Response: common.APIErrorBody{
+					ErrorCode: "PERMISSION_DENIED",
+					Message:   "Service principal is synced from identity provider",
+				},
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/ServicePrincipals/abc",
+				ExpectedRequest: patchRequest{
+					Schemas: []URN{PatchOp},
+					Operations: []patchOperation{
+						{
+							Op:    "replace",
+							Path:  "active",
+							Value: false,
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceServicePrincipal(),
+		Delete:   true,
+		ID:       "abc",
+	}.Apply(t)
+	require.NoError(t, err, err)
+}
+
+func TestResourceServicePrincipalImport_ApplicationID(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/preview/scim/v2/ServicePrincipals?filter=applicationId%20eq%2000000000-0000-0000-0000-000000000000",
+			Response: UserList{
+				Resources: []ScimUser{
+					{
+						ID:            "abc",
+						ApplicationID: "00000000-0000-0000-0000-000000000000",
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+	r := ResourceServicePrincipal()
+	ctx := context.Background()
+
+	d := r.TestResourceData()
+	d.SetId("00000000-0000-0000-0000-000000000000")
+	imported, err := r.Importer.StateContext(ctx, d, client)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	assert.Equal(t, "abc", imported[0].Id())
+
+	d = r.TestResourceData()
+	d.SetId("123")
+	imported, err = r.Importer.StateContext(ctx, d, client)
+	require.NoError(t, err)
+	assert.Equal(t, "123", imported[0].Id())
+}
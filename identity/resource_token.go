@@ -3,6 +3,7 @@ package identity
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/http"
 	"time"
 
@@ -32,6 +33,15 @@ type TokenInfo struct {
 	Comment      string `json:"comment,omitempty"`
 }
 
+// expiresWithin returns true, if token expires within given duration from now. Tokens without
+// lifetime have negative expiry time and never expire.
+func (ti TokenInfo) expiresWithin(window time.Duration) bool {
+	if ti.ExpiryTime <= 0 {
+		return false
+	}
+	return time.Now().Add(window).UnixNano()/int64(time.Millisecond) >= ti.ExpiryTime
+}
+
 // TokenList ...
 type TokenList struct {
 	TokenInfos []TokenInfo `json:"token_infos,omitempty"`
@@ -103,7 +113,6 @@ func ResourceToken() *schema.Resource {
 		"comment": {
 			Type:     schema.TypeString,
 			Optional: true,
-			ForceNew: true,
 			Default:  "",
 		},
 		"token_value": {
@@ -126,8 +135,18 @@ func ResourceToken() *schema.Resource {
 			Optional: true,
 			Computed: true,
 		},
+		"rotate_before_expiry": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+				if _, err := time.ParseDuration(v.(string)); err != nil {
+					es = append(es, fmt.Errorf("%s is not a valid duration: %w", k, err))
+				}
+				return
+			},
+		},
 	}
-	return util.CommonResource{
+	r := util.CommonResource{
 		Schema: s,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			comment := d.Get("comment").(string)
@@ -145,10 +164,62 @@ func ResourceToken() *schema.Resource {
 			if err != nil {
 				return err
 			}
+			if tokenInfo.expiresWithin(0) {
+				// expired tokens are still listed for some time, but cannot be used anymore
+				return common.NotFound(fmt.Sprintf("Token %s has expired", d.Id()))
+			}
+			if !d.IsNewResource() {
+				// comment cannot be changed through API, so the one from the state is kept
+				tokenInfo.Comment = d.Get("comment").(string)
+			}
 			return internal.StructToData(tokenInfo, s, d)
 		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			// comment and rotate_before_expiry are changed only in the state without re-creating the token
+			return nil
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			rotateBeforeExpiry := d.Get("rotate_before_expiry").(string)
+			if d.Id() == "" || rotateBeforeExpiry == "" {
+				return nil
+			}
+			window, err := time.ParseDuration(rotateBeforeExpiry)
+			if err != nil {
+				return err
+			}
+			tokenInfo := TokenInfo{ExpiryTime: int64(d.Get("expiry_time").(int))}
+			if !tokenInfo.expiresWithin(window) {
+				return nil
+			}
+			log.Printf("[INFO] Token %s expires within %s and will be rotated", d.Id(), window)
+			if err = d.SetNewComputed("token_value"); err != nil {
+				return err
+			}
+			return d.ForceNew("token_value")
+		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			return NewTokensAPI(ctx, c).Delete(d.Id())
 		},
 	}.ToResource()
+	r.Importer = &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData,
+			m interface{}) ([]*schema.ResourceData, error) {
+			tokenInfo, err := NewTokensAPI(ctx, m).Read(d.Id())
+			if err != nil {
+				return nil, err
+			}
+			// value of imported token is not available, so token_value stays empty
+			if err = d.Set("comment", tokenInfo.Comment); err != nil {
+				return nil, err
+			}
+			if tokenInfo.ExpiryTime > 0 {
+				lifetime := (tokenInfo.ExpiryTime - tokenInfo.CreationTime) / 1000
+				if err = d.Set("lifetime_seconds", lifetime); err != nil {
+					return nil, err
+				}
+			}
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+	return r
 }
@@ -2,13 +2,16 @@ package identity
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResourceTokenRead(t *testing.T) {
@@ -22,7 +25,7 @@ func TestResourceTokenRead(t *testing.T) {
 						{
 							Comment:      "Hello, world!",
 							CreationTime: 10,
-							ExpiryTime:   20,
+							ExpiryTime:   -1,
 							TokenID:      "abc",
 						},
 					},
@@ -38,7 +41,7 @@ func TestResourceTokenRead(t *testing.T) {
 	assert.Equal(t, "abc", d.Id(), "Id should not be empty")
 	assert.Equal(t, "Hello, world!", d.Get("comment"))
 	assert.Equal(t, 10, d.Get("creation_time"))
-	assert.Equal(t, 20, d.Get("expiry_time"))
+	assert.Equal(t, -1, d.Get("expiry_time"))
 	assert.Equal(t, "", d.Get("token_value"))
 }
 
@@ -114,7 +117,7 @@ func TestResourceTokenCreate(t *testing.T) {
 						{
 							Comment:      "Hello, world!",
 							CreationTime: 10,
-							ExpiryTime:   20,
+							ExpiryTime:   -1,
 							TokenID:      "abc",
 						},
 					},
@@ -197,6 +200,136 @@ func TestResourceTokenDelete_Error(t *testing.T) {
 	assert.Equal(t, "abc", d.Id())
 }
 
+func TestResourceTokenRead_Expired(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/token/list",
+				Response: TokenList{
+					TokenInfos: []TokenInfo{
+						{
+							CreationTime: 10,
+							ExpiryTime:   20,
+							TokenID:      "abc",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceToken(),
+		Read:     true,
+		Removed:  true,
+		ID:       "abc",
+	}.ApplyNoError(t)
+}
+
+func tokenDiff(t *testing.T, expiresIn time.Duration, rotateBeforeExpiry string) *terraform.InstanceDiff {
+	expiryTime := time.Now().Add(expiresIn).UnixNano() / int64(time.Millisecond)
+	diff, err := ResourceToken().Diff(context.Background(), &terraform.InstanceState{
+		ID: "abc",
+		Attributes: map[string]string{
+			"lifetime_seconds":     "86400",
+			"comment":              "",
+			"token_value":          "dapi...",
+			"token_id":             "abc",
+			"creation_time":        "10",
+			"expiry_time":          fmt.Sprintf("%d", expiryTime),
+			"rotate_before_expiry": rotateBeforeExpiry,
+		},
+	}, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"lifetime_seconds":     86400,
+		"rotate_before_expiry": rotateBeforeExpiry,
+	}), nil)
+	require.NoError(t, err)
+	return diff
+}
+
+func TestResourceTokenUpdate_Comment(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/token/list",
+				Response: TokenList{
+					TokenInfos: []TokenInfo{
+						{
+							Comment:      "Old comment",
+							CreationTime: 10,
+							ExpiryTime:   -1,
+							TokenID:      "abc",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceToken(),
+		Update:   true,
+		ID:       "abc",
+		InstanceState: map[string]string{
+			"lifetime_seconds": "0",
+			"comment":          "Old comment",
+			"token_value":      "dapi...",
+			"token_id":         "abc",
+			"creation_time":    "10",
+			"expiry_time":      "-1",
+		},
+		HCL: `comment = "New comment"`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, "New comment", d.Get("comment"))
+	assert.Equal(t, "dapi...", d.Get("token_value"))
+}
+
+func TestResourceTokenImport(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/token/list",
+			Response: TokenList{
+				TokenInfos: []TokenInfo{
+					{
+						Comment:      "Created in UI",
+						CreationTime: 10000,
+						ExpiryTime:   8650000,
+						TokenID:      "abc",
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	r := ResourceToken()
+	d := r.TestResourceData()
+	d.SetId("abc")
+	imported, err := r.Importer.StateContext(context.Background(), d, client)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	assert.Equal(t, "Created in UI", d.Get("comment"))
+	assert.Equal(t, 8640, d.Get("lifetime_seconds"))
+	assert.Equal(t, "", d.Get("token_value"))
+}
+
+func TestResourceTokenDiff_RotatesBeforeExpiry(t *testing.T) {
+	diff := tokenDiff(t, 2*time.Hour, "24h")
+	require.NotNil(t, diff)
+	assert.True(t, diff.RequiresNew())
+	assert.True(t, diff.Attributes["token_value"].NewComputed)
+}
+
+func TestResourceTokenDiff_NotNearExpiry(t *testing.T) {
+	diff := tokenDiff(t, 48*time.Hour, "24h")
+	assert.True(t, diff.Empty())
+}
+
+func TestResourceTokenDiff_NoRotation(t *testing.T) {
+	diff := tokenDiff(t, 2*time.Hour, "")
+	assert.True(t, diff.Empty())
+}
+
 func TestAccCreateToken(t *testing.T) {
 	if _, ok := os.LookupEnv("CLOUD_ENV"); !ok {
 		t.Skip("Acceptance tests skipped unless env 'CLOUD_ENV' is set")
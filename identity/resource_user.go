@@ -15,6 +15,10 @@ func ResourceUser() *schema.Resource {
 		s map[string]*schema.Schema) map[string]*schema.Schema {
 		s["user_name"].ForceNew = true
 		s["active"].Default = true
+		s["disable_as_user_deletion"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+		}
 		return s
 	})
 	return util.CommonResource{
@@ -46,6 +50,9 @@ func ResourceUser() *schema.Resource {
 			return NewUsersAPI(ctx, c).Update(d.Id(), ru)
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			if d.Get("disable_as_user_deletion").(bool) {
+				return NewUsersAPI(ctx, c).Deactivate(d.Id())
+			}
 			return NewUsersAPI(ctx, c).Delete(d.Id())
 		},
 	}.ToResource()
@@ -20,7 +20,7 @@ func ResourceUserInstanceProfile() *schema.Resource {
 			return NewUsersAPI(ctx, c).Patch(userID, scimPatchRequest("add", "roles", roleARN))
 		},
 		ReadContext: func(ctx context.Context, userID, roleARN string, c *common.DatabricksClient) error {
-			user, err := NewUsersAPI(ctx, c).read(userID)
+			user, err := NewUsersAPI(ctx, c).readAttributes(userID, "roles")
 			if err == nil && !user.HasRole(roleARN) {
 				return common.NotFound("User has no role")
 			}
@@ -14,7 +14,7 @@ func TestResourceUserRead(t *testing.T) {
 		Fixtures: []qa.HTTPFixture{
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc?attributes=userName%2CdisplayName%2Cactive%2Centitlements",
 				Response: ScimUser{
 					ID:          "abc",
 					DisplayName: "Example user",
@@ -49,7 +49,7 @@ func TestResourceUserRead_NotFound(t *testing.T) {
 		Fixtures: []qa.HTTPFixture{
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc?attributes=userName%2CdisplayName%2Cactive%2Centitlements",
 				Status:   404,
 			},
 		},
@@ -66,7 +66,7 @@ func TestResourceUserRead_Error(t *testing.T) {
 		Fixtures: []qa.HTTPFixture{
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc?attributes=userName%2CdisplayName%2Cactive%2Centitlements",
 				Status:   400,
 				Response: common.APIErrorBody{
 					ScimDetail: "Something",
@@ -106,7 +106,7 @@ func TestResourceUserCreate(t *testing.T) {
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc?attributes=userName%2CdisplayName%2Cactive%2Centitlements",
 				Response: ScimUser{
 					DisplayName: "Example user",
 					Active:      true,
@@ -237,7 +237,7 @@ func TestResourceUserUpdate(t *testing.T) {
 			},
 			{
 				Method:   "GET",
-				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc?attributes=userName%2CdisplayName%2Cactive%2Centitlements",
 				Response: newUser,
 			},
 		},
@@ -259,6 +259,62 @@ func TestResourceUserUpdate(t *testing.T) {
 	assert.Equal(t, true, d.Get("allow_instance_pool_create"))
 }
 
+func TestResourceUserUpdate_SQLAccess(t *testing.T) {
+	newUser := ScimUser{
+		Schemas:  []URN{UserSchema},
+		UserName: "me@example.com",
+		Active:   true,
+		Entitlements: []entitlementsListItem{
+			{
+				Value: DatabricksSQLAccessEntitlement,
+			},
+		},
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Response: ScimUser{
+					Active:   true,
+					UserName: "me@example.com",
+					ID:       "abc",
+					Entitlements: []entitlementsListItem{
+						{
+							Value: WorkspaceAccessEntitlement,
+						},
+					},
+				},
+			},
+			{
+				Method:          "PUT",
+				Resource:        "/api/2.0/preview/scim/v2/Users/abc",
+				ExpectedRequest: newUser,
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc?attributes=userName%2CdisplayName%2Cactive%2Centitlements",
+				Response: newUser,
+			},
+		},
+		Resource: ResourceUser(),
+		Update:   true,
+		ID:       "abc",
+		InstanceState: map[string]string{
+			"user_name":        "me@example.com",
+			"active":           "true",
+			"workspace_access": "true",
+		},
+		HCL: `
+		user_name    = "me@example.com"
+		databricks_sql_access = true
+		`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, true, d.Get("databricks_sql_access"))
+	assert.Equal(t, false, d.Get("workspace_access"))
+}
+
 func TestResourceUserUpdate_Error(t *testing.T) {
 	_, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -367,3 +423,176 @@ func TestResourceUserDelete_Error(t *testing.T) {
 	}.Apply(t)
 	require.Error(t, err, err)
 }
+
+func TestResourceUserCreate_ReactivatesDeactivatedUser(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/preview/scim/v2/Users",
+				Status:   409,
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_CONFLICT",
+					Message:   "User with username me@example.com already exists.",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?count=100&filter=userName+eq+%22me%40example.com%22&startIndex=1",
+				Response: UserList{
+					TotalResults: 1,
+					Resources: []ScimUser{
+						{
+							ID:       "abc",
+							UserName: "me@example.com",
+							Active:   false,
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				Response: ScimUser{
+					ID:       "abc",
+					UserName: "me@example.com",
+					Active:   false,
+					Groups: []groupsListItem{
+						{
+							Display: "ds",
+							Value:   "9877",
+						},
+					},
+				},
+			},
+			{
+				Method:   "PUT",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				ExpectedRequest: ScimUser{
+					Schemas:      []URN{UserSchema},
+					UserName:     "me@example.com",
+					Active:       true,
+					Entitlements: []entitlementsListItem{},
+					Groups: []groupsListItem{
+						{
+							Display: "ds",
+							Value:   "9877",
+						},
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc?attributes=userName%2CdisplayName%2Cactive%2Centitlements",
+				Response: ScimUser{
+					ID:       "abc",
+					UserName: "me@example.com",
+					Active:   true,
+				},
+			},
+		},
+		Resource: ResourceUser(),
+		Create:   true,
+		HCL: `
+		user_name = "me@example.com"
+		disable_as_user_deletion = true
+		`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, true, d.Get("active"))
+	assert.Equal(t, true, d.Get("disable_as_user_deletion"))
+}
+
+func TestResourceUserCreate_ConflictWithActiveUser(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/preview/scim/v2/Users",
+				Status:   409,
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_CONFLICT",
+					Message:   "User with username me@example.com already exists.",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users?count=100&filter=userName+eq+%22me%40example.com%22&startIndex=1",
+				Response: UserList{
+					TotalResults: 1,
+					Resources: []ScimUser{
+						{
+							ID:       "abc",
+							UserName: "me@example.com",
+							Active:   true,
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceUser(),
+		Create:   true,
+		HCL: `
+		user_name = "me@example.com"
+		`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "User with username me@example.com already exists.")
+}
+
+func TestResourceUserRead_Deactivated(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc?attributes=userName%2CdisplayName%2Cactive%2Centitlements",
+				Response: ScimUser{
+					ID:       "abc",
+					UserName: "me@example.com",
+					Active:   false,
+				},
+			},
+		},
+		Resource: ResourceUser(),
+		Read:     true,
+		ID:       "abc",
+		HCL: `
+		user_name = "me@example.com"
+		active = false
+		disable_as_user_deletion = true
+		`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id(), "inactive user should not be removed from state")
+	assert.Equal(t, false, d.Get("active"))
+	assert.Equal(t, true, d.Get("disable_as_user_deletion"))
+}
+
+func TestResourceUserDelete_Deactivates(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/preview/scim/v2/Users/abc",
+				ExpectedRequest: patchRequest{
+					Schemas: []URN{PatchOp},
+					Operations: []patchOperation{
+						{
+							Op:    "replace",
+							Path:  "active",
+							Value: false,
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceUser(),
+		Delete:   true,
+		ID:       "abc",
+		HCL: `
+		user_name = "me@example.com"
+		disable_as_user_deletion = true
+		`,
+	}.Apply(t)
+	require.NoError(t, err, err)
+}
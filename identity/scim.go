@@ -78,6 +78,7 @@ type ScimGroup struct {
 	ID           string                 `json:"id,omitempty"`
 	Schemas      []URN                  `json:"schemas,omitempty"`
 	DisplayName  string                 `json:"displayName,omitempty"`
+	ExternalID   string                 `json:"externalId,omitempty"`
 	Members      []GroupMember          `json:"members,omitempty"`
 	Groups       []GroupMember          `json:"groups,omitempty"`
 	Roles        []roleListItem         `json:"roles,omitempty"`
@@ -126,6 +127,8 @@ type Entitlement string
 const (
 	AllowClusterCreateEntitlement      Entitlement = "allow-cluster-create"
 	AllowInstancePoolCreateEntitlement Entitlement = "allow-instance-pool-create"
+	DatabricksSQLAccessEntitlement     Entitlement = "databricks-sql-access"
+	WorkspaceAccessEntitlement         Entitlement = "workspace-access"
 )
 
 type groupsListItem struct {
@@ -157,6 +160,7 @@ type ScimUser struct {
 	Schemas       []URN                  `json:"schemas,omitempty"`
 	UserName      string                 `json:"userName,omitempty"`
 	ApplicationID string                 `json:"application_id,omitempty"`
+	ExternalID    string                 `json:"externalId,omitempty"`
 	Groups        []groupsListItem       `json:"groups,omitempty"`
 	Name          map[string]string      `json:"name,omitempty"`
 	Roles         []roleListItem         `json:"roles,omitempty"`
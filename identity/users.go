@@ -3,7 +3,9 @@ package identity
 import (
 	"context"
 	"fmt"
+	"log"
 	"net/http"
+	"strings"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 )
@@ -29,6 +31,8 @@ type UserEntity struct {
 	Active                  bool   `json:"active,omitempty"`
 	AllowClusterCreate      bool   `json:"allow_cluster_create,omitempty"`
 	AllowInstancePoolCreate bool   `json:"allow_instance_pool_create,omitempty"`
+	DatabricksSQLAccess     bool   `json:"databricks_sql_access,omitempty"`
+	WorkspaceAccess         bool   `json:"workspace_access,omitempty"`
 }
 
 func (u UserEntity) toRequest() ScimUser {
@@ -43,6 +47,16 @@ func (u UserEntity) toRequest() ScimUser {
 			Value: Entitlement("allow-instance-pool-create"),
 		})
 	}
+	if u.DatabricksSQLAccess {
+		entitlements = append(entitlements, entitlementsListItem{
+			Value: DatabricksSQLAccessEntitlement,
+		})
+	}
+	if u.WorkspaceAccess {
+		entitlements = append(entitlements, entitlementsListItem{
+			Value: WorkspaceAccessEntitlement,
+		})
+	}
 	return ScimUser{
 		Schemas:      []URN{UserSchema},
 		UserName:     u.UserName,
@@ -52,9 +66,12 @@ func (u UserEntity) toRequest() ScimUser {
 	}
 }
 
-// Create ..
+// Create adds new user to workspace or reactivates previously deactivated user with the same user name
 func (a UsersAPI) Create(ru UserEntity) (user ScimUser, err error) {
 	err = a.client.Scim(a.context, http.MethodPost, "/preview/scim/v2/Users", ru.toRequest(), &user)
+	if e, ok := err.(common.APIError); ok && e.StatusCode == http.StatusConflict {
+		return a.reactivate(ru, err)
+	}
 	return user, err
 }
 
@@ -73,21 +90,68 @@ func (a UsersAPI) Filter(filter string) (u []ScimUser, err error) {
 	return
 }
 
+// number of resources, that are requested from SCIM API in a single page
+const scimPageSize = 100
+
+// scimListRequest is a page of SCIM resources, optionally filtered by SCIM expression
+type scimListRequest struct {
+	Filter     string `url:"filter,omitempty"`
+	Attributes string `url:"attributes,omitempty"`
+	StartIndex int    `url:"startIndex,omitempty"`
+	Count      int    `url:"count,omitempty"`
+}
+
+// List returns all users matching the filter, going through all pages of SCIM API response
+func (a UsersAPI) List(filter string) (users []ScimUser, err error) {
+	req := scimListRequest{
+		Filter:     filter,
+		StartIndex: 1,
+		Count:      scimPageSize,
+	}
+	for {
+		var page UserList
+		err = a.client.Scim(a.context, http.MethodGet, "/preview/scim/v2/Users", req, &page)
+		if err != nil {
+			return
+		}
+		users = append(users, page.Resources...)
+		req.StartIndex += len(page.Resources)
+		if len(page.Resources) == 0 || req.StartIndex > int(page.TotalResults) {
+			return
+		}
+	}
+}
+
+// scimReadRequest narrows down attributes of a single SCIM resource in the response,
+// so that refresh doesn't transfer the data that is not used by the resource
+type scimReadRequest struct {
+	Attributes         string `url:"attributes,omitempty"`
+	ExcludedAttributes string `url:"excludedAttributes,omitempty"`
+}
+
+// userEntityAttributes are SCIM attributes of the user, that are mapped to UserEntity
+const userEntityAttributes = "userName,displayName,active,entitlements"
+
 // Read reads resource-friendly entity
 func (a UsersAPI) Read(userID string) (ru UserEntity, err error) {
-	user, err := a.read(userID)
+	user, err := a.readAttributes(userID, userEntityAttributes)
 	if err != nil {
 		return
 	}
 	ru.UserName = user.UserName
 	ru.DisplayName = user.DisplayName
 	ru.Active = user.Active
+	// SCIM API returns only entitlements assigned directly to the user, not the ones inherited from groups
 	for _, ent := range user.Entitlements {
 		switch ent.Value {
 		case AllowClusterCreateEntitlement:
 			ru.AllowClusterCreate = true
 		case AllowInstancePoolCreateEntitlement:
 			ru.AllowInstancePoolCreate = true
+		case DatabricksSQLAccessEntitlement:
+			ru.DatabricksSQLAccess = true
+		case WorkspaceAccessEntitlement:
+			ru.WorkspaceAccess = true
 		}
 	}
 	return
@@ -98,6 +162,13 @@ func (a UsersAPI) read(userID string) (ScimUser, error) {
 	return a.readByPath(userPath)
 }
 
+// readAttributes reads only given comma-separated attributes of the user
+func (a UsersAPI) readAttributes(userID, attributes string) (user ScimUser, err error) {
+	err = a.client.Scim(a.context, http.MethodGet, fmt.Sprintf("/preview/scim/v2/Users/%v", userID),
+		scimReadRequest{Attributes: attributes}, &user)
+	return
+}
+
 // Me gets user information about caller
 func (a UsersAPI) Me() (ScimUser, error) {
 	return a.readByPath("/preview/scim/v2/Me")
@@ -132,3 +203,34 @@ func (a UsersAPI) Delete(userID string) error {
 	userPath := fmt.Sprintf("/preview/scim/v2/Users/%v", userID)
 	return a.client.Scim(a.context, http.MethodDelete, userPath, nil, nil)
 }
+
+// Deactivate marks the user as inactive instead of deleting it, so that its notebooks and audit history are kept
+func (a UsersAPI) Deactivate(userID string) error {
+	return a.Patch(userID, patchRequest{
+		Schemas: []URN{PatchOp},
+		Operations: []patchOperation{
+			{
+				Op:    "replace",
+				Path:  "active",
+				Value: false,
+			},
+		},
+	})
+}
+
+// reactivate updates previously deactivated user with the same user name. Original conflict
+// error is returned, if there's no such user or it's still active.
+func (a UsersAPI) reactivate(ru UserEntity, conflict error) (ScimUser, error) {
+	users, err := a.List(fmt.Sprintf(`userName eq "%s"`, ru.UserName))
+	if err != nil {
+		return ScimUser{}, err
+	}
+	for _, user := range users {
+		if !strings.EqualFold(user.UserName, ru.UserName) || user.Active {
+			continue
+		}
+		log.Printf("[INFO] Reactivating previously deactivated user %s", user.UserName)
+		return user, a.Update(user.ID, ru)
+	}
+	return ScimUser{}, conflict
+}
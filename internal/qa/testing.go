@@ -78,6 +78,8 @@ type ResourceFixture struct {
 	ID          string
 	NonWritable bool
 	Azure       bool
+	// account id from provider configuration
+	AccountID string
 	// new resource
 	New bool
 }
@@ -95,6 +97,9 @@ func (f ResourceFixture) Apply(t *testing.T) (*schema.ResourceData, error) {
 	if f.Azure {
 		client.AzureAuth.ResourceID = "/subscriptions/a/resourceGroups/b/providers/Microsoft.Databricks/workspaces/c"
 	}
+	if f.AccountID != "" {
+		client.AccountID = f.AccountID
+	}
 	if len(f.HCL) > 0 {
 		var out interface{}
 		err = hcl.Decode(&out, f.HCL)
@@ -111,7 +116,7 @@ func (f ResourceFixture) Apply(t *testing.T) (*schema.ResourceData, error) {
 		if b != nil {
 			ctx := context.Background()
 			diags := b(ctx, d, m)
-			if diags != nil {
+			if diags.HasError() {
 				return fmt.Errorf(diagsToString(diags))
 			}
 			return nil
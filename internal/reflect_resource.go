@@ -243,7 +243,8 @@ func collectionToMaps(v interface{}, s *schema.Schema) ([]interface{}, error) {
 		return nil, fmt.Errorf("not resource")
 	}
 	var allItems []reflect.Value
-	if s.MaxItems == 1 {
+	// computed-only blocks cannot have MaxItems, so pointers to structs are single items as well
+	if s.MaxItems == 1 || reflect.ValueOf(v).Kind() == reflect.Ptr {
 		allItems = append(allItems, reflect.ValueOf(v))
 	} else {
 		vs := reflect.ValueOf(v)
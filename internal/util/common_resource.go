@@ -15,6 +15,7 @@ type CommonResource struct {
 	Read           func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error
 	Update         func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error
 	Delete         func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error
+	CustomizeDiff  schema.CustomizeDiffFunc
 	StateUpgraders []schema.StateUpgrader
 	Schema         map[string]*schema.Schema
 	SchemaVersion  int
@@ -47,6 +48,7 @@ func (r CommonResource) ToResource() *schema.Resource {
 		Schema:         r.Schema,
 		SchemaVersion:  r.SchemaVersion,
 		StateUpgraders: r.StateUpgraders,
+		CustomizeDiff:  r.CustomizeDiff,
 		CreateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 			c := m.(*common.DatabricksClient)
 			err := r.Create(ctx, d, c)
@@ -75,6 +75,20 @@ func (p *Pair) Pack(d *schema.ResourceData) {
 	d.SetId(fmt.Sprintf("%s%s%v", d.Get(p.left), p.separator, d.Get(p.right)))
 }
 
+// Importer returns resource importer, that fails with expected ID format for malformed IDs
+func (p *Pair) Importer() *schema.ResourceImporter {
+	return &schema.ResourceImporter{
+		StateContext: func(ctx context.Context, d *schema.ResourceData,
+			m interface{}) ([]*schema.ResourceData, error) {
+			if _, _, err := p.Unpack(d); err != nil {
+				return nil, fmt.Errorf("%s. Import ID has to be in <%s>%s<%s> format",
+					err, p.left, p.separator, p.right)
+			}
+			return []*schema.ResourceData{d}, nil
+		},
+	}
+}
+
 // BindResource defines resource with simplified functions
 type BindResource struct {
 	ReadContext   func(ctx context.Context, left, right string, c *common.DatabricksClient) error
@@ -174,3 +174,20 @@ func TestPairIDResource(t *testing.T) {
 		})
 	}
 }
+
+func TestPairImporter(t *testing.T) {
+	p := NewPairSeparatedID("account_id", "object_id", "/")
+	r := &schema.Resource{Schema: p.schema}
+	d := r.TestResourceData()
+	d.SetId("abc/def")
+	res, err := p.Importer().StateContext(context.Background(), d, nil)
+	require.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "abc", d.Get("account_id"))
+	assert.Equal(t, "def", d.Get("object_id"))
+
+	d = r.TestResourceData()
+	d.SetId("def")
+	_, err = p.Importer().StateContext(context.Background(), d, nil)
+	assert.EqualError(t, err, "Invalid ID: def. Import ID has to be in <account_id>/<object_id> format")
+}
@@ -0,0 +1,44 @@
+package acceptance
+
+import (
+	"os"
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/internal/acceptance"
+)
+
+func TestMwsAccIPAccessList(t *testing.T) {
+	cloudEnv := os.Getenv("CLOUD_ENV")
+	if cloudEnv != "MWS" {
+		t.Skip("Acceptance tests skipped unless CLOUD_ENV=MWS is set")
+	}
+	acceptance.Test(t, []acceptance.Step{
+		{
+			Template: `provider "databricks" {
+				host     = "{env.DATABRICKS_HOST}"
+				username = "{env.DATABRICKS_USERNAME}"
+				password = "{env.DATABRICKS_PASSWORD}"
+			}
+			resource "databricks_mws_ip_access_list" "this" {
+				account_id   = "{env.DATABRICKS_ACCOUNT_ID}"
+				label        = "block-{var.RANDOM}"
+				list_type    = "BLOCK"
+				ip_addresses = ["192.0.2.1", "198.51.100.0/24"]
+			}`,
+		},
+		{
+			Template: `provider "databricks" {
+				host     = "{env.DATABRICKS_HOST}"
+				username = "{env.DATABRICKS_USERNAME}"
+				password = "{env.DATABRICKS_PASSWORD}"
+			}
+			resource "databricks_mws_ip_access_list" "this" {
+				account_id   = "{env.DATABRICKS_ACCOUNT_ID}"
+				label        = "block-{var.RANDOM}"
+				list_type    = "BLOCK"
+				ip_addresses = ["192.0.2.1"]
+				enabled      = false
+			}`,
+		},
+	})
+}
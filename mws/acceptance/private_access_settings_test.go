@@ -0,0 +1,91 @@
+package acceptance
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/acceptance"
+	"github.com/databrickslabs/databricks-terraform/mws"
+)
+
+func TestMwsAccPrivateAccessSettings(t *testing.T) {
+	cloudEnv := os.Getenv("CLOUD_ENV")
+	if cloudEnv != "MWS" {
+		t.Skip("Acceptance tests skipped unless CLOUD_ENV=MWS is set")
+	}
+	var pasID string
+	acceptance.Test(t, []acceptance.Step{
+		{
+			Template: `provider "databricks" {
+				host     = "{env.DATABRICKS_HOST}"
+				username = "{env.DATABRICKS_USERNAME}"
+				password = "{env.DATABRICKS_PASSWORD}"
+			}
+			resource "databricks_mws_private_access_settings" "this" {
+				account_id                   = "{env.DATABRICKS_ACCOUNT_ID}"
+				private_access_settings_name = "pas-{var.RANDOM}"
+				region                       = "{env.TEST_REGION}"
+				private_access_level         = "ACCOUNT"
+			}`,
+		},
+		{
+			Template: `provider "databricks" {
+				host     = "{env.DATABRICKS_HOST}"
+				username = "{env.DATABRICKS_USERNAME}"
+				password = "{env.DATABRICKS_PASSWORD}"
+			}
+			resource "databricks_mws_private_access_settings" "this" {
+				account_id                   = "{env.DATABRICKS_ACCOUNT_ID}"
+				private_access_settings_name = "pas-{var.RANDOM}"
+				region                       = "{env.TEST_REGION}"
+				private_access_level         = "ENDPOINT"
+				allowed_vpc_endpoint_ids     = [
+					"{env.TEST_WORKSPACE_VPC_ENDPOINT}",
+					"{env.TEST_RELAY_VPC_ENDPOINT}",
+				]
+			}`,
+			Check: acceptance.ResourceCheck("databricks_mws_private_access_settings.this",
+				func(ctx context.Context, client *common.DatabricksClient, id string) error {
+					pasID = id
+					return nil
+				}),
+		},
+		{
+			Template: `provider "databricks" {
+				host     = "{env.DATABRICKS_HOST}"
+				username = "{env.DATABRICKS_USERNAME}"
+				password = "{env.DATABRICKS_PASSWORD}"
+			}
+			resource "databricks_mws_private_access_settings" "this" {
+				account_id                   = "{env.DATABRICKS_ACCOUNT_ID}"
+				private_access_settings_name = "pas-{var.RANDOM}"
+				region                       = "{env.TEST_REGION}"
+				private_access_level         = "ENDPOINT"
+				public_access_enabled        = true
+				allowed_vpc_endpoint_ids     = [
+					"{env.TEST_WORKSPACE_VPC_ENDPOINT}",
+					"{env.TEST_RELAY_VPC_ENDPOINT}",
+				]
+			}`,
+			Check: acceptance.ResourceCheck("databricks_mws_private_access_settings.this",
+				func(ctx context.Context, client *common.DatabricksClient, id string) error {
+					if id != pasID {
+						return fmt.Errorf("Private access settings were replaced: %s != %s", id, pasID)
+					}
+					parts := strings.SplitN(id, "/", 2)
+					pas, err := mws.NewPrivateAccessSettingsAPI(ctx, client).Read(parts[0], parts[1])
+					if err != nil {
+						return err
+					}
+					if !pas.PublicAccessEnabled {
+						return fmt.Errorf("Public access is not enabled for %s", id)
+					}
+					return nil
+				}),
+		},
+	})
+}
@@ -0,0 +1,53 @@
+package acceptance
+
+import (
+	"os"
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/internal/acceptance"
+)
+
+func TestMwsAccVPCEndpointsDataSource(t *testing.T) {
+	cloudEnv := os.Getenv("CLOUD_ENV")
+	if cloudEnv != "MWS" {
+		t.Skip("Cannot run test on non-MWS environment")
+	}
+	acceptance.Test(t, []acceptance.Step{
+		{
+			Template: `provider "databricks" {
+				host     = "{env.DATABRICKS_HOST}"
+				username = "{env.DATABRICKS_USERNAME}"
+				password = "{env.DATABRICKS_PASSWORD}"
+			}
+			data "databricks_mws_vpc_endpoints" "all" {
+				account_id = "{env.DATABRICKS_ACCOUNT_ID}"
+			}
+			output "vpc_endpoint_ids" {
+				value = [for x in data.databricks_mws_vpc_endpoints.all.vpc_endpoints : x.vpc_endpoint_id]
+			}`,
+		},
+	})
+}
+
+func TestMwsAccVPCEndpoint(t *testing.T) {
+	cloudEnv := os.Getenv("CLOUD_ENV")
+	if cloudEnv != "MWS" {
+		t.Skip("Cannot run test on non-MWS environment")
+	}
+	acceptance.Test(t, []acceptance.Step{
+		{
+			Template: `provider "databricks" {
+				host     = "{env.DATABRICKS_HOST}"
+				username = "{env.DATABRICKS_USERNAME}"
+				password = "{env.DATABRICKS_PASSWORD}"
+			}
+			resource "databricks_mws_vpc_endpoint" "this" {
+				account_id          = "{env.DATABRICKS_ACCOUNT_ID}"
+				vpc_endpoint_name   = "vpce-{var.RANDOM}"
+				aws_vpc_endpoint_id = "{env.TEST_RELAY_AWS_VPC_ENDPOINT}"
+				region              = "{env.TEST_REGION}"
+				wait_for_available  = true
+			}`,
+		},
+	})
+}
@@ -58,7 +58,183 @@ func TestMwsAccWorkspaces(t *testing.T) {
 				storage_configuration_id = databricks_mws_storage_configurations.this.storage_configuration_id
 				customer_managed_key_id = databricks_mws_customer_managed_keys.this.customer_managed_key_id
 				network_id = databricks_mws_networks.this.network_id
-				verify_workspace_runnning = true
+
+				token {
+					comment = "Acceptance test bootstrap"
+				}
+			}`,
+		},
+	})
+}
+
+func TestMwsAccWorkspacesNetworkSwap(t *testing.T) {
+	cloudEnv := os.Getenv("CLOUD_ENV")
+	if cloudEnv != "MWS" {
+		t.Skip("Acceptance tests skipped unless CLOUD_ENV=MWS is set")
+	}
+	template := func(networkName string) string {
+		return `provider "databricks" {
+			host     = "{env.DATABRICKS_HOST}"
+			username = "{env.DATABRICKS_USERNAME}"
+			password = "{env.DATABRICKS_PASSWORD}"
+		}
+		resource "databricks_mws_credentials" "this" {
+			account_id       = "{env.DATABRICKS_ACCOUNT_ID}"
+			credentials_name = "credentials-swap-{var.RANDOM}"
+			role_arn         = "{env.TEST_CROSSACCOUNT_ARN}"
+		}
+		resource "databricks_mws_storage_configurations" "this" {
+			account_id                 = "{env.DATABRICKS_ACCOUNT_ID}"
+			storage_configuration_name = "storage-swap-{var.RANDOM}"
+			bucket_name                = "{env.TEST_ROOT_BUCKET}"
+		}
+		resource "databricks_mws_networks" "first" {
+			account_id   = "{env.DATABRICKS_ACCOUNT_ID}"
+			network_name = "network-first-{var.RANDOM}"
+			vpc_id       = "{env.TEST_VPC_ID}"
+			subnet_ids   = [
+				"{env.TEST_SUBNET_PUBLIC}",
+				"{env.TEST_SUBNET_PRIVATE}",
+			]
+			security_group_ids = [
+				"{env.TEST_SECURITY_GROUP}",
+			]
+		}
+		resource "databricks_mws_networks" "second" {
+			account_id   = "{env.DATABRICKS_ACCOUNT_ID}"
+			network_name = "network-second-{var.RANDOM}"
+			vpc_id       = "{env.TEST_VPC_ID}"
+			subnet_ids   = [
+				"{env.TEST_SUBNET_PUBLIC}",
+				"{env.TEST_SUBNET_PRIVATE}",
+			]
+			security_group_ids = [
+				"{env.TEST_SECURITY_GROUP}",
+			]
+		}
+		resource "databricks_mws_workspaces" "this" {
+			account_id      = "{env.DATABRICKS_ACCOUNT_ID}"
+			workspace_name  = "swap-{var.RANDOM}"
+			deployment_name = "swap-{var.RANDOM}"
+			aws_region      = "{env.TEST_REGION}"
+
+			credentials_id           = databricks_mws_credentials.this.credentials_id
+			storage_configuration_id = databricks_mws_storage_configurations.this.storage_configuration_id
+			network_id               = databricks_mws_networks.` + networkName + `.network_id
+		}`
+	}
+	acceptance.Test(t, []acceptance.Step{
+		{
+			Template: template("first"),
+		},
+		{
+			Template: template("second"),
+		},
+	})
+}
+
+func TestMwsAccWorkspacesManagedServicesKeySwap(t *testing.T) {
+	cloudEnv := os.Getenv("CLOUD_ENV")
+	if cloudEnv != "MWS" {
+		t.Skip("Acceptance tests skipped unless CLOUD_ENV=MWS is set")
+	}
+	// both key configurations are registered with MANAGED_SERVICES use case beforehand
+	template := func(keyEnv string) string {
+		return `provider "databricks" {
+			host     = "{env.DATABRICKS_HOST}"
+			username = "{env.DATABRICKS_USERNAME}"
+			password = "{env.DATABRICKS_PASSWORD}"
+		}
+		resource "databricks_mws_credentials" "this" {
+			account_id       = "{env.DATABRICKS_ACCOUNT_ID}"
+			credentials_name = "credentials-cmk-{var.RANDOM}"
+			role_arn         = "{env.TEST_CROSSACCOUNT_ARN}"
+		}
+		resource "databricks_mws_storage_configurations" "this" {
+			account_id                 = "{env.DATABRICKS_ACCOUNT_ID}"
+			storage_configuration_name = "storage-cmk-{var.RANDOM}"
+			bucket_name                = "{env.TEST_ROOT_BUCKET}"
+		}
+		resource "databricks_mws_workspaces" "this" {
+			account_id      = "{env.DATABRICKS_ACCOUNT_ID}"
+			workspace_name  = "cmk-{var.RANDOM}"
+			deployment_name = "cmk-{var.RANDOM}"
+			aws_region      = "{env.TEST_REGION}"
+
+			credentials_id           = databricks_mws_credentials.this.credentials_id
+			storage_configuration_id = databricks_mws_storage_configurations.this.storage_configuration_id
+
+			managed_services_customer_managed_key_id = "{env.` + keyEnv + `}"
+		}`
+	}
+	acceptance.Test(t, []acceptance.Step{
+		{
+			Template: template("TEST_MANAGED_SERVICES_KEY_FIRST"),
+		},
+		{
+			Template: template("TEST_MANAGED_SERVICES_KEY_SECOND"),
+		},
+	})
+}
+
+func TestMwsAccWorkspacesDataSource(t *testing.T) {
+	cloudEnv := os.Getenv("CLOUD_ENV")
+	if cloudEnv != "MWS" {
+		t.Skip("Cannot run test on non-MWS environment")
+	}
+	acceptance.Test(t, []acceptance.Step{
+		{
+			Template: `provider "databricks" {
+				host     = "{env.DATABRICKS_HOST}"
+				username = "{env.DATABRICKS_USERNAME}"
+				password = "{env.DATABRICKS_PASSWORD}"
+			}
+			data "databricks_mws_workspaces" "all" {
+				account_id = "{env.DATABRICKS_ACCOUNT_ID}"
+			}
+			output "deployment_names" {
+				value = [for ws in data.databricks_mws_workspaces.all.workspaces : ws.deployment_name]
+			}`,
+		},
+	})
+}
+
+func TestGcpAccWorkspaces(t *testing.T) {
+	cloudEnv := os.Getenv("CLOUD_ENV")
+	if cloudEnv != "GCP_MWS" {
+		t.Skip("Acceptance tests skipped unless CLOUD_ENV=GCP_MWS is set")
+	}
+	acceptance.Test(t, []acceptance.Step{
+		{
+			Template: `provider "databricks" {
+				host     = "{env.DATABRICKS_HOST}"
+				username = "{env.DATABRICKS_USERNAME}"
+				password = "{env.DATABRICKS_PASSWORD}"
+			}
+			resource "databricks_mws_workspaces" "this" {
+				account_id     = "{env.DATABRICKS_ACCOUNT_ID}"
+				workspace_name  = "terra-{var.RANDOM}"
+				deployment_name = "terra-{var.RANDOM}"
+				location        = "{env.GOOGLE_REGION}"
+
+				cloud_resource_container {
+					gcp {
+						project_id = "{env.GOOGLE_PROJECT}"
+					}
+				}
+
+				network {
+					gcp_managed_network_config {
+						subnet_cidr                  = "10.0.0.0/16"
+						gke_cluster_pod_ip_range     = "10.1.0.0/16"
+						gke_cluster_service_ip_range = "10.2.0.0/20"
+					}
+				}
+
+				gke_config {
+					connectivity_type = "PRIVATE_NODE_PUBLIC_MASTER"
+					master_ip_range   = "10.3.0.0/28"
+				}
 			}`,
 		},
 	})
@@ -0,0 +1,110 @@
+package mws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceCredentials returns map of credentials names to their ids. Credentials with the same name are suffixed with their ids.
+func DataSourceCredentials() *schema.Resource {
+	type entity struct {
+		AccountID string            `json:"account_id,omitempty"`
+		Ids       map[string]string `json:"ids,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["account_id"].Sensitive = true
+		return s
+	})
+	return accountScopedData(&schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			credentials, err := NewCredentialsAPI(ctx, m).List(this.AccountID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			byName := map[string][]string{}
+			for _, c := range credentials {
+				byName[c.CredentialsName] = append(byName[c.CredentialsName], c.CredentialsID)
+			}
+			this.Ids = map[string]string{}
+			for name, ids := range byName {
+				if len(ids) == 1 {
+					this.Ids[name] = ids[0]
+					continue
+				}
+				for _, id := range ids {
+					this.Ids[fmt.Sprintf("%s-%s", name, id)] = id
+				}
+			}
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(this.AccountID)
+			return nil
+		},
+	})
+}
+
+// DataSourceCredential returns single credentials configuration looked up by its name
+func DataSourceCredential() *schema.Resource {
+	type entity struct {
+		AccountID       string `json:"account_id,omitempty"`
+		CredentialsName string `json:"credentials_name"`
+		CredentialsID   string `json:"credentials_id,omitempty" tf:"computed"`
+		RoleArn         string `json:"role_arn,omitempty" tf:"computed"`
+		ExternalID      string `json:"external_id,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["account_id"].Sensitive = true
+		return s
+	})
+	return accountScopedData(&schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			credentials, err := NewCredentialsAPI(ctx, m).List(this.AccountID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			var found []Credentials
+			for _, c := range credentials {
+				if c.CredentialsName == this.CredentialsName {
+					found = append(found, c)
+				}
+			}
+			if len(found) == 0 {
+				return diag.Errorf("Cannot find credentials %s", this.CredentialsName)
+			}
+			if len(found) > 1 {
+				return diag.Errorf("There are %d credentials named %s", len(found), this.CredentialsName)
+			}
+			this.CredentialsID = found[0].CredentialsID
+			if found[0].AwsCredentials != nil && found[0].AwsCredentials.StsRole != nil {
+				this.RoleArn = found[0].AwsCredentials.StsRole.RoleArn
+				this.ExternalID = found[0].AwsCredentials.StsRole.ExternalID
+			}
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(this.CredentialsID)
+			return nil
+		},
+	})
+}
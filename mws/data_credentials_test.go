@@ -0,0 +1,175 @@
+package mws
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCredentials = []Credentials{
+	{
+		AccountID:       "abc",
+		CredentialsID:   "cid_1",
+		CredentialsName: "shared",
+		AwsCredentials: &AwsCredentials{
+			StsRole: &StsRole{
+				RoleArn:    "arn:aws:iam::098765:role/shared",
+				ExternalID: "abc",
+			},
+		},
+	},
+	{
+		AccountID:       "abc",
+		CredentialsID:   "cid_2",
+		CredentialsName: "other",
+	},
+}
+
+func TestDataSourceCredentials(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/credentials",
+				Response: testCredentials,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceCredentials(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id": "abc",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, map[string]interface{}{
+		"shared": "cid_1",
+		"other":  "cid_2",
+	}, d.Get("ids"))
+}
+
+func TestDataSourceCredentials_Duplicates(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/credentials",
+				Response: []Credentials{
+					{CredentialsID: "cid_1", CredentialsName: "shared"},
+					{CredentialsID: "cid_2", CredentialsName: "shared"},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceCredentials(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id": "abc",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"shared-cid_1": "cid_1",
+		"shared-cid_2": "cid_2",
+	}, d.Get("ids"))
+}
+
+func TestDataSourceCredentials_Error(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/credentials",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceCredentials(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id": "abc",
+		},
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+}
+
+func TestDataSourceCredential(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/credentials",
+				Response: testCredentials,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceCredential(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id":       "abc",
+			"credentials_name": "shared",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "cid_1", d.Id())
+	assert.Equal(t, "cid_1", d.Get("credentials_id"))
+	assert.Equal(t, "arn:aws:iam::098765:role/shared", d.Get("role_arn"))
+	assert.Equal(t, "abc", d.Get("external_id"))
+}
+
+func TestDataSourceCredential_NotFound(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/credentials",
+				Response: testCredentials,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceCredential(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id":       "abc",
+			"credentials_name": "missing",
+		},
+	}.Apply(t)
+	assert.EqualError(t, err, "Cannot find credentials missing")
+}
+
+func TestDataSourceCredential_Ambiguous(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/credentials",
+				Response: []Credentials{
+					{CredentialsID: "cid_1", CredentialsName: "shared"},
+					{CredentialsID: "cid_2", CredentialsName: "shared"},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceCredential(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id":       "abc",
+			"credentials_name": "shared",
+		},
+	}.Apply(t)
+	assert.EqualError(t, err, "There are 2 credentials named shared")
+}
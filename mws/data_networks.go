@@ -0,0 +1,139 @@
+package mws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceNetworks returns all network configurations of the account. Networks with the same name are suffixed with their ids.
+func DataSourceNetworks() *schema.Resource {
+	type network struct {
+		NetworkID        string   `json:"network_id,omitempty"`
+		NetworkName      string   `json:"network_name,omitempty"`
+		VPCID            string   `json:"vpc_id,omitempty"`
+		SubnetIds        []string `json:"subnet_ids,omitempty" tf:"slice_set"`
+		SecurityGroupIds []string `json:"security_group_ids,omitempty" tf:"slice_set"`
+		VPCStatus        string   `json:"vpc_status,omitempty"`
+	}
+	type entity struct {
+		AccountID string            `json:"account_id,omitempty"`
+		Networks  []network         `json:"networks,omitempty" tf:"computed"`
+		Ids       map[string]string `json:"ids,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["account_id"].Sensitive = true
+		return s
+	})
+	return accountScopedData(&schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			networks, err := NewNetworksAPI(ctx, m).List(this.AccountID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			byName := map[string][]string{}
+			for _, n := range networks {
+				byName[n.NetworkName] = append(byName[n.NetworkName], n.NetworkID)
+				this.Networks = append(this.Networks, network{
+					NetworkID:        n.NetworkID,
+					NetworkName:      n.NetworkName,
+					VPCID:            n.VPCID,
+					SubnetIds:        n.SubnetIds,
+					SecurityGroupIds: n.SecurityGroupIds,
+					VPCStatus:        n.VPCStatus,
+				})
+			}
+			this.Ids = map[string]string{}
+			for name, ids := range byName {
+				if len(ids) == 1 {
+					this.Ids[name] = ids[0]
+					continue
+				}
+				for _, id := range ids {
+					this.Ids[fmt.Sprintf("%s-%s", name, id)] = id
+				}
+			}
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(this.AccountID)
+			return nil
+		},
+	})
+}
+
+// DataSourceNetwork returns single network configuration looked up by its name
+func DataSourceNetwork() *schema.Resource {
+	type entity struct {
+		AccountID        string   `json:"account_id,omitempty"`
+		NetworkName      string   `json:"network_name"`
+		NetworkID        string   `json:"network_id,omitempty" tf:"computed"`
+		VPCID            string   `json:"vpc_id,omitempty" tf:"computed"`
+		SubnetIds        []string `json:"subnet_ids,omitempty" tf:"computed,slice_set"`
+		SecurityGroupIds []string `json:"security_group_ids,omitempty" tf:"computed,slice_set"`
+		VPCStatus        string   `json:"vpc_status,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["account_id"].Sensitive = true
+		return s
+	})
+	return accountScopedData(&schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			networks, err := NewNetworksAPI(ctx, m).List(this.AccountID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			var found []Network
+			names := []string{}
+			for _, n := range networks {
+				names = append(names, n.NetworkName)
+				if n.NetworkName == this.NetworkName {
+					found = append(found, n)
+				}
+			}
+			if len(found) == 0 {
+				return diag.Errorf("Cannot find network %s. Available networks are: %s",
+					this.NetworkName, strings.Join(names, ", "))
+			}
+			if len(found) > 1 {
+				ids := []string{}
+				for _, n := range found {
+					ids = append(ids, n.NetworkID)
+				}
+				return diag.Errorf("There are %d networks named %s: %s",
+					len(found), this.NetworkName, strings.Join(ids, ", "))
+			}
+			network := found[0]
+			this.NetworkID = network.NetworkID
+			this.VPCID = network.VPCID
+			this.SubnetIds = network.SubnetIds
+			this.SecurityGroupIds = network.SecurityGroupIds
+			this.VPCStatus = network.VPCStatus
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(network.NetworkID)
+			return nil
+		},
+	})
+}
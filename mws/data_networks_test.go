@@ -0,0 +1,190 @@
+package mws
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNetworks = []Network{
+	{
+		AccountID:        "abc",
+		NetworkID:        "net_1",
+		NetworkName:      "first",
+		VPCID:            "vpc-1",
+		SubnetIds:        []string{"subnet-a", "subnet-b"},
+		SecurityGroupIds: []string{"sg-1"},
+		VPCStatus:        VPCStatusValid,
+	},
+	{
+		AccountID:        "abc",
+		NetworkID:        "net_2",
+		NetworkName:      "second",
+		VPCID:            "vpc-2",
+		SubnetIds:        []string{"subnet-c", "subnet-d"},
+		SecurityGroupIds: []string{"sg-2"},
+		VPCStatus:        VPCStatusUnattached,
+	},
+}
+
+func TestDataSourceNetworks(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/networks",
+				Response: testNetworks,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceNetworks(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id": "abc",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, map[string]interface{}{
+		"first":  "net_1",
+		"second": "net_2",
+	}, d.Get("ids"))
+	assert.Equal(t, 2, d.Get("networks.#"))
+	assert.Equal(t, "vpc-2", d.Get("networks.1.vpc_id"))
+	assert.Equal(t, VPCStatusUnattached, d.Get("networks.1.vpc_status"))
+	assert.Equal(t, 2, d.Get("networks.1.subnet_ids.#"))
+}
+
+func TestDataSourceNetworks_Duplicates(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/networks",
+				Response: []Network{
+					{NetworkID: "net_1", NetworkName: "shared"},
+					{NetworkID: "net_2", NetworkName: "shared"},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceNetworks(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id": "abc",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"shared-net_1": "net_1",
+		"shared-net_2": "net_2",
+	}, d.Get("ids"))
+	assert.Equal(t, 2, d.Get("networks.#"))
+}
+
+func TestDataSourceNetworks_Error(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/networks",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceNetworks(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id": "abc",
+		},
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+}
+
+func TestDataSourceNetwork(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/networks",
+				Response: testNetworks,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceNetwork(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id":   "abc",
+			"network_name": "first",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "net_1", d.Id())
+	assert.Equal(t, "net_1", d.Get("network_id"))
+	assert.Equal(t, "vpc-1", d.Get("vpc_id"))
+	assert.Equal(t, 2, d.Get("subnet_ids.#"))
+	assert.Equal(t, 1, d.Get("security_group_ids.#"))
+	assert.Equal(t, VPCStatusValid, d.Get("vpc_status"))
+}
+
+func TestDataSourceNetwork_NotFound(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/networks",
+				Response: testNetworks,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceNetwork(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id":   "abc",
+			"network_name": "missing",
+		},
+	}.Apply(t)
+	assert.EqualError(t, err, "Cannot find network missing. Available networks are: first, second")
+}
+
+func TestDataSourceNetwork_Ambiguous(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/networks",
+				Response: []Network{
+					{
+						NetworkID:   "net_1",
+						NetworkName: "shared",
+					},
+					{
+						NetworkID:   "net_2",
+						NetworkName: "shared",
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceNetwork(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id":   "abc",
+			"network_name": "shared",
+		},
+	}.Apply(t)
+	assert.EqualError(t, err, "There are 2 networks named shared: net_1, net_2")
+}
@@ -0,0 +1,110 @@
+package mws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceStorageConfigurations returns map of storage configuration names to their ids. Configurations with the same name are suffixed with their ids.
+func DataSourceStorageConfigurations() *schema.Resource {
+	type entity struct {
+		AccountID string            `json:"account_id,omitempty"`
+		Ids       map[string]string `json:"ids,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["account_id"].Sensitive = true
+		return s
+	})
+	return accountScopedData(&schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			storageConfigurations, err := NewStorageConfigurationsAPI(ctx, m).List(this.AccountID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			byName := map[string][]string{}
+			for _, sc := range storageConfigurations {
+				byName[sc.StorageConfigurationName] = append(byName[sc.StorageConfigurationName],
+					sc.StorageConfigurationID)
+			}
+			this.Ids = map[string]string{}
+			for name, ids := range byName {
+				if len(ids) == 1 {
+					this.Ids[name] = ids[0]
+					continue
+				}
+				for _, id := range ids {
+					this.Ids[fmt.Sprintf("%s-%s", name, id)] = id
+				}
+			}
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(this.AccountID)
+			return nil
+		},
+	})
+}
+
+// DataSourceStorageConfiguration returns single storage configuration looked up by its name
+func DataSourceStorageConfiguration() *schema.Resource {
+	type entity struct {
+		AccountID                string `json:"account_id,omitempty"`
+		StorageConfigurationName string `json:"storage_configuration_name"`
+		StorageConfigurationID   string `json:"storage_configuration_id,omitempty" tf:"computed"`
+		BucketName               string `json:"bucket_name,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["account_id"].Sensitive = true
+		return s
+	})
+	return accountScopedData(&schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			storageConfigurations, err := NewStorageConfigurationsAPI(ctx, m).List(this.AccountID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			var found []StorageConfiguration
+			for _, sc := range storageConfigurations {
+				if sc.StorageConfigurationName == this.StorageConfigurationName {
+					found = append(found, sc)
+				}
+			}
+			if len(found) == 0 {
+				return diag.Errorf("Cannot find storage configuration %s", this.StorageConfigurationName)
+			}
+			if len(found) > 1 {
+				return diag.Errorf("There are %d storage configurations named %s",
+					len(found), this.StorageConfigurationName)
+			}
+			this.StorageConfigurationID = found[0].StorageConfigurationID
+			if found[0].RootBucketInfo != nil {
+				this.BucketName = found[0].RootBucketInfo.BucketName
+			}
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(this.StorageConfigurationID)
+			return nil
+		},
+	})
+}
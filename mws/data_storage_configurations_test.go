@@ -0,0 +1,171 @@
+package mws
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testStorageConfigurations = []StorageConfiguration{
+	{
+		AccountID:                "abc",
+		StorageConfigurationID:   "scid_1",
+		StorageConfigurationName: "shared",
+		RootBucketInfo: &RootBucketInfo{
+			BucketName: "bucket",
+		},
+	},
+	{
+		AccountID:                "abc",
+		StorageConfigurationID:   "scid_2",
+		StorageConfigurationName: "other",
+	},
+}
+
+func TestDataSourceStorageConfigurations(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/storage-configurations",
+				Response: testStorageConfigurations,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceStorageConfigurations(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id": "abc",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, map[string]interface{}{
+		"shared": "scid_1",
+		"other":  "scid_2",
+	}, d.Get("ids"))
+}
+
+func TestDataSourceStorageConfigurations_Duplicates(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/storage-configurations",
+				Response: []StorageConfiguration{
+					{StorageConfigurationID: "scid_1", StorageConfigurationName: "shared"},
+					{StorageConfigurationID: "scid_2", StorageConfigurationName: "shared"},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceStorageConfigurations(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id": "abc",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"shared-scid_1": "scid_1",
+		"shared-scid_2": "scid_2",
+	}, d.Get("ids"))
+}
+
+func TestDataSourceStorageConfigurations_Error(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/storage-configurations",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceStorageConfigurations(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id": "abc",
+		},
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+}
+
+func TestDataSourceStorageConfiguration(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/storage-configurations",
+				Response: testStorageConfigurations,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceStorageConfiguration(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id":                 "abc",
+			"storage_configuration_name": "shared",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "scid_1", d.Id())
+	assert.Equal(t, "scid_1", d.Get("storage_configuration_id"))
+	assert.Equal(t, "bucket", d.Get("bucket_name"))
+}
+
+func TestDataSourceStorageConfiguration_NotFound(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/storage-configurations",
+				Response: testStorageConfigurations,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceStorageConfiguration(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id":                 "abc",
+			"storage_configuration_name": "missing",
+		},
+	}.Apply(t)
+	assert.EqualError(t, err, "Cannot find storage configuration missing")
+}
+
+func TestDataSourceStorageConfiguration_Ambiguous(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/storage-configurations",
+				Response: []StorageConfiguration{
+					{StorageConfigurationID: "scid_1", StorageConfigurationName: "shared"},
+					{StorageConfigurationID: "scid_2", StorageConfigurationName: "shared"},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceStorageConfiguration(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id":                 "abc",
+			"storage_configuration_name": "shared",
+		},
+	}.Apply(t)
+	assert.EqualError(t, err, "There are 2 storage configurations named shared")
+}
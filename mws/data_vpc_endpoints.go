@@ -0,0 +1,102 @@
+package mws
+
+import (
+	"context"
+
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceVPCEndpoints returns all VPC endpoints registered in the account
+func DataSourceVPCEndpoints() *schema.Resource {
+	type entity struct {
+		AccountID    string        `json:"account_id,omitempty"`
+		VPCEndpoints []VPCEndpoint `json:"vpc_endpoints,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["account_id"].Sensitive = true
+		return s
+	})
+	return accountScopedData(&schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			this.VPCEndpoints, err = NewVPCEndpointAPI(ctx, m).List(this.AccountID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(this.AccountID)
+			return nil
+		},
+	})
+}
+
+// DataSourceVPCEndpoint returns single VPC endpoint registration looked up by its name
+func DataSourceVPCEndpoint() *schema.Resource {
+	type entity struct {
+		AccountID            string `json:"account_id,omitempty"`
+		VPCEndpointName      string `json:"vpc_endpoint_name"`
+		VPCEndpointID        string `json:"vpc_endpoint_id,omitempty" tf:"computed"`
+		AwsVPCEndpointID     string `json:"aws_vpc_endpoint_id,omitempty" tf:"computed"`
+		AwsEndpointServiceID string `json:"aws_endpoint_service_id,omitempty" tf:"computed"`
+		AwsAccountID         string `json:"aws_account_id,omitempty" tf:"computed"`
+		Region               string `json:"region,omitempty" tf:"computed"`
+		UseCase              string `json:"use_case,omitempty" tf:"computed"`
+		State                string `json:"state,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["account_id"].Sensitive = true
+		return s
+	})
+	return accountScopedData(&schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			vpcEndpoints, err := NewVPCEndpointAPI(ctx, m).List(this.AccountID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			var found []VPCEndpoint
+			for _, vpce := range vpcEndpoints {
+				if vpce.VPCEndpointName == this.VPCEndpointName {
+					found = append(found, vpce)
+				}
+			}
+			if len(found) == 0 {
+				return diag.Errorf("Cannot find VPC endpoint %s", this.VPCEndpointName)
+			}
+			if len(found) > 1 {
+				return diag.Errorf("There are %d VPC endpoints named %s", len(found), this.VPCEndpointName)
+			}
+			vpce := found[0]
+			this.VPCEndpointID = vpce.VPCEndpointID
+			this.AwsVPCEndpointID = vpce.AwsVPCEndpointID
+			this.AwsEndpointServiceID = vpce.AwsEndpointServiceID
+			this.AwsAccountID = vpce.AwsAccountID
+			this.Region = vpce.Region
+			this.UseCase = vpce.UseCase
+			this.State = vpce.State
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(vpce.VPCEndpointID)
+			return nil
+		},
+	})
+}
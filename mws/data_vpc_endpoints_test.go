@@ -0,0 +1,151 @@
+package mws
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testVPCEndpoints = []VPCEndpoint{
+	{
+		AccountID:        "abc",
+		VPCEndpointID:    "vpce_1",
+		VPCEndpointName:  "workspace",
+		AwsVPCEndpointID: "vpce-0123",
+		Region:           "eu-west-1",
+		UseCase:          "workspace-access",
+		State:            "available",
+	},
+	{
+		AccountID:        "abc",
+		VPCEndpointID:    "vpce_2",
+		VPCEndpointName:  "relay",
+		AwsVPCEndpointID: "vpce-4567",
+		Region:           "eu-west-1",
+		UseCase:          "dataplane-relay-access",
+		State:            "available",
+	},
+}
+
+func TestDataSourceVPCEndpoints(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints",
+				Response: testVPCEndpoints,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceVPCEndpoints(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id": "abc",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 2, d.Get("vpc_endpoints.#"))
+	assert.Equal(t, "vpce_2", d.Get("vpc_endpoints.1.vpc_endpoint_id"))
+	assert.Equal(t, "vpce-4567", d.Get("vpc_endpoints.1.aws_vpc_endpoint_id"))
+	assert.Equal(t, "dataplane-relay-access", d.Get("vpc_endpoints.1.use_case"))
+}
+
+func TestDataSourceVPCEndpoints_Error(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceVPCEndpoints(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id": "abc",
+		},
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+}
+
+func TestDataSourceVPCEndpoint(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints",
+				Response: testVPCEndpoints,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceVPCEndpoint(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id":        "abc",
+			"vpc_endpoint_name": "relay",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "vpce_2", d.Id())
+	assert.Equal(t, "vpce_2", d.Get("vpc_endpoint_id"))
+	assert.Equal(t, "vpce-4567", d.Get("aws_vpc_endpoint_id"))
+	assert.Equal(t, "eu-west-1", d.Get("region"))
+	assert.Equal(t, "dataplane-relay-access", d.Get("use_case"))
+}
+
+func TestDataSourceVPCEndpoint_NotFound(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints",
+				Response: testVPCEndpoints,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceVPCEndpoint(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id":        "abc",
+			"vpc_endpoint_name": "other",
+		},
+	}.Apply(t)
+	assert.EqualError(t, err, "Cannot find VPC endpoint other")
+}
+
+func TestDataSourceVPCEndpoint_Ambiguous(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints",
+				Response: []VPCEndpoint{
+					{VPCEndpointID: "vpce_1", VPCEndpointName: "relay"},
+					{VPCEndpointID: "vpce_2", VPCEndpointName: "relay"},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceVPCEndpoint(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id":        "abc",
+			"vpc_endpoint_name": "relay",
+		},
+	}.Apply(t)
+	assert.EqualError(t, err, "There are 2 VPC endpoints named relay")
+}
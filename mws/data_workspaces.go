@@ -0,0 +1,81 @@
+package mws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// DataSourceWorkspaces returns all workspaces in the account. Workspaces with the same name are suffixed with their ids.
+func DataSourceWorkspaces() *schema.Resource {
+	type workspace struct {
+		WorkspaceID            int64  `json:"workspace_id,omitempty"`
+		WorkspaceName          string `json:"workspace_name,omitempty"`
+		DeploymentName         string `json:"deployment_name,omitempty"`
+		WorkspaceStatus        string `json:"workspace_status,omitempty"`
+		AwsRegion              string `json:"aws_region,omitempty"`
+		Location               string `json:"location,omitempty"`
+		CredentialsID          string `json:"credentials_id,omitempty"`
+		StorageConfigurationID string `json:"storage_configuration_id,omitempty"`
+		NetworkID              string `json:"network_id,omitempty"`
+	}
+	type entity struct {
+		AccountID  string           `json:"account_id,omitempty"`
+		Workspaces []workspace      `json:"workspaces,omitempty" tf:"computed"`
+		Ids        map[string]int64 `json:"ids,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["account_id"].Sensitive = true
+		s["ids"].Elem = &schema.Schema{Type: schema.TypeInt}
+		return s
+	})
+	return accountScopedData(&schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			workspaces, err := NewWorkspacesAPI(ctx, m).List(this.AccountID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			byName := map[string][]int64{}
+			for _, ws := range workspaces {
+				byName[ws.WorkspaceName] = append(byName[ws.WorkspaceName], ws.WorkspaceID)
+				this.Workspaces = append(this.Workspaces, workspace{
+					WorkspaceID:            ws.WorkspaceID,
+					WorkspaceName:          ws.WorkspaceName,
+					DeploymentName:         ws.DeploymentName,
+					WorkspaceStatus:        ws.WorkspaceStatus,
+					AwsRegion:              ws.AwsRegion,
+					Location:               ws.Location,
+					CredentialsID:          ws.CredentialsID,
+					StorageConfigurationID: ws.StorageConfigurationID,
+					NetworkID:              ws.NetworkID,
+				})
+			}
+			this.Ids = map[string]int64{}
+			for name, ids := range byName {
+				if len(ids) == 1 {
+					this.Ids[name] = ids[0]
+					continue
+				}
+				for _, id := range ids {
+					this.Ids[fmt.Sprintf("%s-%d", name, id)] = id
+				}
+			}
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(this.AccountID)
+			return nil
+		},
+	})
+}
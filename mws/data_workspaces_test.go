@@ -0,0 +1,151 @@
+package mws
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceWorkspaces(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces?limit=100&offset=0",
+				Response: []Workspace{
+					{
+						AccountID:              "abc",
+						WorkspaceID:            1234,
+						WorkspaceName:          "first",
+						DeploymentName:         "first-deployment",
+						WorkspaceStatus:        WorkspaceStatusRunning,
+						AwsRegion:              "us-east-1",
+						CredentialsID:          "bcd",
+						StorageConfigurationID: "ghi",
+						NetworkID:              "fgh",
+					},
+					{
+						AccountID:       "abc",
+						WorkspaceID:     5678,
+						WorkspaceName:   "second",
+						DeploymentName:  "second-deployment",
+						WorkspaceStatus: WorkspaceStatusProvisioning,
+						AwsRegion:       "eu-west-1",
+					},
+					{
+						AccountID:       "abc",
+						WorkspaceID:     9012,
+						WorkspaceName:   "third",
+						DeploymentName:  "third-deployment",
+						WorkspaceStatus: WorkspaceStatusRunning,
+						Cloud:           CloudGcp,
+						Location:        "us-central1",
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceWorkspaces(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id": "abc",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, map[string]interface{}{
+		"first":  1234,
+		"second": 5678,
+		"third":  9012,
+	}, d.Get("ids"))
+	assert.Equal(t, 3, d.Get("workspaces.#"))
+	assert.Equal(t, "first-deployment", d.Get("workspaces.0.deployment_name"))
+	assert.Equal(t, "fgh", d.Get("workspaces.0.network_id"))
+	assert.Equal(t, "PROVISIONING", d.Get("workspaces.1.workspace_status"))
+	assert.Equal(t, "eu-west-1", d.Get("workspaces.1.aws_region"))
+	assert.Equal(t, "us-central1", d.Get("workspaces.2.location"))
+}
+
+func TestDataSourceWorkspaces_Duplicates(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces?limit=100&offset=0",
+				Response: []Workspace{
+					{WorkspaceID: 1234, WorkspaceName: "same"},
+					{WorkspaceID: 5678, WorkspaceName: "same"},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceWorkspaces(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id": "abc",
+		},
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"same-1234": 1234,
+		"same-5678": 5678,
+	}, d.Get("ids"))
+}
+
+func TestDataSourceWorkspaces_Error(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces?limit=100&offset=0",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceWorkspaces(),
+		ID:          ".",
+		State: map[string]interface{}{
+			"account_id": "abc",
+		},
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+}
+
+func TestDataSourceWorkspaces_AccountIDFromProvider(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces?limit=100&offset=0",
+				Response: []Workspace{},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceWorkspaces(),
+		AccountID:   "abc",
+		ID:          ".",
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", d.Id())
+}
+
+func TestDataSourceWorkspaces_NoAccountID(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceWorkspaces(),
+		ID:          ".",
+	}.Apply(t)
+	assert.EqualError(t, err, "account_id has to be set either in resource or in provider configuration")
+}
@@ -1,5 +1,15 @@
 package mws
 
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
 // StsRole is the object that contains cross account role arn and external app id
 type StsRole struct {
 	RoleArn    string `json:"role_arn,omitempty"`
@@ -42,7 +52,7 @@ type NetworkHealth struct {
 
 // Network is the object that contains all the information for BYOVPC
 type Network struct {
-	AccountID        string          `json:"account_id"`
+	AccountID        string          `json:"account_id,omitempty"`
 	NetworkID        string          `json:"network_id,omitempty" tf:"computed"`
 	NetworkName      string          `json:"network_name"`
 	VPCID            string          `json:"vpc_id"`
@@ -54,11 +64,51 @@ type Network struct {
 	CreationTime     int64           `json:"creation_time,omitempty" tf:"computed"`
 }
 
+// VPCEndpoint is the object that contains all the information for registering an AWS VPC endpoint
+type VPCEndpoint struct {
+	AccountID            string `json:"account_id,omitempty"`
+	VPCEndpointID        string `json:"vpc_endpoint_id,omitempty" tf:"computed"`
+	VPCEndpointName      string `json:"vpc_endpoint_name"`
+	AwsVPCEndpointID     string `json:"aws_vpc_endpoint_id"`
+	AwsEndpointServiceID string `json:"aws_endpoint_service_id,omitempty" tf:"computed"`
+	AwsAccountID         string `json:"aws_account_id,omitempty" tf:"computed"`
+	Region               string `json:"region"`
+	UseCase              string `json:"use_case,omitempty" tf:"computed"`
+	State                string `json:"state,omitempty" tf:"computed"`
+}
+
+// Token is the object that holds personal access token minted for the new workspace
+type Token struct {
+	LifetimeSeconds int32  `json:"lifetime_seconds,omitempty"`
+	Comment         string `json:"comment,omitempty"`
+	TokenID         string `json:"token_id,omitempty" tf:"computed"`
+	TokenValue      string `json:"token_value,omitempty" tf:"computed"`
+}
+
+// List of VPC statuses of the network
+const (
+	VPCStatusValid      = "VALID"
+	VPCStatusBroken     = "BROKEN"
+	VPCStatusWarned     = "WARNED"
+	VPCStatusUnattached = "UNATTACHED"
+)
+
+// ErrorSummary returns all network health messages as a single string
+func (n Network) ErrorSummary() string {
+	var strBuffer bytes.Buffer
+	for _, networkHealth := range n.ErrorMessages {
+		strBuffer.WriteString(fmt.Sprintf("error: %s;error_msg: %s;",
+			networkHealth.ErrorType, networkHealth.ErrorMessage))
+	}
+	return strBuffer.String()
+}
+
 // List of workspace statuses for provisioning the workspace
 const (
 	WorkspaceStatusNotProvisioned = "NOT_PROVISIONED"
 	WorkspaceStatusProvisioning   = "PROVISIONING"
 	WorkspaceStatusRunning        = "RUNNING"
+	WorkspaceStatusUpdating       = "UPDATING"
 	WorkspaceStatusFailed         = "FAILED"
 	WorkspaceStatusCanceled       = "CANCELLED"
 )
@@ -77,21 +127,141 @@ func ContainsWorkspaceState(workspaceStates []string, searchState string) bool {
 	return false
 }
 
+// Account is the object that contains settings of the account
+type Account struct {
+	AccountID            string `json:"account_id,omitempty"`
+	DeploymentNamePrefix string `json:"deployment_name_prefix,omitempty"`
+}
+
+// List of clouds, where workspaces could be deployed
+const (
+	CloudAws = "aws"
+	CloudGcp = "gcp"
+)
+
+// GCP is the object that points to the Google Cloud project of the workspace
+type GCP struct {
+	ProjectID string `json:"project_id"`
+}
+
+// CloudResourceContainer is the object that contains the cloud-specific container of workspace resources
+type CloudResourceContainer struct {
+	GCP *GCP `json:"gcp"`
+}
+
+// GkeConfig is the object that configures GKE cluster of a GCP workspace
+type GkeConfig struct {
+	ConnectivityType string `json:"connectivity_type"`
+	MasterIPRange    string `json:"master_ip_range"`
+}
+
+// GCPManagedNetworkConfig is the object that configures the network, which Databricks creates in the project
+type GCPManagedNetworkConfig struct {
+	SubnetCIDR               string `json:"subnet_cidr"`
+	GKEClusterPodIPRange     string `json:"gke_cluster_pod_ip_range"`
+	GKEClusterServiceIPRange string `json:"gke_cluster_service_ip_range"`
+}
+
+// GCPCommonNetworkConfig is the object that configures GKE cluster networking within the network
+type GCPCommonNetworkConfig struct {
+	GKEConnectivityType     string `json:"gke_connectivity_type"`
+	GKEClusterMasterIPRange string `json:"gke_cluster_master_ip_range"`
+}
+
+// GCPNetwork is the object that contains network configuration of a GCP workspace
+type GCPNetwork struct {
+	GCPManagedNetworkConfig *GCPManagedNetworkConfig `json:"gcp_managed_network_config,omitempty"`
+	GCPCommonNetworkConfig  *GCPCommonNetworkConfig  `json:"gcp_common_network_config,omitempty"`
+}
+
 // Workspace is the object that contains all the information for deploying a workspace
 type Workspace struct {
-	AccountID              string `json:"account_id"`
+	AccountID              string `json:"account_id,omitempty"`
 	WorkspaceName          string `json:"workspace_name"`
 	DeploymentName         string `json:"deployment_name"`
-	AwsRegion              string `json:"aws_region"`
-	CredentialsID          string `json:"credentials_id"`
-	StorageConfigurationID string `json:"storage_configuration_id"`
+	AwsRegion              string `json:"aws_region,omitempty"`
+	CredentialsID          string `json:"credentials_id,omitempty"`
+	StorageConfigurationID string `json:"storage_configuration_id,omitempty"`
 	CustomerManagedKeyID   string `json:"customer_managed_key_id,omitempty"`
 	NetworkID              string `json:"network_id,omitempty"`
 	IsNoPublicIPEnabled    bool   `json:"is_no_public_ip_enabled,omitempty"`
 
+	PrivateAccessSettingsID             string            `json:"private_access_settings_id,omitempty"`
+	ManagedServicesCustomerManagedKeyID string            `json:"managed_services_customer_managed_key_id,omitempty"`
+	CustomTags                          map[string]string `json:"custom_tags,omitempty"`
+
+	Cloud                  string                  `json:"cloud,omitempty" tf:"computed"`
+	Location               string                  `json:"location,omitempty"`
+	CloudResourceContainer *CloudResourceContainer `json:"cloud_resource_container,omitempty"`
+	GkeConfig              *GkeConfig              `json:"gke_config,omitempty"`
+	Network                *GCPNetwork             `json:"network,omitempty"`
+
 	WorkspaceID            int64  `json:"workspace_id,omitempty" tf:"computed"`
 	WorkspaceURL           string `json:"workspace_url,omitempty" tf:"computed"`
+	PricingTier            string `json:"pricing_tier,omitempty" tf:"computed"`
 	WorkspaceStatus        string `json:"workspace_status,omitempty" tf:"computed"`
 	WorkspaceStatusMessage string `json:"workspace_status_message,omitempty" tf:"computed"`
 	CreationTime           int64  `json:"creation_time,omitempty" tf:"computed"`
 }
+
+// errAccountIDNotSet is returned when account_id is missing for an account-scoped resource or data source
+var errAccountIDNotSet = fmt.Errorf("account_id has to be set either in resource or in provider configuration")
+
+// accountScoped makes account_id of the resource optional, defaulting it to
+// account_id from provider configuration. Resource-level account_id still takes precedence.
+func accountScoped(r *schema.Resource) *schema.Resource {
+	s := r.Schema["account_id"]
+	s.Required = false
+	s.Optional = true
+	s.Computed = true
+	s.Sensitive = true
+	s.ForceNew = true
+	customizeDiff := r.CustomizeDiff
+	r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		// account_id, that is not configured, cannot be told apart from the one, that is not yet known
+		if d.Get("account_id").(string) == "" {
+			// fail the plan instead of the apply, if there's no account_id to default to
+			if m.(*common.DatabricksClient).AccountID == "" {
+				return errAccountIDNotSet
+			}
+			if err := d.SetNew("account_id", m.(*common.DatabricksClient).AccountID); err != nil {
+				return err
+			}
+		}
+		if customizeDiff == nil {
+			return nil
+		}
+		return customizeDiff(ctx, d, m)
+	}
+	create := r.CreateContext
+	r.CreateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if d.Get("account_id").(string) == "" {
+			return diag.FromErr(errAccountIDNotSet)
+		}
+		return create(ctx, d, m)
+	}
+	return r
+}
+
+// accountScopedData makes account_id of the data source optional, defaulting it to
+// account_id from provider configuration
+func accountScopedData(r *schema.Resource) *schema.Resource {
+	s := r.Schema["account_id"]
+	s.Required = false
+	s.Optional = true
+	s.Sensitive = true
+	// data sources don't have CustomizeDiff, but they are read during plan anyway
+	read := r.ReadContext
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		if d.Get("account_id").(string) == "" {
+			if err := d.Set("account_id", m.(*common.DatabricksClient).AccountID); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if d.Get("account_id").(string) == "" {
+			return diag.FromErr(errAccountIDNotSet)
+		}
+		return read(ctx, d, m)
+	}
+	return r
+}
@@ -3,9 +3,13 @@ package mws
 import (
 	"context"
 	"fmt"
+	"log"
+	"strings"
+	"time"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal/util"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -37,6 +41,35 @@ func (a CredentialsAPI) Create(mwsAcctID, credentialsName string, roleArn string
 	return mwsCreds, err
 }
 
+// CreateWithRetry creates credentials and retries the creation while AWS IAM propagates
+// the cross-account role, which usually takes up to a minute after it is created
+func (a CredentialsAPI) CreateWithRetry(mwsAcctID, credentialsName, roleArn string,
+	timeout time.Duration) (credentials Credentials, err error) {
+	err = resource.RetryContext(a.context, timeout, func() *resource.RetryError {
+		credentials, err = a.Create(mwsAcctID, credentialsName, roleArn)
+		if isCredentialValidationError(err) {
+			log.Printf("[INFO] Cross-account role %s is not yet propagated: %s", roleArn, err)
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if isCredentialValidationError(err) {
+		err = fmt.Errorf("%s. Please check that the trust policy of %s allows "+
+			"sts:AssumeRole for Databricks with your account id as sts:ExternalId",
+			err.(common.APIError).Message, roleArn)
+	}
+	return
+}
+
+func isCredentialValidationError(err error) bool {
+	e, ok := err.(common.APIError)
+	return ok && e.StatusCode == 400 &&
+		strings.Contains(e.Message, "Failed credential validation check")
+}
+
 // Read returns the credentials object along with metadata
 func (a CredentialsAPI) Read(mwsAcctID, credentialsID string) (Credentials, error) {
 	var mwsCreds Credentials
@@ -59,15 +92,100 @@ func (a CredentialsAPI) List(mwsAcctID string) ([]Credentials, error) {
 	return mwsCredsList, err
 }
 
+// attachedWorkspaces returns workspaces of the account, that use given credentials
+func (a CredentialsAPI) attachedWorkspaces(mwsAcctID, credentialsID string) (attached []Workspace, err error) {
+	workspaces, err := NewWorkspacesAPI(a.context, a.client).List(mwsAcctID)
+	if err != nil {
+		return
+	}
+	for _, ws := range workspaces {
+		if ws.CredentialsID == credentialsID {
+			attached = append(attached, ws)
+		}
+	}
+	return
+}
+
+// rotatedCredentialsName returns name for credentials, that replace the given ones, so that both
+// could be told apart while workspaces are switched
+func rotatedCredentialsName(name, oldCredentialsID string) string {
+	return fmt.Sprintf("%s%s%s", name, rotatedCredentialsSuffix, oldCredentialsID)
+}
+
+const rotatedCredentialsSuffix = "-rotated-"
+
+// Rotate replaces credentials with the new ones for a different cross-account role, because
+// accounts API doesn't support updating them. Workspaces using the old credentials are switched
+// to the new ones, if updateWorkspaces is set, as attached credentials cannot be deleted.
+// If switching any of workspaces fails, already switched workspaces are switched back and
+// new credentials are deleted.
+func (a CredentialsAPI) Rotate(old Credentials, roleArn string, updateWorkspaces bool,
+	timeout time.Duration) (Credentials, error) {
+	attached, err := a.attachedWorkspaces(old.AccountID, old.CredentialsID)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if len(attached) > 0 && !updateWorkspaces {
+		names := []string{}
+		for _, ws := range attached {
+			names = append(names, ws.WorkspaceName)
+		}
+		return Credentials{}, fmt.Errorf("Credentials %s are used by workspaces %s and cannot be "+
+			"deleted before workspaces are switched to new credentials. Either set update_workspaces = true, "+
+			"or create new databricks_mws_credentials, change credentials_id of workspaces to it "+
+			"and only then remove the old credentials", old.CredentialsName, strings.Join(names, ", "))
+	}
+	credentials, err := a.CreateWithRetry(old.AccountID,
+		rotatedCredentialsName(old.CredentialsName, old.CredentialsID), roleArn, timeout)
+	if err != nil {
+		return credentials, err
+	}
+	workspacesAPI := NewWorkspacesAPI(a.context, a.client)
+	for i, ws := range attached {
+		log.Printf("[INFO] Switching workspace %s to credentials %s",
+			ws.WorkspaceName, credentials.CredentialsID)
+		ws.AccountID = old.AccountID
+		ws.CredentialsID = credentials.CredentialsID
+		if err = workspacesAPI.Patch(ws, timeout); err != nil {
+			return a.rollbackRotation(old, credentials, attached[:i], timeout, err)
+		}
+	}
+	return credentials, a.Delete(old.AccountID, old.CredentialsID)
+}
+
+// rollbackRotation switches workspaces back to the old credentials and deletes the new ones. If any of
+// workspaces cannot be switched back, new credentials are returned, as they are still in use.
+// Otherwise old credentials stay in use, even if new ones cannot be deleted.
+func (a CredentialsAPI) rollbackRotation(old, credentials Credentials, switched []Workspace,
+	timeout time.Duration, cause error) (Credentials, error) {
+	workspacesAPI := NewWorkspacesAPI(a.context, a.client)
+	for _, ws := range switched {
+		log.Printf("[INFO] Switching workspace %s back to credentials %s",
+			ws.WorkspaceName, old.CredentialsID)
+		ws.AccountID = old.AccountID
+		ws.CredentialsID = old.CredentialsID
+		if err := workspacesAPI.Patch(ws, timeout); err != nil {
+			return credentials, fmt.Errorf("%w. Cannot switch workspace %s back to credentials %s: %s",
+				cause, ws.WorkspaceName, old.CredentialsID, err)
+		}
+	}
+	if err := a.Delete(old.AccountID, credentials.CredentialsID); err != nil {
+		return Credentials{}, fmt.Errorf("%w. Cannot delete new credentials %s: %s",
+			cause, credentials.CredentialsID, err)
+	}
+	return Credentials{}, cause
+}
+
 // ResourceCredentials ...
 func ResourceCredentials() *schema.Resource {
 	p := util.NewPairSeparatedID("account_id", "credentials_id", "/")
-	return util.CommonResource{
+	r := util.CommonResource{
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			accountID := d.Get("account_id").(string)
 			roleArn := d.Get("role_arn").(string)
 			credentialsName := d.Get("credentials_name").(string)
-			credentials, err := NewCredentialsAPI(ctx, c).Create(accountID, credentialsName, roleArn)
+			credentials, err := NewCredentialsAPI(ctx, c).CreateWithRetry(accountID,
+				credentialsName, roleArn, d.Timeout(schema.TimeoutCreate))
 			if err != nil {
 				return err
 			}
@@ -84,11 +202,50 @@ func ResourceCredentials() *schema.Resource {
 			if err != nil {
 				return err
 			}
-			d.Set("credentials_name", credentials.CredentialsName)
+			// rotated credentials keep configured name in state
+			if !strings.HasPrefix(credentials.CredentialsName,
+				d.Get("credentials_name").(string)+rotatedCredentialsSuffix) {
+				d.Set("credentials_name", credentials.CredentialsName)
+			}
 			d.Set("role_arn", credentials.AwsCredentials.StsRole.RoleArn)
 			d.Set("creation_time", credentials.CreationTime)
 			return d.Set("external_id", credentials.AwsCredentials.StsRole.ExternalID)
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			if d.Id() != "" && d.HasChange("role_arn") {
+				// credentials are replaced behind the scenes, so that references get new id
+				if err := d.SetNewComputed("credentials_id"); err != nil {
+					return err
+				}
+				return d.SetNewComputed("creation_time")
+			}
+			return nil
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			if !d.HasChange("role_arn") {
+				return nil
+			}
+			accountID, credsID, err := p.Unpack(d)
+			if err != nil {
+				return err
+			}
+			credentials, err := NewCredentialsAPI(ctx, c).Rotate(Credentials{
+				AccountID:       accountID,
+				CredentialsID:   credsID,
+				CredentialsName: d.Get("credentials_name").(string),
+			}, d.Get("role_arn").(string), d.Get("update_workspaces").(bool),
+				d.Timeout(schema.TimeoutUpdate))
+			if credentials.CredentialsID == "" {
+				// otherwise failed role_arn change gets into state and won't be retried
+				oldRoleArn, _ := d.GetChange("role_arn")
+				d.Set("role_arn", oldRoleArn)
+				return err
+			}
+			// new credentials are kept in state, even if switching workspaces failed
+			d.Set("credentials_id", credentials.CredentialsID)
+			p.Pack(d)
+			return err
+		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			accountID, credsID, err := p.Unpack(d)
 			if err != nil {
@@ -111,7 +268,11 @@ func ResourceCredentials() *schema.Resource {
 			"role_arn": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
+			},
+			"update_workspaces": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 			"creation_time": {
 				Type:     schema.TypeInt,
@@ -127,4 +288,9 @@ func ResourceCredentials() *schema.Resource {
 			},
 		},
 	}.ToResource()
+	r.Timeouts = &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(2 * time.Minute),
+		Update: schema.DefaultTimeout(20 * time.Minute),
+	}
+	return accountScoped(r)
 }
@@ -3,12 +3,15 @@ package mws
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 
 	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMwsAccCreds(t *testing.T) {
@@ -79,6 +82,77 @@ func TestResourceCredentialsCreate(t *testing.T) {
 	assert.Equal(t, "abc/cid", d.Id())
 }
 
+func TestResourceCredentialsCreate_IAMPropagation(t *testing.T) {
+	validationError := qa.HTTPFixture{
+		Method:   "POST",
+		Resource: "/api/2.0/accounts/abc/credentials",
+		Response: common.APIErrorBody{
+			ErrorCode: "INVALID_PARAMETER_VALUE",
+			Message: "Failed credential validation check: cross-account " +
+				"role is not configured correctly",
+		},
+		Status: 400,
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			validationError,
+			validationError,
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/credentials",
+				Response: Credentials{
+					CredentialsID: "cid",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/credentials/cid",
+				Response: Credentials{
+					CredentialsID:   "cid",
+					CredentialsName: "Cross-account ARN",
+					AwsCredentials: &AwsCredentials{
+						StsRole: &StsRole{
+							RoleArn: "arn:aws:iam::098765:role/cross-account",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceCredentials(),
+		State: map[string]interface{}{
+			"account_id":       "abc",
+			"credentials_name": "Cross-account ARN",
+			"role_arn":         "arn:aws:iam::098765:role/cross-account",
+		},
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/cid", d.Id())
+}
+
+func TestCredentialsCreateWithRetry_Timeout(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:       "POST",
+			Resource:     "/api/2.0/accounts/abc/credentials",
+			ReuseRequest: true,
+			Response: common.APIErrorBody{
+				ErrorCode: "INVALID_PARAMETER_VALUE",
+				Message:   "Failed credential validation check: cross-account role is not configured correctly",
+			},
+			Status: 400,
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	_, err = NewCredentialsAPI(context.Background(), client).CreateWithRetry(
+		"abc", "creds", "arn:aws:iam::098765:role/cross-account", 1*time.Second)
+	qa.AssertErrorStartsWith(t, err, "Failed credential validation check: cross-account "+
+		"role is not configured correctly. Please check that the trust policy of "+
+		"arn:aws:iam::098765:role/cross-account allows sts:AssumeRole")
+}
+
 func TestResourceCredentialsCreate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -121,9 +195,10 @@ func TestResourceCredentialsRead(t *testing.T) {
 				},
 			},
 		},
-		Resource: ResourceCredentials(),
-		Read:     true,
-		ID:       "abc/cid",
+		Resource:  ResourceCredentials(),
+		AccountID: "abc",
+		Read:      true,
+		ID:        "abc/cid",
 	}.Apply(t)
 	assert.NoError(t, err, err)
 	assert.Equal(t, "abc/cid", d.Id(), "Id should not be empty")
@@ -147,10 +222,11 @@ func TestResourceCredentialsRead_NotFound(t *testing.T) {
 				Status: 404,
 			},
 		},
-		Resource: ResourceCredentials(),
-		Read:     true,
-		Removed:  true,
-		ID:       "abc/cid",
+		Resource:  ResourceCredentials(),
+		AccountID: "abc",
+		Read:      true,
+		Removed:   true,
+		ID:        "abc/cid",
 	}.ApplyNoError(t)
 }
 
@@ -167,14 +243,257 @@ func TestResourceCredentialsRead_Error(t *testing.T) {
 				Status: 400,
 			},
 		},
-		Resource: ResourceCredentials(),
-		Read:     true,
-		ID:       "abc/cid",
+		Resource:  ResourceCredentials(),
+		AccountID: "abc",
+		Read:      true,
+		ID:        "abc/cid",
 	}.Apply(t)
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc/cid", d.Id(), "Id should not be empty for error reads")
 }
 
+func credentialsRotationFixtures(workspaces ...Workspace) []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc/workspaces?limit=100&offset=0",
+			Response: append([]Workspace{
+				{
+					WorkspaceID:   1,
+					WorkspaceName: "unrelated",
+					CredentialsID: "other",
+				},
+			}, workspaces...),
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/accounts/abc/credentials",
+			ExpectedRequest: Credentials{
+				CredentialsName: "Cross-account ARN-rotated-cid",
+				AwsCredentials: &AwsCredentials{
+					StsRole: &StsRole{
+						RoleArn: "arn:aws:iam::098765:role/new",
+					},
+				},
+			},
+			Response: Credentials{
+				CredentialsID: "new",
+			},
+		},
+	}
+}
+
+var credentialsRotationTail = []qa.HTTPFixture{
+	{
+		Method:   "DELETE",
+		Resource: "/api/2.0/accounts/abc/credentials/cid",
+	},
+	{
+		Method:   "GET",
+		Resource: "/api/2.0/accounts/abc/credentials/new",
+		Response: Credentials{
+			CredentialsID:   "new",
+			CredentialsName: "Cross-account ARN-rotated-cid",
+			AwsCredentials: &AwsCredentials{
+				StsRole: &StsRole{
+					RoleArn: "arn:aws:iam::098765:role/new",
+				},
+			},
+		},
+	},
+}
+
+var credentialsRotationState = map[string]string{
+	"account_id":       "abc",
+	"credentials_id":   "cid",
+	"credentials_name": "Cross-account ARN",
+	"role_arn":         "arn:aws:iam::098765:role/old",
+}
+
+func TestResourceCredentialsUpdate_Unattached(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures:      append(credentialsRotationFixtures(), credentialsRotationTail...),
+		Resource:      ResourceCredentials(),
+		InstanceState: credentialsRotationState,
+		HCL: `
+		account_id = "abc"
+		credentials_name = "Cross-account ARN"
+		role_arn = "arn:aws:iam::098765:role/new"
+		`,
+		Update: true,
+		ID:     "abc/cid",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/new", d.Id())
+	assert.Equal(t, "new", d.Get("credentials_id"))
+	assert.Equal(t, "Cross-account ARN", d.Get("credentials_name"))
+}
+
+func TestResourceCredentialsUpdate_Attached(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces?limit=100&offset=0",
+				Response: []Workspace{
+					{
+						WorkspaceID:   2,
+						WorkspaceName: "prod",
+						CredentialsID: "cid",
+					},
+				},
+			},
+		},
+		Resource:      ResourceCredentials(),
+		InstanceState: credentialsRotationState,
+		HCL: `
+		account_id = "abc"
+		credentials_name = "Cross-account ARN"
+		role_arn = "arn:aws:iam::098765:role/new"
+		`,
+		Update: true,
+		ID:     "abc/cid",
+	}.Apply(t)
+	assert.EqualError(t, err, "Credentials Cross-account ARN are used by workspaces prod "+
+		"and cannot be deleted before workspaces are switched to new credentials. Either set "+
+		"update_workspaces = true, or create new databricks_mws_credentials, change credentials_id "+
+		"of workspaces to it and only then remove the old credentials")
+	assert.Equal(t, "abc/cid", d.Id())
+	assert.Equal(t, "arn:aws:iam::098765:role/old", d.Get("role_arn"))
+}
+
+func TestResourceCredentialsUpdate_AttachedUpdateWorkspaces(t *testing.T) {
+	prod := Workspace{
+		WorkspaceID:            2,
+		WorkspaceName:          "prod",
+		AwsRegion:              "us-east-1",
+		CredentialsID:          "cid",
+		StorageConfigurationID: "sid",
+		NetworkID:              "nid",
+	}
+	fixtures := credentialsRotationFixtures(prod)
+	fixtures = append(fixtures, qa.HTTPFixture{
+		Method:   "PATCH",
+		Resource: "/api/2.0/accounts/abc/workspaces/2",
+		ExpectedRequest: Workspace{
+			AwsRegion:              "us-east-1",
+			CredentialsID:          "new",
+			StorageConfigurationID: "sid",
+			NetworkID:              "nid",
+		},
+	}, qa.HTTPFixture{
+		Method:       "GET",
+		ReuseRequest: true,
+		Resource:     "/api/2.0/accounts/abc/workspaces/2",
+		Response: Workspace{
+			WorkspaceID:     2,
+			WorkspaceStatus: WorkspaceStatusRunning,
+			DeploymentName:  "900150983cd24fb0",
+		},
+	})
+	d, err := qa.ResourceFixture{
+		Fixtures:      append(fixtures, credentialsRotationTail...),
+		Resource:      ResourceCredentials(),
+		InstanceState: credentialsRotationState,
+		HCL: `
+		account_id = "abc"
+		credentials_name = "Cross-account ARN"
+		role_arn = "arn:aws:iam::098765:role/new"
+		update_workspaces = true
+		`,
+		Update: true,
+		ID:     "abc/cid",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/new", d.Id())
+}
+
+func TestResourceCredentialsUpdate_PartialFailureRollsBack(t *testing.T) {
+	patch := func(credentialsID string) qa.HTTPFixture {
+		return qa.HTTPFixture{
+			Method:   "PATCH",
+			Resource: "/api/2.0/accounts/abc/workspaces/2",
+			ExpectedRequest: Workspace{
+				AwsRegion:     "us-east-1",
+				CredentialsID: credentialsID,
+			},
+		}
+	}
+	fixtures := credentialsRotationFixtures(Workspace{
+		WorkspaceID:   2,
+		WorkspaceName: "prod",
+		AwsRegion:     "us-east-1",
+		CredentialsID: "cid",
+	}, Workspace{
+		WorkspaceID:   3,
+		WorkspaceName: "staging",
+		AwsRegion:     "us-east-1",
+		CredentialsID: "cid",
+	})
+	fixtures = append(fixtures, patch("new"), qa.HTTPFixture{
+		Method:       "GET",
+		ReuseRequest: true,
+		Resource:     "/api/2.0/accounts/abc/workspaces/2",
+		Response: Workspace{
+			WorkspaceID:     2,
+			WorkspaceStatus: WorkspaceStatusRunning,
+			DeploymentName:  "900150983cd24fb0",
+		},
+	}, qa.HTTPFixture{
+		Method:   "PATCH",
+		Resource: "/api/2.0/accounts/abc/workspaces/3",
+		Response: common.APIErrorBody{
+			ErrorCode: "MALFORMED_REQUEST",
+			Message:   "Cannot assume cross-account role",
+		},
+		Status: 400,
+	})
+	// prod is switched back and new credentials are removed
+	fixtures = append(fixtures, patch("cid"), qa.HTTPFixture{
+		Method:   "DELETE",
+		Resource: "/api/2.0/accounts/abc/credentials/new",
+	})
+	d, err := qa.ResourceFixture{
+		Fixtures:      fixtures,
+		Resource:      ResourceCredentials(),
+		InstanceState: credentialsRotationState,
+		HCL: `
+		account_id = "abc"
+		credentials_name = "Cross-account ARN"
+		role_arn = "arn:aws:iam::098765:role/new"
+		update_workspaces = true
+		`,
+		Update: true,
+		ID:     "abc/cid",
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Cannot assume cross-account role")
+	assert.Equal(t, "abc/cid", d.Id())
+	assert.Equal(t, "cid", d.Get("credentials_id"))
+	assert.Equal(t, "arn:aws:iam::098765:role/old", d.Get("role_arn"))
+}
+
+func TestResourceCredentialsUpdate_PlansNewID(t *testing.T) {
+	r := ResourceCredentials()
+	state := &terraform.InstanceState{
+		ID: "abc/cid",
+		Attributes: map[string]string{
+			"account_id":        "abc",
+			"credentials_id":    "cid",
+			"credentials_name":  "Cross-account ARN",
+			"role_arn":          "arn:aws:iam::098765:role/old",
+			"update_workspaces": "false",
+		},
+	}
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"account_id":       "abc",
+		"credentials_name": "Cross-account ARN",
+		"role_arn":         "arn:aws:iam::098765:role/new",
+	}), nil)
+	require.NoError(t, err)
+	assert.False(t, diff.RequiresNew())
+	assert.True(t, diff.Attributes["credentials_id"].NewComputed)
+}
+
 func TestResourceCredentialsDelete(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -183,9 +502,10 @@ func TestResourceCredentialsDelete(t *testing.T) {
 				Resource: "/api/2.0/accounts/abc/credentials/cid",
 			},
 		},
-		Resource: ResourceCredentials(),
-		Delete:   true,
-		ID:       "abc/cid",
+		Resource:  ResourceCredentials(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc/cid",
 	}.Apply(t)
 	assert.NoError(t, err, err)
 	assert.Equal(t, "abc/cid", d.Id())
@@ -204,9 +524,10 @@ func TestResourceCredentialsDelete_Error(t *testing.T) {
 				Status: 400,
 			},
 		},
-		Resource: ResourceCredentials(),
-		Delete:   true,
-		ID:       "abc/cid",
+		Resource:  ResourceCredentials(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc/cid",
 	}.Apply(t)
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc/cid", d.Id())
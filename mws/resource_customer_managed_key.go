@@ -8,6 +8,7 @@ import (
 	"github.com/databrickslabs/databricks-terraform/internal"
 	"github.com/databrickslabs/databricks-terraform/internal/util"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // AwsKeyInfo has information about the KMS key for BYOK
@@ -17,12 +18,19 @@ type AwsKeyInfo struct {
 	KeyRegion string `json:"key_region,omitempty" tf:"computed"`
 }
 
+// List of use cases for customer-managed keys
+const (
+	CustomerManagedKeyUseCaseStorage         = "STORAGE"
+	CustomerManagedKeyUseCaseManagedServices = "MANAGED_SERVICES"
+)
+
 // CustomerManagedKey contains key information and metadata for BYOK for E2
 type CustomerManagedKey struct {
 	CustomerManagedKeyID string      `json:"customer_managed_key_id,omitempty" tf:"computed"`
 	AwsKeyInfo           *AwsKeyInfo `json:"aws_key_info"`
-	AccountID            string      `json:"account_id"`
+	AccountID            string      `json:"account_id,omitempty"`
 	CreationTime         int64       `json:"creation_time,omitempty" tf:"computed"`
+	UseCases             []string    `json:"use_cases,omitempty" tf:"slice_set,computed"`
 }
 
 // NewCustomerManagedKeysAPI creates CustomerManagedKeysAPI instance from provider meta
@@ -69,15 +77,28 @@ func ResourceCustomerManagedKey() *schema.Resource {
 		func(s map[string]*schema.Schema) map[string]*schema.Schema {
 			s["aws_key_info"].ForceNew = true
 			s["account_id"].ForceNew = true
+			s["use_cases"].ForceNew = true
+			// nolint
+			s["use_cases"].Elem = &schema.Schema{
+				Type: schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{
+					CustomerManagedKeyUseCaseStorage,
+					CustomerManagedKeyUseCaseManagedServices,
+				}, false),
+			}
 			return s
 		})
 	p := util.NewPairSeparatedID("account_id", "customer_managed_key_id", "/")
-	return util.CommonResource{
+	r := util.CommonResource{
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var cmk CustomerManagedKey
 			if err := internal.DataToStructPointer(d, s, &cmk); err != nil {
 				return err
 			}
+			if len(cmk.UseCases) == 0 {
+				// keys created before use cases were introduced are used only for storage
+				cmk.UseCases = []string{CustomerManagedKeyUseCaseStorage}
+			}
 			customerManagedKeyData, err := NewCustomerManagedKeysAPI(ctx, c).Create(cmk)
 			if err != nil {
 				return err
@@ -106,4 +127,6 @@ func ResourceCustomerManagedKey() *schema.Resource {
 		},
 		Schema: s,
 	}.ToResource()
+	r.Importer = p.Importer()
+	return accountScoped(r)
 }
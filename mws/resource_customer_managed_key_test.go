@@ -55,6 +55,7 @@ func TestResourceCustomerManagedKeyCreate(t *testing.T) {
 						KeyArn:   "key-arn",
 						KeyAlias: "key-alias",
 					},
+					UseCases: []string{"STORAGE"},
 				},
 				Response: CustomerManagedKey{
 					CustomerManagedKeyID: "cmkid",
@@ -92,6 +93,74 @@ func TestResourceCustomerManagedKeyCreate(t *testing.T) {
 	assert.Equal(t, "key-alias", d.Get("aws_key_info.0.key_alias"))
 }
 
+func TestResourceCustomerManagedKeyCreate_ManagedServices(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/customer-managed-keys",
+				ExpectedRequest: CustomerManagedKey{
+					AccountID: "abc",
+					AwsKeyInfo: &AwsKeyInfo{
+						KeyArn:   "key-arn",
+						KeyAlias: "key-alias",
+					},
+					UseCases: []string{"MANAGED_SERVICES", "STORAGE"},
+				},
+				Response: CustomerManagedKey{
+					CustomerManagedKeyID: "cmkid",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/customer-managed-keys/cmkid",
+				Response: CustomerManagedKey{
+					CustomerManagedKeyID: "cmkid",
+					AwsKeyInfo: &AwsKeyInfo{
+						KeyArn:    "key-arn",
+						KeyAlias:  "key-alias",
+						KeyRegion: "us-east-1",
+					},
+					AccountID:    "abc",
+					CreationTime: 123,
+					UseCases:     []string{"STORAGE", "MANAGED_SERVICES"},
+				},
+			},
+		},
+		Resource: ResourceCustomerManagedKey(),
+		HCL: `
+			account_id = "abc"
+			use_cases = ["MANAGED_SERVICES", "STORAGE"]
+
+			aws_key_info {
+				key_arn   = "key-arn"
+				key_alias = "key-alias"
+			}
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/cmkid", d.Id())
+	assert.Equal(t, 2, d.Get("use_cases.#"))
+}
+
+func TestResourceCustomerManagedKeyCreate_InvalidUseCase(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceCustomerManagedKey(),
+		HCL: `
+			account_id = "abc"
+			use_cases = ["NOTEBOOKS"]
+
+			aws_key_info {
+				key_arn   = "key-arn"
+				key_alias = "key-alias"
+			}
+		`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Invalid config supplied. [use_cases")
+}
+
 func TestResourceCustomerManagedKeyCreate_Error(t *testing.T) {
 	_, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -104,6 +173,7 @@ func TestResourceCustomerManagedKeyCreate_Error(t *testing.T) {
 						KeyArn:   "key-arn",
 						KeyAlias: "key-alias",
 					},
+					UseCases: []string{"STORAGE"},
 				},
 				Response: common.APIErrorBody{
 					ErrorCode: "INVALID_REQUEST",
@@ -231,3 +301,13 @@ func TestResourceCustomerManagedKeyDelete(t *testing.T) {
 	assert.NoError(t, err, err)
 	assert.Equal(t, "abc/cmkid", d.Id())
 }
+
+func TestResourceCustomerManagedKeyImport(t *testing.T) {
+	r := ResourceCustomerManagedKey()
+	d := r.TestResourceData()
+	d.SetId("abc/cmkid")
+	_, err := r.Importer.StateContext(context.Background(), d, nil)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Get("account_id"))
+	assert.Equal(t, "cmkid", d.Get("customer_managed_key_id"))
+}
@@ -0,0 +1,209 @@
+package mws
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/databrickslabs/databricks-terraform/access"
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/databrickslabs/databricks-terraform/internal/util"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// List of IP access list types
+const (
+	IPAccessListAllow = "ALLOW"
+	IPAccessListBlock = "BLOCK"
+)
+
+// maxIPAccessListAddresses is the quota of IP addresses and CIDR ranges in all lists of the account combined
+const maxIPAccessListAddresses = 1000
+
+// IPAccessList restricts IP addresses, that could reach the accounts console and account APIs
+type IPAccessList struct {
+	AccountID    string   `json:"account_id,omitempty"`
+	ListID       string   `json:"list_id,omitempty" tf:"computed"`
+	Label        string   `json:"label"`
+	ListType     string   `json:"list_type"`
+	IPAddresses  []string `json:"ip_addresses" tf:"slice_set"`
+	Enabled      bool     `json:"enabled"`
+	AddressCount int      `json:"address_count,omitempty" tf:"computed"`
+}
+
+type ipAccessListWrapper struct {
+	IPAccessList IPAccessList `json:"ip_access_list"`
+}
+
+type ipAccessListsResponse struct {
+	IPAccessLists []IPAccessList `json:"ip_access_lists,omitempty"`
+}
+
+func (l IPAccessList) rule() access.IPAccessListRule {
+	return access.IPAccessListRule{
+		ListType:    l.ListType,
+		IPAddresses: l.IPAddresses,
+		Enabled:     l.Enabled,
+	}
+}
+
+// NewIPAccessListsAPI creates IPAccessListsAPI instance from provider meta
+func NewIPAccessListsAPI(ctx context.Context, m interface{}) IPAccessListsAPI {
+	return IPAccessListsAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// IPAccessListsAPI exposes the account-level IP access lists API
+type IPAccessListsAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+// Create creates IP access list on the account level
+func (a IPAccessListsAPI) Create(acl *IPAccessList) error {
+	var wrapper ipAccessListWrapper
+	aclAPIPath := fmt.Sprintf("/accounts/%s/ip-access-lists", acl.AccountID)
+	if err := a.client.Post(a.context, aclAPIPath, acl, &wrapper); err != nil {
+		return err
+	}
+	acl.ListID = wrapper.IPAccessList.ListID
+	return nil
+}
+
+// Read returns IP access list given its id
+func (a IPAccessListsAPI) Read(mwsAcctID, listID string) (IPAccessList, error) {
+	var wrapper ipAccessListWrapper
+	aclAPIPath := fmt.Sprintf("/accounts/%s/ip-access-lists/%s", mwsAcctID, listID)
+	err := a.client.Get(a.context, aclAPIPath, nil, &wrapper)
+	return wrapper.IPAccessList, err
+}
+
+// Update replaces IP access list in place
+func (a IPAccessListsAPI) Update(acl IPAccessList) error {
+	aclAPIPath := fmt.Sprintf("/accounts/%s/ip-access-lists/%s", acl.AccountID, acl.ListID)
+	return a.client.Put(a.context, aclAPIPath, acl)
+}
+
+// Delete deletes IP access list given its id
+func (a IPAccessListsAPI) Delete(mwsAcctID, listID string) error {
+	aclAPIPath := fmt.Sprintf("/accounts/%s/ip-access-lists/%s", mwsAcctID, listID)
+	return a.client.Delete(a.context, aclAPIPath, nil)
+}
+
+// List returns all IP access lists of the account
+func (a IPAccessListsAPI) List(mwsAcctID string) ([]IPAccessList, error) {
+	var resp ipAccessListsResponse
+	aclAPIPath := fmt.Sprintf("/accounts/%s/ip-access-lists", mwsAcctID)
+	err := a.client.Get(a.context, aclAPIPath, nil, &resp)
+	return resp.IPAccessLists, err
+}
+
+// check verifies, that the account stays within IP address quota after the list is created or updated.
+// If lockout check IP is given, it also verifies, that this address is still able to reach the account.
+func (a IPAccessListsAPI) check(acl IPAccessList, lockoutCheckIP net.IP) error {
+	existing, err := a.List(acl.AccountID)
+	if err != nil {
+		return err
+	}
+	rules := []access.IPAccessListRule{acl.rule()}
+	total := len(acl.IPAddresses)
+	for _, l := range existing {
+		if l.ListID == acl.ListID {
+			continue
+		}
+		rules = append(rules, l.rule())
+		total += len(l.IPAddresses)
+	}
+	if total > maxIPAccessListAddresses {
+		return fmt.Errorf("IP access lists of the account can have at most %d IP addresses "+
+			"and CIDR ranges combined, but would have %d", maxIPAccessListAddresses, total)
+	}
+	if lockoutCheckIP != nil && access.IsBlocked(lockoutCheckIP, rules) {
+		return fmt.Errorf("IP access list %s would block %s from accessing the account. "+
+			"Please allow it or change lockout_check_ip", acl.Label, lockoutCheckIP)
+	}
+	return nil
+}
+
+// ResourceIPAccessList manages IP access lists of the accounts console
+func ResourceIPAccessList() *schema.Resource {
+	s := internal.StructToSchema(IPAccessList{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["account_id"].Sensitive = true
+		s["account_id"].ForceNew = true
+		// nolint
+		s["list_type"].ValidateFunc = validation.StringInSlice([]string{
+			IPAccessListAllow, IPAccessListBlock}, false)
+		s["ip_addresses"].Elem = &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.Any(validation.IsIPv4Address, validation.IsCIDR),
+		}
+		s["ip_addresses"].MaxItems = maxIPAccessListAddresses
+		s["enabled"].Required = false
+		s["enabled"].Optional = true
+		s["enabled"].Default = true
+		s["lockout_check_ip"] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsIPAddress,
+		}
+		return s
+	})
+	p := util.NewPairSeparatedID("account_id", "list_id", "/")
+	r := util.CommonResource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var acl IPAccessList
+			if err := internal.DataToStructPointer(d, s, &acl); err != nil {
+				return err
+			}
+			aclAPI := NewIPAccessListsAPI(ctx, c)
+			if err := aclAPI.check(acl, net.ParseIP(d.Get("lockout_check_ip").(string))); err != nil {
+				return err
+			}
+			if err := aclAPI.Create(&acl); err != nil {
+				return err
+			}
+			d.Set("list_id", acl.ListID)
+			p.Pack(d)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			accountID, listID, err := p.Unpack(d)
+			if err != nil {
+				return err
+			}
+			acl, err := NewIPAccessListsAPI(ctx, c).Read(accountID, listID)
+			if err != nil {
+				return err
+			}
+			acl.AccountID = accountID
+			return internal.StructToData(acl, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			_, listID, err := p.Unpack(d)
+			if err != nil {
+				return err
+			}
+			var acl IPAccessList
+			if err = internal.DataToStructPointer(d, s, &acl); err != nil {
+				return err
+			}
+			acl.ListID = listID
+			aclAPI := NewIPAccessListsAPI(ctx, c)
+			if err = aclAPI.check(acl, net.ParseIP(d.Get("lockout_check_ip").(string))); err != nil {
+				return err
+			}
+			return aclAPI.Update(acl)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			accountID, listID, err := p.Unpack(d)
+			if err != nil {
+				return err
+			}
+			return NewIPAccessListsAPI(ctx, c).Delete(accountID, listID)
+		},
+	}.ToResource()
+	r.Importer = p.Importer()
+	return accountScoped(r)
+}
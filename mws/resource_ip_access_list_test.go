@@ -0,0 +1,429 @@
+package mws
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceIPAccessListCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists",
+				Response: ipAccessListsResponse{
+					IPAccessLists: []IPAccessList{
+						{
+							ListID:      "other",
+							ListType:    "BLOCK",
+							IPAddresses: []string{"8.8.8.8"},
+							Enabled:     true,
+						},
+					},
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists",
+				ExpectedRequest: IPAccessList{
+					AccountID:   "abc",
+					Label:       "office",
+					ListType:    "ALLOW",
+					IPAddresses: []string{"1.2.3.0/24"},
+					Enabled:     true,
+				},
+				Response: ipAccessListWrapper{
+					IPAccessList: IPAccessList{
+						ListID: "123",
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists/123",
+				Response: ipAccessListWrapper{
+					IPAccessList: IPAccessList{
+						ListID:       "123",
+						Label:        "office",
+						ListType:     "ALLOW",
+						IPAddresses:  []string{"1.2.3.0/24"},
+						Enabled:      true,
+						AddressCount: 256,
+					},
+				},
+			},
+		},
+		Resource: ResourceIPAccessList(),
+		HCL: `
+		account_id = "abc"
+		label = "office"
+		list_type = "ALLOW"
+		ip_addresses = ["1.2.3.0/24"]
+		lockout_check_ip = "1.2.3.4"
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/123", d.Id())
+	assert.Equal(t, "123", d.Get("list_id"))
+	assert.Equal(t, 256, d.Get("address_count"))
+	assert.Equal(t, true, d.Get("enabled"))
+}
+
+func TestResourceIPAccessListCreate_Lockout(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists",
+				Response: ipAccessListsResponse{},
+			},
+		},
+		Resource: ResourceIPAccessList(),
+		HCL: `
+		account_id = "abc"
+		label = "office"
+		list_type = "ALLOW"
+		ip_addresses = ["1.2.3.0/24"]
+		lockout_check_ip = "4.3.2.1"
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.EqualError(t, err, "IP access list office would block 4.3.2.1 "+
+		"from accessing the account. Please allow it or change lockout_check_ip")
+}
+
+func TestResourceIPAccessListCreate_NoLockoutCheck(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists",
+				Response: ipAccessListsResponse{},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists",
+				Response: ipAccessListWrapper{
+					IPAccessList: IPAccessList{
+						ListID: "123",
+					},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists/123",
+				Response: ipAccessListWrapper{
+					IPAccessList: IPAccessList{
+						ListID:      "123",
+						Label:       "office",
+						ListType:    "BLOCK",
+						IPAddresses: []string{"1.2.3.4"},
+						Enabled:     true,
+					},
+				},
+			},
+		},
+		Resource: ResourceIPAccessList(),
+		HCL: `
+		account_id = "abc"
+		label = "office"
+		list_type = "BLOCK"
+		ip_addresses = ["1.2.3.4"]
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/123", d.Id())
+}
+
+func TestResourceIPAccessListCreate_Quota(t *testing.T) {
+	addresses := []string{}
+	for i := 0; i < maxIPAccessListAddresses; i++ {
+		addresses = append(addresses, fmt.Sprintf("10.0.%d.%d", i/256, i%256))
+	}
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists",
+				Response: ipAccessListsResponse{
+					IPAccessLists: []IPAccessList{
+						{
+							ListID:      "other",
+							ListType:    "ALLOW",
+							IPAddresses: addresses,
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceIPAccessList(),
+		HCL: `
+		account_id = "abc"
+		label = "office"
+		list_type = "BLOCK"
+		ip_addresses = ["1.2.3.4"]
+		lockout_check_ip = "1.2.3.4"
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.EqualError(t, err, "IP access lists of the account can have at most 1000 "+
+		"IP addresses and CIDR ranges combined, but would have 1001")
+}
+
+func TestResourceIPAccessListCreate_Error(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists",
+				Response: ipAccessListsResponse{},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource: ResourceIPAccessList(),
+		HCL: `
+		account_id = "abc"
+		label = "office"
+		list_type = "ALLOW"
+		ip_addresses = ["1.2.3.4"]
+		`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+	assert.Equal(t, "", d.Id(), "Id should be empty for error creates")
+}
+
+func TestResourceIPAccessListRead(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists/123",
+				Response: ipAccessListWrapper{
+					IPAccessList: IPAccessList{
+						ListID:      "123",
+						Label:       "office",
+						ListType:    "ALLOW",
+						IPAddresses: []string{"1.2.3.4"},
+						Enabled:     false,
+					},
+				},
+			},
+		},
+		Resource:  ResourceIPAccessList(),
+		AccountID: "abc",
+		Read:      true,
+		New:       true,
+		ID:        "abc/123",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/123", d.Id(), "Id should not be empty")
+	assert.Equal(t, "abc", d.Get("account_id"))
+	assert.Equal(t, "office", d.Get("label"))
+	assert.Equal(t, false, d.Get("enabled"))
+	assert.Equal(t, 1, d.Get("ip_addresses.#"))
+}
+
+func TestResourceIPAccessListRead_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists/123",
+				Response: common.APIErrorBody{
+					ErrorCode: "NOT_FOUND",
+					Message:   "Item not found",
+				},
+				Status: 404,
+			},
+		},
+		Resource:  ResourceIPAccessList(),
+		AccountID: "abc",
+		Read:      true,
+		Removed:   true,
+		ID:        "abc/123",
+	}.ApplyNoError(t)
+}
+
+func TestResourceIPAccessListRead_Error(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists/123",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource:  ResourceIPAccessList(),
+		AccountID: "abc",
+		Read:      true,
+		ID:        "abc/123",
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+	assert.Equal(t, "abc/123", d.Id(), "Id should not be empty for error reads")
+}
+
+func TestResourceIPAccessListUpdate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists",
+				Response: ipAccessListsResponse{
+					IPAccessLists: []IPAccessList{
+						{
+							// old version of the same list is ignored
+							ListID:      "123",
+							ListType:    "ALLOW",
+							IPAddresses: []string{"8.8.8.8"},
+							Enabled:     true,
+						},
+					},
+				},
+			},
+			{
+				Method:   "PUT",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists/123",
+				ExpectedRequest: IPAccessList{
+					AccountID:   "abc",
+					ListID:      "123",
+					Label:       "office",
+					ListType:    "ALLOW",
+					IPAddresses: []string{"1.2.3.4"},
+					Enabled:     true,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists/123",
+				Response: ipAccessListWrapper{
+					IPAccessList: IPAccessList{
+						ListID:      "123",
+						Label:       "office",
+						ListType:    "ALLOW",
+						IPAddresses: []string{"1.2.3.4"},
+						Enabled:     true,
+					},
+				},
+			},
+		},
+		Resource: ResourceIPAccessList(),
+		InstanceState: map[string]string{
+			"account_id": "abc",
+			"list_id":    "123",
+			"label":      "office",
+			"list_type":  "ALLOW",
+			"enabled":    "false",
+		},
+		HCL: `
+		account_id = "abc"
+		label = "office"
+		list_type = "ALLOW"
+		ip_addresses = ["1.2.3.4"]
+		`,
+		Update: true,
+		ID:     "abc/123",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/123", d.Id(), "Id should be the same as in reading")
+	assert.Equal(t, true, d.Get("enabled"))
+}
+
+func TestResourceIPAccessListUpdate_Error(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists",
+				Response: ipAccessListsResponse{},
+			},
+			{
+				Method:   "PUT",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists/123",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource: ResourceIPAccessList(),
+		HCL: `
+		account_id = "abc"
+		label = "office"
+		list_type = "ALLOW"
+		ip_addresses = ["1.2.3.4"]
+		`,
+		Update: true,
+		ID:     "abc/123",
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+	assert.Equal(t, "abc/123", d.Id())
+}
+
+func TestResourceIPAccessListDelete(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists/123",
+			},
+		},
+		Resource:  ResourceIPAccessList(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc/123",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/123", d.Id())
+}
+
+func TestResourceIPAccessListDelete_Error(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/accounts/abc/ip-access-lists/123",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource:  ResourceIPAccessList(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc/123",
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+	assert.Equal(t, "abc/123", d.Id())
+}
+
+func TestResourceIPAccessListImport_InvalidID(t *testing.T) {
+	r := ResourceIPAccessList()
+	d := r.TestResourceData()
+	d.SetId("123")
+	_, err := r.Importer.StateContext(context.Background(), d, nil)
+	assert.EqualError(t, err, "Invalid ID: 123. Import ID has to be in "+
+		"<account_id>/<list_id> format")
+}
@@ -17,9 +17,17 @@ type LogDelivery struct {
 	LogDeliveryConfiguration LogDeliveryConfiguration `json:"log_delivery_configuration"`
 }
 
+// LogDeliveryStatus describes the latest attempt to deliver logs
+type LogDeliveryStatus struct {
+	Status                    string `json:"status,omitempty" tf:"computed"`
+	Message                   string `json:"message,omitempty" tf:"computed"`
+	LastAttemptTime           string `json:"last_attempt_time,omitempty" tf:"computed"`
+	LastSuccessfulAttemptTime string `json:"last_successful_attempt_time,omitempty" tf:"computed"`
+}
+
 // LogDeliveryConfiguration describes log delivery
 type LogDeliveryConfiguration struct {
-	AccountID              string   `json:"account_id"`
+	AccountID              string   `json:"account_id,omitempty"`
 	ConfigID               string   `json:"config_id,omitempty" tf:"computed"`
 	CredentialsID          string   `json:"credentials_id"`
 	StorageConfigurationID string   `json:"storage_configuration_id"`
@@ -30,6 +38,8 @@ type LogDeliveryConfiguration struct {
 	OutputFormat           string   `json:"output_format"`
 	DeliveryPathPrefix     string   `json:"delivery_path_prefix,omitempty"`
 	DeliveryStartTime      string   `json:"delivery_start_time,omitempty" tf:"computed"`
+
+	LogDeliveryStatus *LogDeliveryStatus `json:"log_delivery_status,omitempty" tf:"computed"`
 }
 
 // LogDeliveryAPI ...
@@ -74,13 +84,17 @@ func ResourceLogDelivery() *schema.Resource {
 		func(s map[string]*schema.Schema) map[string]*schema.Schema {
 			// nolint
 			s["config_name"].ValidateFunc = validation.StringLenBetween(0, 255)
+			// nolint
+			s["log_type"].ValidateFunc = validation.StringInSlice([]string{"BILLABLE_USAGE", "AUDIT_LOGS"}, false)
+			// nolint
+			s["output_format"].ValidateFunc = validation.StringInSlice([]string{"CSV", "JSON"}, false)
 			s["delivery_start_time"].DiffSuppressFunc = func(
 				k, old, new string, d *schema.ResourceData) bool {
 				return false
 			}
 			return s
 		})
-	return util.CommonResource{
+	return accountScoped(util.CommonResource{
 		Schema: s,
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var ldc LogDeliveryConfiguration
@@ -120,5 +134,5 @@ func ResourceLogDelivery() *schema.Resource {
 			}
 			return NewLogDeliveryAPI(ctx, c).Disable(accountID, configID)
 		},
-	}.ToResource()
+	}.ToResource())
 }
@@ -169,10 +169,11 @@ func TestResourceLogDeliveryRead(t *testing.T) {
 				},
 			},
 		},
-		Resource: ResourceLogDelivery(),
-		Read:     true,
-		New:      true,
-		ID:       "abc|nid",
+		Resource:  ResourceLogDelivery(),
+		AccountID: "abc",
+		Read:      true,
+		New:       true,
+		ID:        "abc|nid",
 	}.Apply(t)
 	assert.NoError(t, err, err)
 	assert.Equal(t, "abc|nid", d.Id(), "Id should not be empty")
@@ -180,6 +181,57 @@ func TestResourceLogDeliveryRead(t *testing.T) {
 	assert.Equal(t, "def", d.Get("storage_configuration_id"))
 }
 
+func TestResourceLogDeliveryRead_DeliveryStatus(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/log-delivery/nid",
+				Response: LogDelivery{
+					LogDeliveryConfiguration: LogDeliveryConfiguration{
+						ConfigID:               "nid",
+						Status:                 "ENABLED",
+						AccountID:              "abc",
+						CredentialsID:          "bcd",
+						LogType:                "BILLABLE_USAGE",
+						OutputFormat:           "CSV",
+						StorageConfigurationID: "def",
+						LogDeliveryStatus: &LogDeliveryStatus{
+							Status:          "USER_FAILURE",
+							Message:         "Access denied to the bucket",
+							LastAttemptTime: "2020-12-01T10:00:00Z",
+						},
+					},
+				},
+			},
+		},
+		Resource:  ResourceLogDelivery(),
+		AccountID: "abc",
+		Read:      true,
+		New:       true,
+		ID:        "abc|nid",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "ENABLED", d.Get("status"))
+	assert.Equal(t, "USER_FAILURE", d.Get("log_delivery_status.0.status"))
+	assert.Equal(t, "Access denied to the bucket", d.Get("log_delivery_status.0.message"))
+}
+
+func TestResourceLogDeliveryCreate_InvalidLogType(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceLogDelivery(),
+		HCL: `
+		account_id = "abc"
+		credentials_id = "bcd"
+		storage_configuration_id = "def"
+		log_type = "CLUSTER_LOGS"
+		output_format = "JSON"
+		`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Invalid config supplied. [log_type]")
+}
+
 func TestResourceLogDeliveryRead_NotFound(t *testing.T) {
 	qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -193,10 +245,11 @@ func TestResourceLogDeliveryRead_NotFound(t *testing.T) {
 				},
 			},
 		},
-		Resource: ResourceLogDelivery(),
-		Read:     true,
-		Removed:  true,
-		ID:       "abc|nid",
+		Resource:  ResourceLogDelivery(),
+		AccountID: "abc",
+		Read:      true,
+		Removed:   true,
+		ID:        "abc|nid",
 	}.ApplyNoError(t)
 }
 
@@ -213,9 +266,10 @@ func TestResourceLogDeliveryRead_Error(t *testing.T) {
 				Status: 400,
 			},
 		},
-		Resource: ResourceLogDelivery(),
-		Read:     true,
-		ID:       "abc|nid",
+		Resource:  ResourceLogDelivery(),
+		AccountID: "abc",
+		Read:      true,
+		ID:        "abc|nid",
 	}.Apply(t)
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc|nid", d.Id(), "Id should not be empty for error reads")
@@ -232,9 +286,10 @@ func TestResourceLogDeliveryDelete(t *testing.T) {
 				},
 			},
 		},
-		Resource: ResourceLogDelivery(),
-		Delete:   true,
-		ID:       "abc|nid",
+		Resource:  ResourceLogDelivery(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc|nid",
 	}.Apply(t)
 	assert.NoError(t, err, err)
 	assert.Equal(t, "abc|nid", d.Id())
@@ -253,9 +308,10 @@ func TestResourceLogDeliveryDelete_Error(t *testing.T) {
 				Status: 400,
 			},
 		},
-		Resource: ResourceLogDelivery(),
-		Delete:   true,
-		ID:       "abc|nid",
+		Resource:  ResourceLogDelivery(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc|nid",
 	}.Apply(t)
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc|nid", d.Id())
@@ -68,25 +68,81 @@ func (a NetworksAPI) List(mwsAcctID string) ([]Network, error) {
 	return mwsNetworkList, err
 }
 
+func checkNetworkBroken(network Network) error {
+	switch network.VPCStatus {
+	case VPCStatusBroken:
+		return fmt.Errorf("Network %s is %s: %s", network.NetworkName,
+			network.VPCStatus, network.ErrorSummary())
+	case VPCStatusWarned:
+		log.Printf("[WARN] Network %s has warnings: %s", network.NetworkName, network.ErrorSummary())
+	}
+	return nil
+}
+
+// WaitForValidation polls the network until its VPC status settles after a workspace
+// got attached to it and fails with collected error messages, when network is broken
+func (a NetworksAPI) WaitForValidation(mwsAcctID, networkID string, timeout time.Duration) error {
+	return resource.RetryContext(a.context, timeout, func() *resource.RetryError {
+		network, err := a.Read(mwsAcctID, networkID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if network.VPCStatus == "" || network.VPCStatus == VPCStatusUnattached {
+			return resource.RetryableError(fmt.Errorf("Network %s is not yet validated",
+				network.NetworkName))
+		}
+		if err = checkNetworkBroken(network); err != nil {
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+}
+
 // ResourceNetwork ...
 func ResourceNetwork() *schema.Resource {
 	s := internal.StructToSchema(Network{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
 		s["account_id"].Sensitive = true
 		// nolint
 		s["network_name"].ValidateFunc = validation.StringLenBetween(4, 256)
+		// availability zones of subnets are validated by Databricks, as it requires AWS credentials
 		s["subnet_ids"].MinItems = 2
 		s["security_group_ids"].MinItems = 1
 		s["security_group_ids"].MaxItems = 5
+		s["fail_if_broken"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		}
 		return s
 	})
 	p := util.NewPairSeparatedID("account_id", "network_id", "/")
-	return util.CommonResource{
+	return accountScoped(util.CommonResource{
 		Schema: s,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			if !d.Get("fail_if_broken").(bool) {
+				return nil
+			}
+			// vpc_status is only known after the network is attached to a workspace
+			network := Network{
+				NetworkName: d.Get("network_name").(string),
+				VPCStatus:   d.Get("vpc_status").(string),
+			}
+			for _, v := range d.Get("error_messages").([]interface{}) {
+				m := v.(map[string]interface{})
+				network.ErrorMessages = append(network.ErrorMessages, NetworkHealth{
+					ErrorType:    m["error_type"].(string),
+					ErrorMessage: m["error_message"].(string),
+				})
+			}
+			return checkNetworkBroken(network)
+		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var network Network
 			if err := internal.DataToStructPointer(d, s, &network); err != nil {
 				return err
 			}
+			// new network is always UNATTACHED, so there's nothing to check until
+			// it's validated by the workspace, that uses it
 			if err := NewNetworksAPI(ctx, c).Create(&network); err != nil {
 				return err
 			}
@@ -112,5 +168,5 @@ func ResourceNetwork() *schema.Resource {
 			}
 			return NewNetworksAPI(ctx, c).Delete(accountID, networkID)
 		},
-	}.ToResource()
+	}.ToResource())
 }
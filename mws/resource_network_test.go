@@ -85,6 +85,71 @@ func TestResourceNetworkCreate(t *testing.T) {
 	assert.Equal(t, "abc/nid", d.Id())
 }
 
+func TestResourceNetworkCreate_FailIfBroken(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/networks",
+				Response: Network{
+					AccountID: "abc",
+					NetworkID: "nid",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/networks/nid",
+				Response: Network{
+					NetworkID:   "nid",
+					NetworkName: "Open Workers",
+					VPCStatus:   VPCStatusUnattached,
+				},
+			},
+		},
+		Resource: ResourceNetwork(),
+		HCL: `
+		account_id = "abc"
+		network_name = "Open Workers"
+		security_group_ids = ["one", "two"]
+		subnet_ids = ["three", "four"]
+		vpc_id = "five"
+		fail_if_broken = true
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/nid", d.Id())
+	assert.Equal(t, VPCStatusUnattached, d.Get("vpc_status"))
+}
+
+func TestResourceNetworkPlan_FailIfBroken(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceNetwork(),
+		InstanceState: map[string]string{
+			"account_id":                     "abc",
+			"network_id":                     "nid",
+			"network_name":                   "Open Workers",
+			"vpc_id":                         "five",
+			"vpc_status":                     "BROKEN",
+			"error_messages.#":               "1",
+			"error_messages.0.error_type":    "securityGroup",
+			"error_messages.0.error_message": "Egress is blocked",
+		},
+		HCL: `
+		account_id = "abc"
+		network_name = "Open Workers"
+		security_group_ids = ["one", "two"]
+		subnet_ids = ["three", "four"]
+		vpc_id = "five"
+		fail_if_broken = true
+		`,
+		Read: true,
+		ID:   "abc/nid",
+	}.Apply(t)
+	assert.EqualError(t, err, "Network Open Workers is BROKEN: error: securityGroup;"+
+		"error_msg: Egress is blocked;")
+}
+
 func TestResourceNetworkCreate_Error(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -125,13 +190,18 @@ func TestResourceNetworkRead(t *testing.T) {
 					VPCID:            "five",
 					SubnetIds:        []string{"four", "three"},
 					WorkspaceID:      789,
+					VPCStatus:        VPCStatusWarned,
+					ErrorMessages: []NetworkHealth{
+						{"securityGroup", "Port 443 is not open"},
+					},
 				},
 			},
 		},
-		Resource: ResourceNetwork(),
-		Read:     true,
-		New:      true,
-		ID:       "abc/nid",
+		Resource:  ResourceNetwork(),
+		AccountID: "abc",
+		Read:      true,
+		New:       true,
+		ID:        "abc/nid",
 	}.Apply(t)
 	assert.NoError(t, err, err)
 	assert.Equal(t, "abc/nid", d.Id(), "Id should not be empty")
@@ -140,6 +210,8 @@ func TestResourceNetworkRead(t *testing.T) {
 	assert.Equal(t, "Open Workers", d.Get("network_name"))
 	assert.Equal(t, "five", d.Get("vpc_id"))
 	assert.Equal(t, 789, d.Get("workspace_id"))
+	assert.Equal(t, "WARNED", d.Get("vpc_status"))
+	assert.Equal(t, "Port 443 is not open", d.Get("error_messages.0.error_message"))
 }
 
 func TestResourceNetworkRead_NotFound(t *testing.T) {
@@ -155,10 +227,11 @@ func TestResourceNetworkRead_NotFound(t *testing.T) {
 				Status: 404,
 			},
 		},
-		Resource: ResourceNetwork(),
-		Read:     true,
-		Removed:  true,
-		ID:       "abc/nid",
+		Resource:  ResourceNetwork(),
+		AccountID: "abc",
+		Read:      true,
+		Removed:   true,
+		ID:        "abc/nid",
 	}.ApplyNoError(t)
 }
 
@@ -175,9 +248,10 @@ func TestResourceNetworkRead_Error(t *testing.T) {
 				Status: 400,
 			},
 		},
-		Resource: ResourceNetwork(),
-		Read:     true,
-		ID:       "abc/nid",
+		Resource:  ResourceNetwork(),
+		AccountID: "abc",
+		Read:      true,
+		ID:        "abc/nid",
 	}.Apply(t)
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc/nid", d.Id(), "Id should not be empty for error reads")
@@ -211,9 +285,10 @@ func TestResourceNetworkDelete(t *testing.T) {
 				Status: 404,
 			},
 		},
-		Resource: ResourceNetwork(),
-		Delete:   true,
-		ID:       "abc/nid",
+		Resource:  ResourceNetwork(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc/nid",
 	}.Apply(t)
 	assert.NoError(t, err, err)
 	assert.Equal(t, "abc/nid", d.Id())
@@ -232,9 +307,10 @@ func TestResourceNetworkDelete_Error(t *testing.T) {
 				Status: 400,
 			},
 		},
-		Resource: ResourceNetwork(),
-		Delete:   true,
-		ID:       "abc/nid",
+		Resource:  ResourceNetwork(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc/nid",
 	}.Apply(t)
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc/nid", d.Id())
@@ -0,0 +1,178 @@
+package mws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/databrickslabs/databricks-terraform/internal/util"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// List of private access levels
+const (
+	PrivateAccessLevelAccount  = "ACCOUNT"
+	PrivateAccessLevelEndpoint = "ENDPOINT"
+)
+
+// PrivateAccessSettings controls which VPC endpoints can reach workspaces over AWS PrivateLink
+type PrivateAccessSettings struct {
+	AccountID             string   `json:"account_id,omitempty"`
+	PasID                 string   `json:"private_access_settings_id,omitempty" tf:"computed"`
+	PasName               string   `json:"private_access_settings_name"`
+	Region                string   `json:"region"`
+	Status                string   `json:"status,omitempty" tf:"computed"`
+	PrivateAccessLevel    string   `json:"private_access_level,omitempty" tf:"default:ACCOUNT"`
+	AllowedVpcEndpointIDs []string `json:"allowed_vpc_endpoint_ids,omitempty" tf:"slice_set"`
+	PublicAccessEnabled   bool     `json:"public_access_enabled"`
+}
+
+// NewPrivateAccessSettingsAPI creates PrivateAccessSettingsAPI instance from provider meta
+func NewPrivateAccessSettingsAPI(ctx context.Context, m interface{}) PrivateAccessSettingsAPI {
+	return PrivateAccessSettingsAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// PrivateAccessSettingsAPI exposes the mws private access settings API
+type PrivateAccessSettingsAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+// Create creates private access settings object
+func (a PrivateAccessSettingsAPI) Create(pas *PrivateAccessSettings) error {
+	pasAPIPath := fmt.Sprintf("/accounts/%s/private-access-settings", pas.AccountID)
+	return a.client.Post(a.context, pasAPIPath, pas, &pas)
+}
+
+// Read returns private access settings object along with its status
+func (a PrivateAccessSettingsAPI) Read(mwsAcctID, pasID string) (pas PrivateAccessSettings, err error) {
+	pasAPIPath := fmt.Sprintf("/accounts/%s/private-access-settings/%s", mwsAcctID, pasID)
+	err = a.client.Get(a.context, pasAPIPath, nil, &pas)
+	return
+}
+
+// Update replaces private access settings object in place
+func (a PrivateAccessSettingsAPI) Update(pas PrivateAccessSettings) error {
+	pasAPIPath := fmt.Sprintf("/accounts/%s/private-access-settings/%s", pas.AccountID, pas.PasID)
+	return a.client.Put(a.context, pasAPIPath, pas)
+}
+
+// Delete deletes private access settings object given its id
+func (a PrivateAccessSettingsAPI) Delete(mwsAcctID, pasID string) error {
+	pasAPIPath := fmt.Sprintf("/accounts/%s/private-access-settings/%s", mwsAcctID, pasID)
+	return a.client.Delete(a.context, pasAPIPath, nil)
+}
+
+// List lists all private access settings objects in the mws account
+func (a PrivateAccessSettingsAPI) List(mwsAcctID string) (pasList []PrivateAccessSettings, err error) {
+	pasAPIPath := fmt.Sprintf("/accounts/%s/private-access-settings", mwsAcctID)
+	err = a.client.Get(a.context, pasAPIPath, nil, &pasList)
+	return
+}
+
+// checkDetached returns an error with workspace names, if private access settings are used by any workspace,
+// as backend doesn't allow deleting them in this case
+func (a PrivateAccessSettingsAPI) checkDetached(mwsAcctID, pasID string) error {
+	workspaces, err := NewWorkspacesAPI(a.context, a.client).List(mwsAcctID)
+	if err != nil {
+		return err
+	}
+	names := []string{}
+	for _, ws := range workspaces {
+		if ws.PrivateAccessSettingsID == pasID {
+			names = append(names, ws.WorkspaceName)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return fmt.Errorf("Private access settings %s are used by workspaces %s. Please change "+
+		"private_access_settings_id of these workspaces before replacing or deleting private access settings",
+		pasID, strings.Join(names, ", "))
+}
+
+// ResourcePrivateAccessSettings manages private access settings for E2 workspaces
+func ResourcePrivateAccessSettings() *schema.Resource {
+	s := internal.StructToSchema(PrivateAccessSettings{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["private_access_settings_name"].ValidateFunc = validation.StringLenBetween(4, 256)
+		s["private_access_level"].ValidateFunc = validation.StringInSlice([]string{
+			PrivateAccessLevelAccount, PrivateAccessLevelEndpoint}, false)
+		// could be changed in-place, even when settings are attached to a running workspace
+		s["public_access_enabled"].Required = false
+		s["public_access_enabled"].Optional = true
+		s["public_access_enabled"].Default = false
+		s["account_id"].Sensitive = true
+		s["account_id"].ForceNew = true
+		s["region"].ForceNew = true
+		return s
+	})
+	p := util.NewPairSeparatedID("account_id", "private_access_settings_id", "/")
+	r := util.CommonResource{
+		Schema: s,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			endpoints := d.Get("allowed_vpc_endpoint_ids").(*schema.Set)
+			level := d.Get("private_access_level").(string)
+			if endpoints.Len() > 0 && level != PrivateAccessLevelEndpoint {
+				return fmt.Errorf("allowed_vpc_endpoint_ids can only be set when "+
+					"private_access_level is %s, but it is %s", PrivateAccessLevelEndpoint, level)
+			}
+			if d.Id() != "" && d.HasChange("region") {
+				// fail the plan early, because replacement would fail on delete anyway
+				accountID, pasID := d.Get("account_id").(string), d.Get("private_access_settings_id").(string)
+				return NewPrivateAccessSettingsAPI(ctx, c).checkDetached(accountID, pasID)
+			}
+			return nil
+		},
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var pas PrivateAccessSettings
+			if err := internal.DataToStructPointer(d, s, &pas); err != nil {
+				return err
+			}
+			if err := NewPrivateAccessSettingsAPI(ctx, c).Create(&pas); err != nil {
+				return err
+			}
+			d.Set("private_access_settings_id", pas.PasID)
+			p.Pack(d)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			accountID, pasID, err := p.Unpack(d)
+			if err != nil {
+				return err
+			}
+			pas, err := NewPrivateAccessSettingsAPI(ctx, c).Read(accountID, pasID)
+			if err != nil {
+				return err
+			}
+			return internal.StructToData(pas, s, d)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			_, pasID, err := p.Unpack(d)
+			if err != nil {
+				return err
+			}
+			var pas PrivateAccessSettings
+			if err = internal.DataToStructPointer(d, s, &pas); err != nil {
+				return err
+			}
+			pas.PasID = pasID
+			return NewPrivateAccessSettingsAPI(ctx, c).Update(pas)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			accountID, pasID, err := p.Unpack(d)
+			if err != nil {
+				return err
+			}
+			pasAPI := NewPrivateAccessSettingsAPI(ctx, c)
+			if err = pasAPI.checkDetached(accountID, pasID); err != nil {
+				return err
+			}
+			return pasAPI.Delete(accountID, pasID)
+		},
+	}.ToResource()
+	r.Importer = p.Importer()
+	return accountScoped(r)
+}
@@ -0,0 +1,525 @@
+package mws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourcePrivateAccessSettingsCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/private-access-settings",
+				ExpectedRequest: PrivateAccessSettings{
+					AccountID:             "abc",
+					PasName:               "pas-name",
+					Region:                "eu-west-1",
+					PrivateAccessLevel:    "ENDPOINT",
+					AllowedVpcEndpointIDs: []string{"a", "b"},
+				},
+				Response: PrivateAccessSettings{
+					PasID: "pas_id",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/private-access-settings/pas_id",
+				Response: PrivateAccessSettings{
+					AccountID:             "abc",
+					PasID:                 "pas_id",
+					PasName:               "pas-name",
+					Region:                "eu-west-1",
+					Status:                "AVAILABLE",
+					PrivateAccessLevel:    "ENDPOINT",
+					AllowedVpcEndpointIDs: []string{"b", "a"},
+				},
+			},
+		},
+		Resource: ResourcePrivateAccessSettings(),
+		HCL: `
+		account_id = "abc"
+		private_access_settings_name = "pas-name"
+		region = "eu-west-1"
+		private_access_level = "ENDPOINT"
+		allowed_vpc_endpoint_ids = ["a", "b"]
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/pas_id", d.Id())
+	assert.Equal(t, "AVAILABLE", d.Get("status"))
+	assert.Equal(t, 2, d.Get("allowed_vpc_endpoint_ids.#"))
+}
+
+func TestResourcePrivateAccessSettingsCreate_EndpointsOnAccountLevel(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourcePrivateAccessSettings(),
+		HCL: `
+		account_id = "abc"
+		private_access_settings_name = "pas-name"
+		region = "eu-west-1"
+		allowed_vpc_endpoint_ids = ["a"]
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.EqualError(t, err, "allowed_vpc_endpoint_ids can only be set when "+
+		"private_access_level is ENDPOINT, but it is ACCOUNT")
+}
+
+func TestResourcePrivateAccessSettingsCreate_Error(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/private-access-settings",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource: ResourcePrivateAccessSettings(),
+		HCL: `
+		account_id = "abc"
+		private_access_settings_name = "pas-name"
+		region = "eu-west-1"
+		`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+	assert.Equal(t, "", d.Id(), "Id should be empty for error creates")
+}
+
+func TestResourcePrivateAccessSettingsRead(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/private-access-settings/pas_id",
+				Response: PrivateAccessSettings{
+					AccountID:          "abc",
+					PasID:              "pas_id",
+					PasName:            "pas-name",
+					Region:             "eu-west-1",
+					Status:             "AVAILABLE",
+					PrivateAccessLevel: "ACCOUNT",
+				},
+			},
+		},
+		Resource:  ResourcePrivateAccessSettings(),
+		AccountID: "abc",
+		Read:      true,
+		New:       true,
+		ID:        "abc/pas_id",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/pas_id", d.Id(), "Id should not be empty")
+	assert.Equal(t, "pas_id", d.Get("private_access_settings_id"))
+	assert.Equal(t, "pas-name", d.Get("private_access_settings_name"))
+	assert.Equal(t, "eu-west-1", d.Get("region"))
+	assert.Equal(t, "ACCOUNT", d.Get("private_access_level"))
+}
+
+func TestResourcePrivateAccessSettingsRead_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/private-access-settings/pas_id",
+				Response: common.APIErrorBody{
+					ErrorCode: "NOT_FOUND",
+					Message:   "Item not found",
+				},
+				Status: 404,
+			},
+		},
+		Resource:  ResourcePrivateAccessSettings(),
+		AccountID: "abc",
+		Read:      true,
+		Removed:   true,
+		ID:        "abc/pas_id",
+	}.ApplyNoError(t)
+}
+
+func TestResourcePrivateAccessSettingsRead_Error(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/private-access-settings/pas_id",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource:  ResourcePrivateAccessSettings(),
+		AccountID: "abc",
+		Read:      true,
+		ID:        "abc/pas_id",
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+	assert.Equal(t, "abc/pas_id", d.Id(), "Id should not be empty for error reads")
+}
+
+func TestResourcePrivateAccessSettingsUpdate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PUT",
+				Resource: "/api/2.0/accounts/abc/private-access-settings/pas_id",
+				ExpectedRequest: PrivateAccessSettings{
+					AccountID:             "abc",
+					PasID:                 "pas_id",
+					PasName:               "pas-name",
+					Region:                "eu-west-1",
+					PrivateAccessLevel:    "ENDPOINT",
+					AllowedVpcEndpointIDs: []string{"a"},
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/private-access-settings/pas_id",
+				Response: PrivateAccessSettings{
+					AccountID:             "abc",
+					PasID:                 "pas_id",
+					PasName:               "pas-name",
+					Region:                "eu-west-1",
+					Status:                "AVAILABLE",
+					PrivateAccessLevel:    "ENDPOINT",
+					AllowedVpcEndpointIDs: []string{"a"},
+				},
+			},
+		},
+		Resource: ResourcePrivateAccessSettings(),
+		InstanceState: map[string]string{
+			"account_id":                   "abc",
+			"private_access_settings_id":   "pas_id",
+			"private_access_settings_name": "pas-name",
+			"region":                       "eu-west-1",
+			"private_access_level":         "ACCOUNT",
+		},
+		HCL: `
+		account_id = "abc"
+		private_access_settings_name = "pas-name"
+		region = "eu-west-1"
+		private_access_level = "ENDPOINT"
+		allowed_vpc_endpoint_ids = ["a"]
+		`,
+		Update: true,
+		ID:     "abc/pas_id",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/pas_id", d.Id(), "Id should be the same as in reading")
+	assert.Equal(t, "ENDPOINT", d.Get("private_access_level"))
+}
+
+func TestResourcePrivateAccessSettingsUpdate_PublicAccess(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PUT",
+				Resource: "/api/2.0/accounts/abc/private-access-settings/pas_id",
+				ExpectedRequest: PrivateAccessSettings{
+					AccountID:           "abc",
+					PasID:               "pas_id",
+					PasName:             "pas-name",
+					Region:              "eu-west-1",
+					PrivateAccessLevel:  "ACCOUNT",
+					PublicAccessEnabled: true,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/private-access-settings/pas_id",
+				Response: PrivateAccessSettings{
+					AccountID:           "abc",
+					PasID:               "pas_id",
+					PasName:             "pas-name",
+					Region:              "eu-west-1",
+					Status:              "AVAILABLE",
+					PrivateAccessLevel:  "ACCOUNT",
+					PublicAccessEnabled: true,
+				},
+			},
+		},
+		Resource: ResourcePrivateAccessSettings(),
+		InstanceState: map[string]string{
+			"account_id":                   "abc",
+			"private_access_settings_id":   "pas_id",
+			"private_access_settings_name": "pas-name",
+			"region":                       "eu-west-1",
+			"private_access_level":         "ACCOUNT",
+			"public_access_enabled":        "false",
+		},
+		HCL: `
+		account_id = "abc"
+		private_access_settings_name = "pas-name"
+		region = "eu-west-1"
+		public_access_enabled = true
+		`,
+		Update: true,
+		ID:     "abc/pas_id",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/pas_id", d.Id(), "Id should be the same as in reading")
+	assert.Equal(t, true, d.Get("public_access_enabled"))
+}
+
+func TestResourcePrivateAccessSettingsUpdate_Error(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PUT",
+				Resource: "/api/2.0/accounts/abc/private-access-settings/pas_id",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource: ResourcePrivateAccessSettings(),
+		HCL: `
+		account_id = "abc"
+		private_access_settings_name = "pas-name"
+		region = "eu-west-1"
+		`,
+		Update: true,
+		ID:     "abc/pas_id",
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+	assert.Equal(t, "abc/pas_id", d.Id())
+}
+
+func TestResourcePrivateAccessSettingsDelete(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces?limit=100&offset=0",
+				Response: []Workspace{
+					{
+						WorkspaceName: "other",
+					},
+				},
+			},
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/accounts/abc/private-access-settings/pas_id",
+			},
+		},
+		Resource:  ResourcePrivateAccessSettings(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc/pas_id",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/pas_id", d.Id())
+}
+
+func TestResourcePrivateAccessSettingsDelete_Error(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces?limit=100&offset=0",
+				Response: []Workspace{
+					{
+						WorkspaceName: "other",
+					},
+				},
+			},
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/accounts/abc/private-access-settings/pas_id",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource:  ResourcePrivateAccessSettings(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc/pas_id",
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+	assert.Equal(t, "abc/pas_id", d.Id())
+}
+
+func TestResourcePrivateAccessSettingsDelete_Attached(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces?limit=100&offset=0",
+				Response: []Workspace{
+					{
+						WorkspaceID:             1,
+						WorkspaceName:           "first",
+						PrivateAccessSettingsID: "pas_id",
+					},
+					{
+						WorkspaceID:   2,
+						WorkspaceName: "other",
+					},
+					{
+						WorkspaceID:             3,
+						WorkspaceName:           "second",
+						PrivateAccessSettingsID: "pas_id",
+					},
+				},
+			},
+		},
+		Resource:  ResourcePrivateAccessSettings(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc/pas_id",
+	}.Apply(t)
+	assert.EqualError(t, err, "Private access settings pas_id are used by workspaces first, second. "+
+		"Please change private_access_settings_id of these workspaces before replacing or deleting "+
+		"private access settings")
+	assert.Equal(t, "abc/pas_id", d.Id())
+}
+
+func pasRegionChangeDiff(t *testing.T, fixtures []qa.HTTPFixture) (*terraform.InstanceDiff, error) {
+	client, server, err := qa.HttpFixtureClient(t, fixtures)
+	defer server.Close()
+	require.NoError(t, err)
+	state := &terraform.InstanceState{
+		ID: "abc/pas_id",
+		Attributes: map[string]string{
+			"account_id":                   "abc",
+			"private_access_settings_id":   "pas_id",
+			"private_access_settings_name": "pas-name",
+			"region":                       "eu-west-1",
+			"private_access_level":         "ACCOUNT",
+			"public_access_enabled":        "false",
+		},
+	}
+	return ResourcePrivateAccessSettings().Diff(context.Background(), state,
+		terraform.NewResourceConfigRaw(map[string]interface{}{
+			"account_id":                   "abc",
+			"private_access_settings_name": "pas-name",
+			"region":                       "us-east-1",
+		}), client)
+}
+
+func TestResourcePrivateAccessSettings_RegionForcesNew(t *testing.T) {
+	diff, err := pasRegionChangeDiff(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc/workspaces?limit=100&offset=0",
+			Response: []Workspace{},
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, diff.RequiresNew())
+	assert.True(t, diff.Attributes["region"].RequiresNew)
+}
+
+func TestResourcePrivateAccessSettings_RegionAttached(t *testing.T) {
+	_, err := pasRegionChangeDiff(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc/workspaces?limit=100&offset=0",
+			Response: []Workspace{
+				{
+					WorkspaceName:           "first",
+					PrivateAccessSettingsID: "pas_id",
+				},
+			},
+		},
+	})
+	assert.EqualError(t, err, "Private access settings pas_id are used by workspaces first. "+
+		"Please change private_access_settings_id of these workspaces before replacing or deleting "+
+		"private access settings")
+}
+
+func TestResourcePrivateAccessSettingsImport_InvalidID(t *testing.T) {
+	r := ResourcePrivateAccessSettings()
+	d := r.TestResourceData()
+	d.SetId("pas_id")
+	_, err := r.Importer.StateContext(context.Background(), d, nil)
+	assert.EqualError(t, err, "Invalid ID: pas_id. Import ID has to be in "+
+		"<account_id>/<private_access_settings_id> format")
+}
+
+func TestResourcePrivateAccessSettingsCreate_AccountIDFromProvider(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/private-access-settings",
+				ExpectedRequest: PrivateAccessSettings{
+					AccountID:          "abc",
+					PasName:            "pas-name",
+					Region:             "eu-west-1",
+					PrivateAccessLevel: "ACCOUNT",
+				},
+				Response: PrivateAccessSettings{
+					PasID: "pas_id",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/private-access-settings/pas_id",
+				Response: PrivateAccessSettings{
+					AccountID:          "abc",
+					PasID:              "pas_id",
+					PasName:            "pas-name",
+					Region:             "eu-west-1",
+					Status:             "AVAILABLE",
+					PrivateAccessLevel: "ACCOUNT",
+				},
+			},
+		},
+		Resource:  ResourcePrivateAccessSettings(),
+		AccountID: "abc",
+		HCL: `
+		private_access_settings_name = "pas-name"
+		region = "eu-west-1"
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/pas_id", d.Id())
+	assert.Equal(t, "abc", d.Get("account_id"))
+}
+
+func TestResourcePrivateAccessSettingsCreate_NoAccountID(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourcePrivateAccessSettings(),
+		HCL: `
+		private_access_settings_name = "pas-name"
+		region = "eu-west-1"
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.EqualError(t, err, "account_id has to be set either in resource or in provider configuration")
+}
+
+func TestResourcePrivateAccessSettingsPlan_NoAccountID(t *testing.T) {
+	r := ResourcePrivateAccessSettings()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"private_access_settings_name": "pas-name",
+		"region":                       "eu-west-1",
+	})
+	_, err := r.Diff(context.Background(), nil, config, &common.DatabricksClient{})
+	assert.EqualError(t, err, "account_id has to be set either in resource or in provider configuration")
+
+	diff, err := r.Diff(context.Background(), nil, config, &common.DatabricksClient{AccountID: "abc"})
+	require.NoError(t, err)
+	assert.Equal(t, "abc", diff.Attributes["account_id"].New)
+}
@@ -58,7 +58,7 @@ func (a StorageConfigurationsAPI) List(mwsAcctID string) ([]StorageConfiguration
 // ResourceStorageConfiguration ...
 func ResourceStorageConfiguration() *schema.Resource {
 	p := util.NewPairSeparatedID("account_id", "storage_configuration_id", "/")
-	return util.CommonResource{
+	return accountScoped(util.CommonResource{
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			name := d.Get("storage_configuration_name").(string)
 			bucketName := d.Get("bucket_name").(string)
@@ -114,5 +114,5 @@ func ResourceStorageConfiguration() *schema.Resource {
 				Computed: true,
 			},
 		},
-	}.ToResource()
+	}.ToResource())
 }
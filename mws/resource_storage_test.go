@@ -112,9 +112,10 @@ func TestResourceStorageConfigurationRead(t *testing.T) {
 				},
 			},
 		},
-		Resource: ResourceStorageConfiguration(),
-		Read:     true,
-		ID:       "abc/scid",
+		Resource:  ResourceStorageConfiguration(),
+		AccountID: "abc",
+		Read:      true,
+		ID:        "abc/scid",
 	}.Apply(t)
 	assert.NoError(t, err, err)
 	assert.Equal(t, "abc/scid", d.Id(), "Id should not be empty")
@@ -137,10 +138,11 @@ func TestResourceStorageConfigurationRead_NotFound(t *testing.T) {
 				Status: 404,
 			},
 		},
-		Resource: ResourceStorageConfiguration(),
-		Read:     true,
-		Removed:  true,
-		ID:       "abc/scid",
+		Resource:  ResourceStorageConfiguration(),
+		AccountID: "abc",
+		Read:      true,
+		Removed:   true,
+		ID:        "abc/scid",
 	}.ApplyNoError(t)
 }
 
@@ -157,9 +159,10 @@ func TestResourceStorageConfigurationRead_Error(t *testing.T) {
 				Status: 400,
 			},
 		},
-		Resource: ResourceStorageConfiguration(),
-		Read:     true,
-		ID:       "abc/scid",
+		Resource:  ResourceStorageConfiguration(),
+		AccountID: "abc",
+		Read:      true,
+		ID:        "abc/scid",
 	}.Apply(t)
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc/scid", d.Id(), "Id should not be empty for error reads")
@@ -173,9 +176,10 @@ func TestResourceStorageConfigurationDelete(t *testing.T) {
 				Resource: "/api/2.0/accounts/abc/storage-configurations/scid",
 			},
 		},
-		Resource: ResourceStorageConfiguration(),
-		Delete:   true,
-		ID:       "abc/scid",
+		Resource:  ResourceStorageConfiguration(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc/scid",
 	}.Apply(t)
 	assert.NoError(t, err, err)
 	assert.Equal(t, "abc/scid", d.Id())
@@ -194,9 +198,10 @@ func TestResourceStorageConfigurationDelete_Error(t *testing.T) {
 				Status: 400,
 			},
 		},
-		Resource: ResourceStorageConfiguration(),
-		Delete:   true,
-		ID:       "abc/scid",
+		Resource:  ResourceStorageConfiguration(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc/scid",
 	}.Apply(t)
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc/scid", d.Id())
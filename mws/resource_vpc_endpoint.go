@@ -0,0 +1,157 @@
+package mws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/databrickslabs/databricks-terraform/internal/util"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// List of AWS-side states of VPC endpoints
+const (
+	VPCEndpointStateAvailable         = "available"
+	VPCEndpointStatePendingAcceptance = "pendingAcceptance"
+	VPCEndpointStatePending           = "pending"
+	VPCEndpointStateRejected          = "rejected"
+	VPCEndpointStateFailed            = "failed"
+	VPCEndpointStateExpired           = "expired"
+)
+
+// NewVPCEndpointAPI creates VPCEndpointAPI instance from provider meta
+func NewVPCEndpointAPI(ctx context.Context, m interface{}) VPCEndpointAPI {
+	return VPCEndpointAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// VPCEndpointAPI exposes the mws VPC endpoints API
+type VPCEndpointAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+// Create registers AWS VPC endpoint within Databricks account
+func (a VPCEndpointAPI) Create(vpcEndpoint *VPCEndpoint) error {
+	vpcEndpointAPIPath := fmt.Sprintf("/accounts/%s/vpc-endpoints", vpcEndpoint.AccountID)
+	return a.client.Post(a.context, vpcEndpointAPIPath, vpcEndpoint, &vpcEndpoint)
+}
+
+// Read returns VPC endpoint registration given its Databricks-side id
+func (a VPCEndpointAPI) Read(mwsAcctID, vpcEndpointID string) (vpcEndpoint VPCEndpoint, err error) {
+	vpcEndpointAPIPath := fmt.Sprintf("/accounts/%s/vpc-endpoints/%s", mwsAcctID, vpcEndpointID)
+	err = a.client.Get(a.context, vpcEndpointAPIPath, nil, &vpcEndpoint)
+	return
+}
+
+// Delete removes VPC endpoint registration, but keeps AWS VPC endpoint itself
+func (a VPCEndpointAPI) Delete(mwsAcctID, vpcEndpointID string) error {
+	vpcEndpointAPIPath := fmt.Sprintf("/accounts/%s/vpc-endpoints/%s", mwsAcctID, vpcEndpointID)
+	return a.client.Delete(a.context, vpcEndpointAPIPath, nil)
+}
+
+// List lists all VPC endpoints registered in the mws account
+func (a VPCEndpointAPI) List(mwsAcctID string) (vpcEndpoints []VPCEndpoint, err error) {
+	vpcEndpointAPIPath := fmt.Sprintf("/accounts/%s/vpc-endpoints", mwsAcctID)
+	err = a.client.Get(a.context, vpcEndpointAPIPath, nil, &vpcEndpoints)
+	return
+}
+
+// WaitForAvailable polls VPC endpoint until AWS accepts the connection to Databricks endpoint service
+func (a VPCEndpointAPI) WaitForAvailable(mwsAcctID, vpcEndpointID string, timeout time.Duration) error {
+	return resource.RetryContext(a.context, timeout, func() *resource.RetryError {
+		vpcEndpoint, err := a.Read(mwsAcctID, vpcEndpointID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		switch vpcEndpoint.State {
+		case VPCEndpointStateAvailable:
+			return nil
+		case VPCEndpointStateRejected, VPCEndpointStateFailed, VPCEndpointStateExpired:
+			return resource.NonRetryableError(fmt.Errorf("VPC endpoint %s is %s",
+				vpcEndpoint.AwsVPCEndpointID, vpcEndpoint.State))
+		default:
+			log.Printf("[INFO] VPC endpoint %s is %s", vpcEndpoint.AwsVPCEndpointID, vpcEndpoint.State)
+			return resource.RetryableError(fmt.Errorf("VPC endpoint %s is %s, but not %s yet",
+				vpcEndpoint.AwsVPCEndpointID, vpcEndpoint.State, VPCEndpointStateAvailable))
+		}
+	})
+}
+
+// ResourceVPCEndpoint registers AWS VPC endpoint for AWS PrivateLink connectivity of E2 workspaces
+func ResourceVPCEndpoint() *schema.Resource {
+	s := internal.StructToSchema(VPCEndpoint{}, func(s map[string]*schema.Schema) map[string]*schema.Schema {
+		s["account_id"].Sensitive = true
+		for _, k := range []string{"account_id", "vpc_endpoint_name", "aws_vpc_endpoint_id", "region"} {
+			s[k].ForceNew = true
+		}
+		s["wait_for_available"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		}
+		return s
+	})
+	p := util.NewPairSeparatedID("account_id", "vpc_endpoint_id", "/")
+	r := util.CommonResource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			var vpcEndpoint VPCEndpoint
+			if err := internal.DataToStructPointer(d, s, &vpcEndpoint); err != nil {
+				return err
+			}
+			vpcEndpointAPI := NewVPCEndpointAPI(ctx, c)
+			if err := vpcEndpointAPI.Create(&vpcEndpoint); err != nil {
+				return err
+			}
+			d.Set("vpc_endpoint_id", vpcEndpoint.VPCEndpointID)
+			p.Pack(d)
+			if !d.Get("wait_for_available").(bool) {
+				return nil
+			}
+			return vpcEndpointAPI.WaitForAvailable(d.Get("account_id").(string),
+				vpcEndpoint.VPCEndpointID, d.Timeout(schema.TimeoutCreate))
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			accountID, vpcEndpointID, err := p.Unpack(d)
+			if err != nil {
+				return err
+			}
+			vpcEndpoint, err := NewVPCEndpointAPI(ctx, c).Read(accountID, vpcEndpointID)
+			if err != nil {
+				return err
+			}
+			if err = internal.StructToData(vpcEndpoint, s, d); err != nil {
+				return err
+			}
+			if vpcEndpoint.State == VPCEndpointStateRejected {
+				log.Printf("[WARN] VPC endpoint %s is rejected by Databricks endpoint service %s, planning to replace it",
+					vpcEndpoint.AwsVPCEndpointID, vpcEndpoint.AwsEndpointServiceID)
+				// rejected endpoint cannot be used anymore, so it has to be registered again. Forgetting
+				// the AWS endpoint in state makes the next plan replace it through ForceNew on the
+				// configured aws_vpc_endpoint_id, while delete still works with the Databricks-side id.
+				return d.Set("aws_vpc_endpoint_id", "")
+			}
+			return nil
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			// only wait_for_available could be changed and it matters only for creation
+			return nil
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			accountID, vpcEndpointID, err := p.Unpack(d)
+			if err != nil {
+				return err
+			}
+			return NewVPCEndpointAPI(ctx, c).Delete(accountID, vpcEndpointID)
+		},
+	}.ToResource()
+	r.Importer = p.Importer()
+	r.Timeouts = &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(10 * time.Minute),
+	}
+	return accountScoped(r)
+}
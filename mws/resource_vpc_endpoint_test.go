@@ -0,0 +1,318 @@
+package mws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testVPCEndpointResponse = VPCEndpoint{
+	AccountID:            "abc",
+	VPCEndpointID:        "vpce_id",
+	VPCEndpointName:      "relay",
+	AwsVPCEndpointID:     "vpce-0123",
+	AwsEndpointServiceID: "com.amazonaws.vpce.eu-west-1.vpce-svc-0123",
+	AwsAccountID:         "098765",
+	Region:               "eu-west-1",
+	UseCase:              "dataplane-relay-access",
+	State:                VPCEndpointStateAvailable,
+}
+
+func TestResourceVPCEndpointCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints",
+				ExpectedRequest: VPCEndpoint{
+					AccountID:        "abc",
+					VPCEndpointName:  "relay",
+					AwsVPCEndpointID: "vpce-0123",
+					Region:           "eu-west-1",
+				},
+				Response: VPCEndpoint{
+					VPCEndpointID: "vpce_id",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints/vpce_id",
+				Response: testVPCEndpointResponse,
+			},
+		},
+		Resource: ResourceVPCEndpoint(),
+		HCL: `
+		account_id = "abc"
+		vpc_endpoint_name = "relay"
+		aws_vpc_endpoint_id = "vpce-0123"
+		region = "eu-west-1"
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/vpce_id", d.Id())
+	assert.Equal(t, "com.amazonaws.vpce.eu-west-1.vpce-svc-0123", d.Get("aws_endpoint_service_id"))
+	assert.Equal(t, "available", d.Get("state"))
+}
+
+func TestResourceVPCEndpointCreate_WaitForAvailable(t *testing.T) {
+	pending := testVPCEndpointResponse
+	pending.State = VPCEndpointStatePendingAcceptance
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints",
+				Response: VPCEndpoint{
+					VPCEndpointID: "vpce_id",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints/vpce_id",
+				Response: pending,
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/accounts/abc/vpc-endpoints/vpce_id",
+				Response:     testVPCEndpointResponse,
+			},
+		},
+		Resource: ResourceVPCEndpoint(),
+		HCL: `
+		account_id = "abc"
+		vpc_endpoint_name = "relay"
+		aws_vpc_endpoint_id = "vpce-0123"
+		region = "eu-west-1"
+		wait_for_available = true
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/vpce_id", d.Id())
+	assert.Equal(t, "available", d.Get("state"))
+}
+
+func TestResourceVPCEndpointCreate_WaitRejected(t *testing.T) {
+	rejected := testVPCEndpointResponse
+	rejected.State = VPCEndpointStateRejected
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints",
+				Response: VPCEndpoint{
+					VPCEndpointID: "vpce_id",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints/vpce_id",
+				Response: rejected,
+			},
+		},
+		Resource: ResourceVPCEndpoint(),
+		HCL: `
+		account_id = "abc"
+		vpc_endpoint_name = "relay"
+		aws_vpc_endpoint_id = "vpce-0123"
+		region = "eu-west-1"
+		wait_for_available = true
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.EqualError(t, err, "VPC endpoint vpce-0123 is rejected")
+}
+
+func TestResourceVPCEndpointCreate_Error(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource: ResourceVPCEndpoint(),
+		HCL: `
+		account_id = "abc"
+		vpc_endpoint_name = "relay"
+		aws_vpc_endpoint_id = "vpce-0123"
+		region = "eu-west-1"
+		`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+	assert.Equal(t, "", d.Id(), "Id should be empty for error creates")
+}
+
+func TestResourceVPCEndpointRead(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints/vpce_id",
+				Response: testVPCEndpointResponse,
+			},
+		},
+		Resource:  ResourceVPCEndpoint(),
+		AccountID: "abc",
+		Read:      true,
+		New:       true,
+		ID:        "abc/vpce_id",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/vpce_id", d.Id(), "Id should not be empty")
+	assert.Equal(t, "vpce_id", d.Get("vpc_endpoint_id"))
+	assert.Equal(t, "relay", d.Get("vpc_endpoint_name"))
+	assert.Equal(t, "vpce-0123", d.Get("aws_vpc_endpoint_id"))
+	assert.Equal(t, "098765", d.Get("aws_account_id"))
+	assert.Equal(t, "dataplane-relay-access", d.Get("use_case"))
+}
+
+func TestResourceVPCEndpointRead_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints/vpce_id",
+				Response: common.APIErrorBody{
+					ErrorCode: "NOT_FOUND",
+					Message:   "Item not found",
+				},
+				Status: 404,
+			},
+		},
+		Resource:  ResourceVPCEndpoint(),
+		AccountID: "abc",
+		Read:      true,
+		Removed:   true,
+		ID:        "abc/vpce_id",
+	}.ApplyNoError(t)
+}
+
+func TestResourceVPCEndpointRead_Error(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints/vpce_id",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource:  ResourceVPCEndpoint(),
+		AccountID: "abc",
+		Read:      true,
+		ID:        "abc/vpce_id",
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+	assert.Equal(t, "abc/vpce_id", d.Id(), "Id should not be empty for error reads")
+}
+
+func TestResourceVPCEndpointRead_Rejected(t *testing.T) {
+	rejected := testVPCEndpointResponse
+	rejected.State = VPCEndpointStateRejected
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints/vpce_id",
+				Response: rejected,
+			},
+		},
+		Resource:  ResourceVPCEndpoint(),
+		AccountID: "abc",
+		Read:      true,
+		New:       true,
+		ID:        "abc/vpce_id",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/vpce_id", d.Id(), "Id should not be empty")
+	assert.Equal(t, VPCEndpointStateRejected, d.Get("state"))
+	assert.Equal(t, "", d.Get("aws_vpc_endpoint_id"))
+}
+
+func TestResourceVPCEndpointRejectedRequiresNew(t *testing.T) {
+	r := ResourceVPCEndpoint()
+	state := &terraform.InstanceState{
+		ID: "abc/vpce_id",
+		Attributes: map[string]string{
+			"account_id":          "abc",
+			"vpc_endpoint_id":     "vpce_id",
+			"vpc_endpoint_name":   "relay",
+			"aws_vpc_endpoint_id": "",
+			"region":              "eu-west-1",
+			"state":               VPCEndpointStateRejected,
+			"wait_for_available":  "false",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"account_id":          "abc",
+		"vpc_endpoint_name":   "relay",
+		"aws_vpc_endpoint_id": "vpce-0123",
+		"region":              "eu-west-1",
+	})
+	diff, err := r.Diff(context.Background(), state, config, &common.DatabricksClient{})
+	require.NoError(t, err)
+	assert.True(t, diff.RequiresNew())
+	assert.True(t, diff.Attributes["aws_vpc_endpoint_id"].RequiresNew)
+
+	state.Attributes["aws_vpc_endpoint_id"] = "vpce-0123"
+	state.Attributes["state"] = VPCEndpointStateAvailable
+	diff, err = r.Diff(context.Background(), state, config, &common.DatabricksClient{})
+	require.NoError(t, err)
+	assert.Nil(t, diff)
+}
+
+func TestResourceVPCEndpointDelete(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints/vpce_id",
+			},
+		},
+		Resource:  ResourceVPCEndpoint(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc/vpce_id",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/vpce_id", d.Id())
+}
+
+func TestResourceVPCEndpointDelete_Error(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "DELETE",
+				Resource: "/api/2.0/accounts/abc/vpc-endpoints/vpce_id",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource:  ResourceVPCEndpoint(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc/vpce_id",
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+	assert.Equal(t, "abc/vpce_id", d.Id())
+}
@@ -1,21 +1,37 @@
 package mws
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"log"
 	"net"
+	"reflect"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/identity"
 	"github.com/databrickslabs/databricks-terraform/internal"
 	"github.com/databrickslabs/databricks-terraform/internal/util"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const workspaceListPageSize = 100
+
+var deploymentNameRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// connectivity types of GKE clusters in GCP workspaces
+var gkeConnectivityTypes = []string{"PRIVATE_NODE_PUBLIC_MASTER", "PUBLIC_NODE_PUBLIC_MASTER"}
+
+type workspaceListRequest struct {
+	Offset int `url:"offset"`
+	Limit  int `url:"limit"`
+}
+
 // NewWorkspacesAPI creates MWSWorkspacesAPI instance from provider meta
 func NewWorkspacesAPI(ctx context.Context, m interface{}) WorkspacesAPI {
 	return WorkspacesAPI{m.(*common.DatabricksClient), ctx}
@@ -28,13 +44,17 @@ type WorkspacesAPI struct {
 }
 
 // Create creates the workspace creation process
-func (a WorkspacesAPI) Create(ws *Workspace) error {
+func (a WorkspacesAPI) Create(ws *Workspace, timeout time.Duration) error {
 	workspacesAPIPath := fmt.Sprintf("/accounts/%s/workspaces", ws.AccountID)
 	err := a.client.Post(a.context, workspacesAPIPath, ws, &ws)
 	if err != nil {
 		return err
 	}
-	if err = a.waitForRunning(*ws, 15*time.Minute); err != nil {
+	if err = a.waitForRunning(*ws, timeout); err != nil {
+		if _, ok := err.(workspaceNotReadyError); ok {
+			// workspace is still provisioning, so we keep it and let next apply resume waiting
+			return err
+		}
 		log.Printf("[ERROR] Deleting failed workspace: %s", err)
 		if derr := a.Delete(ws.AccountID, fmt.Sprintf("%d", ws.WorkspaceID)); derr != nil {
 			return fmt.Errorf("%s - %s", err, derr)
@@ -54,19 +74,37 @@ func dial(hostAndPort, url string, timeout time.Duration) *resource.RetryError {
 	return nil
 }
 
+// workspaceNotReadyError is returned when workspace neither got to RUNNING, nor failed within timeout
+type workspaceNotReadyError struct {
+	status  string
+	message string
+	timeout time.Duration
+}
+
+func (e workspaceNotReadyError) Error() string {
+	return fmt.Sprintf("Workspace is still %s after %s: %s", e.status, e.timeout, e.message)
+}
+
 // waitForRunning will wait until workspace is running, otherwise will try to explain why it failed
 func (a WorkspacesAPI) waitForRunning(ws Workspace, timeout time.Duration) error {
-	return resource.RetryContext(a.context, timeout, func() *resource.RetryError {
+	var notReady *workspaceNotReadyError
+	err := resource.RetryContext(a.context, timeout, func() *resource.RetryError {
+		notReady = nil
 		workspace, err := a.Read(ws.AccountID, fmt.Sprintf("%d", ws.WorkspaceID))
 		if err != nil {
 			return resource.NonRetryableError(err)
 		}
+		notReady = &workspaceNotReadyError{
+			status:  workspace.WorkspaceStatus,
+			message: workspace.WorkspaceStatusMessage,
+			timeout: timeout,
+		}
 		switch workspace.WorkspaceStatus {
 		case WorkspaceStatusRunning:
 			// wait for DNS caches to refresh, as sometimes we cannot make
 			// API calls to new workspaces immediately after it's created
-			hostAndPort := fmt.Sprintf("%s.cloud.databricks.com:443", workspace.DeploymentName)
-			url := fmt.Sprintf("https://%s.cloud.databricks.com", workspace.DeploymentName)
+			hostAndPort := fmt.Sprintf("%s:443", workspaceHost(workspace))
+			url := fmt.Sprintf("https://%s", workspaceHost(workspace))
 			log.Printf("[INFO] Workspace is now running")
 			if strings.Contains(workspace.DeploymentName, "900150983cd24fb0") {
 				// nobody would probably name workspace as 900150983cd24fb0,
@@ -75,46 +113,83 @@ func (a WorkspacesAPI) waitForRunning(ws Workspace, timeout time.Duration) error
 			}
 			return dial(hostAndPort, url, 1*time.Minute)
 		case WorkspaceStatusCanceled, WorkspaceStatusFailed:
+			notReady = nil
 			log.Printf("[ERROR] Cannot start workspace: %s", workspace.WorkspaceStatusMessage)
 			if workspace.NetworkID == "" {
 				return resource.NonRetryableError(fmt.Errorf(workspace.WorkspaceStatusMessage))
 			}
-			network, nerr := NewNetworksAPI(a.context, a.client).Read(ws.AccountID, ws.NetworkID)
+			network, nerr := NewNetworksAPI(a.context, a.client).Read(ws.AccountID, workspace.NetworkID)
 			if nerr != nil {
 				return resource.NonRetryableError(fmt.Errorf(
 					"Failed to start workspace. Cannot read network: %s", nerr))
 			}
-			var strBuffer bytes.Buffer
-			for _, networkHealth := range network.ErrorMessages {
-				strBuffer.WriteString(fmt.Sprintf("error: %s;error_msg: %s;",
-					networkHealth.ErrorType, networkHealth.ErrorMessage))
-			}
 			return resource.NonRetryableError(fmt.Errorf(
 				"Workspace failed to create: %v, network error message: %v",
-				workspace.WorkspaceStatusMessage, strBuffer.String()))
+				workspace.WorkspaceStatusMessage, network.ErrorSummary()))
 		default:
 			log.Printf("[INFO] Workspace %s is %s: %s", workspace.DeploymentName,
 				workspace.WorkspaceStatus, workspace.WorkspaceStatusMessage)
 			return resource.RetryableError(fmt.Errorf(workspace.WorkspaceStatusMessage))
 		}
 	})
+	if err != nil && notReady != nil && !ContainsWorkspaceState(
+		WorkspaceStatusesNonRunnable, notReady.status) {
+		// the last poll was retryable, so we've ran out of time
+		return *notReady
+	}
+	return err
+}
+
+// workspacePatch is the same as Workspace, but sends empty non-nil custom tags,
+// so that all tags could be removed from the workspace
+type workspacePatch struct {
+	Workspace
+	CustomTags *map[string]string `json:"custom_tags,omitempty"`
 }
 
-// Patch will relaunch the workspace deployment
-func (a WorkspacesAPI) Patch(ws Workspace) error {
+// Patch will relaunch the workspace deployment, which is used for switching networks
+// or private access settings of a running workspace without recreating it
+func (a WorkspacesAPI) Patch(ws Workspace, timeout time.Duration) error {
 	workspacesAPIPath := fmt.Sprintf("/accounts/%s/workspaces/%d", ws.AccountID, ws.WorkspaceID)
-	err := a.client.Patch(a.context, workspacesAPIPath, Workspace{
-		AwsRegion:              ws.AwsRegion,
-		CredentialsID:          ws.CredentialsID,
-		StorageConfigurationID: ws.StorageConfigurationID,
-		IsNoPublicIPEnabled:    ws.IsNoPublicIPEnabled,
-		NetworkID:              ws.NetworkID,
-		CustomerManagedKeyID:   ws.CustomerManagedKeyID,
+	patch := workspacePatch{Workspace: Workspace{
+		AwsRegion:                           ws.AwsRegion,
+		CredentialsID:                       ws.CredentialsID,
+		StorageConfigurationID:              ws.StorageConfigurationID,
+		IsNoPublicIPEnabled:                 ws.IsNoPublicIPEnabled,
+		NetworkID:                           ws.NetworkID,
+		CustomerManagedKeyID:                ws.CustomerManagedKeyID,
+		PrivateAccessSettingsID:             ws.PrivateAccessSettingsID,
+		ManagedServicesCustomerManagedKeyID: ws.ManagedServicesCustomerManagedKeyID,
+	}}
+	if ws.CustomTags != nil {
+		patch.CustomTags = &ws.CustomTags
+	}
+	err := resource.RetryContext(a.context, timeout, func() *resource.RetryError {
+		err := a.client.Patch(a.context, workspacesAPIPath, patch)
+		if isWorkspaceConflict(err) {
+			log.Printf("[INFO] Workspace %d is still settling after previous update: %s",
+				ws.WorkspaceID, err)
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		return nil
 	})
 	if err != nil {
 		return err
 	}
-	return a.waitForRunning(ws, 10*time.Minute)
+	if err = a.waitForRunning(ws, timeout); err != nil {
+		return fmt.Errorf("Workspace %d did not return to %s after update: %w",
+			ws.WorkspaceID, WorkspaceStatusRunning, err)
+	}
+	return nil
+}
+
+// isWorkspaceConflict returns true, if workspace cannot be updated, because previous update is not yet settled
+func isWorkspaceConflict(err error) bool {
+	e, ok := err.(common.APIError)
+	return ok && (e.StatusCode == 409 || e.ErrorCode == "RESOURCE_CONFLICT")
 }
 
 // Read will return the mws workspace metadata and status of the workspace deployment
@@ -153,8 +228,271 @@ func (a WorkspacesAPI) Delete(mwsAcctID, workspaceID string) error {
 func (a WorkspacesAPI) List(mwsAcctID string) ([]Workspace, error) {
 	var mwsWorkspacesList []Workspace
 	workspacesAPIPath := fmt.Sprintf("/accounts/%s/workspaces", mwsAcctID)
-	err := a.client.Get(a.context, workspacesAPIPath, nil, &mwsWorkspacesList)
-	return mwsWorkspacesList, err
+	seen := map[int64]bool{}
+	request := workspaceListRequest{Limit: workspaceListPageSize}
+	for {
+		var page []Workspace
+		err := a.client.Get(a.context, workspacesAPIPath, request, &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, ws := range page {
+			if seen[ws.WorkspaceID] {
+				// accounts API may ignore paging parameters and return everything at once
+				return mwsWorkspacesList, nil
+			}
+			seen[ws.WorkspaceID] = true
+			mwsWorkspacesList = append(mwsWorkspacesList, ws)
+		}
+		if len(page) < request.Limit {
+			return mwsWorkspacesList, nil
+		}
+		request.Offset += len(page)
+	}
+}
+
+// DeploymentNamePrefix returns prefix of deployment names, that is assigned to the account
+func (a WorkspacesAPI) DeploymentNamePrefix(mwsAcctID string) (string, error) {
+	var account Account
+	err := a.client.Get(a.context, fmt.Sprintf("/accounts/%s", mwsAcctID), nil, &account)
+	return account.DeploymentNamePrefix, err
+}
+
+// checkDeploymentNamePrefix fails fast, if deployment name doesn't start with prefix of the account,
+// as otherwise workspace fails only after several minutes of provisioning
+func (a WorkspacesAPI) checkDeploymentNamePrefix(ws Workspace) error {
+	prefix, err := a.DeploymentNamePrefix(ws.AccountID)
+	if err != nil {
+		return fmt.Errorf("Cannot get deployment name prefix of the account: %w. "+
+			"Set skip_validation = true, if it's not available", err)
+	}
+	if prefix == "" || strings.HasPrefix(ws.DeploymentName, prefix) {
+		return nil
+	}
+	return fmt.Errorf("deployment_name %s must start with %s prefix of the account, like %s-%s",
+		ws.DeploymentName, prefix, prefix, ws.DeploymentName)
+}
+
+// rootBucketErrorCode is returned by workspace creation, when Databricks cannot access the root bucket.
+// The same code is used for other failures, like credentials or network, so the message is checked as well.
+const rootBucketErrorCode = "INVALID_STATE"
+
+// explainRootBucketError converts cryptic workspace creation failures, that are caused by the
+// root bucket policy not granting access to Databricks, into an actionable message
+func (a WorkspacesAPI) explainRootBucketError(mwsAcctID, storageConfigurationID string, err error) error {
+	e, ok := err.(common.APIError)
+	if !ok || e.ErrorCode != rootBucketErrorCode || storageConfigurationID == "" {
+		return err
+	}
+	message := strings.ToLower(e.Message)
+	if !strings.Contains(message, "bucket") && !strings.Contains(message, "storage") {
+		return err
+	}
+	bucket := fmt.Sprintf("of storage configuration %s", storageConfigurationID)
+	storage, serr := NewStorageConfigurationsAPI(a.context, a.client).Read(mwsAcctID, storageConfigurationID)
+	if serr == nil && storage.RootBucketInfo != nil {
+		bucket = storage.RootBucketInfo.BucketName
+	}
+	return fmt.Errorf("Root bucket %s is likely not accessible by Databricks. Bucket policy has to "+
+		"allow arn:aws:iam::%s:root to access the bucket, which could be generated with "+
+		"databricks_aws_bucket_policy data source. See "+
+		"https://docs.databricks.com/administration-guide/account-api/aws-storage.html. Original error: %w",
+		bucket, common.DatabricksAwsAccountID, err)
+}
+
+// workspaceClient returns client for calling APIs of the given workspace with account credentials
+func (a WorkspacesAPI) workspaceClient(ws Workspace) (*common.DatabricksClient, error) {
+	host := fmt.Sprintf("https://%s", workspaceHost(ws))
+	if strings.Contains(ws.DeploymentName, "900150983cd24fb0") {
+		// unit testing shim, same as in waitForRunning
+		host = a.client.Host
+	}
+	return a.client.ClientForHost(host)
+}
+
+// CreateToken mints personal access token in the given workspace
+func (a WorkspacesAPI) CreateToken(ws Workspace, token *Token) error {
+	client, err := a.workspaceClient(ws)
+	if err != nil {
+		return err
+	}
+	lifetime := time.Duration(token.LifetimeSeconds) * time.Second
+	tr, err := identity.NewTokensAPI(a.context, client).Create(lifetime, token.Comment)
+	if err != nil {
+		return err
+	}
+	token.TokenValue = tr.TokenValue
+	if tr.TokenInfo != nil {
+		token.TokenID = tr.TokenInfo.TokenID
+	}
+	return nil
+}
+
+// RevokeToken removes personal access token from the given workspace and
+// doesn't fail, if the token was already revoked
+func (a WorkspacesAPI) RevokeToken(ws Workspace, tokenID string) error {
+	client, err := a.workspaceClient(ws)
+	if err != nil {
+		return err
+	}
+	err = identity.NewTokensAPI(a.context, client).Delete(tokenID)
+	if e, ok := err.(common.APIError); ok && e.IsMissing() {
+		log.Printf("[INFO] Token %s is already revoked", tokenID)
+		return nil
+	}
+	return err
+}
+
+// workspaceHost returns hostname of the workspace. Databricks may assign DNS name, that differs
+// from deployment name, so the one from API response takes precedence.
+func workspaceHost(ws Workspace) string {
+	if ws.WorkspaceURL != "" {
+		host := strings.TrimPrefix(ws.WorkspaceURL, "https://")
+		return strings.TrimSuffix(host, "/")
+	}
+	if ws.Cloud == CloudGcp {
+		return fmt.Sprintf("%s.gcp.databricks.com", ws.DeploymentName)
+	}
+	return fmt.Sprintf("%s.cloud.databricks.com", ws.DeploymentName)
+}
+
+// attributes, that could only be used with workspaces on AWS
+var awsOnlyWorkspaceAttributes = []string{
+	"aws_region",
+	"credentials_id",
+	"storage_configuration_id",
+	"network_id",
+	"customer_managed_key_id",
+	"managed_services_customer_managed_key_id",
+	"private_access_settings_id",
+	"is_no_public_ip_enabled",
+}
+
+// attributes, that could only be used with workspaces on GCP
+var gcpOnlyWorkspaceAttributes = []string{
+	"location",
+	"cloud_resource_container",
+	"gke_config",
+	"network",
+}
+
+// workspaceCloud returns cloud of the workspace either from configuration or from the account host
+func workspaceCloud(cloud string, c *common.DatabricksClient) string {
+	if cloud != "" {
+		return cloud
+	}
+	if c.IsGcp() {
+		return CloudGcp
+	}
+	return CloudAws
+}
+
+// validateWorkspaceCloud checks, that attributes of other clouds are not configured.
+// Attributes with values, that are not yet known, are considered configured.
+func validateWorkspaceCloud(d *schema.ResourceDiff, cloud string) error {
+	forbidden := gcpOnlyWorkspaceAttributes
+	if cloud == CloudGcp {
+		forbidden = awsOnlyWorkspaceAttributes
+	}
+	for _, k := range forbidden {
+		if _, ok := d.GetOk(k); ok || !d.NewValueKnown(k) {
+			return fmt.Errorf("%s cannot be used for workspaces on %s", k, cloud)
+		}
+	}
+	return nil
+}
+
+// checkWorkspaceCloudRequired checks, that attributes required on the given cloud are present
+func checkWorkspaceCloudRequired(d *schema.ResourceData, cloud string) error {
+	required := []string{"aws_region", "credentials_id", "storage_configuration_id"}
+	if cloud == CloudGcp {
+		required = []string{"location", "cloud_resource_container"}
+	}
+	for _, k := range required {
+		if _, ok := d.GetOk(k); !ok {
+			return fmt.Errorf("%s is required for workspaces on %s", k, cloud)
+		}
+	}
+	return nil
+}
+
+// isWorkspaceInTransition returns true while workspace is being provisioned or updated
+func isWorkspaceInTransition(ws Workspace) bool {
+	return ws.WorkspaceStatus == WorkspaceStatusProvisioning ||
+		ws.WorkspaceStatus == WorkspaceStatusNotProvisioned ||
+		ws.WorkspaceStatus == WorkspaceStatusUpdating
+}
+
+// retainPriorValues fills fields, that API temporarily omits while workspace is in transition,
+// with values from the state, so that refresh doesn't show a diff suggesting replacement
+func retainPriorValues(ws *Workspace, prior Workspace) {
+	current := reflect.ValueOf(ws).Elem()
+	previous := reflect.ValueOf(prior)
+	for i := 0; i < current.NumField(); i++ {
+		field := current.Field(i)
+		if field.IsZero() && !previous.Field(i).IsZero() {
+			field.Set(previous.Field(i))
+		}
+	}
+}
+
+func hasWorkspaceConfigChanged(d *schema.ResourceData, s map[string]*schema.Schema) bool {
+	for k, v := range s {
+		if k == "token" || k == "skip_validation" || k == "verify_network" || (v.Computed && !v.Optional) {
+			continue
+		}
+		if d.HasChange(k) {
+			return true
+		}
+	}
+	return false
+}
+
+// configuredTags returns only tags, that are configured for the workspace, as Databricks
+// adds default tags, like databricks-env, and they should not appear as a drift
+func configuredTags(d *schema.ResourceData, tags map[string]string) map[string]string {
+	configured := d.Get("custom_tags").(map[string]interface{})
+	result := map[string]string{}
+	for k, v := range tags {
+		if _, ok := configured[k]; ok {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// verifyWorkspaceNetwork waits for validation of the network, that was just attached to workspace
+func verifyWorkspaceNetwork(ctx context.Context, d *schema.ResourceData,
+	c *common.DatabricksClient, ws Workspace, timeout time.Duration) error {
+	if !d.Get("verify_network").(bool) || ws.NetworkID == "" {
+		return nil
+	}
+	return NewNetworksAPI(ctx, c).WaitForValidation(ws.AccountID, ws.NetworkID, timeout)
+}
+
+func tokenFromData(d *schema.ResourceData) *Token {
+	if _, ok := d.GetOk("token"); !ok {
+		return nil
+	}
+	return &Token{
+		LifetimeSeconds: int32(d.Get("token.0.lifetime_seconds").(int)),
+		Comment:         d.Get("token.0.comment").(string),
+		TokenID:         d.Get("token.0.token_id").(string),
+	}
+}
+
+func tokenToData(d *schema.ResourceData, token *Token) error {
+	if token == nil {
+		return d.Set("token", []interface{}{})
+	}
+	return d.Set("token", []interface{}{
+		map[string]interface{}{
+			"lifetime_seconds": token.LifetimeSeconds,
+			"comment":          token.Comment,
+			"token_id":         token.TokenID,
+			"token_value":      token.TokenValue,
+		},
+	})
 }
 
 // ResourceWorkspace manages E2 workspaces
@@ -163,7 +501,11 @@ func ResourceWorkspace() *schema.Resource {
 		s["account_id"].Sensitive = true
 		s["account_id"].ForceNew = true
 		s["workspace_name"].ForceNew = true
+		s["aws_region"].ForceNew = true
 		s["deployment_name"].ForceNew = true
+		// backend requires recreation of the workspace to change storage encryption key,
+		// though managed services key could be attached or rotated in-place
+		s["customer_managed_key_id"].ForceNew = true
 		s["deployment_name"].DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
 			if old == "" && new != "" {
 				return false
@@ -173,28 +515,132 @@ func ResourceWorkspace() *schema.Resource {
 			// https://github.com/databrickslabs/terraform-provider-databricks/issues/382
 			return !strings.HasSuffix(new, old)
 		}
+		s["deployment_name"].ValidateFunc = validation.All(
+			validation.StringLenBetween(3, 63),
+			validation.StringMatch(deploymentNameRegex,
+				"must contain only lowercase letters, digits and dashes, and cannot start or end with a dash"))
 		s["is_no_public_ip_enabled"].Default = false
+		s["skip_validation"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				// workspaces created with previous versions of provider
+				return old == "" && new == "false"
+			},
+		}
+		s["verify_network"] = &schema.Schema{
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				return old == "" && new == "false"
+			},
+		}
+		s["cloud"].ValidateFunc = validation.StringInSlice([]string{CloudAws, CloudGcp}, false)
+		for _, k := range gcpOnlyWorkspaceAttributes {
+			s[k].ForceNew = true
+		}
+		if p, err := internal.SchemaPath(s, "gke_config", "connectivity_type"); err == nil {
+			p.ValidateFunc = validation.StringInSlice(gkeConnectivityTypes, false)
+		}
+		if p, err := internal.SchemaPath(s, "network", "gcp_common_network_config",
+			"gke_connectivity_type"); err == nil {
+			p.ValidateFunc = validation.StringInSlice(gkeConnectivityTypes, false)
+		}
+		s["token"] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: internal.StructToSchema(Token{}, func(ts map[string]*schema.Schema) map[string]*schema.Schema {
+					ts["lifetime_seconds"].Default = 2592000
+					ts["comment"].Default = "Terraform PAT"
+					ts["token_value"].Sensitive = true
+					return ts
+				}),
+			},
+		}
 		return s
 	})
 	p := util.NewPairSeparatedID("account_id", "workspace_id", "/").Schema(
 		func(_ map[string]*schema.Schema) map[string]*schema.Schema {
 			return s
 		})
-	return util.CommonResource{
+	r := util.CommonResource{
 		Schema:        s,
 		SchemaVersion: 2,
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, c interface{}) error {
+			cloud := workspaceCloud(d.Get("cloud").(string), c.(*common.DatabricksClient))
+			if err := validateWorkspaceCloud(d, cloud); err != nil {
+				return err
+			}
+			if old, _ := d.GetChange("cloud"); old != "" && d.HasChange("cloud") {
+				// workspace cannot move between clouds
+				if err := d.ForceNew("cloud"); err != nil {
+					return err
+				}
+			}
+			if old, new := d.GetChange("is_no_public_ip_enabled"); d.Id() != "" &&
+				old.(bool) && !new.(bool) {
+				return fmt.Errorf("is_no_public_ip_enabled cannot be disabled " +
+					"once secure cluster connectivity is enabled for the workspace")
+			}
+			status := d.Get("workspace_status").(string)
+			if d.Id() == "" || status == "" || status == WorkspaceStatusRunning {
+				return nil
+			}
+			// plan an update, that waits for the workspace to get to RUNNING
+			log.Printf("[INFO] Workspace %s is %s, planning to wait for it", d.Id(), status)
+			return d.SetNewComputed("workspace_status")
+		},
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			var workspace Workspace
 			workspacesAPI := NewWorkspacesAPI(ctx, c)
 			if err := internal.DataToStructPointer(d, s, &workspace); err != nil {
 				return err
 			}
-			if err := workspacesAPI.Create(&workspace); err != nil {
+			if err := checkWorkspaceCloudRequired(d, workspaceCloud(workspace.Cloud, c)); err != nil {
+				return err
+			}
+			if !d.Get("skip_validation").(bool) {
+				if err := workspacesAPI.checkDeploymentNamePrefix(workspace); err != nil {
+					return err
+				}
+			}
+			err := workspacesAPI.Create(&workspace, d.Timeout(schema.TimeoutCreate))
+			if _, ok := err.(workspaceNotReadyError); ok {
+				// keep the workspace in state with its real id, so that the next apply
+				// resumes waiting for it instead of tainting and recreating it. Returning
+				// an error would taint it, so it's reported as a warning after Read.
+				log.Printf("[WARN] %s. Run apply again to continue waiting.", err)
+				d.Set("workspace_id", workspace.WorkspaceID)
+				p.Pack(d)
+				return nil
+			}
+			if err != nil && !d.Get("skip_validation").(bool) {
+				// workspace struct may already be overwritten by the API response
+				return workspacesAPI.explainRootBucketError(d.Get("account_id").(string),
+					d.Get("storage_configuration_id").(string), err)
+			}
+			if err != nil {
 				return err
 			}
 			d.Set("workspace_id", workspace.WorkspaceID)
 			p.Pack(d)
-			return nil
+			// workspace is already in state, so broken network taints it
+			if err := verifyWorkspaceNetwork(ctx, d, c, workspace,
+				d.Timeout(schema.TimeoutCreate)); err != nil {
+				return err
+			}
+			token := tokenFromData(d)
+			if token == nil {
+				return nil
+			}
+			if err := workspacesAPI.CreateToken(workspace, token); err != nil {
+				return err
+			}
+			return tokenToData(d, token)
 		},
 		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			accountID, workspaceID, err := p.Unpack(d)
@@ -206,10 +652,24 @@ func ResourceWorkspace() *schema.Resource {
 			if err != nil {
 				return err
 			}
-			workspace.WorkspaceURL = fmt.Sprintf("https://%s.cloud.databricks.com", workspace.DeploymentName)
+			inTransition := isWorkspaceInTransition(workspace)
+			if inTransition {
+				var prior Workspace
+				if err = internal.DataToStructPointer(d, s, &prior); err != nil {
+					return err
+				}
+				prior.WorkspaceStatusMessage = ""
+				retainPriorValues(&workspace, prior)
+			}
+			workspace.WorkspaceURL = fmt.Sprintf("https://%s", workspaceHost(workspace))
+			workspace.CustomTags = configuredTags(d, workspace.CustomTags)
 			if err = internal.StructToData(workspace, s, d); err != nil {
 				return err
 			}
+			if inTransition {
+				log.Printf("[INFO] Workspace %s is still %s", workspace.DeploymentName, workspace.WorkspaceStatus)
+				return nil
+			}
 			return workspacesAPI.waitForRunning(workspace, 10*time.Minute)
 		},
 		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
@@ -218,7 +678,49 @@ func ResourceWorkspace() *schema.Resource {
 			if err := internal.DataToStructPointer(d, s, &workspace); err != nil {
 				return err
 			}
-			return workspacesAPI.Patch(workspace)
+			timeout := d.Timeout(schema.TimeoutUpdate)
+			if d.HasChange("custom_tags") && workspace.CustomTags == nil {
+				// all tags were removed from configuration
+				workspace.CustomTags = map[string]string{}
+			}
+			if hasWorkspaceConfigChanged(d, s) {
+				err := workspacesAPI.Patch(workspace, timeout)
+				if e, ok := err.(common.APIError); ok && e.StatusCode == 400 &&
+					d.HasChange("managed_services_customer_managed_key_id") {
+					return fmt.Errorf("Cannot use managed services key %s: %s. Please check that "+
+						"KMS key policy allows Databricks to use the key",
+						workspace.ManagedServicesCustomerManagedKeyID, e.Message)
+				}
+				if err != nil {
+					return err
+				}
+				if d.HasChange("network_id") {
+					if err := verifyWorkspaceNetwork(ctx, d, c, workspace, timeout); err != nil {
+						return err
+					}
+				}
+			} else if status, _ := d.GetChange("workspace_status"); status != WorkspaceStatusRunning {
+				// previous apply timed out while workspace was provisioning
+				if err := workspacesAPI.waitForRunning(workspace, timeout); err != nil {
+					return err
+				}
+			}
+			token := tokenFromData(d)
+			if !d.HasChange("token") && (token == nil || token.TokenID != "") {
+				return nil
+			}
+			old, _ := d.GetChange("token.0.token_id")
+			if old.(string) != "" {
+				if err := workspacesAPI.RevokeToken(workspace, old.(string)); err != nil {
+					return err
+				}
+			}
+			if token != nil {
+				if err := workspacesAPI.CreateToken(workspace, token); err != nil {
+					return err
+				}
+			}
+			return tokenToData(d, token)
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			accountID, workspaceID, err := p.Unpack(d)
@@ -228,4 +730,34 @@ func ResourceWorkspace() *schema.Resource {
 			return NewWorkspacesAPI(ctx, c).Delete(accountID, workspaceID)
 		},
 	}.ToResource()
+	r.Timeouts = &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(20 * time.Minute),
+		Update: schema.DefaultTimeout(20 * time.Minute),
+	}
+	create := r.CreateContext
+	r.CreateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		diags := create(ctx, d, m)
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, notRunningWarnings(d)...)
+	}
+	return accountScoped(r)
+}
+
+// notRunningWarnings tells, that created workspace didn't get to RUNNING within timeout
+// and the next apply continues waiting for it
+func notRunningWarnings(d *schema.ResourceData) diag.Diagnostics {
+	status := d.Get("workspace_status").(string)
+	if d.Id() == "" || status == "" || status == WorkspaceStatusRunning {
+		return nil
+	}
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary: fmt.Sprintf("Workspace %s is still %s after %s: %s", d.Get("workspace_name"),
+				status, d.Timeout(schema.TimeoutCreate), d.Get("workspace_status_message")),
+			Detail: "Workspace is kept in state and the next apply continues waiting for it to get to RUNNING",
+		},
+	}
 }
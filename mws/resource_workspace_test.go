@@ -2,6 +2,7 @@ package mws
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -9,7 +10,10 @@ import (
 	"time"
 
 	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/identity"
 	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -28,6 +32,13 @@ func TestMwsAccWorkspace(t *testing.T) {
 func TestResourceWorkspaceCreate(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc",
+				Response: Account{
+					AccountID: "abc",
+				},
+			},
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/accounts/abc/workspaces",
@@ -41,6 +52,8 @@ func TestResourceWorkspaceCreate(t *testing.T) {
 					StorageConfigurationID: "ghi",
 					NetworkID:              "fgh",
 					CustomerManagedKeyID:   "def",
+
+					ManagedServicesCustomerManagedKeyID: "jkl",
 				},
 				Response: Workspace{
 					WorkspaceID:    1234,
@@ -77,17 +90,568 @@ func TestResourceWorkspaceCreate(t *testing.T) {
 			"is_no_public_ip_enabled":  true,
 			"network_id":               "fgh",
 			"storage_configuration_id": "ghi",
+
+			"managed_services_customer_managed_key_id": "jkl",
+		},
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/1234", d.Id())
+	assert.Equal(t, "jkl", d.Get("managed_services_customer_managed_key_id"))
+}
+
+func workspaceWithNetworkFixtures(networkStatuses ...Network) []qa.HTTPFixture {
+	fixtures := []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc",
+			Response: Account{
+				AccountID: "abc",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/accounts/abc/workspaces",
+			Response: Workspace{
+				WorkspaceID:    1234,
+				AccountID:      "abc",
+				DeploymentName: "900150983cd24fb0",
+			},
+		},
+		{
+			Method:       "GET",
+			ReuseRequest: true,
+			Resource:     "/api/2.0/accounts/abc/workspaces/1234",
+			Response: Workspace{
+				WorkspaceID:            1234,
+				WorkspaceStatus:        WorkspaceStatusRunning,
+				WorkspaceName:          "labdata",
+				DeploymentName:         "900150983cd24fb0",
+				AwsRegion:              "us-east-1",
+				CredentialsID:          "bcd",
+				StorageConfigurationID: "ghi",
+				NetworkID:              "fgh",
+				AccountID:              "abc",
+			},
+		},
+	}
+	for _, network := range networkStatuses {
+		fixtures = append(fixtures, qa.HTTPFixture{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc/networks/fgh",
+			Response: network,
+		})
+	}
+	return fixtures
+}
+
+func TestResourceWorkspaceCreate_VerifyNetwork(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: workspaceWithNetworkFixtures(
+			Network{
+				NetworkName: "net",
+				VPCStatus:   VPCStatusUnattached,
+			},
+			Network{
+				NetworkName: "net",
+				VPCStatus:   VPCStatusValid,
+			}),
+		Resource: ResourceWorkspace(),
+		HCL: `
+		account_id = "abc"
+		aws_region = "us-east-1"
+		credentials_id = "bcd"
+		storage_configuration_id = "ghi"
+		network_id = "fgh"
+		deployment_name = "900150983cd24fb0"
+		workspace_name = "labdata"
+		verify_network = true
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/1234", d.Id())
+}
+
+func TestResourceWorkspaceCreate_VerifyNetworkBroken(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: workspaceWithNetworkFixtures(
+			Network{
+				NetworkName: "net",
+				VPCStatus:   VPCStatusBroken,
+				ErrorMessages: []NetworkHealth{
+					{
+						ErrorType:    "securityGroup",
+						ErrorMessage: "sg-1 does not allow egress",
+					},
+				},
+			}),
+		Resource: ResourceWorkspace(),
+		HCL: `
+		account_id = "abc"
+		aws_region = "us-east-1"
+		credentials_id = "bcd"
+		storage_configuration_id = "ghi"
+		network_id = "fgh"
+		deployment_name = "900150983cd24fb0"
+		workspace_name = "labdata"
+		verify_network = true
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.EqualError(t, err, "Network net is BROKEN: "+
+		"error: securityGroup;error_msg: sg-1 does not allow egress;")
+	assert.Equal(t, "abc/1234", d.Id(), "workspace should remain in state to get tainted")
+}
+
+func rootBucketErrorFixtures() []qa.HTTPFixture {
+	return []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc",
+			Response: Account{
+				AccountID: "abc",
+			},
+		},
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/accounts/abc/workspaces",
+			Response: common.APIErrorBody{
+				ErrorCode: "INVALID_STATE",
+				Message:   "Cannot access S3 bucket in storage configuration ghi.",
+			},
+			Status: 400,
+		},
+	}
+}
+
+const rootBucketErrorHCL = `
+account_id = "abc"
+aws_region = "us-east-1"
+credentials_id = "bcd"
+storage_configuration_id = "ghi"
+deployment_name = "900150983cd24fb0"
+workspace_name = "labdata"
+`
+
+func TestResourceWorkspaceCreate_RootBucketError(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: append(rootBucketErrorFixtures(), qa.HTTPFixture{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc/storage-configurations/ghi",
+			Response: StorageConfiguration{
+				StorageConfigurationID: "ghi",
+				RootBucketInfo: &RootBucketInfo{
+					BucketName: "root-bucket",
+				},
+			},
+		}),
+		Resource: ResourceWorkspace(),
+		HCL:      rootBucketErrorHCL,
+		Create:   true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Root bucket root-bucket is likely not accessible by Databricks. "+
+		"Bucket policy has to allow arn:aws:iam::414351767826:root to access the bucket, which could be "+
+		"generated with databricks_aws_bucket_policy data source. See "+
+		"https://docs.databricks.com/administration-guide/account-api/aws-storage.html. "+
+		"Original error: Cannot access S3 bucket in storage configuration ghi.")
+}
+
+func TestResourceWorkspaceCreate_RootBucketErrorSkipValidation(t *testing.T) {
+	fixtures := rootBucketErrorFixtures()
+	_, err := qa.ResourceFixture{
+		Fixtures: fixtures[1:],
+		Resource: ResourceWorkspace(),
+		HCL:      rootBucketErrorHCL + "skip_validation = true",
+		Create:   true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Cannot access S3 bucket in storage configuration ghi.")
+}
+
+func TestResourceWorkspaceCreate_OtherInvalidStateNotExplained(t *testing.T) {
+	fixtures := rootBucketErrorFixtures()
+	fixtures[1].Response = common.APIErrorBody{
+		ErrorCode: "INVALID_STATE",
+		Message:   "Cannot assume cross-account role of credentials bcd.",
+	}
+	_, err := qa.ResourceFixture{
+		Fixtures: fixtures,
+		Resource: ResourceWorkspace(),
+		HCL:      rootBucketErrorHCL,
+		Create:   true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Cannot assume cross-account role of credentials bcd.")
+}
+
+func TestResourceWorkspaceCreate_OtherErrorNotExplained(t *testing.T) {
+	fixtures := rootBucketErrorFixtures()
+	fixtures[1].Response = common.APIErrorBody{
+		ErrorCode: "MALFORMED_REQUEST",
+		Message:   "Invalid bucket name in storage configuration ghi.",
+	}
+	_, err := qa.ResourceFixture{
+		Fixtures: fixtures,
+		Resource: ResourceWorkspace(),
+		HCL:      rootBucketErrorHCL,
+		Create:   true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Invalid bucket name in storage configuration ghi.")
+}
+
+func TestResourceWorkspaceCreateGcp(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc",
+				Response: Account{
+					AccountID: "abc",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/workspaces",
+				ExpectedRequest: Workspace{
+					AccountID:      "abc",
+					WorkspaceName:  "labdata",
+					DeploymentName: "900150983cd24fb0",
+					Cloud:          "gcp",
+					Location:       "us-central1",
+					CloudResourceContainer: &CloudResourceContainer{
+						GCP: &GCP{
+							ProjectID: "def",
+						},
+					},
+					GkeConfig: &GkeConfig{
+						ConnectivityType: "PRIVATE_NODE_PUBLIC_MASTER",
+						MasterIPRange:    "10.3.0.0/28",
+					},
+					Network: &GCPNetwork{
+						GCPManagedNetworkConfig: &GCPManagedNetworkConfig{
+							SubnetCIDR:               "10.0.0.0/16",
+							GKEClusterPodIPRange:     "10.1.0.0/16",
+							GKEClusterServiceIPRange: "10.2.0.0/20",
+						},
+					},
+				},
+				Response: Workspace{
+					WorkspaceID:    1234,
+					AccountID:      "abc",
+					DeploymentName: "900150983cd24fb0",
+					Cloud:          "gcp",
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/accounts/abc/workspaces/1234",
+				Response: Workspace{
+					WorkspaceID:     1234,
+					WorkspaceStatus: WorkspaceStatusRunning,
+					WorkspaceName:   "labdata",
+					DeploymentName:  "900150983cd24fb0",
+					AccountID:       "abc",
+					Cloud:           "gcp",
+					Location:        "us-central1",
+					CloudResourceContainer: &CloudResourceContainer{
+						GCP: &GCP{
+							ProjectID: "def",
+						},
+					},
+					GkeConfig: &GkeConfig{
+						ConnectivityType: "PRIVATE_NODE_PUBLIC_MASTER",
+						MasterIPRange:    "10.3.0.0/28",
+					},
+					Network: &GCPNetwork{
+						GCPManagedNetworkConfig: &GCPManagedNetworkConfig{
+							SubnetCIDR:               "10.0.0.0/16",
+							GKEClusterPodIPRange:     "10.1.0.0/16",
+							GKEClusterServiceIPRange: "10.2.0.0/20",
+						},
+					},
+				},
+			},
+		},
+		Resource: ResourceWorkspace(),
+		HCL: `
+		account_id      = "abc"
+		workspace_name  = "labdata"
+		deployment_name = "900150983cd24fb0"
+		cloud           = "gcp"
+		location        = "us-central1"
+		cloud_resource_container {
+			gcp {
+				project_id = "def"
+			}
+		}
+		gke_config {
+			connectivity_type = "PRIVATE_NODE_PUBLIC_MASTER"
+			master_ip_range   = "10.3.0.0/28"
+		}
+		network {
+			gcp_managed_network_config {
+				subnet_cidr                  = "10.0.0.0/16"
+				gke_cluster_pod_ip_range     = "10.1.0.0/16"
+				gke_cluster_service_ip_range = "10.2.0.0/20"
+			}
+		}
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/1234", d.Id())
+	assert.Equal(t, "gcp", d.Get("cloud"))
+	assert.Equal(t, "def", d.Get("cloud_resource_container.0.gcp.0.project_id"))
+	assert.Equal(t, "https://900150983cd24fb0.gcp.databricks.com", d.Get("workspace_url"))
+}
+
+func TestResourceWorkspaceCreateGcp_AwsAttributes(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceWorkspace(),
+		HCL: `
+		account_id      = "abc"
+		workspace_name  = "labdata"
+		deployment_name = "900150983cd24fb0"
+		cloud           = "gcp"
+		location        = "us-central1"
+		credentials_id  = "bcd"
+		cloud_resource_container {
+			gcp {
+				project_id = "def"
+			}
+		}
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.EqualError(t, err, "credentials_id cannot be used for workspaces on gcp")
+}
+
+func TestResourceWorkspaceCreateGcp_NoProject(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceWorkspace(),
+		HCL: `
+		account_id      = "abc"
+		workspace_name  = "labdata"
+		deployment_name = "900150983cd24fb0"
+		cloud           = "gcp"
+		location        = "us-central1"
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.EqualError(t, err, "cloud_resource_container is required for workspaces on gcp")
+}
+
+func TestResourceWorkspaceCreate_GcpAttributesOnAws(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceWorkspace(),
+		HCL: `
+		account_id               = "abc"
+		workspace_name           = "labdata"
+		deployment_name          = "900150983cd24fb0"
+		aws_region               = "us-east-1"
+		credentials_id           = "bcd"
+		storage_configuration_id = "ghi"
+		location                 = "us-central1"
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.EqualError(t, err, "location cannot be used for workspaces on aws")
+}
+
+func TestResourceWorkspaceCreate_NoRegion(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceWorkspace(),
+		HCL: `
+		account_id               = "abc"
+		workspace_name           = "labdata"
+		deployment_name          = "900150983cd24fb0"
+		credentials_id           = "bcd"
+		storage_configuration_id = "ghi"
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.EqualError(t, err, "aws_region is required for workspaces on aws")
+}
+
+func TestResourceWorkspaceCreate_WrongPrefix(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc",
+				Response: Account{
+					AccountID:            "abc",
+					DeploymentNamePrefix: "acme",
+				},
+			},
+		},
+		Resource: ResourceWorkspace(),
+		HCL: `
+		account_id               = "abc"
+		workspace_name           = "labdata"
+		deployment_name          = "labdata"
+		aws_region               = "us-east-1"
+		credentials_id           = "bcd"
+		storage_configuration_id = "ghi"
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.EqualError(t, err, "deployment_name labdata must start with acme prefix of the account, like acme-labdata")
+}
+
+func TestResourceWorkspaceCreate_PrefixNotAvailable(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc",
+				Response: common.APIErrorBody{
+					ErrorCode: "PERMISSION_DENIED",
+					Message:   "Not allowed",
+				},
+				Status: 403,
+			},
+		},
+		Resource: ResourceWorkspace(),
+		HCL: `
+		account_id               = "abc"
+		workspace_name           = "labdata"
+		deployment_name          = "labdata"
+		aws_region               = "us-east-1"
+		credentials_id           = "bcd"
+		storage_configuration_id = "ghi"
+		`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Cannot get deployment name prefix of the account: Not allowed")
+	assert.Contains(t, err.Error(), "Set skip_validation = true")
+}
+
+func TestResourceWorkspaceCreate_SkipValidation(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/workspaces",
+				Response: common.APIErrorBody{
+					ErrorCode: "MALFORMED_REQUEST",
+					Message:   "Invalid deployment name",
+				},
+				Status: 400,
+			},
+		},
+		Resource: ResourceWorkspace(),
+		HCL: `
+		account_id               = "abc"
+		workspace_name           = "labdata"
+		deployment_name          = "labdata"
+		aws_region               = "us-east-1"
+		credentials_id           = "bcd"
+		storage_configuration_id = "ghi"
+		skip_validation          = true
+		`,
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Invalid deployment name")
+}
+
+func TestResourceWorkspaceCreate_InvalidDeploymentName(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceWorkspace(),
+		State: map[string]interface{}{
+			"account_id":               "abc",
+			"workspace_name":           "labdata",
+			"deployment_name":          "Lab_Data",
+			"aws_region":               "us-east-1",
+			"credentials_id":           "bcd",
+			"storage_configuration_id": "ghi",
+		},
+		Create: true,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Invalid config supplied. [deployment_name] invalid value")
+}
+
+func TestResourceWorkspaceCreateWithToken(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc",
+				Response: Account{
+					AccountID: "abc",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/workspaces",
+				Response: Workspace{
+					WorkspaceID:    1234,
+					AccountID:      "abc",
+					DeploymentName: "900150983cd24fb0",
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/accounts/abc/workspaces/1234",
+				Response: Workspace{
+					WorkspaceID:            1234,
+					WorkspaceStatus:        WorkspaceStatusRunning,
+					WorkspaceName:          "labdata",
+					DeploymentName:         "900150983cd24fb0",
+					AwsRegion:              "us-east-1",
+					CredentialsID:          "bcd",
+					StorageConfigurationID: "ghi",
+					AccountID:              "abc",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token/create",
+				ExpectedRequest: identity.TokenRequest{
+					LifetimeSeconds: 3600,
+					Comment:         "Bootstrap",
+				},
+				Response: identity.TokenResponse{
+					TokenValue: "dapi123",
+					TokenInfo: &identity.TokenInfo{
+						TokenID: "tid",
+					},
+				},
+			},
 		},
+		Resource: ResourceWorkspace(),
+		HCL: `
+		account_id = "abc"
+		aws_region = "us-east-1"
+		credentials_id = "bcd"
+		deployment_name = "900150983cd24fb0"
+		workspace_name = "labdata"
+		storage_configuration_id = "ghi"
+		token {
+			lifetime_seconds = 3600
+			comment = "Bootstrap"
+		}
+		`,
 		Create: true,
 	}.Apply(t)
 	assert.NoError(t, err, err)
 	assert.Equal(t, "abc/1234", d.Id())
+	assert.Equal(t, "tid", d.Get("token.0.token_id"))
+	assert.Equal(t, "dapi123", d.Get("token.0.token_value"))
 }
 
 func TestResourceWorkspaceCreate_Error(t *testing.T) {
 	t.Skipf("Making this test skip until we can configure sleep timings for test purposes")
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc",
+				Response: Account{
+					AccountID: "abc",
+				},
+			},
 			{
 				Method:   "POST",
 				Resource: "/api/2.0/accounts/abc/workspaces",
@@ -147,10 +711,11 @@ func TestResourceWorkspaceRead(t *testing.T) {
 				},
 			},
 		},
-		Resource: ResourceWorkspace(),
-		Read:     true,
-		New:      true,
-		ID:       "abc/1234",
+		Resource:  ResourceWorkspace(),
+		AccountID: "abc",
+		Read:      true,
+		New:       true,
+		ID:        "abc/1234",
 	}.Apply(t)
 	assert.NoError(t, err, err)
 	assert.Equal(t, "abc/1234", d.Id(), "Id should not be empty")
@@ -222,60 +787,758 @@ func TestResourceWorkspaceRead_Issue382(t *testing.T) {
 	assert.Equal(t, "https://prefix-900150983cd24fb0.cloud.databricks.com", d.Get("workspace_url"))
 }
 
-func TestResourceWorkspaceRead_NotFound(t *testing.T) {
-	qa.ResourceFixture{
+func TestResourceWorkspaceRead_URLAndPricingTier(t *testing.T) {
+	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
 			{
-				Method:   "GET",
-				Resource: "/api/2.0/accounts/abc/workspaces/1234",
-				Response: common.APIErrorBody{
-					ErrorCode: "NOT_FOUND",
-					Message:   "Item not found",
-				},
-				Status: 404,
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/accounts/abc/workspaces/1234",
+				Response: Workspace{
+					AccountID:              "abc",
+					WorkspaceStatus:        WorkspaceStatusRunning,
+					WorkspaceName:          "labdata",
+					DeploymentName:         "900150983cd24fb0",
+					WorkspaceURL:           "dbc-a1b2c3d4-e5f6.cloud.databricks.com",
+					PricingTier:            "ENTERPRISE",
+					AwsRegion:              "us-east-1",
+					CredentialsID:          "bcd",
+					StorageConfigurationID: "ghi",
+					WorkspaceID:            1234,
+				},
+			},
+		},
+		Resource:  ResourceWorkspace(),
+		AccountID: "abc",
+		Read:      true,
+		New:       true,
+		ID:        "abc/1234",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "https://dbc-a1b2c3d4-e5f6.cloud.databricks.com", d.Get("workspace_url"))
+	assert.Equal(t, "ENTERPRISE", d.Get("pricing_tier"))
+}
+
+func TestResourceWorkspaceRead_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/1234",
+				Response: common.APIErrorBody{
+					ErrorCode: "NOT_FOUND",
+					Message:   "Item not found",
+				},
+				Status: 404,
+			},
+		},
+		Resource:  ResourceWorkspace(),
+		AccountID: "abc",
+		Read:      true,
+		Removed:   true,
+		ID:        "abc/1234",
+	}.ApplyNoError(t)
+}
+
+func TestResourceWorkspaceRead_Error(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{ // read log output for correct url...
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/1234",
+				Response: common.APIErrorBody{
+					ErrorCode: "INVALID_REQUEST",
+					Message:   "Internal error happened",
+				},
+				Status: 400,
+			},
+		},
+		Resource:  ResourceWorkspace(),
+		AccountID: "abc",
+		Read:      true,
+		ID:        "abc/1234",
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Internal error happened")
+	assert.Equal(t, "abc/1234", d.Id(), "Id should not be empty for error reads")
+}
+
+func TestResourceWorkspaceUpdate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/accounts/abc/workspaces/1234",
+				ExpectedRequest: Workspace{
+					StorageConfigurationID: "ghi",
+					NetworkID:              "fgh",
+					CustomerManagedKeyID:   "def",
+					IsNoPublicIPEnabled:    true,
+					AwsRegion:              "us-east-1",
+					CredentialsID:          "bcd",
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/accounts/abc/workspaces/1234",
+				Response: Workspace{
+					WorkspaceStatus:        WorkspaceStatusRunning,
+					IsNoPublicIPEnabled:    true,
+					WorkspaceName:          "labdata",
+					DeploymentName:         "900150983cd24fb0",
+					AwsRegion:              "us-east-1",
+					CredentialsID:          "bcd",
+					StorageConfigurationID: "ghi",
+					NetworkID:              "fgh",
+					CustomerManagedKeyID:   "def",
+					AccountID:              "abc",
+					WorkspaceID:            1234,
+				},
+			},
+		},
+		Resource: ResourceWorkspace(),
+		State: map[string]interface{}{
+			"account_id":               "abc",
+			"aws_region":               "us-east-1",
+			"credentials_id":           "bcd",
+			"customer_managed_key_id":  "def",
+			"deployment_name":          "900150983cd24fb0",
+			"workspace_name":           "labdata",
+			"is_no_public_ip_enabled":  true,
+			"network_id":               "fgh",
+			"storage_configuration_id": "ghi",
+			"workspace_id":             1234,
+		},
+		Update: true,
+		ID:     "abc/1234",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/1234", d.Id(), "Id should be the same as in reading")
+}
+
+var noPublicIPState = map[string]string{
+	"account_id":               "abc",
+	"aws_region":               "us-east-1",
+	"credentials_id":           "bcd",
+	"deployment_name":          "900150983cd24fb0",
+	"workspace_name":           "labdata",
+	"storage_configuration_id": "ghi",
+	"workspace_id":             "1234",
+	"workspace_status":         WorkspaceStatusRunning,
+}
+
+func TestResourceWorkspaceUpdate_EnableNoPublicIP(t *testing.T) {
+	state := map[string]string{"is_no_public_ip_enabled": "false"}
+	for k, v := range noPublicIPState {
+		state[k] = v
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/accounts/abc/workspaces/1234",
+				ExpectedRequest: Workspace{
+					AwsRegion:              "us-east-1",
+					CredentialsID:          "bcd",
+					StorageConfigurationID: "ghi",
+					IsNoPublicIPEnabled:    true,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/accounts/abc/workspaces/1234",
+				Response: Workspace{
+					WorkspaceStatus:        WorkspaceStatusRunning,
+					WorkspaceName:          "labdata",
+					DeploymentName:         "900150983cd24fb0",
+					AwsRegion:              "us-east-1",
+					CredentialsID:          "bcd",
+					StorageConfigurationID: "ghi",
+					IsNoPublicIPEnabled:    true,
+					AccountID:              "abc",
+					WorkspaceID:            1234,
+				},
+			},
+		},
+		Resource:      ResourceWorkspace(),
+		InstanceState: state,
+		HCL: `
+		account_id = "abc"
+		aws_region = "us-east-1"
+		credentials_id = "bcd"
+		deployment_name = "900150983cd24fb0"
+		workspace_name = "labdata"
+		storage_configuration_id = "ghi"
+		is_no_public_ip_enabled = true
+		`,
+		Update: true,
+		ID:     "abc/1234",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, true, d.Get("is_no_public_ip_enabled"))
+}
+
+func TestResourceWorkspace_DisableNoPublicIP(t *testing.T) {
+	r := ResourceWorkspace()
+	state := map[string]string{"is_no_public_ip_enabled": "true"}
+	for k, v := range noPublicIPState {
+		state[k] = v
+	}
+	_, err := r.Diff(context.Background(), &terraform.InstanceState{
+		ID:         "abc/1234",
+		Attributes: state,
+	}, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"account_id":               "abc",
+		"aws_region":               "us-east-1",
+		"credentials_id":           "bcd",
+		"deployment_name":          "900150983cd24fb0",
+		"workspace_name":           "labdata",
+		"storage_configuration_id": "ghi",
+		"is_no_public_ip_enabled":  false,
+	}), &common.DatabricksClient{Host: "https://accounts.cloud.databricks.com"})
+	assert.EqualError(t, err, "is_no_public_ip_enabled cannot be disabled "+
+		"once secure cluster connectivity is enabled for the workspace")
+}
+
+func TestResourceWorkspaceCreate_CustomTags(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc",
+				Response: Account{
+					AccountID: "abc",
+				},
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/accounts/abc/workspaces",
+				ExpectedRequest: Workspace{
+					AccountID:              "abc",
+					WorkspaceName:          "labdata",
+					DeploymentName:         "900150983cd24fb0",
+					AwsRegion:              "us-east-1",
+					CredentialsID:          "bcd",
+					StorageConfigurationID: "ghi",
+					CustomTags: map[string]string{
+						"cost-center": "42",
+					},
+				},
+				Response: Workspace{
+					WorkspaceID:    1234,
+					AccountID:      "abc",
+					DeploymentName: "900150983cd24fb0",
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/accounts/abc/workspaces/1234",
+				Response: Workspace{
+					WorkspaceID:            1234,
+					WorkspaceStatus:        WorkspaceStatusRunning,
+					WorkspaceName:          "labdata",
+					DeploymentName:         "900150983cd24fb0",
+					AwsRegion:              "us-east-1",
+					CredentialsID:          "bcd",
+					StorageConfigurationID: "ghi",
+					AccountID:              "abc",
+					CustomTags: map[string]string{
+						"cost-center":    "42",
+						"databricks-env": "production",
+					},
+				},
+			},
+		},
+		Resource: ResourceWorkspace(),
+		HCL: `
+		account_id = "abc"
+		aws_region = "us-east-1"
+		credentials_id = "bcd"
+		deployment_name = "900150983cd24fb0"
+		workspace_name = "labdata"
+		storage_configuration_id = "ghi"
+		custom_tags = {
+			"cost-center" = "42"
+		}
+		`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, map[string]interface{}{"cost-center": "42"}, d.Get("custom_tags"),
+		"default tags should not get into state")
+}
+
+func TestResourceWorkspaceUpdate_CustomTags(t *testing.T) {
+	state := map[string]string{
+		"custom_tags.%":           "1",
+		"custom_tags.cost-center": "42",
+	}
+	for k, v := range noPublicIPState {
+		state[k] = v
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/accounts/abc/workspaces/1234",
+				ExpectedRequest: Workspace{
+					AwsRegion:              "us-east-1",
+					CredentialsID:          "bcd",
+					StorageConfigurationID: "ghi",
+					CustomTags: map[string]string{
+						"cost-center": "43",
+						"team":        "data",
+					},
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/accounts/abc/workspaces/1234",
+				Response: Workspace{
+					WorkspaceStatus:        WorkspaceStatusRunning,
+					WorkspaceName:          "labdata",
+					DeploymentName:         "900150983cd24fb0",
+					AwsRegion:              "us-east-1",
+					CredentialsID:          "bcd",
+					StorageConfigurationID: "ghi",
+					AccountID:              "abc",
+					WorkspaceID:            1234,
+					CustomTags: map[string]string{
+						"cost-center":    "43",
+						"team":           "data",
+						"databricks-env": "production",
+					},
+				},
+			},
+		},
+		Resource:      ResourceWorkspace(),
+		InstanceState: state,
+		HCL: `
+		account_id = "abc"
+		aws_region = "us-east-1"
+		credentials_id = "bcd"
+		deployment_name = "900150983cd24fb0"
+		workspace_name = "labdata"
+		storage_configuration_id = "ghi"
+		custom_tags = {
+			"cost-center" = "43"
+			"team" = "data"
+		}
+		`,
+		Update: true,
+		ID:     "abc/1234",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, map[string]interface{}{
+		"cost-center": "43",
+		"team":        "data",
+	}, d.Get("custom_tags"))
+}
+
+func TestResourceWorkspaceUpdate_RemoveAllCustomTags(t *testing.T) {
+	state := map[string]string{
+		"custom_tags.%":           "1",
+		"custom_tags.cost-center": "42",
+	}
+	for k, v := range noPublicIPState {
+		state[k] = v
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/accounts/abc/workspaces/1234",
+				ExpectedRequest: workspacePatch{
+					Workspace: Workspace{
+						AwsRegion:              "us-east-1",
+						CredentialsID:          "bcd",
+						StorageConfigurationID: "ghi",
+					},
+					CustomTags: &map[string]string{},
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/accounts/abc/workspaces/1234",
+				Response: Workspace{
+					WorkspaceStatus:        WorkspaceStatusRunning,
+					WorkspaceName:          "labdata",
+					DeploymentName:         "900150983cd24fb0",
+					AwsRegion:              "us-east-1",
+					CredentialsID:          "bcd",
+					StorageConfigurationID: "ghi",
+					AccountID:              "abc",
+					WorkspaceID:            1234,
+					CustomTags: map[string]string{
+						"databricks-env": "production",
+					},
+				},
+			},
+		},
+		Resource:      ResourceWorkspace(),
+		InstanceState: state,
+		HCL: `
+		account_id = "abc"
+		aws_region = "us-east-1"
+		credentials_id = "bcd"
+		deployment_name = "900150983cd24fb0"
+		workspace_name = "labdata"
+		storage_configuration_id = "ghi"
+		`,
+		Update: true,
+		ID:     "abc/1234",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, map[string]interface{}{}, d.Get("custom_tags"))
+}
+
+func TestResourceWorkspaceUpdate_Network(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/accounts/abc/workspaces/1234",
+				ExpectedRequest: Workspace{
+					StorageConfigurationID:  "ghi",
+					NetworkID:               "new_network",
+					PrivateAccessSettingsID: "pas",
+					AwsRegion:               "us-east-1",
+					CredentialsID:           "bcd",
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/accounts/abc/workspaces/1234",
+				Response: Workspace{
+					WorkspaceStatus:         WorkspaceStatusRunning,
+					WorkspaceName:           "labdata",
+					DeploymentName:          "900150983cd24fb0",
+					AwsRegion:               "us-east-1",
+					CredentialsID:           "bcd",
+					StorageConfigurationID:  "ghi",
+					NetworkID:               "new_network",
+					PrivateAccessSettingsID: "pas",
+					AccountID:               "abc",
+					WorkspaceID:             1234,
+				},
+			},
+		},
+		Resource: ResourceWorkspace(),
+		InstanceState: map[string]string{
+			"account_id":               "abc",
+			"aws_region":               "us-east-1",
+			"credentials_id":           "bcd",
+			"deployment_name":          "900150983cd24fb0",
+			"workspace_name":           "labdata",
+			"network_id":               "old_network",
+			"storage_configuration_id": "ghi",
+			"workspace_id":             "1234",
+		},
+		HCL: `
+		account_id = "abc"
+		aws_region = "us-east-1"
+		credentials_id = "bcd"
+		deployment_name = "900150983cd24fb0"
+		workspace_name = "labdata"
+		network_id = "new_network"
+		private_access_settings_id = "pas"
+		storage_configuration_id = "ghi"
+		`,
+		Update: true,
+		ID:     "abc/1234",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/1234", d.Id(), "Id should be the same as in reading")
+	assert.Equal(t, "new_network", d.Get("network_id"))
+	assert.Equal(t, "pas", d.Get("private_access_settings_id"))
+}
+
+func TestResourceWorkspaceUpdate_NetworkFails(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/accounts/abc/workspaces/1234",
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/1234",
+				Response: Workspace{
+					WorkspaceStatus:        WorkspaceStatusFailed,
+					WorkspaceStatusMessage: "Network is broken",
+					DeploymentName:         "900150983cd24fb0",
+					NetworkID:              "new_network",
+					AccountID:              "abc",
+					WorkspaceID:            1234,
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/networks/new_network",
+				Response: Network{
+					ErrorMessages: []NetworkHealth{
+						{"securityGroup", "Egress is not allowed"},
+					},
+				},
+			},
+		},
+		Resource: ResourceWorkspace(),
+		InstanceState: map[string]string{
+			"account_id":               "abc",
+			"aws_region":               "us-east-1",
+			"credentials_id":           "bcd",
+			"deployment_name":          "900150983cd24fb0",
+			"workspace_name":           "labdata",
+			"network_id":               "old_network",
+			"storage_configuration_id": "ghi",
+			"workspace_id":             "1234",
+		},
+		HCL: `
+		account_id = "abc"
+		aws_region = "us-east-1"
+		credentials_id = "bcd"
+		deployment_name = "900150983cd24fb0"
+		workspace_name = "labdata"
+		network_id = "new_network"
+		storage_configuration_id = "ghi"
+		`,
+		Update: true,
+		ID:     "abc/1234",
+	}.Apply(t)
+	assert.EqualError(t, err, "Workspace 1234 did not return to RUNNING after update: "+
+		"Workspace failed to create: Network is broken, network error message: "+
+		"error: securityGroup;error_msg: Egress is not allowed;")
+}
+
+var managedServicesKeyState = map[string]string{
+	"account_id":               "abc",
+	"aws_region":               "us-east-1",
+	"credentials_id":           "bcd",
+	"deployment_name":          "900150983cd24fb0",
+	"workspace_name":           "labdata",
+	"storage_configuration_id": "ghi",
+	"workspace_id":             "1234",
+	"workspace_status":         WorkspaceStatusRunning,
+
+	"managed_services_customer_managed_key_id": "old_key",
+}
+
+const managedServicesKeyHCL = `
+account_id = "abc"
+aws_region = "us-east-1"
+credentials_id = "bcd"
+deployment_name = "900150983cd24fb0"
+workspace_name = "labdata"
+storage_configuration_id = "ghi"
+managed_services_customer_managed_key_id = "new_key"
+`
+
+func TestResourceWorkspaceUpdate_ManagedServicesKey(t *testing.T) {
+	updated := Workspace{
+		WorkspaceStatus:        WorkspaceStatusRunning,
+		WorkspaceName:          "labdata",
+		DeploymentName:         "900150983cd24fb0",
+		AwsRegion:              "us-east-1",
+		CredentialsID:          "bcd",
+		StorageConfigurationID: "ghi",
+		AccountID:              "abc",
+		WorkspaceID:            1234,
+
+		ManagedServicesCustomerManagedKeyID: "new_key",
+	}
+	updating := updated
+	updating.WorkspaceStatus = WorkspaceStatusUpdating
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/accounts/abc/workspaces/1234",
+				ExpectedRequest: Workspace{
+					AwsRegion:              "us-east-1",
+					CredentialsID:          "bcd",
+					StorageConfigurationID: "ghi",
+
+					ManagedServicesCustomerManagedKeyID: "new_key",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/1234",
+				Response: updating,
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/accounts/abc/workspaces/1234",
+				Response:     updated,
 			},
 		},
-		Resource: ResourceWorkspace(),
-		Read:     true,
-		Removed:  true,
-		ID:       "abc/1234",
-	}.ApplyNoError(t)
+		Resource:      ResourceWorkspace(),
+		InstanceState: managedServicesKeyState,
+		HCL:           managedServicesKeyHCL,
+		Update:        true,
+		ID:            "abc/1234",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc/1234", d.Id(), "Id should be the same as in reading")
+	assert.Equal(t, "new_key", d.Get("managed_services_customer_managed_key_id"))
 }
 
-func TestResourceWorkspaceRead_Error(t *testing.T) {
-	d, err := qa.ResourceFixture{
+func TestResourceWorkspaceUpdate_ManagedServicesKeyPermissions(t *testing.T) {
+	_, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
-			{ // read log output for correct url...
-				Method:   "GET",
+			{
+				Method:   "PATCH",
 				Resource: "/api/2.0/accounts/abc/workspaces/1234",
 				Response: common.APIErrorBody{
-					ErrorCode: "INVALID_REQUEST",
-					Message:   "Internal error happened",
+					ErrorCode: "INVALID_PARAMETER_VALUE",
+					Message:   "Databricks is not authorized to use the key",
 				},
 				Status: 400,
 			},
 		},
-		Resource: ResourceWorkspace(),
-		Read:     true,
-		ID:       "abc/1234",
+		Resource:      ResourceWorkspace(),
+		InstanceState: managedServicesKeyState,
+		HCL:           managedServicesKeyHCL,
+		Update:        true,
+		ID:            "abc/1234",
 	}.Apply(t)
-	qa.AssertErrorStartsWith(t, err, "Internal error happened")
-	assert.Equal(t, "abc/1234", d.Id(), "Id should not be empty for error reads")
+	assert.EqualError(t, err, "Cannot use managed services key new_key: Databricks is not "+
+		"authorized to use the key. Please check that KMS key policy allows Databricks to use the key")
 }
 
-func TestResourceWorkspaceUpdate(t *testing.T) {
+func TestResourceWorkspaceUpdate_CredentialsConflict(t *testing.T) {
+	state := map[string]string{"credentials_id": "old"}
+	for k, v := range noPublicIPState {
+		if k != "credentials_id" {
+			state[k] = v
+		}
+	}
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/accounts/abc/workspaces/1234",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_CONFLICT",
+					Message:   "Workspace is being updated",
+				},
+				Status: 409,
+			},
+			{
+				Method:   "PATCH",
+				Resource: "/api/2.0/accounts/abc/workspaces/1234",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_CONFLICT",
+					Message:   "Workspace is being updated",
+				},
+				Status: 409,
+			},
 			{
 				Method:   "PATCH",
 				Resource: "/api/2.0/accounts/abc/workspaces/1234",
 				ExpectedRequest: Workspace{
+					AwsRegion:              "us-east-1",
+					CredentialsID:          "bcd",
 					StorageConfigurationID: "ghi",
-					NetworkID:              "fgh",
-					CustomerManagedKeyID:   "def",
-					IsNoPublicIPEnabled:    true,
+				},
+			},
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/accounts/abc/workspaces/1234",
+				Response: Workspace{
+					WorkspaceStatus:        WorkspaceStatusRunning,
+					WorkspaceName:          "labdata",
+					DeploymentName:         "900150983cd24fb0",
 					AwsRegion:              "us-east-1",
 					CredentialsID:          "bcd",
+					StorageConfigurationID: "ghi",
+					AccountID:              "abc",
+					WorkspaceID:            1234,
+				},
+			},
+		},
+		Resource:      ResourceWorkspace(),
+		InstanceState: state,
+		HCL: `
+		account_id               = "abc"
+		aws_region               = "us-east-1"
+		credentials_id           = "bcd"
+		deployment_name          = "900150983cd24fb0"
+		workspace_name           = "labdata"
+		storage_configuration_id = "ghi"
+		`,
+		Update: true,
+		ID:     "abc/1234",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "bcd", d.Get("credentials_id"))
+}
+
+func TestIsWorkspaceConflict(t *testing.T) {
+	assert.True(t, isWorkspaceConflict(common.APIError{StatusCode: 409}))
+	assert.True(t, isWorkspaceConflict(common.APIError{StatusCode: 400, ErrorCode: "RESOURCE_CONFLICT"}))
+	assert.False(t, isWorkspaceConflict(common.APIError{StatusCode: 400, ErrorCode: "INVALID_PARAMETER_VALUE"}))
+	assert.False(t, isWorkspaceConflict(nil))
+}
+
+func TestResourceWorkspace_StorageKeyForcesNew(t *testing.T) {
+	r := ResourceWorkspace()
+	state := &terraform.InstanceState{
+		ID:         "abc/1234",
+		Attributes: managedServicesKeyState,
+	}
+	raw := map[string]interface{}{
+		"account_id":               "abc",
+		"aws_region":               "us-east-1",
+		"credentials_id":           "bcd",
+		"deployment_name":          "900150983cd24fb0",
+		"workspace_name":           "labdata",
+		"storage_configuration_id": "ghi",
+		"customer_managed_key_id":  "storage_key",
+
+		"managed_services_customer_managed_key_id": "old_key",
+	}
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw),
+		&common.DatabricksClient{Host: "https://accounts.cloud.databricks.com"})
+	require.NoError(t, err)
+	assert.True(t, diff.Attributes["customer_managed_key_id"].RequiresNew)
+	assert.True(t, diff.RequiresNew())
+}
+
+func TestResourceWorkspaceUpdate_Token(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token/delete",
+				ExpectedRequest: map[string]string{
+					"token_id": "old_tid",
+				},
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Token already revoked",
+				},
+				Status: 404,
+			},
+			{
+				Method:   "POST",
+				Resource: "/api/2.0/token/create",
+				ExpectedRequest: identity.TokenRequest{
+					LifetimeSeconds: 2592000,
+					Comment:         "Rotated",
+				},
+				Response: identity.TokenResponse{
+					TokenValue: "dapi456",
+					TokenInfo: &identity.TokenInfo{
+						TokenID: "new_tid",
+					},
 				},
 			},
 			{
@@ -284,37 +1547,56 @@ func TestResourceWorkspaceUpdate(t *testing.T) {
 				Resource:     "/api/2.0/accounts/abc/workspaces/1234",
 				Response: Workspace{
 					WorkspaceStatus:        WorkspaceStatusRunning,
-					IsNoPublicIPEnabled:    true,
 					WorkspaceName:          "labdata",
 					DeploymentName:         "900150983cd24fb0",
 					AwsRegion:              "us-east-1",
 					CredentialsID:          "bcd",
 					StorageConfigurationID: "ghi",
-					NetworkID:              "fgh",
-					CustomerManagedKeyID:   "def",
 					AccountID:              "abc",
 					WorkspaceID:            1234,
 				},
 			},
 		},
 		Resource: ResourceWorkspace(),
-		State: map[string]interface{}{
+		InstanceState: map[string]string{
 			"account_id":               "abc",
 			"aws_region":               "us-east-1",
 			"credentials_id":           "bcd",
-			"customer_managed_key_id":  "def",
 			"deployment_name":          "900150983cd24fb0",
 			"workspace_name":           "labdata",
-			"is_no_public_ip_enabled":  true,
-			"network_id":               "fgh",
 			"storage_configuration_id": "ghi",
-			"workspace_id":             1234,
+			"workspace_id":             "1234",
+			"is_no_public_ip_enabled":  "false",
+			"token.#":                  "1",
+			"token.0.comment":          "Bootstrap",
+			"token.0.lifetime_seconds": "2592000",
+			"token.0.token_id":         "old_tid",
+			"token.0.token_value":      "dapi123",
 		},
+		HCL: `
+		account_id = "abc"
+		aws_region = "us-east-1"
+		credentials_id = "bcd"
+		deployment_name = "900150983cd24fb0"
+		workspace_name = "labdata"
+		storage_configuration_id = "ghi"
+		token {
+			comment = "Rotated"
+		}
+		`,
 		Update: true,
 		ID:     "abc/1234",
 	}.Apply(t)
 	assert.NoError(t, err, err)
-	assert.Equal(t, "abc/1234", d.Id(), "Id should be the same as in reading")
+	assert.Equal(t, "new_tid", d.Get("token.0.token_id"))
+	assert.Equal(t, "dapi456", d.Get("token.0.token_value"))
+}
+
+func TestResourceWorkspaceRegionRequiresNew(t *testing.T) {
+	s := ResourceWorkspace().Schema
+	assert.True(t, s["aws_region"].ForceNew)
+	assert.False(t, s["network_id"].ForceNew)
+	assert.False(t, s["private_access_settings_id"].ForceNew)
 }
 
 func TestResourceWorkspaceUpdate_Error(t *testing.T) {
@@ -376,9 +1658,10 @@ func TestResourceWorkspaceDelete(t *testing.T) {
 				Status: 404,
 			},
 		},
-		Resource: ResourceWorkspace(),
-		Delete:   true,
-		ID:       "abc/1234",
+		Resource:  ResourceWorkspace(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc/1234",
 	}.Apply(t)
 	assert.NoError(t, err, err)
 	assert.Equal(t, "abc/1234", d.Id())
@@ -397,9 +1680,10 @@ func TestResourceWorkspaceDelete_Error(t *testing.T) {
 				Status: 400,
 			},
 		},
-		Resource: ResourceWorkspace(),
-		Delete:   true,
-		ID:       "abc/1234",
+		Resource:  ResourceWorkspace(),
+		AccountID: "abc",
+		Delete:    true,
+		ID:        "abc/1234",
 	}.Apply(t)
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc/1234", d.Id())
@@ -473,7 +1757,7 @@ func TestWaitForRunning(t *testing.T) {
 		StorageConfigurationID: "ghi",
 		NetworkID:              "fgh",
 		CustomerManagedKeyID:   "def",
-	})
+	}, 15*time.Minute)
 	require.NoError(t, err)
 }
 
@@ -545,7 +1829,7 @@ func TestCreateFailsAndCleansUp(t *testing.T) {
 		StorageConfigurationID: "ghi",
 		NetworkID:              "fgh",
 		CustomerManagedKeyID:   "def",
-	})
+	}, 15*time.Minute)
 	require.EqualError(t, err, "Workspace failed to create: Always fails, network error message: error: FAIL;error_msg: Message;")
 }
 
@@ -553,7 +1837,7 @@ func TestListWorkspaces(t *testing.T) {
 	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
 		{
 			Method:   "GET",
-			Resource: "/api/2.0/accounts/abc/workspaces",
+			Resource: "/api/2.0/accounts/abc/workspaces?limit=100&offset=0",
 			Response: []Workspace{},
 		},
 	})
@@ -565,6 +1849,59 @@ func TestListWorkspaces(t *testing.T) {
 	assert.Len(t, l, 0)
 }
 
+func pageOfWorkspaces(offset, count int) (page []Workspace) {
+	for i := offset; i < offset+count; i++ {
+		page = append(page, Workspace{
+			WorkspaceID:   int64(i + 1),
+			WorkspaceName: fmt.Sprintf("ws-%d", i+1),
+		})
+	}
+	return
+}
+
+func TestListWorkspaces_Paginated(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc/workspaces?limit=100&offset=0",
+			Response: pageOfWorkspaces(0, 100),
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc/workspaces?limit=100&offset=100",
+			Response: pageOfWorkspaces(100, 20),
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	l, err := NewWorkspacesAPI(context.Background(), client).List("abc")
+	require.NoError(t, err)
+	assert.Len(t, l, 120)
+	assert.Equal(t, int64(120), l[119].WorkspaceID)
+}
+
+func TestListWorkspaces_PagingIgnored(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc/workspaces?limit=100&offset=0",
+			Response: pageOfWorkspaces(0, 100),
+		},
+		{
+			Method:   "GET",
+			Resource: "/api/2.0/accounts/abc/workspaces?limit=100&offset=100",
+			Response: pageOfWorkspaces(0, 100),
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	l, err := NewWorkspacesAPI(context.Background(), client).List("abc")
+	require.NoError(t, err)
+	assert.Len(t, l, 100)
+}
+
 func TestDial(t *testing.T) {
 	err := dial("127.0.0.1:32456", "localhost", 50*time.Millisecond)
 	assert.NotNil(t, err)
@@ -575,3 +1912,226 @@ func TestDial(t *testing.T) {
 	err = dial(strings.ReplaceAll(s.URL, "http://", ""), s.URL, 500*time.Millisecond)
 	assert.Nil(t, err)
 }
+
+func TestCreateTimeoutKeepsWorkspace(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   "POST",
+			Resource: "/api/2.0/accounts/abc/workspaces",
+			Response: Workspace{
+				WorkspaceID:    1234,
+				AccountID:      "abc",
+				DeploymentName: "900150983cd24fb0",
+			},
+		},
+		{
+			Method:       "GET",
+			Resource:     "/api/2.0/accounts/abc/workspaces/1234",
+			ReuseRequest: true,
+			Response: Workspace{
+				WorkspaceID:            1234,
+				WorkspaceStatus:        WorkspaceStatusProvisioning,
+				WorkspaceStatusMessage: "Workspace resources are being set up",
+				DeploymentName:         "900150983cd24fb0",
+				AccountID:              "abc",
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+
+	err = NewWorkspacesAPI(context.Background(), client).Create(&Workspace{
+		AccountID:      "abc",
+		DeploymentName: "900150983cd24fb0",
+	}, 1*time.Second)
+	require.EqualError(t, err, "Workspace is still PROVISIONING after 1s: "+
+		"Workspace resources are being set up")
+	assert.IsType(t, workspaceNotReadyError{}, err)
+}
+
+func TestNotRunningWarnings(t *testing.T) {
+	d := ResourceWorkspace().TestResourceData()
+	d.Set("workspace_name", "labdata")
+	d.Set("workspace_status", WorkspaceStatusProvisioning)
+	assert.Len(t, notRunningWarnings(d), 0, "not created yet")
+
+	d.SetId("abc/1234")
+	d.Set("workspace_status", WorkspaceStatusRunning)
+	assert.Len(t, notRunningWarnings(d), 0)
+
+	d.Set("workspace_status", WorkspaceStatusProvisioning)
+	d.Set("workspace_status_message", "Workspace resources are being set up")
+	diags := notRunningWarnings(d)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "Workspace labdata is still PROVISIONING after 20m0s: "+
+		"Workspace resources are being set up", diags[0].Summary)
+}
+
+func TestResourceWorkspaceRead_Provisioning(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/1234",
+				Response: Workspace{
+					WorkspaceID:     1234,
+					WorkspaceStatus: WorkspaceStatusProvisioning,
+					WorkspaceName:   "labdata",
+					DeploymentName:  "900150983cd24fb0",
+					AccountID:       "abc",
+				},
+			},
+		},
+		Resource:  ResourceWorkspace(),
+		AccountID: "abc",
+		Read:      true,
+		New:       true,
+		ID:        "abc/1234",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "PROVISIONING", d.Get("workspace_status"))
+}
+
+func TestResourceWorkspaceRead_UpdatingRetainsPriorValues(t *testing.T) {
+	state := map[string]string{
+		"network_id":                 "fgh",
+		"private_access_settings_id": "pas",
+	}
+	for k, v := range noPublicIPState {
+		state[k] = v
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/accounts/abc/workspaces/1234",
+				Response: Workspace{
+					WorkspaceID:            1234,
+					AccountID:              "abc",
+					WorkspaceStatus:        WorkspaceStatusUpdating,
+					WorkspaceStatusMessage: "Attaching private access settings",
+					DeploymentName:         "900150983cd24fb0",
+					// the rest of fields is temporarily omitted
+				},
+			},
+		},
+		Resource:      ResourceWorkspace(),
+		InstanceState: state,
+		HCL: `
+		account_id = "abc"
+		aws_region = "us-east-1"
+		credentials_id = "bcd"
+		deployment_name = "900150983cd24fb0"
+		workspace_name = "labdata"
+		storage_configuration_id = "ghi"
+		network_id = "fgh"
+		private_access_settings_id = "pas"
+		`,
+		Read: true,
+		ID:   "abc/1234",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "UPDATING", d.Get("workspace_status"))
+	assert.Equal(t, "Attaching private access settings", d.Get("workspace_status_message"))
+	assert.Equal(t, "labdata", d.Get("workspace_name"))
+	assert.Equal(t, "us-east-1", d.Get("aws_region"))
+	assert.Equal(t, "bcd", d.Get("credentials_id"))
+	assert.Equal(t, "ghi", d.Get("storage_configuration_id"))
+	assert.Equal(t, "fgh", d.Get("network_id"))
+	assert.Equal(t, "pas", d.Get("private_access_settings_id"))
+}
+
+func TestResourceWorkspaceRead_Failed(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/accounts/abc/workspaces/1234",
+				Response: Workspace{
+					WorkspaceID:            1234,
+					AccountID:              "abc",
+					WorkspaceStatus:        WorkspaceStatusFailed,
+					WorkspaceStatusMessage: "Credentials are not valid",
+					DeploymentName:         "900150983cd24fb0",
+				},
+			},
+		},
+		Resource:  ResourceWorkspace(),
+		AccountID: "abc",
+		Read:      true,
+		New:       true,
+		ID:        "abc/1234",
+	}.Apply(t)
+	assert.EqualError(t, err, "Credentials are not valid")
+}
+
+func TestRetainPriorValues(t *testing.T) {
+	ws := Workspace{
+		WorkspaceStatus: WorkspaceStatusUpdating,
+		NetworkID:       "new",
+	}
+	retainPriorValues(&ws, Workspace{
+		WorkspaceStatus: WorkspaceStatusRunning,
+		NetworkID:       "old",
+		AwsRegion:       "us-east-1",
+		CustomTags:      map[string]string{"a": "b"},
+	})
+	assert.Equal(t, WorkspaceStatusUpdating, ws.WorkspaceStatus)
+	assert.Equal(t, "new", ws.NetworkID)
+	assert.Equal(t, "us-east-1", ws.AwsRegion)
+	assert.Equal(t, "b", ws.CustomTags["a"])
+}
+
+func TestResourceWorkspaceUpdate_ResumesWaiting(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:       "GET",
+				ReuseRequest: true,
+				Resource:     "/api/2.0/accounts/abc/workspaces/1234",
+				Response: Workspace{
+					WorkspaceStatus:        WorkspaceStatusRunning,
+					WorkspaceName:          "labdata",
+					DeploymentName:         "900150983cd24fb0",
+					AwsRegion:              "us-east-1",
+					CredentialsID:          "bcd",
+					StorageConfigurationID: "ghi",
+					AccountID:              "abc",
+					WorkspaceID:            1234,
+				},
+			},
+		},
+		Resource: ResourceWorkspace(),
+		InstanceState: map[string]string{
+			"account_id":               "abc",
+			"aws_region":               "us-east-1",
+			"credentials_id":           "bcd",
+			"deployment_name":          "900150983cd24fb0",
+			"workspace_name":           "labdata",
+			"storage_configuration_id": "ghi",
+			"workspace_id":             "1234",
+			"is_no_public_ip_enabled":  "false",
+			"workspace_status":         "PROVISIONING",
+		},
+		HCL: `
+		account_id = "abc"
+		aws_region = "us-east-1"
+		credentials_id = "bcd"
+		deployment_name = "900150983cd24fb0"
+		workspace_name = "labdata"
+		storage_configuration_id = "ghi"
+		`,
+		Update: true,
+		ID:     "abc/1234",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "RUNNING", d.Get("workspace_status"))
+}
+
+func TestResourceWorkspaceTimeouts(t *testing.T) {
+	r := ResourceWorkspace()
+	assert.Equal(t, 20*time.Minute, *r.Timeouts.Create)
+	assert.Equal(t, 20*time.Minute, *r.Timeouts.Update)
+}
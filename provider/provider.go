@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/databrickslabs/databricks-terraform/access"
 	"github.com/databrickslabs/databricks-terraform/common"
@@ -22,25 +24,47 @@ import (
 func DatabricksProvider() *schema.Provider {
 	p := &schema.Provider{
 		DataSourcesMap: map[string]*schema.Resource{
-			"databricks_aws_crossaccount_policy": access.DataAwsCrossAccountRolicy(),
-			"databricks_aws_assume_role_policy":  access.DataAwsAssumeRolePolicy(),
-			"databricks_aws_bucket_policy":       access.DataAwsBucketPolicy(),
-			"databricks_dbfs_file":               storage.DataSourceDBFSFile(),
-			"databricks_dbfs_file_paths":         storage.DataSourceDBFSFilePaths(),
-			"databricks_group":                   identity.DataSourceGroup(),
-			"databricks_me":                      identity.DataSourceMe(),
-			"databricks_node_type":               compute.DataSourceNodeType(),
-			"databricks_notebook":                workspace.DataSourceNotebook(),
-			"databricks_notebook_paths":          workspace.DataSourceNotebookPaths(),
-			"databricks_spark_version":           compute.DataSourceSparkVersion(),
-			"databricks_zones":                   compute.DataSourceClusterZones(),
+			"databricks_aws_crossaccount_policy":    access.DataAwsCrossAccountRolicy(),
+			"databricks_aws_assume_role_policy":     access.DataAwsAssumeRolePolicy(),
+			"databricks_aws_bucket_policy":          access.DataAwsBucketPolicy(),
+			"databricks_cluster_events":             compute.DataSourceClusterEvents(),
+			"databricks_clusters":                   compute.DataSourceClusters(),
+			"databricks_current_user":               identity.DataSourceCurrentUser(),
+			"databricks_dbfs_file":                  storage.DataSourceDBFSFile(),
+			"databricks_dbfs_file_paths":            storage.DataSourceDBFSFilePaths(),
+			"databricks_group":                      identity.DataSourceGroup(),
+			"databricks_job":                        compute.DataSourceJob(),
+			"databricks_jobs":                       compute.DataSourceJobs(),
+			"databricks_me":                         identity.DataSourceMe(),
+			"databricks_mws_credential":             mws.DataSourceCredential(),
+			"databricks_mws_credentials":            mws.DataSourceCredentials(),
+			"databricks_mws_network":                mws.DataSourceNetwork(),
+			"databricks_mws_networks":               mws.DataSourceNetworks(),
+			"databricks_mws_storage_configuration":  mws.DataSourceStorageConfiguration(),
+			"databricks_mws_storage_configurations": mws.DataSourceStorageConfigurations(),
+			"databricks_mws_vpc_endpoint":           mws.DataSourceVPCEndpoint(),
+			"databricks_mws_vpc_endpoints":          mws.DataSourceVPCEndpoints(),
+			"databricks_mws_workspaces":             mws.DataSourceWorkspaces(),
+			"databricks_node_type":                  compute.DataSourceNodeType(),
+			"databricks_notebook":                   workspace.DataSourceNotebook(),
+			"databricks_notebook_paths":             workspace.DataSourceNotebookPaths(),
+			"databricks_notification_destination":   workspace.DataSourceNotificationDestination(),
+			"databricks_secret":                     access.DataSourceSecret(),
+			"databricks_secret_scope":               access.DataSourceSecretScope(),
+			"databricks_service_principal":          identity.DataSourceServicePrincipal(),
+			"databricks_spark_version":              compute.DataSourceSparkVersion(),
+			"databricks_tokens":                     identity.DataSourceTokens(),
+			"databricks_user":                       identity.DataSourceUser(),
+			"databricks_users":                      identity.DataSourceUsers(),
+			"databricks_zones":                      compute.DataSourceClusterZones(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"databricks_secret":         access.ResourceSecret(),
-			"databricks_secret_scope":   access.ResourceSecretScope(),
-			"databricks_secret_acl":     access.ResourceSecretACL(),
-			"databricks_permissions":    access.ResourcePermissions(),
-			"databricks_ip_access_list": access.ResourceIPAccessList(),
+			"databricks_secret":            access.ResourceSecret(),
+			"databricks_secret_scope":      access.ResourceSecretScope(),
+			"databricks_secret_acl":        access.ResourceSecretACL(),
+			"databricks_secret_scope_acls": access.ResourceSecretScopeACLs(),
+			"databricks_permissions":       access.ResourcePermissions(),
+			"databricks_ip_access_list":    access.ResourceIPAccessList(),
 
 			"databricks_cluster":        compute.ResourceCluster(),
 			"databricks_cluster_policy": compute.ResourceClusterPolicy(),
@@ -53,15 +77,21 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_instance_profile":       identity.ResourceInstanceProfile(),
 			"databricks_group_member":           identity.ResourceGroupMember(),
 			"databricks_token":                  identity.ResourceToken(),
+			"databricks_obo_token":              identity.ResourceOboToken(),
 			"databricks_user":                   identity.ResourceUser(),
 			"databricks_service_principal":      identity.ResourceServicePrincipal(),
+			"databricks_service_principal_role": identity.ResourceServicePrincipalRole(),
+			"databricks_entitlements":           identity.ResourceEntitlements(),
 
-			"databricks_mws_customer_managed_keys":  mws.ResourceCustomerManagedKey(),
-			"databricks_mws_credentials":            mws.ResourceCredentials(),
-			"databricks_mws_log_delivery":           mws.ResourceLogDelivery(),
-			"databricks_mws_networks":               mws.ResourceNetwork(),
-			"databricks_mws_storage_configurations": mws.ResourceStorageConfiguration(),
-			"databricks_mws_workspaces":             mws.ResourceWorkspace(),
+			"databricks_mws_customer_managed_keys":   mws.ResourceCustomerManagedKey(),
+			"databricks_mws_credentials":             mws.ResourceCredentials(),
+			"databricks_mws_ip_access_list":          mws.ResourceIPAccessList(),
+			"databricks_mws_log_delivery":            mws.ResourceLogDelivery(),
+			"databricks_mws_networks":                mws.ResourceNetwork(),
+			"databricks_mws_private_access_settings": mws.ResourcePrivateAccessSettings(),
+			"databricks_mws_storage_configurations":  mws.ResourceStorageConfiguration(),
+			"databricks_mws_vpc_endpoint":            mws.ResourceVPCEndpoint(),
+			"databricks_mws_workspaces":              mws.ResourceWorkspace(),
 
 			"databricks_aws_s3_mount":          storage.ResourceAWSS3Mount(),
 			"databricks_azure_adls_gen1_mount": storage.ResourceAzureAdlsGen1Mount(),
@@ -69,8 +99,10 @@ func DatabricksProvider() *schema.Provider {
 			"databricks_azure_blob_mount":      storage.ResourceAzureBlobMount(),
 			"databricks_dbfs_file":             storage.ResourceDBFSFile(),
 
-			"databricks_notebook":       workspace.ResourceNotebook(),
-			"databricks_workspace_conf": workspace.ResourceWorkspaceConf(),
+			"databricks_directory":          workspace.ResourceDirectory(),
+			"databricks_global_init_script": workspace.ResourceGlobalInitScript(),
+			"databricks_notebook":           workspace.ResourceNotebook(),
+			"databricks_workspace_conf":     workspace.ResourceWorkspaceConf(),
 		},
 		Schema: map[string]*schema.Schema{
 			"host": {
@@ -133,6 +165,14 @@ func DatabricksProvider() *schema.Provider {
 					"token",
 				},
 			},
+			"account_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("DATABRICKS_ACCOUNT_ID", nil),
+				Description: "Account ID, that is used by databricks_mws_* resources and data sources, " +
+					"when they have no account_id set.",
+			},
 			"azure_workspace_resource_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -210,6 +250,25 @@ func DatabricksProvider() *schema.Provider {
 				Description: "Debug HTTP headers of requests made by the provider. Default is false. Visible only when TF_LOG=DEBUG is set",
 				DefaultFunc: schema.EnvDefaultFunc("DATABRICKS_DEBUG_HEADERS", false),
 			},
+			"retry_max_attempts": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of attempts for a single HTTP request on transient errors. Default is 30",
+				DefaultFunc:  schema.EnvDefaultFunc("DATABRICKS_RETRY_MAX_ATTEMPTS", 30),
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"retry_initial_interval": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "Wait before the first retry, that grows linearly with every next attempt. Default is 10s",
+				DefaultFunc: schema.EnvDefaultFunc("DATABRICKS_RETRY_INITIAL_INTERVAL", "10s"),
+			},
+			"retry_max_elapsed_time": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "Maximum time spent on retrying a single HTTP request. Default is 5m",
+				DefaultFunc: schema.EnvDefaultFunc("DATABRICKS_RETRY_MAX_ELAPSED_TIME", "5m"),
+			},
 		},
 		ConfigureContextFunc: func(c context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 			pc := common.DatabricksClient{}
@@ -247,6 +306,9 @@ func DatabricksProvider() *schema.Provider {
 					pc.Password = fmt.Sprintf("%s", password)
 				}
 			}
+			if v, ok := d.GetOk("account_id"); ok {
+				pc.AccountID = v.(string)
+			}
 			if v, ok := d.GetOk("azure_workspace_resource_id"); ok {
 				authsUsed["azure"] = true
 				pc.AzureAuth.ResourceID = v.(string)
@@ -287,6 +349,29 @@ func DatabricksProvider() *schema.Provider {
 			if v, ok := d.GetOk("debug_headers"); ok {
 				pc.DebugHeaders = v.(bool)
 			}
+			if v, ok := d.GetOk("retry_max_attempts"); ok {
+				pc.RetryMaxAttempts = v.(int)
+			}
+			if v, ok := d.GetOk("retry_initial_interval"); ok {
+				interval, err := time.ParseDuration(v.(string))
+				if err != nil {
+					return nil, diag.Errorf("Invalid retry_initial_interval: %s", err)
+				}
+				if interval <= 0 {
+					return nil, diag.Errorf("Invalid retry_initial_interval: must be positive, but got %s", interval)
+				}
+				pc.RetryInitialInterval = interval
+			}
+			if v, ok := d.GetOk("retry_max_elapsed_time"); ok {
+				elapsed, err := time.ParseDuration(v.(string))
+				if err != nil {
+					return nil, diag.Errorf("Invalid retry_max_elapsed_time: %s", err)
+				}
+				if elapsed <= 0 {
+					return nil, diag.Errorf("Invalid retry_max_elapsed_time: must be positive, but got %s", elapsed)
+				}
+				pc.RetryMaxElapsedTime = elapsed
+			}
 			if v, ok := d.GetOk("azure_use_pat_for_cli"); ok {
 				pc.AzureAuth.UsePATForCLI = v.(bool)
 			}
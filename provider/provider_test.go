@@ -173,6 +173,31 @@ func TestProviderConfigurationOptions(t *testing.T) {
 			},
 			assertError: "More than one authorization method configured: password and token",
 		},
+		{
+			env: map[string]string{
+				"DATABRICKS_HOST":                   "x",
+				"DATABRICKS_TOKEN":                  "x",
+				"DATABRICKS_RETRY_INITIAL_INTERVAL": "1m",
+				"DATABRICKS_RETRY_MAX_ELAPSED_TIME": "30s",
+			},
+			assertError: "retry_max_elapsed_time (30s) must not be shorter than retry_initial_interval (1m0s)",
+		},
+		{
+			env: map[string]string{
+				"DATABRICKS_HOST":                   "x",
+				"DATABRICKS_TOKEN":                  "x",
+				"DATABRICKS_RETRY_MAX_ELAPSED_TIME": "a while",
+			},
+			assertError: "Invalid retry_max_elapsed_time: time: invalid duration",
+		},
+		{
+			env: map[string]string{
+				"DATABRICKS_HOST":                   "x",
+				"DATABRICKS_TOKEN":                  "x",
+				"DATABRICKS_RETRY_INITIAL_INTERVAL": "0s",
+			},
+			assertError: "Invalid retry_initial_interval: must be positive, but got 0s",
+		},
 		{
 			env: map[string]string{
 				"CONFIG_FILE": "x",
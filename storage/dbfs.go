@@ -1,8 +1,13 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"path"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 )
@@ -53,27 +58,73 @@ type DbfsAPI struct {
 	context context.Context
 }
 
+// dbfsBlockSize is the maximum size of data in a single add-block request
+const dbfsBlockSize = 1e6
+
 // Create creates a file on DBFS
-func (a DbfsAPI) Create(path string, byteArr []byte, overwrite bool) (err error) {
-	byteChunks := split(byteArr, 1e6)
-	handle, err := a.createHandle(path, overwrite)
+func (a DbfsAPI) Create(path string, byteArr []byte, overwrite bool) error {
+	return a.Upload(path, bytes.NewReader(byteArr), overwrite)
+}
+
+// Upload streams content to a temporary file next to the path in blocks of up to 1MB and moves
+// it to the path only once all blocks are uploaded, so that failed upload leaves no partial file.
+// Replacement is not atomic: existing file is deleted right before the move, as DBFS cannot move
+// over it, so the path is left empty, if the move fails.
+func (a DbfsAPI) Upload(path string, r io.Reader, overwrite bool) error {
+	tmp := uploadTempPath(path)
+	err := a.upload(tmp, r)
+	if err == nil && overwrite {
+		// move fails if the destination exists
+		err = a.Delete(path, false)
+		if e, ok := err.(common.APIError); ok && e.IsMissing() {
+			err = nil
+		}
+	}
+	if err == nil {
+		err = a.Move(tmp, path)
+	}
+	if err != nil {
+		if derr := a.Delete(tmp, false); derr != nil {
+			log.Printf("[WARN] Cannot remove temporary upload %s: %s", tmp, derr)
+		}
+	}
+	return err
+}
+
+// uploadTempPath returns hidden file in the same directory, so that move doesn't cross mounts
+func uploadTempPath(p string) string {
+	return path.Join(path.Dir(p), fmt.Sprintf(".%s.tf-upload", path.Base(p)))
+}
+
+// upload sends content block by block, so that only a single block is kept in memory.
+// Every block is a separate request, which is retried by the client on transient failures.
+func (a DbfsAPI) upload(path string, r io.Reader) (err error) {
+	handle, err := a.createHandle(path, true)
 	if err != nil {
 		return
 	}
 	defer func() {
 		cerr := a.closeHandle(handle)
-		if cerr != nil {
+		if cerr != nil && err == nil {
 			err = cerr
 		}
 	}()
-	for _, byteChunk := range byteChunks {
-		b64Data := base64.StdEncoding.EncodeToString(byteChunk)
-		err = a.addBlock(b64Data, handle)
-		if err != nil {
+	buf := make([]byte, dbfsBlockSize)
+	for {
+		n, rerr := io.ReadFull(r, buf)
+		if n > 0 {
+			err = a.addBlock(base64.StdEncoding.EncodeToString(buf[:n]), handle)
+			if err != nil {
+				return
+			}
+		}
+		if rerr == io.EOF || rerr == io.ErrUnexpectedEOF {
 			return
 		}
+		if rerr != nil {
+			return rerr
+		}
 	}
-	return
 }
 
 func (a DbfsAPI) createHandle(path string, overwrite bool) (int64, error) {
@@ -205,16 +256,3 @@ func (a DbfsAPI) Mkdirs(path string) error {
 		"path": path,
 	}, nil)
 }
-
-func split(buf []byte, lim int) [][]byte {
-	var chunk []byte
-	chunks := make([][]byte, 0, len(buf)/lim+1)
-	for len(buf) >= lim {
-		chunk, buf = buf[:lim], buf[lim:]
-		chunks = append(chunks, chunk)
-	}
-	if len(buf) > 0 {
-		chunks = append(chunks, buf)
-	}
-	return chunks
-}
@@ -2,6 +2,10 @@ package storage
 
 import (
 	"context"
+	"crypto/md5"
+	"fmt"
+	"io"
+	"log"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal/util"
@@ -9,6 +13,24 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// uploadDBFSFile streams content to DBFS and records its checksum, so that content is never kept in state
+func uploadDBFSFile(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient, path string) error {
+	content, err := workspace.OpenContent(d)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+	h := md5.New()
+	if err = NewDbfsAPI(ctx, c).Upload(path, io.TeeReader(content, h), true); err != nil {
+		return err
+	}
+	if err = d.Set("md5", fmt.Sprintf("%x", h.Sum(nil))); err != nil {
+		return err
+	}
+	// size of own upload is recorded by read and is not a change outside of Terraform
+	return d.Set("file_size", 0)
+}
+
 // ResourceDBFSFile manages files on DBFS
 func ResourceDBFSFile() *schema.Resource {
 	return util.CommonResource{
@@ -22,11 +44,7 @@ func ResourceDBFSFile() *schema.Resource {
 		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			// TODO: make mandatory DBFS prefix or something to facilitate use for DBFS libraries?...
 			path := d.Get("path").(string) // fmt.Sprintf("dbfs:%s", d.Get("path"))
-			content, err := workspace.ReadContent(d)
-			if err != nil {
-				return err
-			}
-			if err = NewDbfsAPI(ctx, c).Create(path, content, true); err != nil {
+			if err := uploadDBFSFile(ctx, d, c, path); err != nil {
 				return err
 			}
 			d.SetId(path)
@@ -38,10 +56,19 @@ func ResourceDBFSFile() *schema.Resource {
 			if err != nil {
 				return err
 			}
+			if fileSize, ok := d.GetOk("file_size"); ok && int64(fileSize.(int)) != fileInfo.FileSize {
+				log.Printf("[INFO] %s was changed outside of Terraform, it will be uploaded again", d.Id())
+				d.Set("md5", "")
+			}
 			d.Set("path", fileInfo.Path)
 			d.Set("file_size", fileInfo.FileSize)
 			return nil
 		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			// path forces new resource, so only content could change. Failed upload keeps the old file,
+			// but if the move of uploaded content fails, the file is missing until the next apply.
+			return uploadDBFSFile(ctx, d, c, d.Id())
+		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			return NewDbfsAPI(ctx, c).Delete(d.Id(), false)
 		},
@@ -1,13 +1,20 @@
 package storage
 
 import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"testing"
 
+	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal/qa"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // func notebookToB64(filePath string) (string, error) {
@@ -88,7 +95,7 @@ func getBaseDBFSFileCreateFixtures(path string) []qa.HTTPFixture {
 			Method:   http.MethodPost,
 			Resource: "/api/2.0/dbfs/create",
 			ExpectedRequest: CreateHandle{
-				Path:      path,
+				Path:      uploadTempPath(path),
 				Overwrite: true,
 			},
 			Response: Handle{329874298374132},
@@ -106,6 +113,19 @@ func getBaseDBFSFileCreateFixtures(path string) []qa.HTTPFixture {
 			Resource: "/api/2.0/dbfs/close",
 			Response: Handle{329874298374132},
 		},
+		{
+			Method:          http.MethodPost,
+			Resource:        "/api/2.0/dbfs/delete",
+			ExpectedRequest: dbfsRequest{Path: path},
+		},
+		{
+			Method:   http.MethodPost,
+			Resource: "/api/2.0/dbfs/move",
+			ExpectedRequest: map[string]string{
+				"source_path":      uploadTempPath(path),
+				"destination_path": path,
+			},
+		},
 		{
 			Method:   http.MethodGet,
 			Resource: fmt.Sprintf("/api/2.0/dbfs/get-status?path=%s", url.PathEscape(path)),
@@ -267,3 +287,215 @@ func TestDBFSFileRead_IsMissingResource(t *testing.T) {
 		},
 	}.ApplyNoError(t)
 }
+
+func TestDBFSFileCreate_MultipleBlocks(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 250000)
+	source := filepath.Join(t.TempDir(), "large.bin")
+	err := ioutil.WriteFile(source, content, 0644)
+	require.NoError(t, err)
+	fixtures := []qa.HTTPFixture{
+		{
+			Method:   http.MethodPost,
+			Resource: "/api/2.0/dbfs/create",
+			ExpectedRequest: CreateHandle{
+				Path:      "/data/.large.bin.tf-upload",
+				Overwrite: true,
+			},
+			Response: Handle{123},
+		},
+	}
+	for _, block := range [][]byte{content[:1e6], content[1e6:2e6], content[2e6:]} {
+		fixtures = append(fixtures, qa.HTTPFixture{
+			Method:   http.MethodPost,
+			Resource: "/api/2.0/dbfs/add-block",
+			ExpectedRequest: AddBlock{
+				Data:   base64.StdEncoding.EncodeToString(block),
+				Handle: 123,
+			},
+		})
+	}
+	fixtures = append(fixtures, []qa.HTTPFixture{
+		{
+			Method:          http.MethodPost,
+			Resource:        "/api/2.0/dbfs/close",
+			ExpectedRequest: Handle{123},
+		},
+		{
+			Method:          http.MethodPost,
+			Resource:        "/api/2.0/dbfs/delete",
+			ExpectedRequest: dbfsRequest{Path: "/data/large.bin"},
+			Response: common.APIErrorBody{
+				ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+				Message:   "No file or directory exists on path /data/large.bin.",
+			},
+			Status: 404,
+		},
+		{
+			Method:   http.MethodPost,
+			Resource: "/api/2.0/dbfs/move",
+			ExpectedRequest: map[string]string{
+				"source_path":      "/data/.large.bin.tf-upload",
+				"destination_path": "/data/large.bin",
+			},
+		},
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/dbfs/get-status?path=%2Fdata%2Flarge.bin",
+			Response: FileInfo{
+				Path:     "/data/large.bin",
+				FileSize: 2500000,
+			},
+		},
+	}...)
+	d, err := qa.ResourceFixture{
+		Fixtures: fixtures,
+		Resource: ResourceDBFSFile(),
+		Create:   true,
+		State: map[string]interface{}{
+			"source": source,
+			"path":   "/data/large.bin",
+		},
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, fmt.Sprintf("%x", md5.Sum(content)), d.Get("md5"))
+	assert.Equal(t, 2500000, d.Get("file_size"))
+}
+
+func TestDBFSFileCreate_FailedBlock(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/dbfs/create",
+				ExpectedRequest: CreateHandle{
+					Path:      "/.abc.tf-upload",
+					Overwrite: true,
+				},
+				Response: Handle{123},
+			},
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/dbfs/add-block",
+				Response: common.APIErrorBody{
+					ErrorCode: "MAX_BLOCK_SIZE_EXCEEDED",
+					Message:   "The block of data exceeds 1 MB",
+				},
+				Status: 400,
+			},
+			{
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/dbfs/close",
+				ExpectedRequest: Handle{123},
+			},
+			{
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/dbfs/delete",
+				ExpectedRequest: dbfsRequest{Path: "/.abc.tf-upload"},
+			},
+		},
+		Resource: ResourceDBFSFile(),
+		Create:   true,
+		State: map[string]interface{}{
+			"content_base64": "YWJjCg==",
+			"path":           "/abc",
+		},
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "The block of data exceeds 1 MB")
+}
+
+func TestDBFSFileCreate_FailedMove(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/dbfs/create",
+				ExpectedRequest: CreateHandle{
+					Path:      "/.abc.tf-upload",
+					Overwrite: true,
+				},
+				Response: Handle{123},
+			},
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/dbfs/add-block",
+			},
+			{
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/dbfs/close",
+				ExpectedRequest: Handle{123},
+			},
+			{
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/dbfs/delete",
+				ExpectedRequest: dbfsRequest{Path: "/abc"},
+			},
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/dbfs/move",
+				Response: common.APIErrorBody{
+					ErrorCode: "INTERNAL_ERROR",
+					Message:   "Cannot move file",
+				},
+				Status: 500,
+			},
+			{
+				Method:          http.MethodPost,
+				Resource:        "/api/2.0/dbfs/delete",
+				ExpectedRequest: dbfsRequest{Path: "/.abc.tf-upload"},
+			},
+		},
+		Resource: ResourceDBFSFile(),
+		Create:   true,
+		State: map[string]interface{}{
+			"content_base64": "YWJjCg==",
+			"path":           "/abc",
+		},
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Cannot move file")
+}
+
+func TestDBFSFileRead_ChangedOutside(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: getBaseDBFSFileGetStatusFixtures("/abc", false, false),
+		Resource: ResourceDBFSFile(),
+		Read:     true,
+		ID:       "/abc",
+		InstanceState: map[string]string{
+			"path":           "/abc",
+			"content_base64": "YWJjCg==",
+			"md5":            "0bee89b07a248e27c83fc3d5951213c1",
+			"file_size":      "4",
+		},
+		HCL: `
+		path = "/abc"
+		content_base64 = "YWJjCg=="
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "", d.State().Attributes["md5"])
+	assert.Equal(t, 1024, d.Get("file_size"))
+}
+
+func TestDBFSFileUpdate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: qa.UnionFixturesLists(
+			getBaseDBFSFileCreateFixtures("/abc"),
+		),
+		Resource: ResourceDBFSFile(),
+		Update:   true,
+		ID:       "/abc",
+		InstanceState: map[string]string{
+			"path":           "/abc",
+			"content_base64": "YWJjCg==",
+			"md5":            "0bee89b07a248e27c83fc3d5951213c1",
+			"file_size":      "4",
+		},
+		HCL: `
+		path = "/abc"
+		content_base64 = "YWJjZAo="
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "/abc", d.Id())
+	assert.Equal(t, fmt.Sprintf("%x", md5.Sum([]byte("abcd\n"))), d.State().Attributes["md5"])
+}
@@ -0,0 +1,101 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// NotificationDestination is a target of job and alert notifications, like email, Slack or PagerDuty
+type NotificationDestination struct {
+	ID              string `json:"id"`
+	DisplayName     string `json:"display_name"`
+	DestinationType string `json:"destination_type,omitempty"`
+}
+
+type notificationDestinationList struct {
+	Results       []NotificationDestination `json:"results,omitempty"`
+	NextPageToken string                    `json:"next_page_token,omitempty"`
+}
+
+// NotificationDestinationsAPI exposes the notification destinations API
+type NotificationDestinationsAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+// NewNotificationDestinationsAPI returns notification destinations API
+func NewNotificationDestinationsAPI(ctx context.Context, m interface{}) NotificationDestinationsAPI {
+	return NotificationDestinationsAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// List returns all notification destinations of the workspace
+func (a NotificationDestinationsAPI) List() (destinations []NotificationDestination, err error) {
+	request := map[string]string{}
+	for {
+		var page notificationDestinationList
+		err = a.client.Get(a.context, "/notification-destinations", request, &page)
+		if err != nil {
+			return
+		}
+		destinations = append(destinations, page.Results...)
+		if page.NextPageToken == "" {
+			return
+		}
+		request["page_token"] = page.NextPageToken
+	}
+}
+
+// DataSourceNotificationDestination returns single notification destination looked up by its name
+func DataSourceNotificationDestination() *schema.Resource {
+	type entity struct {
+		DisplayName     string `json:"display_name"`
+		DestinationType string `json:"destination_type,omitempty" tf:"computed"`
+		ID              string `json:"id,omitempty" tf:"computed"`
+	}
+	s := internal.StructToSchema(entity{}, func(
+		s map[string]*schema.Schema) map[string]*schema.Schema {
+		return s
+	})
+	return &schema.Resource{
+		Schema: s,
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			var this entity
+			err := internal.DataToStructPointer(d, s, &this)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			destinations, err := NewNotificationDestinationsAPI(ctx, m).List()
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			var found []NotificationDestination
+			for _, nd := range destinations {
+				if nd.DisplayName != this.DisplayName {
+					continue
+				}
+				if this.DestinationType != "" && nd.DestinationType != this.DestinationType {
+					continue
+				}
+				found = append(found, nd)
+			}
+			if len(found) == 0 {
+				return diag.Errorf("Cannot find notification destination %s", this.DisplayName)
+			}
+			if len(found) > 1 {
+				return diag.Errorf("There are %d notification destinations named %s", len(found), this.DisplayName)
+			}
+			this.ID = found[0].ID
+			this.DestinationType = found[0].DestinationType
+			err = internal.StructToData(this, s, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			d.SetId(this.ID)
+			return nil
+		},
+	}
+}
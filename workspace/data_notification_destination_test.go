@@ -0,0 +1,86 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceNotificationDestination(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/notification-destinations?",
+				Response: notificationDestinationList{
+					Results: []NotificationDestination{
+						{ID: "abc", DisplayName: "On-call", DestinationType: "SLACK"},
+					},
+					NextPageToken: "next",
+				},
+			},
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/notification-destinations?page_token=next",
+				Response: notificationDestinationList{
+					Results: []NotificationDestination{
+						{ID: "def", DisplayName: "On-call", DestinationType: "PAGERDUTY"},
+						{ID: "ghi", DisplayName: "Data team", DestinationType: "EMAIL"},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceNotificationDestination(),
+		ID:          ".",
+		HCL: `display_name = "On-call"
+		destination_type = "PAGERDUTY"`,
+	}.Apply(t)
+	require.NoError(t, err)
+	assert.Equal(t, "def", d.Id())
+	assert.Equal(t, "def", d.Get("id"))
+}
+
+func TestDataSourceNotificationDestination_Ambiguous(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/notification-destinations?",
+				Response: notificationDestinationList{
+					Results: []NotificationDestination{
+						{ID: "abc", DisplayName: "On-call", DestinationType: "SLACK"},
+						{ID: "def", DisplayName: "On-call", DestinationType: "PAGERDUTY"},
+					},
+				},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceNotificationDestination(),
+		ID:          ".",
+		HCL:         `display_name = "On-call"`,
+	}.Apply(t)
+	assert.EqualError(t, err, "There are 2 notification destinations named On-call")
+}
+
+func TestDataSourceNotificationDestination_NotFound(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   "GET",
+				Resource: "/api/2.0/notification-destinations?",
+				Response: notificationDestinationList{},
+			},
+		},
+		Read:        true,
+		NonWritable: true,
+		Resource:    DataSourceNotificationDestination(),
+		ID:          ".",
+		HCL:         `display_name = "On-call"`,
+	}.Apply(t)
+	assert.EqualError(t, err, "Cannot find notification destination On-call")
+}
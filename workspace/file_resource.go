@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"hash/fnv"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
@@ -46,6 +47,31 @@ func ReadContent(d *schema.ResourceData) (content []byte, err error) {
 	return
 }
 
+// OpenContent returns reader of `content_base64` or `source`, so that large files are not read into memory at once
+func OpenContent(d *schema.ResourceData) (io.ReadCloser, error) {
+	b64 := d.Get("content_base64").(string)
+	if b64 != "" {
+		return ioutil.NopCloser(base64.NewDecoder(base64.StdEncoding, strings.NewReader(b64))), nil
+	}
+	source := d.Get("source").(string)
+	log.Printf("[INFO] Reading %s", source)
+	return os.Open(source)
+}
+
+// ContentChecksum returns md5 of `content_base64` or `source`, reading the content incrementally
+func ContentChecksum(d *schema.ResourceData) (string, error) {
+	r, err := OpenContent(d)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	h := md5.New()
+	if _, err = io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
 // FileContentSchema returns common schema for file resources
 func FileContentSchema(extra map[string]*schema.Schema) map[string]*schema.Schema {
 	s := map[string]*schema.Schema{
@@ -54,11 +80,12 @@ func FileContentSchema(extra map[string]*schema.Schema) map[string]*schema.Schem
 			Default:  "different",
 			Optional: true,
 			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-				if _, err := ReadContent(d); err != nil {
+				checksum, err := ContentChecksum(d)
+				if err != nil {
 					return false
 				}
-				log.Printf("[INFO] Suppressing %s diff: %v", d.Id(), old == d.Get("md5"))
-				return old == d.Get("md5")
+				log.Printf("[INFO] Suppressing %s diff: %v", d.Id(), old == checksum)
+				return old == checksum
 			},
 		},
 		"content_base64": {
@@ -0,0 +1,60 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/util"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ResourceDirectory manages workspace directories
+func ResourceDirectory() *schema.Resource {
+	s := FileContentSchema(map[string]*schema.Schema{
+		"object_id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"delete_recursive": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	})
+	delete(s, "md5")
+	delete(s, "content_base64")
+	delete(s, "source")
+	return util.CommonResource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			path := d.Get("path").(string)
+			if err := NewNotebooksAPI(ctx, c).Mkdirs(path); err != nil {
+				return err
+			}
+			d.SetId(path)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			objectStatus, err := NewNotebooksAPI(ctx, c).Read(d.Id())
+			if err != nil {
+				return err
+			}
+			if objectStatus.ObjectType != Directory {
+				return fmt.Errorf("%s exists, but is a %s, not a directory",
+					d.Id(), objectStatus.ObjectType)
+			}
+			if err = d.Set("path", objectStatus.Path); err != nil {
+				return err
+			}
+			return d.Set("object_id", objectStatus.ObjectID)
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			// only delete_recursive could change, which is used just on delete
+			return nil
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			return NewNotebooksAPI(ctx, c).Delete(d.Id(), d.Get("delete_recursive").(bool))
+		},
+	}.ToResource()
+}
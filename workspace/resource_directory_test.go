@@ -0,0 +1,181 @@
+package workspace
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceDirectoryCreate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/mkdirs",
+				ExpectedRequest: map[string]string{
+					"path": "/Shared/terraform-managed",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2FShared%2Fterraform-managed",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: Directory,
+					Path:       "/Shared/terraform-managed",
+				},
+			},
+		},
+		Resource: ResourceDirectory(),
+		Create:   true,
+		HCL:      `path = "/Shared/terraform-managed"`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "/Shared/terraform-managed", d.Id())
+	assert.Equal(t, 4567, d.Get("object_id"))
+	assert.Equal(t, false, d.Get("delete_recursive"))
+}
+
+func TestResourceDirectoryRead_Import(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2FShared%2Fterraform-managed",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: Directory,
+					Path:       "/Shared/terraform-managed",
+				},
+			},
+		},
+		Resource: ResourceDirectory(),
+		Read:     true,
+		New:      true,
+		ID:       "/Shared/terraform-managed",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "/Shared/terraform-managed", d.Get("path"))
+	assert.Equal(t, 4567, d.Get("object_id"))
+}
+
+func TestResourceDirectoryRead_Notebook(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2FShared%2Fnotebook",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: Notebook,
+					Path:       "/Shared/notebook",
+					Language:   Python,
+				},
+			},
+		},
+		Resource: ResourceDirectory(),
+		Read:     true,
+		New:      true,
+		ID:       "/Shared/notebook",
+	}.Apply(t)
+	assert.EqualError(t, err, "/Shared/notebook exists, but is a NOTEBOOK, not a directory")
+}
+
+func TestResourceDirectoryRead_NotFound(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2FShared%2Fgone",
+				Response: common.APIErrorBody{
+					ErrorCode: "RESOURCE_DOES_NOT_EXIST",
+					Message:   "Path (/Shared/gone) doesn't exist.",
+				},
+				Status: 404,
+			},
+		},
+		Resource: ResourceDirectory(),
+		Read:     true,
+		Removed:  true,
+		ID:       "/Shared/gone",
+	}.ApplyNoError(t)
+}
+
+func TestResourceDirectoryUpdate_DeleteRecursive(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2FShared%2Fterraform-managed",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: Directory,
+					Path:       "/Shared/terraform-managed",
+				},
+			},
+		},
+		Resource: ResourceDirectory(),
+		Update:   true,
+		ID:       "/Shared/terraform-managed",
+		InstanceState: map[string]string{
+			"path":             "/Shared/terraform-managed",
+			"object_id":        "4567",
+			"delete_recursive": "false",
+		},
+		HCL: `
+		path = "/Shared/terraform-managed"
+		delete_recursive = true
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, true, d.Get("delete_recursive"))
+}
+
+func TestResourceDirectoryDelete(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/delete",
+				ExpectedRequest: NotebookDeleteRequest{
+					Path: "/Shared/terraform-managed",
+				},
+				Response: common.APIErrorBody{
+					ErrorCode: "DIRECTORY_NOT_EMPTY",
+					Message:   "Folder (/Shared/terraform-managed) is not empty",
+				},
+				Status: 400,
+			},
+		},
+		Resource: ResourceDirectory(),
+		Delete:   true,
+		ID:       "/Shared/terraform-managed",
+		HCL:      `path = "/Shared/terraform-managed"`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Folder (/Shared/terraform-managed) is not empty")
+}
+
+func TestResourceDirectoryDelete_Recursive(t *testing.T) {
+	qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/delete",
+				ExpectedRequest: NotebookDeleteRequest{
+					Path:      "/Shared/terraform-managed",
+					Recursive: true,
+				},
+			},
+		},
+		Resource: ResourceDirectory(),
+		Delete:   true,
+		ID:       "/Shared/terraform-managed",
+		HCL: `
+		path = "/Shared/terraform-managed"
+		delete_recursive = true
+		`,
+	}.ApplyNoError(t)
+}
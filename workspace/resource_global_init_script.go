@@ -0,0 +1,196 @@
+package workspace
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/databrickslabs/databricks-terraform/common"
+	"github.com/databrickslabs/databricks-terraform/internal/util"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// GlobalInitScriptInfo contains information about registered global init script
+type GlobalInitScriptInfo struct {
+	ScriptID      string `json:"script_id"`
+	Name          string `json:"name"`
+	Position      int32  `json:"position"`
+	Enabled       bool   `json:"enabled,omitempty"`
+	CreatedBy     string `json:"created_by,omitempty"`
+	CreatedAt     int64  `json:"created_at,omitempty"`
+	UpdatedBy     string `json:"updated_by,omitempty"`
+	UpdatedAt     int64  `json:"updated_at,omitempty"`
+	ContentBase64 string `json:"script,omitempty"`
+}
+
+// globalInitScriptPayload is sent on create and update. Position is a pointer, so that zero
+// could be sent explicitly, while leaving it out keeps the current position on update.
+type globalInitScriptPayload struct {
+	Name          string `json:"name,omitempty"`
+	Position      *int32 `json:"position,omitempty"`
+	Enabled       bool   `json:"enabled"`
+	ContentBase64 string `json:"script,omitempty"`
+}
+
+type globalInitScriptCreateResponse struct {
+	ScriptID string `json:"script_id"`
+}
+
+// GlobalInitScriptsAPI exposes the global init scripts API
+type GlobalInitScriptsAPI struct {
+	client  *common.DatabricksClient
+	context context.Context
+}
+
+// NewGlobalInitScriptsAPI returns global init scripts API
+func NewGlobalInitScriptsAPI(ctx context.Context, m interface{}) GlobalInitScriptsAPI {
+	return GlobalInitScriptsAPI{m.(*common.DatabricksClient), ctx}
+}
+
+// Create registers new global init script. Backend shifts positions of scripts at or after given one.
+func (a GlobalInitScriptsAPI) Create(payload globalInitScriptPayload) (string, error) {
+	var response globalInitScriptCreateResponse
+	err := a.client.Post(a.context, "/global-init-scripts", payload, &response)
+	return response.ScriptID, err
+}
+
+// Get returns global init script with its content
+func (a GlobalInitScriptsAPI) Get(scriptID string) (script GlobalInitScriptInfo, err error) {
+	err = a.client.Get(a.context, "/global-init-scripts/"+scriptID, nil, &script)
+	return
+}
+
+// Update changes global init script in place, keeping the position if it's not sent
+func (a GlobalInitScriptsAPI) Update(scriptID string, payload globalInitScriptPayload) error {
+	return a.client.Patch(a.context, "/global-init-scripts/"+scriptID, payload)
+}
+
+// Delete removes global init script
+func (a GlobalInitScriptsAPI) Delete(scriptID string) error {
+	return a.client.Delete(a.context, "/global-init-scripts/"+scriptID, nil)
+}
+
+// globalInitScriptPosition returns configured position or nil, if position is not set
+func globalInitScriptPosition(d *schema.ResourceData) *int32 {
+	v, ok := d.GetOkExists("position")
+	if !ok {
+		return nil
+	}
+	position := int32(v.(int))
+	return &position
+}
+
+// contentChecksumStateFunc keeps only checksum of the script in the state, the same as in md5 attribute
+func contentChecksumStateFunc(v interface{}) string {
+	content, err := base64.StdEncoding.DecodeString(v.(string))
+	if err != nil {
+		// invalid values are reported by validation
+		return v.(string)
+	}
+	return fmt.Sprintf("%x", md5.Sum(content))
+}
+
+// ResourceGlobalInitScript manages global init scripts
+func ResourceGlobalInitScript() *schema.Resource {
+	s := FileContentSchema(map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"enabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"position": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+	})
+	delete(s, "path")
+	s["content_base64"].StateFunc = contentChecksumStateFunc
+	return util.CommonResource{
+		Schema: s,
+		Create: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			content, err := ReadContent(d)
+			if err != nil {
+				return err
+			}
+			scriptID, err := NewGlobalInitScriptsAPI(ctx, c).Create(globalInitScriptPayload{
+				Name:          d.Get("name").(string),
+				Position:      globalInitScriptPosition(d),
+				Enabled:       d.Get("enabled").(bool),
+				ContentBase64: base64.StdEncoding.EncodeToString(content),
+			})
+			if err != nil {
+				return err
+			}
+			d.SetId(scriptID)
+			return nil
+		},
+		Read: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			script, err := NewGlobalInitScriptsAPI(ctx, c).Get(d.Id())
+			if err != nil {
+				return err
+			}
+			if err = d.Set("name", script.Name); err != nil {
+				return err
+			}
+			if err = d.Set("enabled", script.Enabled); err != nil {
+				return err
+			}
+			content, err := base64.StdEncoding.DecodeString(script.ContentBase64)
+			if err != nil {
+				return err
+			}
+			checksum := fmt.Sprintf("%x", md5.Sum(content))
+			if checksum != d.Get("md5").(string) {
+				// script was edited outside of Terraform, so that content has to be sent again
+				if err = d.Set("content_base64", ""); err != nil {
+					return err
+				}
+			}
+			if err = d.Set("md5", checksum); err != nil {
+				return err
+			}
+			// creating or removing other scripts renumbers this one, so the effective position
+			// is only recorded when it's not known yet, e.g. after import or without explicit position
+			if _, ok := d.GetOkExists("position"); !ok {
+				return d.Set("position", script.Position)
+			}
+			return nil
+		},
+		Update: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			globalInitScriptsAPI := NewGlobalInitScriptsAPI(ctx, c)
+			payload := globalInitScriptPayload{
+				Name:    d.Get("name").(string),
+				Enabled: d.Get("enabled").(bool),
+			}
+			if d.HasChanges("content_base64", "source", "md5") {
+				content, err := ReadContent(d)
+				if err != nil {
+					return err
+				}
+				payload.ContentBase64 = base64.StdEncoding.EncodeToString(content)
+			} else {
+				// state has only the checksum of unchanged content, so the current script is sent back
+				script, err := globalInitScriptsAPI.Get(d.Id())
+				if err != nil {
+					return err
+				}
+				payload.ContentBase64 = script.ContentBase64
+			}
+			if d.HasChange("position") {
+				payload.Position = globalInitScriptPosition(d)
+			}
+			return globalInitScriptsAPI.Update(d.Id(), payload)
+		},
+		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
+			return NewGlobalInitScriptsAPI(ctx, c).Delete(d.Id())
+		},
+	}.ToResource()
+}
@@ -0,0 +1,337 @@
+package workspace
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/stretchr/testify/assert"
+)
+
+// helloChecksum is md5 of "echo hello"
+const helloChecksum = "cd18203adcdc4404664fea34541d8717"
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func TestResourceGlobalInitScriptCreate_Position(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/global-init-scripts",
+				ExpectedRequest: globalInitScriptPayload{
+					Name:          "security",
+					Position:      int32Ptr(0),
+					Enabled:       true,
+					ContentBase64: "ZWNobyBoZWxsbw==",
+				},
+				Response: globalInitScriptCreateResponse{
+					ScriptID: "abc",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/global-init-scripts/abc",
+				Response: GlobalInitScriptInfo{
+					ScriptID:      "abc",
+					Name:          "security",
+					Position:      0,
+					Enabled:       true,
+					ContentBase64: "ZWNobyBoZWxsbw==",
+				},
+			},
+		},
+		Resource: ResourceGlobalInitScript(),
+		Create:   true,
+		HCL: `
+		name = "security"
+		content_base64 = "ZWNobyBoZWxsbw=="
+		enabled = true
+		position = 0
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+	assert.Equal(t, 0, d.Get("position"))
+}
+
+func TestResourceGlobalInitScriptCreate_NoPosition(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/global-init-scripts",
+				ExpectedRequest: globalInitScriptPayload{
+					Name:          "logging",
+					ContentBase64: "ZWNobyBoZWxsbw==",
+				},
+				Response: globalInitScriptCreateResponse{
+					ScriptID: "abc",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/global-init-scripts/abc",
+				Response: GlobalInitScriptInfo{
+					ScriptID: "abc",
+					Name:     "logging",
+					Position: 3,
+				},
+			},
+		},
+		Resource: ResourceGlobalInitScript(),
+		Create:   true,
+		HCL: `
+		name = "logging"
+		content_base64 = "ZWNobyBoZWxsbw=="
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 3, d.Get("position"))
+}
+
+func TestResourceGlobalInitScriptCreate_NegativePosition(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceGlobalInitScript(),
+		Create:   true,
+		HCL: `
+		name = "logging"
+		content_base64 = "ZWNobyBoZWxsbw=="
+		position = -1
+		`,
+	}.Apply(t)
+	qa.AssertErrorStartsWith(t, err, "Invalid config supplied. [position] expected position to be at least (0), got -1")
+}
+
+func TestResourceGlobalInitScriptRead_ShiftedByOthers(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/global-init-scripts/abc",
+				Response: GlobalInitScriptInfo{
+					ScriptID: "abc",
+					Name:     "logging",
+					Position: 2,
+				},
+			},
+		},
+		Resource: ResourceGlobalInitScript(),
+		Read:     true,
+		ID:       "abc",
+		InstanceState: map[string]string{
+			"name":     "logging",
+			"position": "1",
+		},
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 1, d.Get("position"))
+}
+
+func TestResourceGlobalInitScriptRead_Import(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/global-init-scripts/abc",
+				Response: GlobalInitScriptInfo{
+					ScriptID: "abc",
+					Name:     "logging",
+					Position: 2,
+					Enabled:  true,
+				},
+			},
+		},
+		Resource: ResourceGlobalInitScript(),
+		Read:     true,
+		New:      true,
+		ID:       "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "logging", d.Get("name"))
+	assert.Equal(t, 2, d.Get("position"))
+	assert.Equal(t, true, d.Get("enabled"))
+}
+
+func TestResourceGlobalInitScriptUpdate_KeepsPosition(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPatch,
+				Resource: "/api/2.0/global-init-scripts/abc",
+				ExpectedRequest: globalInitScriptPayload{
+					Name:          "logging",
+					ContentBase64: "ZWNobyBieWU=",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/global-init-scripts/abc",
+				Response: GlobalInitScriptInfo{
+					ScriptID: "abc",
+					Name:     "logging",
+					Position: 4,
+				},
+			},
+		},
+		Resource: ResourceGlobalInitScript(),
+		Update:   true,
+		ID:       "abc",
+		InstanceState: map[string]string{
+			"name":           "logging",
+			"content_base64": "ZWNobyBoZWxsbw==",
+			"position":       "1",
+		},
+		HCL: `
+		name = "logging"
+		content_base64 = "ZWNobyBieWU="
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, 1, d.Get("position"))
+}
+
+func TestResourceGlobalInitScriptUpdate_Position(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/global-init-scripts/abc",
+				Response: GlobalInitScriptInfo{
+					ScriptID:      "abc",
+					Name:          "logging",
+					Position:      1,
+					ContentBase64: "ZWNobyBoZWxsbw==",
+				},
+			},
+			{
+				Method:   http.MethodPatch,
+				Resource: "/api/2.0/global-init-scripts/abc",
+				ExpectedRequest: globalInitScriptPayload{
+					Name:          "logging",
+					Position:      int32Ptr(0),
+					ContentBase64: "ZWNobyBoZWxsbw==",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/global-init-scripts/abc",
+				Response: GlobalInitScriptInfo{
+					ScriptID: "abc",
+					Name:     "logging",
+					Position: 0,
+				},
+			},
+		},
+		Resource: ResourceGlobalInitScript(),
+		Update:   true,
+		ID:       "abc",
+		InstanceState: map[string]string{
+			"name":           "logging",
+			"content_base64": helloChecksum,
+			"md5":            helloChecksum,
+			"position":       "1",
+		},
+		HCL: `
+		name = "logging"
+		content_base64 = "ZWNobyBoZWxsbw=="
+		position = 0
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+}
+
+func TestResourceGlobalInitScriptRead_EditedOutside(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/global-init-scripts/abc",
+				Response: GlobalInitScriptInfo{
+					ScriptID:      "abc",
+					Name:          "logging",
+					Position:      1,
+					Enabled:       true,
+					ContentBase64: "ZWNobyBoYWNrZWQ=",
+				},
+			},
+		},
+		Resource: ResourceGlobalInitScript(),
+		Read:     true,
+		ID:       "abc",
+		InstanceState: map[string]string{
+			"name":           "logging",
+			"content_base64": helloChecksum,
+			"md5":            helloChecksum,
+			"enabled":        "true",
+			"position":       "1",
+		},
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "022e9aaae078e0dbeaf64a282d869559", d.Get("md5"))
+	assert.Equal(t, "", d.Get("content_base64"))
+}
+
+func TestResourceGlobalInitScriptUpdate_EditedOutside(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPatch,
+				Resource: "/api/2.0/global-init-scripts/abc",
+				ExpectedRequest: globalInitScriptPayload{
+					Name:          "logging",
+					Enabled:       true,
+					ContentBase64: "ZWNobyBoZWxsbw==",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/global-init-scripts/abc",
+				Response: GlobalInitScriptInfo{
+					ScriptID:      "abc",
+					Name:          "logging",
+					Position:      1,
+					Enabled:       true,
+					ContentBase64: "ZWNobyBoZWxsbw==",
+				},
+			},
+		},
+		Resource: ResourceGlobalInitScript(),
+		Update:   true,
+		ID:       "abc",
+		InstanceState: map[string]string{
+			"name":           "logging",
+			"content_base64": "",
+			"md5":            "022e9aaae078e0dbeaf64a282d869559",
+			"enabled":        "true",
+			"position":       "1",
+		},
+		HCL: `
+		name = "logging"
+		content_base64 = "ZWNobyBoZWxsbw=="
+		enabled = true
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, helloChecksum, d.State().Attributes["md5"])
+	assert.Equal(t, helloChecksum, d.State().Attributes["content_base64"])
+	assert.Equal(t, 1, d.Get("position"))
+}
+
+func TestResourceGlobalInitScriptDelete(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodDelete,
+				Resource: "/api/2.0/global-init-scripts/abc",
+			},
+		},
+		Resource: ResourceGlobalInitScript(),
+		Delete:   true,
+		ID:       "abc",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "abc", d.Id())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"log"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -55,6 +56,7 @@ type ObjectStatus struct {
 	ObjectType ObjectType `json:"object_type,omitempty" tf:"computed"`
 	Path       string     `json:"path"`
 	Language   Language   `json:"language,omitempty"`
+	ModifiedAt int64      `json:"modified_at,omitempty"`
 }
 
 // NotebookContent contains the base64 content of the notebook
@@ -190,6 +192,37 @@ func (a NotebooksAPI) Delete(path string, recursive bool) error {
 	}, nil)
 }
 
+// notebookFormat detects import format from extension of the source file
+func notebookFormat(source string) ExportFormat {
+	if strings.ToLower(filepath.Ext(source)) == ".ipynb" {
+		return Jupyter
+	}
+	return Source
+}
+
+// notebookImportRequest prepares import of the content, detecting language and format from
+// the source file, if they are not specified. Jupyter notebooks have language in their metadata.
+func notebookImportRequest(d *schema.ResourceData, path string, content []byte) (ImportRequest, error) {
+	source := d.Get("source").(string)
+	r := ImportRequest{
+		Content:   base64.StdEncoding.EncodeToString(content),
+		Format:    string(notebookFormat(source)),
+		Overwrite: true,
+		Path:      path,
+	}
+	if r.Format == string(Jupyter) {
+		return r, nil
+	}
+	r.Language = d.Get("language").(string)
+	if r.Language == "" {
+		r.Language = extMap[strings.ToLower(filepath.Ext(source))]
+	}
+	if r.Language == "" {
+		return r, fmt.Errorf("Cannot detect language of %s, please set language", source)
+	}
+	return r, nil
+}
+
 // ResourceNotebook manages notebooks
 func ResourceNotebook() *schema.Resource {
 	s := FileContentSchema(map[string]*schema.Schema{
@@ -207,6 +240,9 @@ func ResourceNotebook() *schema.Resource {
 				if source == "" {
 					return false
 				}
+				if notebookFormat(source) == Jupyter {
+					return new == ""
+				}
 				return old == extMap[strings.ToLower(filepath.Ext(source))]
 			},
 		},
@@ -224,6 +260,11 @@ func ResourceNotebook() *schema.Resource {
 			Optional: true,
 			Computed: true,
 		},
+		"modified_at": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
 	})
 	s["content_base64"].RequiredWith = []string{"language"}
 	return util.CommonResource{
@@ -245,18 +286,11 @@ func ResourceNotebook() *schema.Resource {
 					return err
 				}
 			}
-			lang := d.Get("language").(string)
-			if lang == "" {
-				// TODO: check what happens with empty source
-				lang = extMap[strings.ToLower(filepath.Ext(d.Get("source").(string)))]
+			r, err := notebookImportRequest(d, path, content)
+			if err != nil {
+				return err
 			}
-			if err = notebooksAPI.Create(ImportRequest{
-				Content:   base64.StdEncoding.EncodeToString(content),
-				Language:  lang,
-				Format:    "SOURCE",
-				Overwrite: true,
-				Path:      path,
-			}); err != nil {
+			if err = notebooksAPI.Create(r); err != nil {
 				return err
 			}
 			d.SetId(path)
@@ -268,6 +302,13 @@ func ResourceNotebook() *schema.Resource {
 			if err != nil {
 				return err
 			}
+			modifiedAt := int64(d.Get("modified_at").(int))
+			if modifiedAt > 0 && objectStatus.ModifiedAt > modifiedAt {
+				log.Printf("[INFO] %s was modified outside of Terraform, it will be uploaded again", d.Id())
+				if err = d.Set("md5", ""); err != nil {
+					return err
+				}
+			}
 			d.Set("url", fmt.Sprintf("%s#workspace%s", c.Host, d.Id()))
 			return internal.StructToData(objectStatus, s, d)
 		},
@@ -277,13 +318,15 @@ func ResourceNotebook() *schema.Resource {
 			if err != nil {
 				return err
 			}
-			return notebooksAPI.Create(ImportRequest{
-				Content:   base64.StdEncoding.EncodeToString(content),
-				Language:  d.Get("language").(string),
-				Format:    "SOURCE",
-				Overwrite: true,
-				Path:      d.Id(),
-			})
+			r, err := notebookImportRequest(d, d.Id(), content)
+			if err != nil {
+				return err
+			}
+			if err = notebooksAPI.Create(r); err != nil {
+				return err
+			}
+			// own upload is not a change outside of Terraform
+			return d.Set("modified_at", 0)
 		},
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			return NewNotebooksAPI(ctx, c).Delete(d.Id(), true)
@@ -1,13 +1,22 @@
 package workspace
 
 import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"path/filepath"
 	"testing"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // func TestResourceNotebookCreate_DirDoesNotExists(t *testing.T) {
@@ -321,3 +330,206 @@ func TestResourceNotebookDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "abc", d.Id())
 }
+
+// assertNoNotebookDiff plans the same configuration again, which must not upload the notebook
+func assertNoNotebookDiff(t *testing.T, d *schema.ResourceData, config map[string]interface{}) {
+	diff, err := ResourceNotebook().Diff(context.Background(), d.State(),
+		terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), "unexpected diff: %v", diff)
+}
+
+func TestResourceNotebookCreateSource_Jupyter(t *testing.T) {
+	source := "acceptance/testdata/tf-test-jupyter.ipynb"
+	content, err := ioutil.ReadFile(source)
+	require.NoError(t, err)
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ImportRequest{
+					Content:   base64.StdEncoding.EncodeToString(content),
+					Path:      "/Mars",
+					Overwrite: true,
+					Format:    "JUPYTER",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2FMars",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: "NOTEBOOK",
+					Path:       "/Mars",
+					Language:   "PYTHON",
+					ModifiedAt: 1000,
+				},
+			},
+		},
+		Resource: ResourceNotebook(),
+		State: map[string]interface{}{
+			"source": source,
+			"path":   "/Mars",
+		},
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, fmt.Sprintf("%x", md5.Sum(content)), d.Get("md5"))
+	assert.Equal(t, 1000, d.Get("modified_at"))
+	// language comes from notebook metadata, when it's recorded in the state
+	require.NoError(t, d.Set("language", "PYTHON"))
+	assertNoNotebookDiff(t, d, map[string]interface{}{
+		"source": source,
+		"path":   "/Mars",
+	})
+}
+
+func TestResourceNotebookCreateSource_ExportHeader(t *testing.T) {
+	// workspace prepends "# Databricks notebook source" to exports of SOURCE notebooks,
+	// but only the checksum of local file is compared, so that it never shows up in the plan
+	source := filepath.Join(t.TempDir(), "hello.py")
+	err := ioutil.WriteFile(source, []byte("print(1)\n"), 0644)
+	require.NoError(t, err)
+	config := map[string]interface{}{
+		"source": source,
+		"path":   "/hello",
+	}
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ImportRequest{
+					Content:   "cHJpbnQoMSkK",
+					Path:      "/hello",
+					Language:  "PYTHON",
+					Overwrite: true,
+					Format:    "SOURCE",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2Fhello",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: "NOTEBOOK",
+					Path:       "/hello",
+					Language:   "PYTHON",
+					ModifiedAt: 1000,
+				},
+			},
+		},
+		Resource: ResourceNotebook(),
+		State:    config,
+		Create:   true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assertNoNotebookDiff(t, d, config)
+
+	err = ioutil.WriteFile(source, []byte("print(2)\n"), 0644)
+	require.NoError(t, err)
+	diff, err := ResourceNotebook().Diff(context.Background(), d.State(),
+		terraform.NewResourceConfigRaw(config), nil)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Contains(t, diff.Attributes, "md5")
+}
+
+func TestResourceNotebookCreateSource_UnknownLanguage(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "hello.txt")
+	err := ioutil.WriteFile(source, []byte("print(1)\n"), 0644)
+	require.NoError(t, err)
+	_, err = qa.ResourceFixture{
+		Resource: ResourceNotebook(),
+		State: map[string]interface{}{
+			"source": source,
+			"path":   "/hello",
+		},
+		Create: true,
+	}.Apply(t)
+	assert.EqualError(t, err, fmt.Sprintf("Cannot detect language of %s, please set language", source))
+}
+
+func TestResourceNotebookRead_ModifiedOutside(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2Fhello",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: "NOTEBOOK",
+					Path:       "/hello",
+					Language:   "PYTHON",
+					ModifiedAt: 2000,
+				},
+			},
+		},
+		Resource: ResourceNotebook(),
+		Read:     true,
+		ID:       "/hello",
+		InstanceState: map[string]string{
+			"path":           "/hello",
+			"content_base64": "cHJpbnQoMSkK",
+			"language":       "PYTHON",
+			"md5":            "abc",
+			"modified_at":    "1000",
+		},
+		HCL: `
+		path = "/hello"
+		content_base64 = "cHJpbnQoMSkK"
+		language = "PYTHON"
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "", d.State().Attributes["md5"])
+	assert.Equal(t, 2000, d.Get("modified_at"))
+}
+
+func TestResourceNotebookUpdate(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPost,
+				Resource: "/api/2.0/workspace/import",
+				ExpectedRequest: ImportRequest{
+					Content:   "cHJpbnQoMikK",
+					Path:      "/hello",
+					Language:  "PYTHON",
+					Overwrite: true,
+					Format:    "SOURCE",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace/get-status?path=%2Fhello",
+				Response: ObjectStatus{
+					ObjectID:   4567,
+					ObjectType: "NOTEBOOK",
+					Path:       "/hello",
+					Language:   "PYTHON",
+					ModifiedAt: 3000,
+				},
+			},
+		},
+		Resource: ResourceNotebook(),
+		Update:   true,
+		ID:       "/hello",
+		InstanceState: map[string]string{
+			"path":           "/hello",
+			"content_base64": "cHJpbnQoMSkK",
+			"language":       "PYTHON",
+			"md5":            "",
+			"modified_at":    "2000",
+		},
+		HCL: `
+		path = "/hello"
+		content_base64 = "cHJpbnQoMikK"
+		language = "PYTHON"
+		`,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, fmt.Sprintf("%x", md5.Sum([]byte("print(2)\n"))), d.State().Attributes["md5"])
+	assert.Equal(t, 3000, d.Get("modified_at"))
+}
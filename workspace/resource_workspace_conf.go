@@ -7,10 +7,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal/util"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -31,15 +34,112 @@ func (a WorkspaceConfAPI) Update(workspaceConfMap map[string]interface{}) error
 	return a.client.Patch(a.context, "/workspace-conf", workspaceConfMap)
 }
 
-// Read just returns back a map of keys and values which keys are the configuration items and values are the settings
+// Read fetches values of all keys in the map with a single request. Keys, that are not returned by the API,
+// like write-only ones on some workspace tiers, keep their current values in the map.
 func (a WorkspaceConfAPI) Read(conf *map[string]interface{}) error {
+	if len(*conf) == 0 {
+		return nil
+	}
 	keys := []string{}
 	for k := range *conf {
 		keys = append(keys, k)
 	}
-	return a.client.Get(a.context, "/workspace-conf", map[string]string{
+	sort.Strings(keys)
+	remote := map[string]interface{}{}
+	err := a.client.Get(a.context, "/workspace-conf", map[string]string{
 		"keys": strings.Join(keys, ","),
-	}, &conf)
+	}, &remote)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		v, ok := remote[k]
+		if !ok || v == nil {
+			log.Printf("[DEBUG] %s is not returned by the API, keeping its current value", k)
+			continue
+		}
+		(*conf)[k] = v
+	}
+	return nil
+}
+
+// workspaceConfDefaults are known configuration keys with their documented default values,
+// which are restored once the key is no longer managed
+var workspaceConfDefaults = map[string]string{
+	"enableDbfsFileBrowser": "false",
+	"enableDcs":             "false",
+	"enableDeprecatedClusterNamedInitScripts":          "false",
+	"enableDeprecatedGlobalInitScripts":                "false",
+	"enableEnforceImdsV2":                              "false",
+	"enableExportNotebook":                             "true",
+	"enableGp3":                                        "true",
+	"enableHlsRuntime":                                 "false",
+	"enableIpAccessLists":                              "false",
+	"enableJobViewAcls":                                "false",
+	"enableNotebookTableClipboard":                     "true",
+	"enableProjectTypeInWorkspace":                     "true",
+	"enableResultsDownloading":                         "true",
+	"enableTokensConfig":                               "true",
+	"enableUploadDataUis":                              "true",
+	"enableVerboseAuditLogs":                           "false",
+	"enableWebTerminal":                                "false",
+	"enableWorkspaceFilesystem":                        "true",
+	"enforceUserIsolation":                             "false",
+	"maxTokenLifetimeDays":                             "",
+	"mlflowRunArtifactDownloadEnabled":                 "true",
+	"storeInteractiveNotebookResultsInCustomerAccount": "false",
+}
+
+// workspaceConfDefault returns the value, that resets the key. Unknown keys, that look like feature
+// flags, are disabled and all other unknown keys are erased.
+func workspaceConfDefault(key string) string {
+	if v, ok := workspaceConfDefaults[key]; ok {
+		return v
+	}
+	if strings.HasPrefix(key, "enable") ||
+		strings.HasPrefix(key, "enforce") ||
+		strings.HasSuffix(key, "Enabled") {
+		return "false"
+	}
+	return ""
+}
+
+// normalizeWorkspaceConfValue makes boolean values lowercase, as they are returned by the API
+func normalizeWorkspaceConfValue(v interface{}) string {
+	value := fmt.Sprint(v)
+	if strings.EqualFold(value, "true") || strings.EqualFold(value, "false") {
+		return strings.ToLower(value)
+	}
+	return value
+}
+
+// checkWorkspaceConfKeys warns about keys, that are not known to the provider, as the API silently
+// accepts typos. Similarly named known key is suggested, if there's any.
+func checkWorkspaceConfKeys(i interface{}, p cty.Path) (diags diag.Diagnostics) {
+	keys := []string{}
+	for key := range i.(map[string]interface{}) {
+		if _, ok := workspaceConfDefaults[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		detail := "The API accepts any key, so that a typo has no effect."
+		for known := range workspaceConfDefaults {
+			lk, lknown := strings.ToLower(key), strings.ToLower(known)
+			if lk == lknown || strings.HasPrefix(lknown, lk) || strings.HasPrefix(lk, lknown) {
+				detail = fmt.Sprintf("%s Did you mean %s?", detail, known)
+				break
+			}
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       fmt.Sprintf("%s is not a known workspace configuration key", key),
+			Detail:        detail,
+			AttributePath: append(p, cty.IndexStep{Key: cty.StringVal(key)}),
+		})
+	}
+	return
 }
 
 // ResourceWorkspaceConf maintains workspace configuration for specified keys
@@ -55,7 +155,7 @@ func ResourceWorkspaceConf() *schema.Resource {
 		log.Printf("[DEBUG] Old worspace config: %v, new: %v", old, new)
 		patch := map[string]interface{}{}
 		for k, v := range new {
-			patch[k] = v
+			patch[k] = normalizeWorkspaceConfValue(v)
 		}
 		for k := range old {
 			_, keep := new[k]
@@ -63,13 +163,7 @@ func ResourceWorkspaceConf() *schema.Resource {
 				continue
 			}
 			log.Printf("[DEBUG] Erasing configuration of %s", k)
-			if strings.HasPrefix(k, "enable") ||
-				strings.HasPrefix(k, "enforce") ||
-				strings.HasSuffix(k, "Enabled") {
-				patch[k] = "false"
-			} else {
-				patch[k] = ""
-			}
+			patch[k] = workspaceConfDefault(k)
 		}
 		err := wsConfAPI.Update(patch)
 		if err != nil {
@@ -95,21 +189,22 @@ func ResourceWorkspaceConf() *schema.Resource {
 		Delete: func(ctx context.Context, d *schema.ResourceData, c *common.DatabricksClient) error {
 			config := d.Get("custom_config").(map[string]interface{})
 			for k := range config {
-				if strings.HasPrefix(k, "enable") ||
-					strings.HasPrefix(k, "enforce") ||
-					strings.HasSuffix(k, "Enabled") {
-					config[k] = "false"
-				} else {
-					config[k] = ""
-				}
+				config[k] = workspaceConfDefault(k)
 			}
 			wsConfAPI := NewWorkspaceConfAPI(ctx, c)
 			return wsConfAPI.Update(config)
 		},
 		Schema: map[string]*schema.Schema{
 			"custom_config": {
-				Type:     schema.TypeMap,
-				Optional: true,
+				Type:             schema.TypeMap,
+				Optional:         true,
+				ValidateDiagFunc: checkWorkspaceConfKeys,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					if k == "custom_config.%" {
+						return false
+					}
+					return normalizeWorkspaceConfValue(old) == normalizeWorkspaceConfValue(new)
+				},
 			},
 		},
 	}.ToResource()
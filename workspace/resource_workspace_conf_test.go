@@ -1,12 +1,18 @@
 package workspace
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/databrickslabs/databricks-terraform/common"
 	"github.com/databrickslabs/databricks-terraform/internal/qa"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWorkspaceConfCreate(t *testing.T) {
@@ -127,19 +133,67 @@ func TestWorkspaceConfUpdate_Error(t *testing.T) {
 }
 
 func TestWorkspaceConfRead(t *testing.T) {
-	_, err := qa.ResourceFixture{
+	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
 			{
 				Method:   http.MethodGet,
-				Resource: "/api/2.0/workspace-conf?",
-				Response: map[string]string{},
+				Resource: "/api/2.0/workspace-conf?keys=enableIpAccessLists%2CenableTokensConfig%2CmaxTokenLifetimeDays",
+				Response: map[string]interface{}{
+					"enableIpAccessLists":  "false",
+					"enableTokensConfig":   "true",
+					"maxTokenLifetimeDays": "90",
+				},
 			},
 		},
 		Resource: ResourceWorkspaceConf(),
 		Read:     true,
 		ID:       "_",
+		State: map[string]interface{}{
+			"custom_config": map[string]interface{}{
+				"enableIpAccessLists":  "true",
+				"enableTokensConfig":   "true",
+				"maxTokenLifetimeDays": "30",
+			},
+		},
 	}.Apply(t)
 	assert.NoError(t, err, err)
+	assert.Equal(t, map[string]interface{}{
+		"enableIpAccessLists":  "false",
+		"enableTokensConfig":   "true",
+		"maxTokenLifetimeDays": "90",
+	}, d.Get("custom_config"))
+}
+
+func TestWorkspaceConfRead_Empty(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Resource: ResourceWorkspaceConf(),
+		Read:     true,
+		ID:       "_",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+}
+
+func TestWorkspaceConfRead_WriteOnlyKey(t *testing.T) {
+	client, server, err := qa.HttpFixtureClient(t, []qa.HTTPFixture{
+		{
+			Method:   http.MethodGet,
+			Resource: "/api/2.0/workspace-conf?keys=enableIpAccessLists%2CenableTokensConfig",
+			Response: map[string]interface{}{
+				"enableIpAccessLists": "true",
+				"enableTokensConfig":  nil,
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer server.Close()
+	conf := map[string]interface{}{
+		"enableIpAccessLists": "true",
+		"enableTokensConfig":  "false",
+	}
+	err = NewWorkspaceConfAPI(context.Background(), client).Read(&conf)
+	require.NoError(t, err)
+	assert.Equal(t, "false", conf["enableTokensConfig"])
+	assert.Equal(t, "true", conf["enableIpAccessLists"])
 }
 
 func TestWorkspaceConfRead_Error(t *testing.T) {
@@ -147,7 +201,7 @@ func TestWorkspaceConfRead_Error(t *testing.T) {
 		Fixtures: []qa.HTTPFixture{
 			{
 				Method:   http.MethodGet,
-				Resource: "/api/2.0/workspace-conf?",
+				Resource: "/api/2.0/workspace-conf?keys=enableIpAccessLists",
 				Response: common.APIErrorBody{
 					ErrorCode: "INVALID_REQUEST",
 					Message:   "Internal error happened",
@@ -158,11 +212,62 @@ func TestWorkspaceConfRead_Error(t *testing.T) {
 		Resource: ResourceWorkspaceConf(),
 		Read:     true,
 		ID:       "_",
+		State: map[string]interface{}{
+			"custom_config": map[string]interface{}{
+				"enableIpAccessLists": "true",
+			},
+		},
 	}.Apply(t)
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "_", d.Id(), "Id should not be empty for error reads")
 }
 
+func TestWorkspaceConfCreate_ManyKeys(t *testing.T) {
+	keys := []string{
+		"enableDbfsFileBrowser",
+		"enableDcs",
+		"enableDeprecatedClusterNamedInitScripts",
+		"enableDeprecatedGlobalInitScripts",
+		"enableEnforceImdsV2",
+		"enableExportNotebook",
+		"enableGp3",
+		"enableHlsRuntime",
+		"enableIpAccessLists",
+		"enableJobViewAcls",
+		"enableNotebookTableClipboard",
+		"enableProjectTypeInWorkspace",
+		"enableResultsDownloading",
+		"enableTokensConfig",
+		"enableUploadDataUis",
+	}
+	conf := map[string]string{}
+	hcl := "custom_config {\n"
+	for _, k := range keys {
+		conf[k] = "true"
+		hcl += fmt.Sprintf("%s = \"true\"\n", k)
+	}
+	hcl += "}"
+	// fixtures are not reused, so that any extra request fails the test
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:          http.MethodPatch,
+				Resource:        "/api/2.0/workspace-conf",
+				ExpectedRequest: conf,
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace-conf?keys=" + strings.Join(keys, "%2C"),
+				Response: conf,
+			},
+		},
+		Resource: ResourceWorkspaceConf(),
+		HCL:      hcl,
+		Create:   true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+}
+
 func TestWorkspaceConfDelete(t *testing.T) {
 	d, err := qa.ResourceFixture{
 		Fixtures: []qa.HTTPFixture{
@@ -211,3 +316,113 @@ func TestWorkspaceConfDelete_Error(t *testing.T) {
 	qa.AssertErrorStartsWith(t, err, "Internal error happened")
 	assert.Equal(t, "_", d.Id())
 }
+
+func TestWorkspaceConfDelete_KnownDefaults(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPatch,
+				Resource: "/api/2.0/workspace-conf",
+				ExpectedRequest: map[string]string{
+					"enableIpAccessLists":  "false",
+					"enableTokensConfig":   "true",
+					"maxTokenLifetimeDays": "",
+				},
+			},
+		},
+		HCL: `custom_config {
+			enableIpAccessLists = "true"
+			enableTokensConfig = "false"
+			maxTokenLifetimeDays = "90"
+		}`,
+		Resource: ResourceWorkspaceConf(),
+		Delete:   true,
+		ID:       "_",
+	}.Apply(t)
+	assert.NoError(t, err, err)
+}
+
+func TestWorkspaceConfCreate_UnknownKey(t *testing.T) {
+	_, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPatch,
+				Resource: "/api/2.0/workspace-conf",
+				ExpectedRequest: map[string]string{
+					"enableIpAccessList": "true",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace-conf?keys=enableIpAccessList",
+				Response: map[string]interface{}{},
+			},
+		},
+		Resource: ResourceWorkspaceConf(),
+		HCL: `custom_config {
+			enableIpAccessList = "true"
+		}`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+}
+
+func TestCheckWorkspaceConfKeys(t *testing.T) {
+	diags := checkWorkspaceConfKeys(map[string]interface{}{
+		"enableIpAccessList":  "true",
+		"enableIpAccessLists": "true",
+		"someProperty":        "thing",
+	}, cty.Path{cty.GetAttrStep{Name: "custom_config"}})
+	require.Len(t, diags, 2)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "enableIpAccessList is not a known workspace configuration key", diags[0].Summary)
+	assert.Equal(t, "The API accepts any key, so that a typo has no effect. "+
+		"Did you mean enableIpAccessLists?", diags[0].Detail)
+	assert.Equal(t, cty.Path{
+		cty.GetAttrStep{Name: "custom_config"},
+		cty.IndexStep{Key: cty.StringVal("enableIpAccessList")},
+	}, diags[0].AttributePath)
+	assert.Equal(t, "someProperty is not a known workspace configuration key", diags[1].Summary)
+	assert.False(t, diags.HasError())
+}
+
+func TestWorkspaceConfCreate_NormalizesBooleans(t *testing.T) {
+	d, err := qa.ResourceFixture{
+		Fixtures: []qa.HTTPFixture{
+			{
+				Method:   http.MethodPatch,
+				Resource: "/api/2.0/workspace-conf",
+				ExpectedRequest: map[string]string{
+					"enableIpAccessLists":  "true",
+					"enableTokensConfig":   "false",
+					"maxTokenLifetimeDays": "90",
+				},
+			},
+			{
+				Method:   http.MethodGet,
+				Resource: "/api/2.0/workspace-conf?keys=enableIpAccessLists%2CenableTokensConfig%2CmaxTokenLifetimeDays",
+				Response: map[string]interface{}{
+					"enableIpAccessLists":  "true",
+					"enableTokensConfig":   "false",
+					"maxTokenLifetimeDays": "90",
+				},
+			},
+		},
+		Resource: ResourceWorkspaceConf(),
+		HCL: `custom_config {
+			enableIpAccessLists = "TRUE"
+			enableTokensConfig = "False"
+			maxTokenLifetimeDays = 90
+		}`,
+		Create: true,
+	}.Apply(t)
+	assert.NoError(t, err, err)
+	assert.Equal(t, "true", d.Get("custom_config.enableIpAccessLists"))
+}
+
+func TestWorkspaceConfDiffSuppress(t *testing.T) {
+	s := ResourceWorkspaceConf().Schema["custom_config"]
+	assert.True(t, s.DiffSuppressFunc("custom_config.enableIpAccessLists", "true", "True", nil))
+	assert.False(t, s.DiffSuppressFunc("custom_config.enableIpAccessLists", "false", "true", nil))
+	assert.False(t, s.DiffSuppressFunc("custom_config.maxTokenLifetimeDays", "90", "91", nil))
+}